@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+// runImport implements `niotebook-server import`, which creates a user's
+// posts from an archive file without going through the API. It returns the
+// process exit status: 1 if the import could not run or any row failed.
+func runImport(args []string) int {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	username := fs.String("user", "", "username to import the posts as (required)")
+	format := fs.String("format", "", "archive format: json, jsonl or csv (default: from the file extension)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: niotebook-server import -user <username> [-format json|jsonl|csv] <file|->")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if *username == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = importFormatFor(path)
+	}
+
+	dbURL := os.Getenv("NIOTEBOOK_DB_URL")
+	if dbURL == "" {
+		fmt.Fprintln(os.Stderr, "NIOTEBOOK_DB_URL is required")
+		return 1
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer func() { _ = f.Close() }()
+		in = f
+	}
+
+	ctx := context.Background()
+	pool, err := store.NewPool(ctx, dbURL)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "database connection failed:", err)
+		return 1
+	}
+	defer pool.Close()
+
+	user, err := store.NewUserStore(pool).GetUserByUsername(ctx, *username)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "find user %q: %v\n", *username, err)
+		return 1
+	}
+
+	importSvc := service.NewImportService(store.NewPostStore(pool))
+	result, err := importSvc.ImportPosts(ctx, user.ID, *format, in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "import failed:", err)
+		return 1
+	}
+
+	for _, e := range result.Errors {
+		fmt.Fprintf(os.Stderr, "row %d: %s\n", e.Row, e.Message)
+	}
+	fmt.Printf("imported %d, skipped %d (already imported or reposts), %d failed\n", result.Imported, result.Skipped, len(result.Errors))
+	if len(result.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// importFormatFor guesses an archive's format from its file extension.
+func importFormatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return models.ImportJSONL
+	case ".csv":
+		return models.ImportCSV
+	default:
+		return models.ImportJSON
+	}
+}
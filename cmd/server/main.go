@@ -5,17 +5,26 @@ import (
 	"flag"
 	"log/slog"
 	"net/http"
+	"net/mail"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/build"
 	"github.com/Akram012388/niotebook-tui/internal/server"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+	"github.com/Akram012388/niotebook-tui/internal/server/mailer"
 	"github.com/Akram012388/niotebook-tui/internal/server/store"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		os.Exit(runImport(os.Args[2:]))
+	}
+
 	port := flag.String("port", envOrDefault("NIOTEBOOK_PORT", "8080"), "listen port")
 	host := flag.String("host", envOrDefault("NIOTEBOOK_HOST", "localhost"), "listen host")
 	_ = flag.Bool("migrate", false, "run pending migrations on startup")
@@ -40,15 +49,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Access tokens are signed with the keys in NIOTEBOOK_JWT_KEYS_DIR, or
+	// with NIOTEBOOK_JWT_SECRET when no key directory is set. With both, the
+	// secret only verifies tokens issued before the move to keys.
 	jwtSecret := os.Getenv("NIOTEBOOK_JWT_SECRET")
-	if jwtSecret == "" {
-		slog.Error("NIOTEBOOK_JWT_SECRET is required")
+	keysDir := os.Getenv("NIOTEBOOK_JWT_KEYS_DIR")
+	if jwtSecret == "" && keysDir == "" {
+		slog.Error("NIOTEBOOK_JWT_KEYS_DIR or NIOTEBOOK_JWT_SECRET is required")
 		os.Exit(1)
 	}
-	if len(jwtSecret) < 32 {
+	if jwtSecret != "" && len(jwtSecret) < 32 {
 		slog.Error("NIOTEBOOK_JWT_SECRET must be at least 32 bytes", "length", len(jwtSecret))
 		os.Exit(1)
 	}
+	keys := jwtkeys.FromSecret(jwtSecret)
+	if keysDir != "" {
+		var err error
+		keys, err = jwtkeys.LoadDir(keysDir)
+		if err != nil {
+			slog.Error("loading NIOTEBOOK_JWT_KEYS_DIR failed", "dir", keysDir, "err", err)
+			os.Exit(1)
+		}
+		if jwtSecret != "" {
+			keys.AcceptSecret(jwtSecret)
+		}
+	}
 
 	corsOrigin := os.Getenv("NIOTEBOOK_CORS_ORIGIN")
 	if corsOrigin == "" {
@@ -56,6 +81,41 @@ func main() {
 		corsOrigin = "http://localhost:3000"
 	}
 
+	var moderators []string
+	if m := os.Getenv("NIOTEBOOK_MODERATORS"); m != "" {
+		moderators = strings.Split(m, ",")
+	}
+
+	deletionGrace, err := time.ParseDuration(envOrDefault("NIOTEBOOK_DELETION_GRACE", "720h"))
+	if err != nil || deletionGrace < 0 {
+		slog.Error("NIOTEBOOK_DELETION_GRACE must be a non-negative duration such as 720h", "value", os.Getenv("NIOTEBOOK_DELETION_GRACE"))
+		os.Exit(1)
+	}
+
+	// Verification and password reset emails go out over SMTP, or to a file
+	// or the log when no SMTP server is configured.
+	mailFrom := envOrDefault("NIOTEBOOK_MAIL_FROM", "niotebook <noreply@localhost>")
+	if _, err := mail.ParseAddress(mailFrom); err != nil {
+		slog.Error("NIOTEBOOK_MAIL_FROM must be an email address", "value", mailFrom, "err", err)
+		os.Exit(1)
+	}
+	var sender mailer.Mailer
+	switch {
+	case os.Getenv("NIOTEBOOK_SMTP_ADDR") != "":
+		sender = mailer.NewSMTP(os.Getenv("NIOTEBOOK_SMTP_ADDR"), os.Getenv("NIOTEBOOK_SMTP_USERNAME"), os.Getenv("NIOTEBOOK_SMTP_PASSWORD"), mailFrom)
+	case os.Getenv("NIOTEBOOK_MAIL_FILE") != "":
+		sender = mailer.NewFile(os.Getenv("NIOTEBOOK_MAIL_FILE"), mailFrom)
+	default:
+		slog.Warn("NIOTEBOOK_SMTP_ADDR not set, emails will be logged")
+		sender = mailer.NewLog()
+	}
+
+	requireVerified, err := strconv.ParseBool(envOrDefault("NIOTEBOOK_REQUIRE_VERIFIED_EMAIL", "false"))
+	if err != nil {
+		slog.Error("NIOTEBOOK_REQUIRE_VERIFIED_EMAIL must be true or false", "value", os.Getenv("NIOTEBOOK_REQUIRE_VERIFIED_EMAIL"))
+		os.Exit(1)
+	}
+
 	// Database
 	ctx := context.Background()
 	pool, err := store.NewPool(ctx, dbURL)
@@ -65,7 +125,16 @@ func main() {
 	}
 
 	// Server
-	cfg := &server.Config{JWTSecret: jwtSecret, Host: *host, Port: *port, CORSOrigin: corsOrigin}
+	cfg := &server.Config{
+		Keys:                 keys,
+		Host:                 *host,
+		Port:                 *port,
+		CORSOrigin:           corsOrigin,
+		Moderators:           moderators,
+		DeletionGrace:        deletionGrace,
+		Mailer:               sender,
+		RequireVerifiedEmail: requireVerified,
+	}
 	srv := server.NewServer(cfg, pool)
 
 	go func() {
@@ -76,10 +145,11 @@ func main() {
 		}
 	}()
 
-	// Background: token cleanup
+	// Background: token cleanup and account purge
 	cleanupCtx, cleanupCancel := context.WithCancel(context.Background())
 	tokenStore := store.NewRefreshTokenStore(pool)
-	go runTokenCleanup(cleanupCtx, tokenStore)
+	go runTokenCleanup(cleanupCtx, tokenStore, store.NewPasswordResetStore(pool), store.NewEmailVerificationStore(pool), store.NewLoginChallengeStore(pool))
+	go runAccountPurge(cleanupCtx, store.NewUserStore(pool), deletionGrace)
 
 	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
@@ -100,7 +170,7 @@ func main() {
 	slog.Info("server stopped")
 }
 
-func runTokenCleanup(ctx context.Context, tokens store.RefreshTokenStore) {
+func runTokenCleanup(ctx context.Context, tokens store.RefreshTokenStore, resets store.PasswordResetStore, verifications store.EmailVerificationStore, challenges store.LoginChallengeStore) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
@@ -115,6 +185,45 @@ func runTokenCleanup(ctx context.Context, tokens store.RefreshTokenStore) {
 			} else {
 				slog.Debug("token cleanup complete", "deleted", deleted)
 			}
+			deleted, err = resets.DeleteExpired(ctx)
+			if err != nil {
+				slog.Error("password reset cleanup failed", "err", err)
+			} else {
+				slog.Debug("password reset cleanup complete", "deleted", deleted)
+			}
+			deleted, err = verifications.DeleteExpired(ctx)
+			if err != nil {
+				slog.Error("email verification cleanup failed", "err", err)
+			} else {
+				slog.Debug("email verification cleanup complete", "deleted", deleted)
+			}
+			deleted, err = challenges.DeleteExpired(ctx)
+			if err != nil {
+				slog.Error("login challenge cleanup failed", "err", err)
+			} else {
+				slog.Debug("login challenge cleanup complete", "deleted", deleted)
+			}
+		}
+	}
+}
+
+// runAccountPurge deletes the accounts whose deletion grace window has
+// passed.
+func runAccountPurge(ctx context.Context, users store.UserStore, grace time.Duration) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := users.DeletePendingUsers(ctx, time.Now().Add(-grace))
+			if err != nil {
+				slog.Error("account purge failed", "err", err)
+			} else if deleted > 0 {
+				slog.Info("account purge complete", "deleted", deleted)
+			}
 		}
 	}
 }
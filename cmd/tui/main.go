@@ -48,6 +48,9 @@ func main() {
 
 	// Create HTTP client
 	c := client.New(cfg.ServerURL)
+	if hostname, err := os.Hostname(); err == nil {
+		c.SetDeviceName(hostname)
+	}
 	if storedAuth != nil {
 		c.SetToken(storedAuth.AccessToken)
 		c.SetRefreshToken(storedAuth.RefreshToken)
@@ -61,6 +64,10 @@ func main() {
 		})
 	})
 
+	c.OnLogout(func() {
+		_ = config.ClearAuth(authFile)
+	})
+
 	// Create and run app
 	factory := views.NewFactory()
 	model := app.NewAppModelWithFactory(c, storedAuth, factory)
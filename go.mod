@@ -13,6 +13,7 @@ require (
 	golang.org/x/crypto v0.48.0
 	golang.org/x/time v0.14.0
 	gopkg.in/yaml.v3 v3.0.1
+	rsc.io/qr v0.2.0
 )
 
 require (
@@ -6,15 +6,73 @@ type RegisterRequest struct {
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Device
 }
 
 type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	Device
 }
 
 type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token"`
+	Device
+}
+
+// ChangePasswordRequest changes a signed-in user's password. Password is
+// their current one.
+type ChangePasswordRequest struct {
+	Password    string `json:"password"`
+	NewPassword string `json:"new_password"`
+}
+
+type PasswordResetRequest struct {
+	Email string `json:"email"`
+}
+
+// ConfirmPasswordResetRequest sets a new password with the token from a
+// password reset email.
+type ConfirmPasswordResetRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// VerifyEmailRequest confirms an email address with the code sent to it.
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// Device describes the client a session is used from. Clients may name
+// themselves; the server fills in the user agent and IP address.
+type Device struct {
+	DeviceName string `json:"device_name,omitempty"`
+	UserAgent  string `json:"-"`
+	IP         string `json:"-"`
+}
+
+// Session is one signed-in device, identified by its refresh token family.
+// LastUsedAt is when the session last signed in or refreshed its tokens.
+// Current marks the session the request was made from.
+type Session struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+// RefreshToken is a stored refresh token. It is never sent to clients.
+// Refreshing marks a token rotated and issues the next one in its family,
+// so every token of a session shares a FamilyID.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	ExpiresAt time.Time
+	RotatedAt *time.Time
 }
 
 type TokenPair struct {
@@ -23,9 +81,58 @@ type TokenPair struct {
 	ExpiresAt    time.Time `json:"expires_at"`
 }
 
+// AuthResponse signs a user in. EmailVerified is false until they confirm
+// their address with the code emailed at registration.
+//
+// For an account with two-factor authentication, the password alone only
+// gets a ChallengeToken, with no User or Tokens. Sending it back with a
+// code completes the sign-in.
 type AuthResponse struct {
-	User   *User     `json:"user"`
-	Tokens *TokenPair `json:"tokens"`
+	User           *User      `json:"user,omitempty"`
+	Tokens         *TokenPair `json:"tokens,omitempty"`
+	EmailVerified  bool       `json:"email_verified"`
+	ChallengeToken string     `json:"challenge_token,omitempty"`
+}
+
+// TwoFactorLoginRequest completes a sign-in with the challenge token from
+// the password step and a code from the authenticator app or a recovery
+// code.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	Device
+}
+
+// TwoFactorSetup is a new, not yet enabled, TOTP secret. URI is the
+// otpauth:// form authenticator apps scan as a QR code.
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool `json:"enabled"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// TwoFactorCodeRequest carries a code from the authenticator app.
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// DisableTwoFactorRequest turns off two-factor authentication. It needs
+// both the password and a current code or recovery code.
+type DisableTwoFactorRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// CancelDeletionRequest restores an account pending deletion. Accounts
+// with two-factor authentication also need a current code or recovery code.
+type CancelDeletionRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Code     string `json:"code,omitempty"`
 }
 
 type TimelineResponse struct {
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// RelationEntry is a user in a block or mute list along with the time the
+// relation was created.
+type RelationEntry struct {
+	User      User      `json:"user"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RelationListResponse struct {
+	Users      []RelationEntry `json:"users"`
+	NextCursor *string         `json:"next_cursor"`
+	HasMore    bool            `json:"has_more"`
+}
@@ -23,4 +23,10 @@ const (
 	ErrCodeConflict    = "conflict"
 	ErrCodeRateLimited = "rate_limited"
 	ErrCodeInternal    = "internal_error"
+
+	ErrCodeAccountDeactivated     = "account_deactivated"
+	ErrCodeAccountSuspended       = "account_suspended"
+	ErrCodeAccountPendingDeletion = "account_pending_deletion"
+
+	ErrCodeEmailUnverified = "email_unverified"
 )
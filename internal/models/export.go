@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Export statuses. An export is pending while the server assembles its
+// archive, then ready to download, or failed.
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+)
+
+// Export is a request for a copy of a user's data.
+type Export struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ExportArchive is the document a finished export contains.
+type ExportArchive struct {
+	ExportedAt time.Time     `json:"exported_at"`
+	User       *User         `json:"user"`
+	Posts      []Post        `json:"posts"`
+	Following  []FollowEntry `json:"following"`
+	Followers  []FollowEntry `json:"followers"`
+	Likes      []LikeEntry   `json:"likes"`
+}
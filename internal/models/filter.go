@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Filter match types. A phrase matches anywhere in a post, a word only as
+// whole words, and a regex is a case-insensitive regular expression.
+const (
+	FilterMatchPhrase = "phrase"
+	FilterMatchWord   = "word"
+	FilterMatchRegex  = "regex"
+)
+
+// Filter actions. Hidden posts are left out of feeds; flagged posts are
+// returned with Filtered set so clients can collapse them.
+const (
+	FilterActionHide = "hide"
+	FilterActionFlag = "flag"
+)
+
+// Filter is a user's keyword filter over the posts they read.
+type Filter struct {
+	ID        string     `json:"id"`
+	Phrase    string     `json:"phrase"`
+	Match     string     `json:"match"`
+	Action    string     `json:"action"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type FilterListResponse struct {
+	Filters []Filter `json:"filters"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// FollowEntry is a user in a follower/following list along with the time the
+// follow was created. FollowedAt doubles as the pagination cursor.
+type FollowEntry struct {
+	User       User      `json:"user"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+type FollowListResponse struct {
+	Users      []FollowEntry `json:"users"`
+	NextCursor *string       `json:"next_cursor"`
+	HasMore    bool          `json:"has_more"`
+}
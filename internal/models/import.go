@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Import formats. ImportJSON is the archive a data export produces;
+// ImportJSONL has one ImportItem object per line; ImportCSV has a header
+// row naming the content and created_at columns.
+const (
+	ImportJSON  = "json"
+	ImportJSONL = "jsonl"
+	ImportCSV   = "csv"
+)
+
+// ImportItem is one post to import. A zero CreatedAt means the time of
+// the import.
+type ImportItem struct {
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ImportRowError explains why one item of an import was not imported.
+// Row is the item's 1-based position in the archive.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportResult summarizes an import. Skipped counts items that were
+// already imported and reposts, which are not imported.
+type ImportResult struct {
+	Imported int              `json:"imported"`
+	Skipped  int              `json:"skipped"`
+	Errors   []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Conversation is a private one-to-one or small-group conversation. Unread
+// is set when someone else has messaged since the viewer last read it.
+type Conversation struct {
+	ID            string    `json:"id"`
+	Members       []User    `json:"members"`
+	LastMessage   *Message  `json:"last_message,omitempty"`
+	Unread        bool      `json:"unread"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastMessageAt time.Time `json:"last_message_at"`
+}
+
+// Message is a direct message within a conversation.
+type Message struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	Sender         User      `json:"sender"`
+	Content        string    `json:"content"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ConversationListResponse struct {
+	Conversations []Conversation `json:"conversations"`
+	NextCursor    *string        `json:"next_cursor"`
+	HasMore       bool           `json:"has_more"`
+}
+
+type MessageListResponse struct {
+	Messages   []Message `json:"messages"`
+	NextCursor *string   `json:"next_cursor"`
+	HasMore    bool      `json:"has_more"`
+}
@@ -126,3 +126,19 @@ func TestAPIErrorJSON(t *testing.T) {
 		t.Errorf("round trip mismatch: got %+v, want %+v", got, apiErr)
 	}
 }
+
+func TestAccountStatusError(t *testing.T) {
+	if err := models.AccountStatusError(models.AccountActive); err != nil {
+		t.Errorf("active: got %v, want nil", err)
+	}
+	tests := map[string]string{
+		models.AccountDeactivated:     models.ErrCodeAccountDeactivated,
+		models.AccountSuspended:       models.ErrCodeAccountSuspended,
+		models.AccountPendingDeletion: models.ErrCodeAccountPendingDeletion,
+	}
+	for status, code := range tests {
+		if err := models.AccountStatusError(status); err == nil || err.Code != code {
+			t.Errorf("%s: got %v, want code %s", status, err, code)
+		}
+	}
+}
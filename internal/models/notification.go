@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Notification kinds.
+const (
+	NotificationKindReply   = "reply"
+	NotificationKindFollow  = "follow"
+	NotificationKindLike    = "like"
+	NotificationKindMention = "mention"
+)
+
+// Notification tells a user that Actor replied to, followed, liked or
+// mentioned them. Post is the reply, liked post or mentioning post, and is
+// nil for follows.
+type Notification struct {
+	ID        string    `json:"id"`
+	Kind      string    `json:"kind"`
+	Actor     User      `json:"actor"`
+	Post      *Post     `json:"post,omitempty"`
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []Notification `json:"notifications"`
+	NextCursor    *string        `json:"next_cursor"`
+	HasMore       bool           `json:"has_more"`
+	UnreadCount   int            `json:"unread_count"`
+}
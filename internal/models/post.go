@@ -2,10 +2,84 @@ package models
 
 import "time"
 
+// Post kinds. A repost boosts Original without content of its own; a quote
+// is a regular post that embeds Original.
+const (
+	PostKindPost   = "post"
+	PostKindRepost = "repost"
+	PostKindQuote  = "quote"
+)
+
 type Post struct {
-	ID        string    `json:"id"`
-	AuthorID  string    `json:"author_id"`
-	Author    *User     `json:"author,omitempty"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           string     `json:"id"`
+	AuthorID     string     `json:"author_id"`
+	Author       *User      `json:"author,omitempty"`
+	Kind         string     `json:"kind"`
+	ParentID     *string    `json:"parent_id,omitempty"`
+	RootID       *string    `json:"root_id,omitempty"`
+	OriginalID   *string    `json:"original_id,omitempty"`
+	Original     *Post      `json:"original,omitempty"`
+	Content      string     `json:"content"`
+	Mentions     []Mention  `json:"mentions,omitempty"`
+	Tags         []string   `json:"tags,omitempty"`
+	LikeCount    int        `json:"like_count"`
+	LikedByMe    bool       `json:"liked_by_me"`
+	RepostCount  int        `json:"repost_count"`
+	RepostedByMe bool       `json:"reposted_by_me"`
+	Deleted      bool       `json:"deleted"`
+	EditedAt     *time.Time `json:"edited_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	// Filtered is the phrase of the viewer's filter that flagged the post,
+	// if any.
+	Filtered string `json:"filtered,omitempty"`
+}
+
+// Subject returns the post that interactions should target: the original
+// for a repost, otherwise the post itself.
+func (p *Post) Subject() *Post {
+	if p.Kind == PostKindRepost && p.Original != nil {
+		return p.Original
+	}
+	return p
+}
+
+// LikeEntry is a post a user liked along with when they liked it. LikedAt
+// doubles as the pagination cursor.
+type LikeEntry struct {
+	Post    Post      `json:"post"`
+	LikedAt time.Time `json:"liked_at"`
+}
+
+// Mention is a user referenced as @username in a post's content.
+type Mention struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// TrendingTag is a hashtag with the number of recent posts using it.
+type TrendingTag struct {
+	Tag       string `json:"tag"`
+	PostCount int    `json:"post_count"`
+}
+
+// PostEdit is one entry in a post's edit history: the content the post had
+// until EditedAt.
+type PostEdit struct {
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+// PostHistoryResponse is a post together with its edits, newest first.
+type PostHistoryResponse struct {
+	Post  Post       `json:"post"`
+	Edits []PostEdit `json:"edits"`
+}
+
+// ThreadResponse is a post together with its conversation context.
+// Ancestors run from the thread root down to the post's parent; Replies
+// holds every descendant in depth-first order, oldest sibling first.
+type ThreadResponse struct {
+	Ancestors []Post `json:"ancestors"`
+	Post      Post   `json:"post"`
+	Replies   []Post `json:"replies"`
 }
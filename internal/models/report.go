@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Report reasons.
+const (
+	ReportReasonSpam       = "spam"
+	ReportReasonHarassment = "harassment"
+	ReportReasonHate       = "hate"
+	ReportReasonViolence   = "violence"
+	ReportReasonSexual     = "sexual"
+	ReportReasonOther      = "other"
+)
+
+// Report statuses. A report is resolved when a moderator acts on the
+// reported post or account, and dismissed when they decide not to.
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusResolved  = "resolved"
+	ReportStatusDismissed = "dismissed"
+)
+
+// Moderation actions recorded in the moderation log.
+const (
+	ModerationHidePost      = "hide_post"
+	ModerationSuspendUser   = "suspend_user"
+	ModerationUnsuspendUser = "unsuspend_user"
+	ModerationDismissReport = "dismiss_report"
+)
+
+// Report flags an account, or one of its posts, for moderators. Reporter,
+// User and Post are only filled in for moderators reading the queue; Post
+// then carries the post's content even if it has since been removed.
+type Report struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	PostID     *string    `json:"post_id,omitempty"`
+	Reason     string     `json:"reason"`
+	Details    string     `json:"details,omitempty"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	Reporter   *User      `json:"reporter,omitempty"`
+	User       *User      `json:"user,omitempty"`
+	Post       *Post      `json:"post,omitempty"`
+}
+
+type ReportListResponse struct {
+	Reports    []Report `json:"reports"`
+	NextCursor *string  `json:"next_cursor"`
+	HasMore    bool     `json:"has_more"`
+}
+
+// ModerationAction is an entry in the moderation log. ModeratorID is nil
+// once the moderator's account is gone.
+type ModerationAction struct {
+	ID          string    `json:"id"`
+	ModeratorID *string   `json:"moderator_id"`
+	Action      string    `json:"action"`
+	UserID      *string   `json:"user_id,omitempty"`
+	PostID      *string   `json:"post_id,omitempty"`
+	ReportID    *string   `json:"report_id,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ModerationLogResponse struct {
+	Actions    []ModerationAction `json:"actions"`
+	NextCursor *string            `json:"next_cursor"`
+	HasMore    bool               `json:"has_more"`
+}
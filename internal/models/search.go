@@ -0,0 +1,13 @@
+package models
+
+// Search result kinds accepted by the search endpoint's type parameter.
+const (
+	SearchTypePosts = "posts"
+	SearchTypeUsers = "users"
+)
+
+type UserSearchResponse struct {
+	Users      []User  `json:"users"`
+	NextCursor *string `json:"next_cursor"`
+	HasMore    bool    `json:"has_more"`
+}
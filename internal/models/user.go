@@ -2,11 +2,48 @@ package models
 
 import "time"
 
+// User roles. Moderators work the moderation queue; admins also manage
+// accounts through the admin API.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Account statuses. Only active accounts can sign in; the others are
+// rejected with the matching ErrCodeAccount* error.
+const (
+	AccountActive          = "active"
+	AccountDeactivated     = "deactivated"
+	AccountSuspended       = "suspended"
+	AccountPendingDeletion = "pending_deletion"
+)
+
+// AccountStatusError returns the error for a request by an account in
+// status, or nil if the account is active.
+func AccountStatusError(status string) *APIError {
+	switch status {
+	case AccountActive, "":
+		return nil
+	case AccountDeactivated:
+		return &APIError{Code: ErrCodeAccountDeactivated, Message: "this account has been deactivated"}
+	case AccountSuspended:
+		return &APIError{Code: ErrCodeAccountSuspended, Message: "this account has been suspended"}
+	case AccountPendingDeletion:
+		return &APIError{Code: ErrCodeAccountPendingDeletion, Message: "this account is scheduled for deletion"}
+	default:
+		return &APIError{Code: ErrCodeForbidden, Message: "this account is not active"}
+	}
+}
+
+// User is a public profile. Role is only filled in where a user is loaded
+// on their own, such as auth responses and profiles, not in feeds or lists.
 type User struct {
 	ID          string    `json:"id"`
 	Username    string    `json:"username"`
 	DisplayName string    `json:"display_name"`
 	Bio         string    `json:"bio"`
+	Role        string    `json:"role,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 }
 
@@ -14,3 +51,18 @@ type UserUpdate struct {
 	DisplayName *string `json:"display_name,omitempty"`
 	Bio         *string `json:"bio,omitempty"`
 }
+
+// AdminUserEntry is a user as the admin API lists them, with their email
+// and account status.
+type AdminUserEntry struct {
+	User            User       `json:"user"`
+	Email           string     `json:"email"`
+	Status          string     `json:"status"`
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty"`
+}
+
+type AdminUserListResponse struct {
+	Users      []AdminUserEntry `json:"users"`
+	NextCursor *string          `json:"next_cursor"`
+	HasMore    bool             `json:"has_more"`
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// HandleDeleteAccount schedules the caller's account for deletion and
+// responds with when it will be purged.
+func HandleDeleteAccount(accountSvc *service.AccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Password string `json:"password"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		deleteAfter, err := accountSvc.ScheduleDeletion(r.Context(), userID, body.Password)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"delete_after": deleteAfter})
+	}
+}
+
+// HandleCancelDeletion restores an account pending deletion. It is
+// unauthenticated and takes the account's login credentials instead, plus
+// a two-factor code when the account has one.
+func HandleCancelDeletion(accountSvc *service.AccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.CancelDeletionRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := accountSvc.CancelDeletion(r.Context(), &req); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"cancelled": true})
+	}
+}
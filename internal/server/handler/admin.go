@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// The admin handlers are mounted behind middleware.RequireRole, so they do
+// not check the caller's role themselves.
+
+func HandleAdminListUsers(adminSvc *service.AdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		users, err := adminSvc.ListUsers(r.Context(), cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		resp := models.AdminUserListResponse{
+			Users:   users,
+			HasMore: len(users) == limit,
+		}
+		if len(users) > 0 {
+			resp.NextCursor = nextCursor(users[len(users)-1].User.CreatedAt)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func HandleAdminSuspendUser(adminSvc *service.AdminService) http.HandlerFunc {
+	return handleModerationAction(adminSvc.SuspendUser, "suspended")
+}
+
+func HandleAdminUnsuspendUser(adminSvc *service.AdminService) http.HandlerFunc {
+	return handleModerationAction(adminSvc.UnsuspendUser, "unsuspended")
+}
+
+// HandleAdminLogoutUser revokes every refresh token the {id} user holds.
+func HandleAdminLogoutUser(adminSvc *service.AdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := adminSvc.ForceLogout(r.Context(), r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"logged_out": true})
+	}
+}
+
+func HandleAdminSetRole(adminSvc *service.AdminService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Role string `json:"role"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := adminSvc.SetRole(r.Context(), userID, r.PathValue("id"), body.Role); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"role": body.Role})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
 	"github.com/Akram012388/niotebook-tui/internal/server/service"
 )
 
@@ -18,6 +19,7 @@ func HandleRegister(authSvc *service.AuthService) http.HandlerFunc {
 			return
 		}
 
+		req.Device = requestDevice(r, req.Device)
 		resp, err := authSvc.Register(r.Context(), &req)
 		if err != nil {
 			writeAPIError(w, err)
@@ -39,6 +41,7 @@ func HandleLogin(authSvc *service.AuthService) http.HandlerFunc {
 			return
 		}
 
+		req.Device = requestDevice(r, req.Device)
 		resp, err := authSvc.Login(r.Context(), &req)
 		if err != nil {
 			writeAPIError(w, err)
@@ -49,6 +52,30 @@ func HandleLogin(authSvc *service.AuthService) http.HandlerFunc {
 	}
 }
 
+// HandleCompleteLogin signs in with the challenge token a login answered
+// with and a two-factor code. It is exempt from auth, like login.
+func HandleCompleteLogin(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.TwoFactorLoginRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		req.Device = requestDevice(r, req.Device)
+		resp, err := authSvc.CompleteLogin(r.Context(), &req)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
 func HandleRefresh(authSvc *service.AuthService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req models.RefreshRequest
@@ -60,7 +87,7 @@ func HandleRefresh(authSvc *service.AuthService) http.HandlerFunc {
 			return
 		}
 
-		tokens, err := authSvc.Refresh(r.Context(), req.RefreshToken)
+		tokens, err := authSvc.Refresh(r.Context(), req.RefreshToken, requestDevice(r, req.Device))
 		if err != nil {
 			writeAPIError(w, err)
 			return
@@ -69,3 +96,168 @@ func HandleRefresh(authSvc *service.AuthService) http.HandlerFunc {
 		writeJSON(w, http.StatusOK, map[string]any{"tokens": tokens})
 	}
 }
+
+// HandleLogout ends the session of the refresh token in the body. It is
+// exempt from auth so that a client whose access token has expired can
+// still log out.
+func HandleLogout(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.RefreshRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := authSvc.Logout(r.Context(), req.RefreshToken); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"logged_out": true})
+	}
+}
+
+// HandleLogoutAll ends every session of the caller.
+func HandleLogoutAll(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := authSvc.LogoutAll(r.Context(), userID); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"logged_out": true})
+	}
+}
+
+// HandleListSessions lists the caller's signed-in devices, marking the one
+// making the request as current.
+func HandleListSessions(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		sessions, err := authSvc.ListSessions(r.Context(), userID, middleware.SessionIDFromContext(r.Context()))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"sessions": sessions})
+	}
+}
+
+// HandleDeleteSession signs one of the caller's devices out.
+func HandleDeleteSession(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := authSvc.DeleteSession(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"deleted": true})
+	}
+}
+
+// HandleVerifyEmail confirms an email address with the code sent to it.
+// It is exempt from auth so the code works from any device.
+func HandleVerifyEmail(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.VerifyEmailRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := authSvc.VerifyEmail(r.Context(), req.Token); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"verified": true})
+	}
+}
+
+// HandleResendVerification emails the caller a new verification code.
+func HandleResendVerification(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := authSvc.ResendVerification(r.Context(), userID); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, map[string]any{"sent": true})
+	}
+}
+
+// HandleReactivate signs in a deactivated user and restores their account.
+func HandleReactivate(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.LoginRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		req.Device = requestDevice(r, req.Device)
+		resp, err := authSvc.Reactivate(r.Context(), &req)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func HandleDeactivate(authSvc *service.AuthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Password string `json:"password"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := authSvc.Deactivate(r.Context(), userID, body.Password); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"deactivated": true})
+	}
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleBlock(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := blockSvc.Block(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"blocking": true})
+	}
+}
+
+func HandleUnblock(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := blockSvc.Unblock(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"blocking": false})
+	}
+}
+
+func HandleGetBlockStatus(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		blocking, err := blockSvc.IsBlocking(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"blocking": blocking})
+	}
+}
+
+func HandleMute(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := blockSvc.Mute(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"muting": true})
+	}
+}
+
+func HandleUnmute(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := blockSvc.Unmute(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"muting": false})
+	}
+}
+
+func HandleGetMuteStatus(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		muting, err := blockSvc.IsMuting(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"muting": muting})
+	}
+}
+
+// HandleGetBlocked serves GET /api/v1/users/me/blocks.
+func HandleGetBlocked(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		entries, err := blockSvc.GetBlocked(r.Context(), userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newRelationListResponse(entries, limit))
+	}
+}
+
+// HandleGetMuted serves GET /api/v1/users/me/mutes.
+func HandleGetMuted(blockSvc *service.BlockService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		entries, err := blockSvc.GetMuted(r.Context(), userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newRelationListResponse(entries, limit))
+	}
+}
+
+func newRelationListResponse(entries []models.RelationEntry, limit int) models.RelationListResponse {
+	resp := models.RelationListResponse{
+		Users:   entries,
+		HasMore: len(entries) == limit,
+	}
+	if len(entries) > 0 {
+		resp.NextCursor = nextCursor(entries[len(entries)-1].CreatedAt)
+	}
+	return resp
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// HandleRequestExport starts building an archive of the caller's data and
+// responds with the pending export to poll.
+func HandleRequestExport(exportSvc *service.ExportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		export, err := exportSvc.RequestExport(r.Context(), userID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, map[string]any{"export": export})
+	}
+}
+
+func HandleGetExport(exportSvc *service.ExportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		export, err := exportSvc.GetExport(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"export": export})
+	}
+}
+
+// HandleDownloadExport serves a ready export's archive as a JSON file.
+func HandleDownloadExport(exportSvc *service.ExportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		archive, err := exportSvc.GetArchive(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="niotebook-export.json"`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(archive)
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleGetFilters(filterSvc *service.FilterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		filters, err := filterSvc.GetFilters(r.Context(), userID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, models.FilterListResponse{Filters: filters})
+	}
+}
+
+func HandleCreateFilter(filterSvc *service.FilterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		// ExpiresIn is in seconds; zero keeps the filter until it is deleted.
+		var body struct {
+			Phrase    string `json:"phrase"`
+			Match     string `json:"match"`
+			Action    string `json:"action"`
+			ExpiresIn int64  `json:"expires_in"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := service.ValidateFilterExpiry(body.ExpiresIn); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		filter, err := filterSvc.CreateFilter(r.Context(), userID, body.Phrase, body.Match, body.Action,
+			time.Duration(body.ExpiresIn)*time.Second)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, map[string]any{"filter": filter})
+	}
+}
+
+func HandleDeleteFilter(filterSvc *service.FilterService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := filterSvc.DeleteFilter(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"deleted": true})
+	}
+}
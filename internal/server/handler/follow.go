@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleFollow(followSvc *service.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := followSvc.Follow(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"following": true})
+	}
+}
+
+func HandleUnfollow(followSvc *service.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := followSvc.Unfollow(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"following": false})
+	}
+}
+
+func HandleGetFollowStatus(followSvc *service.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		following, err := followSvc.IsFollowing(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"following": following})
+	}
+}
+
+func HandleGetFollowers(followSvc *service.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := pathUserID(r)
+		if userID == "" {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "user id is required",
+			})
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		entries, err := followSvc.GetFollowers(r.Context(), userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newFollowListResponse(entries, limit))
+	}
+}
+
+func HandleGetFollowing(followSvc *service.FollowService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := pathUserID(r)
+		if userID == "" {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "user id is required",
+			})
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		entries, err := followSvc.GetFollowing(r.Context(), userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newFollowListResponse(entries, limit))
+	}
+}
+
+func newFollowListResponse(entries []models.FollowEntry, limit int) models.FollowListResponse {
+	resp := models.FollowListResponse{
+		Users:   entries,
+		HasMore: len(entries) == limit,
+	}
+	if len(entries) > 0 {
+		resp.NextCursor = nextCursor(entries[len(entries)-1].FollowedAt)
+	}
+	return resp
+}
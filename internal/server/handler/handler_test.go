@@ -5,19 +5,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/server/handler"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+	"github.com/Akram012388/niotebook-tui/internal/server/mailer"
 	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
 	"github.com/Akram012388/niotebook-tui/internal/server/service"
 	"github.com/Akram012388/niotebook-tui/internal/server/store"
+	"github.com/Akram012388/niotebook-tui/internal/server/totp"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
@@ -53,11 +59,11 @@ func setupTestDB(t *testing.T) *pgxpool.Pool {
 
 	// Clean before test
 	_, _ = pool.Exec(context.Background(),
-		"TRUNCATE users, posts, refresh_tokens CASCADE")
+		"TRUNCATE users, posts, tags, conversations, refresh_tokens CASCADE")
 
 	t.Cleanup(func() {
 		_, _ = pool.Exec(context.Background(),
-			"TRUNCATE users, posts, refresh_tokens CASCADE")
+			"TRUNCATE users, posts, tags, conversations, refresh_tokens CASCADE")
 		pool.Close()
 	})
 
@@ -65,11 +71,19 @@ func setupTestDB(t *testing.T) *pgxpool.Pool {
 }
 
 type testServer struct {
-	mux     *http.ServeMux
-	handler http.Handler
-	authSvc *service.AuthService
-	postSvc *service.PostService
-	userSvc *service.UserService
+	mux       *http.ServeMux
+	handler   http.Handler
+	authSvc   *service.AuthService
+	postSvc   *service.PostService
+	userSvc   *service.UserService
+	importSvc *service.ImportService
+	// passwordSvc sends reset emails in the background; Wait on it before
+	// reading mailFile.
+	passwordSvc *service.PasswordService
+	users       store.UserStore
+	denylist    *middleware.Denylist
+	// mailFile collects the emails the server sends.
+	mailFile string
 }
 
 func setupTestServer(t *testing.T) *testServer {
@@ -80,10 +94,43 @@ func setupTestServer(t *testing.T) *testServer {
 	userStore := store.NewUserStore(pool)
 	postStore := store.NewPostStore(pool)
 	tokenStore := store.NewRefreshTokenStore(pool)
-
-	authSvc := service.NewAuthService(userStore, tokenStore, testJWTSecret)
-	postSvc := service.NewPostService(postStore)
+	followStore := store.NewFollowStore(pool)
+	likeStore := store.NewLikeStore(pool)
+	mentionStore := store.NewMentionStore(pool)
+	tagStore := store.NewTagStore(pool)
+	searchStore := store.NewSearchStore(pool)
+	notificationStore := store.NewNotificationStore(pool)
+	conversationStore := store.NewConversationStore(pool)
+	blockStore := store.NewBlockStore(pool)
+	muteStore := store.NewMuteStore(pool)
+	filterStore := store.NewFilterStore(pool)
+	reportStore := store.NewReportStore(pool)
+	moderationStore := store.NewModerationStore(pool)
+	exportStore := store.NewExportStore(pool)
+	resetStore := store.NewPasswordResetStore(pool)
+	verificationStore := store.NewEmailVerificationStore(pool)
+	twoFactorStore := store.NewTwoFactorStore(pool)
+	challengeStore := store.NewLoginChallengeStore(pool)
+	mailFile := filepath.Join(t.TempDir(), "mail.txt")
+	mail := mailer.NewFile(mailFile, "noreply@example.com")
+
+	authSvc := service.NewAuthService(userStore, tokenStore, verificationStore, twoFactorStore, challengeStore, jwtkeys.FromSecret(testJWTSecret), mail)
+	twoFactorSvc := service.NewTwoFactorService(userStore, twoFactorStore)
+	passwordSvc := service.NewPasswordService(userStore, tokenStore, resetStore, mail)
+	postSvc := service.NewPostService(postStore, mentionStore, tagStore, notificationStore, filterStore)
 	userSvc := service.NewUserService(userStore)
+	followSvc := service.NewFollowService(followStore, blockStore, notificationStore)
+	likeSvc := service.NewLikeService(likeStore, notificationStore)
+	searchSvc := service.NewSearchService(searchStore)
+	notificationSvc := service.NewNotificationService(notificationStore)
+	msgSvc := service.NewMessageService(conversationStore, userStore, blockStore)
+	blockSvc := service.NewBlockService(blockStore, muteStore)
+	filterSvc := service.NewFilterService(filterStore)
+	modSvc := service.NewModerationService(reportStore, moderationStore, userStore, tokenStore, []string{"mod"})
+	adminSvc := service.NewAdminService(userStore, tokenStore, moderationStore)
+	accountSvc := service.NewAccountService(userStore, tokenStore, twoFactorStore, time.Hour)
+	exportSvc := service.NewExportService(exportStore, userStore, postStore, followStore, likeStore)
+	importSvc := service.NewImportService(postStore)
 
 	mux := http.NewServeMux()
 
@@ -91,31 +138,131 @@ func setupTestServer(t *testing.T) *testServer {
 	mux.HandleFunc("POST /api/v1/auth/register", handler.HandleRegister(authSvc))
 	mux.HandleFunc("POST /api/v1/auth/login", handler.HandleLogin(authSvc))
 	mux.HandleFunc("POST /api/v1/auth/refresh", handler.HandleRefresh(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/logout", handler.HandleLogout(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/logout-all", handler.HandleLogoutAll(authSvc))
+	mux.HandleFunc("GET /api/v1/auth/sessions", handler.HandleListSessions(authSvc))
+	mux.HandleFunc("DELETE /api/v1/auth/sessions/{id}", handler.HandleDeleteSession(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/reactivate", handler.HandleReactivate(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/cancel-deletion", handler.HandleCancelDeletion(accountSvc))
+	mux.HandleFunc("POST /api/v1/auth/password", handler.HandleChangePassword(passwordSvc))
+	mux.HandleFunc("POST /api/v1/auth/password-reset/request", handler.HandleRequestPasswordReset(passwordSvc))
+	mux.HandleFunc("POST /api/v1/auth/password-reset/confirm", handler.HandleConfirmPasswordReset(passwordSvc))
+	mux.HandleFunc("POST /api/v1/auth/verify-email", handler.HandleVerifyEmail(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/verify-email/resend", handler.HandleResendVerification(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/login/2fa", handler.HandleCompleteLogin(authSvc))
+	mux.HandleFunc("GET /api/v1/auth/2fa", handler.HandleTwoFactorStatus(twoFactorSvc))
+	mux.HandleFunc("POST /api/v1/auth/2fa/setup", handler.HandleSetupTwoFactor(twoFactorSvc))
+	mux.HandleFunc("POST /api/v1/auth/2fa/enable", handler.HandleEnableTwoFactor(twoFactorSvc))
+	mux.HandleFunc("POST /api/v1/auth/2fa/disable", handler.HandleDisableTwoFactor(twoFactorSvc))
 
 	// Post routes
 	mux.HandleFunc("POST /api/v1/posts", handler.HandleCreatePost(postSvc))
 	mux.HandleFunc("GET /api/v1/posts/{id}", handler.HandleGetPost(postSvc))
+	mux.HandleFunc("PATCH /api/v1/posts/{id}", handler.HandleEditPost(postSvc))
+	mux.HandleFunc("DELETE /api/v1/posts/{id}", handler.HandleDeletePost(postSvc))
+	mux.HandleFunc("GET /api/v1/posts/{id}/history", handler.HandleGetPostHistory(postSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/replies", handler.HandleCreateReply(postSvc))
+	mux.HandleFunc("GET /api/v1/posts/{id}/thread", handler.HandleGetThread(postSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/like", handler.HandleLike(likeSvc))
+	mux.HandleFunc("DELETE /api/v1/posts/{id}/like", handler.HandleUnlike(likeSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/repost", handler.HandleRepost(postSvc))
+	mux.HandleFunc("DELETE /api/v1/posts/{id}/repost", handler.HandleUnrepost(postSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/quote", handler.HandleQuote(postSvc))
 
 	// Timeline
 	mux.HandleFunc("GET /api/v1/timeline", handler.HandleTimeline(postSvc))
+	mux.HandleFunc("GET /api/v1/timeline/home", handler.HandleHomeTimeline(postSvc))
 
 	// User routes
 	mux.HandleFunc("GET /api/v1/users/{id}", handler.HandleGetUser(userSvc))
 	mux.HandleFunc("GET /api/v1/users/{id}/posts", handler.HandleGetUserPosts(postSvc))
 	mux.HandleFunc("PATCH /api/v1/users/me", handler.HandleUpdateUser(userSvc))
+	mux.HandleFunc("POST /api/v1/users/me/deactivate", handler.HandleDeactivate(authSvc))
+	mux.HandleFunc("DELETE /api/v1/users/me", handler.HandleDeleteAccount(accountSvc))
+	mux.HandleFunc("POST /api/v1/users/me/export", handler.HandleRequestExport(exportSvc))
+	mux.HandleFunc("GET /api/v1/users/me/export/{id}", handler.HandleGetExport(exportSvc))
+	mux.HandleFunc("GET /api/v1/users/me/export/{id}/archive", handler.HandleDownloadExport(exportSvc))
+	mux.HandleFunc("POST /api/v1/users/me/import", handler.HandleImport(importSvc))
+	mux.HandleFunc("GET /api/v1/users/me/mentions", handler.HandleGetMentions(postSvc))
+	mux.HandleFunc("GET /api/v1/tags/trending", handler.HandleTrendingTags(postSvc))
+	mux.HandleFunc("GET /api/v1/tags/{tag}/posts", handler.HandleGetTagPosts(postSvc))
+	mux.HandleFunc("GET /api/v1/search", handler.HandleSearch(searchSvc))
+
+	// Notification routes
+	mux.HandleFunc("GET /api/v1/notifications", handler.HandleGetNotifications(notificationSvc))
+	mux.HandleFunc("GET /api/v1/notifications/unread_count", handler.HandleUnreadNotificationCount(notificationSvc))
+	mux.HandleFunc("POST /api/v1/notifications/read", handler.HandleMarkNotificationsRead(notificationSvc))
+
+	// Conversation routes
+	mux.HandleFunc("GET /api/v1/conversations", handler.HandleGetConversations(msgSvc))
+	mux.HandleFunc("POST /api/v1/conversations", handler.HandleCreateConversation(msgSvc))
+	mux.HandleFunc("GET /api/v1/conversations/{id}", handler.HandleGetConversation(msgSvc))
+	mux.HandleFunc("GET /api/v1/conversations/{id}/messages", handler.HandleGetMessages(msgSvc))
+	mux.HandleFunc("POST /api/v1/conversations/{id}/messages", handler.HandleSendMessage(msgSvc))
+	mux.HandleFunc("POST /api/v1/conversations/{id}/read", handler.HandleMarkConversationRead(msgSvc))
+
+	// Follow routes
+	mux.HandleFunc("POST /api/v1/users/{id}/follow", handler.HandleFollow(followSvc))
+	mux.HandleFunc("DELETE /api/v1/users/{id}/follow", handler.HandleUnfollow(followSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/follow", handler.HandleGetFollowStatus(followSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/followers", handler.HandleGetFollowers(followSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/following", handler.HandleGetFollowing(followSvc))
+
+	// Block and mute routes
+	mux.HandleFunc("POST /api/v1/users/{id}/block", handler.HandleBlock(blockSvc))
+	mux.HandleFunc("DELETE /api/v1/users/{id}/block", handler.HandleUnblock(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/block", handler.HandleGetBlockStatus(blockSvc))
+	mux.HandleFunc("POST /api/v1/users/{id}/mute", handler.HandleMute(blockSvc))
+	mux.HandleFunc("DELETE /api/v1/users/{id}/mute", handler.HandleUnmute(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/mute", handler.HandleGetMuteStatus(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/me/blocks", handler.HandleGetBlocked(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/me/mutes", handler.HandleGetMuted(blockSvc))
+
+	// Filter routes
+	mux.HandleFunc("GET /api/v1/filters", handler.HandleGetFilters(filterSvc))
+	mux.HandleFunc("POST /api/v1/filters", handler.HandleCreateFilter(filterSvc))
+	mux.HandleFunc("DELETE /api/v1/filters/{id}", handler.HandleDeleteFilter(filterSvc))
+
+	// Report and moderation routes
+	mux.HandleFunc("POST /api/v1/posts/{id}/report", handler.HandleReportPost(modSvc))
+	mux.HandleFunc("POST /api/v1/users/{id}/report", handler.HandleReportUser(modSvc))
+	mux.HandleFunc("GET /api/v1/moderation/reports", handler.HandleGetReports(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/reports/{id}/dismiss", handler.HandleDismissReport(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/posts/{id}/hide", handler.HandleHidePost(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/users/{id}/suspend", handler.HandleSuspendUser(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/users/{id}/unsuspend", handler.HandleUnsuspendUser(modSvc))
+	mux.HandleFunc("GET /api/v1/moderation/log", handler.HandleGetModerationLog(modSvc))
+
+	adminMux := http.NewServeMux()
+	adminMux.HandleFunc("GET /api/v1/admin/users", handler.HandleAdminListUsers(adminSvc))
+	adminMux.HandleFunc("POST /api/v1/admin/users/{id}/suspend", handler.HandleAdminSuspendUser(adminSvc))
+	adminMux.HandleFunc("POST /api/v1/admin/users/{id}/unsuspend", handler.HandleAdminUnsuspendUser(adminSvc))
+	adminMux.HandleFunc("POST /api/v1/admin/users/{id}/logout", handler.HandleAdminLogoutUser(adminSvc))
+	adminMux.HandleFunc("PUT /api/v1/admin/users/{id}/role", handler.HandleAdminSetRole(adminSvc))
+	mux.Handle("/api/v1/admin/", middleware.RequireRole(userStore.GetRole, models.RoleAdmin)(adminMux))
 
 	// Health
 	mux.HandleFunc("GET /health", handler.HandleHealth(pool))
 
+	// Public keys for verifying access tokens
+	mux.HandleFunc("GET /.well-known/jwks.json", handler.HandleJWKS(jwtkeys.FromSecret(testJWTSecret)))
+
 	// Apply auth middleware
-	h := middleware.Auth(testJWTSecret)(mux)
+	denylist := middleware.NewDenylist(userStore.ListInactive, time.Hour)
+	t.Cleanup(denylist.Stop)
+	h := middleware.Auth(jwtkeys.FromSecret(testJWTSecret), denylist)(mux)
 
 	return &testServer{
-		mux:     mux,
-		handler: h,
-		authSvc: authSvc,
-		postSvc: postSvc,
-		userSvc: userSvc,
+		mux:         mux,
+		handler:     h,
+		authSvc:     authSvc,
+		postSvc:     postSvc,
+		userSvc:     userSvc,
+		importSvc:   importSvc,
+		passwordSvc: passwordSvc,
+		users:       userStore,
+		denylist:    denylist,
+		mailFile:    mailFile,
 	}
 }
 
@@ -374,6 +521,288 @@ func TestRefreshExpiredToken(t *testing.T) {
 	}
 }
 
+func TestLogout(t *testing.T) {
+	ts := setupTestServer(t)
+
+	rec := ts.do("POST", "/api/v1/auth/register", models.RegisterRequest{
+		Username: "akram",
+		Email:    "akram@example.com",
+		Password: "securepass123",
+	}, "")
+	var laptop models.AuthResponse
+	parseJSON(t, rec, &laptop)
+
+	// Logging out needs only the refresh token
+	rec = ts.do("POST", "/api/v1/auth/logout", models.RefreshRequest{RefreshToken: laptop.Tokens.RefreshToken}, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("logout: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/refresh", models.RefreshRequest{RefreshToken: laptop.Tokens.RefreshToken}, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}, "")
+	var phone models.AuthResponse
+	parseJSON(t, rec, &phone)
+
+	if rec := ts.do("POST", "/api/v1/auth/logout-all", nil, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous logout-all: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("POST", "/api/v1/auth/logout-all", nil, phone.Tokens.AccessToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("logout-all: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/refresh", models.RefreshRequest{RefreshToken: phone.Tokens.RefreshToken}, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout-all: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	ts := setupTestServer(t)
+
+	rec := ts.do("POST", "/api/v1/auth/register", models.RegisterRequest{
+		Username: "akram",
+		Email:    "akram@example.com",
+		Password: "securepass123",
+		Device:   models.Device{DeviceName: "laptop"},
+	}, "")
+	var laptop models.AuthResponse
+	parseJSON(t, rec, &laptop)
+
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{
+		Email:    "akram@example.com",
+		Password: "securepass123",
+		Device:   models.Device{DeviceName: "phone"},
+	}, "")
+	var phone models.AuthResponse
+	parseJSON(t, rec, &phone)
+
+	if rec := ts.do("GET", "/api/v1/auth/sessions", nil, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous sessions: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("GET", "/api/v1/auth/sessions", nil, phone.Tokens.AccessToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("sessions: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var listed struct {
+		Sessions []models.Session `json:"sessions"`
+	}
+	parseJSON(t, rec, &listed)
+	if len(listed.Sessions) != 2 {
+		t.Fatalf("got %d sessions, want 2", len(listed.Sessions))
+	}
+	var laptopID string
+	for _, sess := range listed.Sessions {
+		if sess.Current != (sess.DeviceName == "phone") {
+			t.Errorf("session %q current = %v, want only the phone current", sess.DeviceName, sess.Current)
+		}
+		if sess.DeviceName == "laptop" {
+			laptopID = sess.ID
+		}
+	}
+
+	rec = ts.do("DELETE", "/api/v1/auth/sessions/"+laptopID, nil, phone.Tokens.AccessToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("delete session: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/refresh", models.RefreshRequest{RefreshToken: laptop.Tokens.RefreshToken}, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after delete session: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("DELETE", "/api/v1/auth/sessions/"+laptopID, nil, phone.Tokens.AccessToken)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("delete session twice: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+var mailedCodeRegex = regexp.MustCompile(`\r\n    (\S+)\r\n`)
+
+// lastMailedCode returns the code in the most recent email the server
+// sent, and the whole mail file.
+func lastMailedCode(t *testing.T, ts *testServer) (string, string) {
+	t.Helper()
+	mail, err := os.ReadFile(ts.mailFile)
+	if err != nil {
+		t.Fatalf("read mail file: %v", err)
+	}
+	matches := mailedCodeRegex.FindAllStringSubmatch(string(mail), -1)
+	if len(matches) == 0 {
+		t.Fatalf("no code in mail file:\n%s", mail)
+	}
+	return matches[len(matches)-1][1], string(mail)
+}
+
+func TestPasswordChangeAndReset(t *testing.T) {
+	ts := setupTestServer(t)
+	laptop, _ := registerTestUser(t, ts, "akram")
+
+	rec := ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}, "")
+	var phone models.AuthResponse
+	parseJSON(t, rec, &phone)
+
+	if rec := ts.do("POST", "/api/v1/auth/password", models.ChangePasswordRequest{Password: "securepass123", NewPassword: "newpass12345"}, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous change: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("POST", "/api/v1/auth/password", models.ChangePasswordRequest{Password: "wrongpass", NewPassword: "newpass12345"}, laptop)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("change with wrong password: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("POST", "/api/v1/auth/password", models.ChangePasswordRequest{Password: "securepass123", NewPassword: "newpass12345"}, laptop)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("change password: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/refresh", models.RefreshRequest{RefreshToken: phone.Tokens.RefreshToken}, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh other device after change: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// Unknown addresses get the same answer
+	for _, email := range []string{"akram@example.com", "nobody@example.com"} {
+		rec = ts.do("POST", "/api/v1/auth/password-reset/request", models.PasswordResetRequest{Email: email}, "")
+		if rec.Code != http.StatusAccepted {
+			t.Fatalf("request reset for %s: status = %d, want %d\nbody: %s", email, rec.Code, http.StatusAccepted, rec.Body.String())
+		}
+	}
+	if err := ts.passwordSvc.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	code, mail := lastMailedCode(t, ts)
+	if !strings.Contains(mail, "Subject: Reset your niotebook password") || strings.Contains(mail, "nobody@example.com") {
+		t.Fatalf("want one reset email to akram, got:\n%s", mail)
+	}
+
+	confirm := models.ConfirmPasswordResetRequest{Token: code, NewPassword: "resetpass123"}
+	rec = ts.do("POST", "/api/v1/auth/password-reset/confirm", confirm, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("confirm reset: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/password-reset/confirm", confirm, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("confirm reset twice: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "akram@example.com", Password: "resetpass123"}, "")
+	if rec.Code != http.StatusOK {
+		t.Errorf("login with reset password: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	ts := setupTestServer(t)
+
+	rec := ts.do("POST", "/api/v1/auth/register", models.RegisterRequest{
+		Username: "akram",
+		Email:    "akram@example.com",
+		Password: "securepass123",
+	}, "")
+	var registered models.AuthResponse
+	parseJSON(t, rec, &registered)
+	if registered.EmailVerified {
+		t.Error("register: email_verified = true, want false")
+	}
+	token := registered.Tokens.AccessToken
+	first, _ := lastMailedCode(t, ts)
+
+	rec = ts.do("POST", "/api/v1/auth/verify-email/resend", nil, token)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("resend: status = %d, want %d\nbody: %s", rec.Code, http.StatusAccepted, rec.Body.String())
+	}
+	second, _ := lastMailedCode(t, ts)
+
+	if rec := ts.do("POST", "/api/v1/auth/verify-email", models.VerifyEmailRequest{Token: first}, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("verify with replaced code: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("POST", "/api/v1/auth/verify-email", models.VerifyEmailRequest{Token: second}, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("verify: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if rec := ts.do("POST", "/api/v1/auth/verify-email/resend", nil, token); rec.Code != http.StatusConflict {
+		t.Errorf("resend when verified: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}, "")
+	var loggedIn models.AuthResponse
+	parseJSON(t, rec, &loggedIn)
+	if !loggedIn.EmailVerified {
+		t.Error("login after verifying: email_verified = false, want true")
+	}
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	ts := setupTestServer(t)
+	token, _ := registerTestUser(t, ts, "akram")
+
+	rec := ts.do("POST", "/api/v1/auth/2fa/setup", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("setup: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var setup models.TwoFactorSetup
+	parseJSON(t, rec, &setup)
+	if !strings.HasPrefix(setup.URI, "otpauth://totp/") {
+		t.Errorf("setup: otpauth_uri = %q", setup.URI)
+	}
+
+	code, err := totp.Code(setup.Secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("Code: %v", err)
+	}
+	rec = ts.do("POST", "/api/v1/auth/2fa/enable", models.TwoFactorCodeRequest{Code: code}, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("enable: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var enabled struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	parseJSON(t, rec, &enabled)
+	if len(enabled.RecoveryCodes) == 0 {
+		t.Fatal("enable: no recovery codes")
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var challenged models.AuthResponse
+	parseJSON(t, rec, &challenged)
+	if challenged.ChallengeToken == "" || challenged.Tokens != nil {
+		t.Fatalf("login: got %+v, want only a challenge token", challenged)
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/login/2fa", models.TwoFactorLoginRequest{ChallengeToken: challenged.ChallengeToken, Code: "aaaaa-aaaaa"}, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("login/2fa with a wrong code: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("POST", "/api/v1/auth/login/2fa", models.TwoFactorLoginRequest{ChallengeToken: challenged.ChallengeToken, Code: enabled.RecoveryCodes[0]}, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login/2fa: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var signedIn models.AuthResponse
+	parseJSON(t, rec, &signedIn)
+	if signedIn.Tokens == nil || signedIn.User == nil {
+		t.Fatalf("login/2fa: got %+v, want tokens and a user", signedIn)
+	}
+	token = signedIn.Tokens.AccessToken
+
+	rec = ts.do("GET", "/api/v1/auth/2fa", nil, token)
+	var status models.TwoFactorStatus
+	parseJSON(t, rec, &status)
+	if !status.Enabled || status.RecoveryCodesLeft != len(enabled.RecoveryCodes)-1 {
+		t.Errorf("status = %+v, want enabled with %d recovery codes", status, len(enabled.RecoveryCodes)-1)
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/2fa/disable", models.DisableTwoFactorRequest{Password: "securepass123", Code: enabled.RecoveryCodes[1]}, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("disable: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}, "")
+	var loggedIn models.AuthResponse
+	parseJSON(t, rec, &loggedIn)
+	if loggedIn.Tokens == nil {
+		t.Errorf("login after disabling: got %+v, want tokens", loggedIn)
+	}
+}
+
 func TestCreatePostTooLong(t *testing.T) {
 	ts := setupTestServer(t)
 
@@ -817,3 +1246,1034 @@ func TestGetUserPostsInvalidLimit(t *testing.T) {
 		t.Fatalf("invalid user posts limit: status = %d, want %d\nbody: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
 	}
 }
+
+// registerTestUser registers a user and returns its access token and ID.
+func registerTestUser(t *testing.T, ts *testServer, username string) (string, string) {
+	t.Helper()
+	rec := ts.do("POST", "/api/v1/auth/register", models.RegisterRequest{
+		Username: username,
+		Email:    username + "@example.com",
+		Password: "securepass123",
+	}, "")
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register %s: status = %d, want %d\nbody: %s", username, rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	var authResp models.AuthResponse
+	parseJSON(t, rec, &authResp)
+	return authResp.Tokens.AccessToken, authResp.User.ID
+}
+
+func TestFollowAndHomeTimeline(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, saraID := registerTestUser(t, ts, "sara")
+	omarToken, _ := registerTestUser(t, ts, "omar")
+
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "From sara"}, saraToken)
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "From omar"}, omarToken)
+
+	// Follow sara
+	rec := ts.do("POST", "/api/v1/users/"+saraID+"/follow", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("follow: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = ts.do("GET", "/api/v1/users/"+saraID+"/follow", nil, akramToken)
+	var status map[string]bool
+	parseJSON(t, rec, &status)
+	if !status["following"] {
+		t.Error("follow status: expected following = true")
+	}
+
+	// Home timeline only contains sara's post
+	rec = ts.do("GET", "/api/v1/timeline/home", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("home timeline: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var home models.TimelineResponse
+	parseJSON(t, rec, &home)
+	if len(home.Posts) != 1 || home.Posts[0].Content != "From sara" {
+		t.Errorf("home timeline = %+v, want only sara's post", home.Posts)
+	}
+
+	// Sara's followers list contains akram
+	rec = ts.do("GET", "/api/v1/users/"+saraID+"/followers", nil, akramToken)
+	var followers models.FollowListResponse
+	parseJSON(t, rec, &followers)
+	if len(followers.Users) != 1 || followers.Users[0].User.Username != "akram" {
+		t.Errorf("followers = %+v, want only akram", followers.Users)
+	}
+
+	// Akram's following list via "me"
+	rec = ts.do("GET", "/api/v1/users/me/following", nil, akramToken)
+	var following models.FollowListResponse
+	parseJSON(t, rec, &following)
+	if len(following.Users) != 1 || following.Users[0].User.ID != saraID {
+		t.Errorf("following = %+v, want only sara", following.Users)
+	}
+
+	// Unfollow empties the home timeline
+	rec = ts.do("DELETE", "/api/v1/users/"+saraID+"/follow", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unfollow: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("GET", "/api/v1/timeline/home", nil, akramToken)
+	var empty models.TimelineResponse
+	parseJSON(t, rec, &empty)
+	if len(empty.Posts) != 0 {
+		t.Errorf("home timeline after unfollow: got %d posts, want 0", len(empty.Posts))
+	}
+}
+
+func TestFollowSelf(t *testing.T) {
+	ts := setupTestServer(t)
+
+	token, userID := registerTestUser(t, ts, "akram")
+
+	rec := ts.do("POST", "/api/v1/users/"+userID+"/follow", nil, token)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("self follow: status = %d, want %d\nbody: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestRepliesAndThread(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, _ := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Root post"}, akramToken)
+	var created struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &created)
+	rootID := created.Post.ID
+
+	rec = ts.do("POST", "/api/v1/posts/"+rootID+"/replies", map[string]string{"content": "A reply"}, saraToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("reply: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var reply struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &reply)
+	if reply.Post.ParentID == nil || *reply.Post.ParentID != rootID {
+		t.Errorf("reply parent_id = %v, want %q", reply.Post.ParentID, rootID)
+	}
+
+	rec = ts.do("POST", "/api/v1/posts/"+reply.Post.ID+"/replies", map[string]string{"content": "Nested"}, akramToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("nested reply: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	rec = ts.do("GET", "/api/v1/posts/"+reply.Post.ID+"/thread", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("thread: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var thread models.ThreadResponse
+	parseJSON(t, rec, &thread)
+	if len(thread.Ancestors) != 1 || thread.Ancestors[0].ID != rootID {
+		t.Errorf("ancestors = %+v, want root only", thread.Ancestors)
+	}
+	if thread.Post.ID != reply.Post.ID {
+		t.Errorf("thread post = %q, want %q", thread.Post.ID, reply.Post.ID)
+	}
+	if len(thread.Replies) != 1 || thread.Replies[0].Content != "Nested" {
+		t.Errorf("replies = %+v, want the nested reply", thread.Replies)
+	}
+}
+
+func TestReplyTooLong(t *testing.T) {
+	ts := setupTestServer(t)
+
+	token, _ := registerTestUser(t, ts, "akram")
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Root"}, token)
+	var created struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &created)
+
+	rec = ts.do("POST", "/api/v1/posts/"+created.Post.ID+"/replies",
+		map[string]string{"content": strings.Repeat("a", 141)}, token)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d\nbody: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
+
+func TestLikeAndUnlike(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, _ := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Likeable"}, akramToken)
+	var created struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &created)
+	postID := created.Post.ID
+
+	rec = ts.do("POST", "/api/v1/posts/"+postID+"/like", nil, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("like: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var liked struct {
+		Liked     bool `json:"liked"`
+		LikeCount int  `json:"like_count"`
+	}
+	parseJSON(t, rec, &liked)
+	if !liked.Liked || liked.LikeCount != 1 {
+		t.Errorf("like response = %+v, want liked with count 1", liked)
+	}
+
+	// The liker sees liked_by_me; the author does not
+	rec = ts.do("GET", "/api/v1/posts/"+postID, nil, saraToken)
+	var got struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &got)
+	if got.Post.LikeCount != 1 || !got.Post.LikedByMe {
+		t.Errorf("sara sees %+v, want like_count=1 liked_by_me=true", got.Post)
+	}
+
+	rec = ts.do("GET", "/api/v1/timeline", nil, akramToken)
+	var timeline models.TimelineResponse
+	parseJSON(t, rec, &timeline)
+	if len(timeline.Posts) != 1 || timeline.Posts[0].LikeCount != 1 || timeline.Posts[0].LikedByMe {
+		t.Errorf("akram timeline = %+v, want like_count=1 liked_by_me=false", timeline.Posts)
+	}
+
+	rec = ts.do("DELETE", "/api/v1/posts/"+postID+"/like", nil, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unlike: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	parseJSON(t, rec, &liked)
+	if liked.Liked || liked.LikeCount != 0 {
+		t.Errorf("unlike response = %+v, want unliked with count 0", liked)
+	}
+}
+
+func TestRepostAndQuote(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, _ := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Worth sharing"}, akramToken)
+	var created struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &created)
+	postID := created.Post.ID
+
+	rec = ts.do("POST", "/api/v1/posts/"+postID+"/repost", nil, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("repost: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var reposted struct {
+		Reposted    bool `json:"reposted"`
+		RepostCount int  `json:"repost_count"`
+	}
+	parseJSON(t, rec, &reposted)
+	if !reposted.Reposted || reposted.RepostCount != 1 {
+		t.Errorf("repost response = %+v, want reposted with count 1", reposted)
+	}
+
+	rec = ts.do("POST", "/api/v1/posts/"+postID+"/repost", nil, saraToken)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("duplicate repost: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	// The repost replaces the original in the timeline and embeds it
+	rec = ts.do("GET", "/api/v1/timeline", nil, akramToken)
+	var timeline models.TimelineResponse
+	parseJSON(t, rec, &timeline)
+	if len(timeline.Posts) != 1 {
+		t.Fatalf("timeline has %d posts, want 1", len(timeline.Posts))
+	}
+	entry := timeline.Posts[0]
+	if entry.Kind != models.PostKindRepost || entry.Original == nil || entry.Original.ID != postID {
+		t.Errorf("timeline entry = %+v, want sara's repost", entry)
+	}
+
+	rec = ts.do("POST", "/api/v1/posts/"+postID+"/quote", map[string]string{"content": "Agreed"}, saraToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("quote: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var quoted struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &quoted)
+	if quoted.Post.Kind != models.PostKindQuote || quoted.Post.Original == nil || quoted.Post.Original.ID != postID {
+		t.Errorf("quote = %+v, want quote embedding %q", quoted.Post, postID)
+	}
+
+	rec = ts.do("DELETE", "/api/v1/posts/"+postID+"/repost", nil, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unrepost: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	parseJSON(t, rec, &reposted)
+	if reposted.Reposted || reposted.RepostCount != 0 {
+		t.Errorf("unrepost response = %+v, want not reposted with count 0", reposted)
+	}
+}
+
+func TestEditAndDeletePost(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, _ := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Frist post"}, akramToken)
+	var created struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &created)
+	postID := created.Post.ID
+
+	rec = ts.do("PATCH", "/api/v1/posts/"+postID, map[string]string{"content": "Hijacked"}, saraToken)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("edit by other user: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = ts.do("PATCH", "/api/v1/posts/"+postID, map[string]string{"content": "First post"}, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("edit: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var edited struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &edited)
+	if edited.Post.Content != "First post" || edited.Post.EditedAt == nil {
+		t.Errorf("edited post = %+v, want new content with edited_at", edited.Post)
+	}
+
+	rec = ts.do("GET", "/api/v1/posts/"+postID+"/history", nil, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("history: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var history models.PostHistoryResponse
+	parseJSON(t, rec, &history)
+	if len(history.Edits) != 1 || history.Edits[0].Content != "Frist post" {
+		t.Errorf("edits = %+v, want the original typo", history.Edits)
+	}
+
+	rec = ts.do("POST", "/api/v1/posts/"+postID+"/replies", map[string]string{"content": "Nice"}, saraToken)
+	var reply struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &reply)
+
+	rec = ts.do("DELETE", "/api/v1/posts/"+postID, nil, saraToken)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("delete by other user: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	rec = ts.do("DELETE", "/api/v1/posts/"+postID, nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	// The post leaves the timeline but stays in the thread as a placeholder
+	rec = ts.do("GET", "/api/v1/timeline", nil, akramToken)
+	var timeline models.TimelineResponse
+	parseJSON(t, rec, &timeline)
+	if len(timeline.Posts) != 1 || timeline.Posts[0].ID != reply.Post.ID {
+		t.Errorf("timeline = %+v, want only the reply", timeline.Posts)
+	}
+
+	rec = ts.do("GET", "/api/v1/posts/"+reply.Post.ID+"/thread", nil, saraToken)
+	var thread models.ThreadResponse
+	parseJSON(t, rec, &thread)
+	if len(thread.Ancestors) != 1 || !thread.Ancestors[0].Deleted || thread.Ancestors[0].Content != "" {
+		t.Errorf("ancestors = %+v, want a deleted placeholder", thread.Ancestors)
+	}
+}
+
+func TestMentionsFeed(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, saraID := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Welcome @sara!"}, akramToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "Unrelated"}, akramToken)
+
+	rec = ts.do("GET", "/api/v1/users/me/mentions", nil, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mentions: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var feed models.TimelineResponse
+	parseJSON(t, rec, &feed)
+	if len(feed.Posts) != 1 || feed.Posts[0].Content != "Welcome @sara!" {
+		t.Fatalf("mentions feed = %+v, want the welcome post", feed.Posts)
+	}
+	mentions := feed.Posts[0].Mentions
+	if len(mentions) != 1 || mentions[0].UserID != saraID || mentions[0].Username != "sara" {
+		t.Errorf("mentions = %+v, want sara", mentions)
+	}
+
+	rec = ts.do("GET", "/api/v1/users/me/mentions", nil, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous mentions: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestTagTimelineAndTrending(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Building a #TUI in #go"}, akramToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "Another #go post"}, akramToken)
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "No tags"}, akramToken)
+
+	rec = ts.do("GET", "/api/v1/tags/tui/posts", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("tag posts: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var feed models.TimelineResponse
+	parseJSON(t, rec, &feed)
+	if len(feed.Posts) != 1 || feed.Posts[0].Content != "Building a #TUI in #go" {
+		t.Fatalf("#tui posts = %+v, want the building post", feed.Posts)
+	}
+	if tags := feed.Posts[0].Tags; len(tags) != 2 || tags[0] != "tui" || tags[1] != "go" {
+		t.Errorf("tags = %v, want [tui go]", tags)
+	}
+
+	rec = ts.do("GET", "/api/v1/tags/bad-tag/posts", nil, akramToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid tag: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = ts.do("GET", "/api/v1/tags/trending", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("trending: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var trending struct {
+		Tags []models.TrendingTag `json:"tags"`
+	}
+	parseJSON(t, rec, &trending)
+	if len(trending.Tags) != 2 || trending.Tags[0].Tag != "go" || trending.Tags[0].PostCount != 2 {
+		t.Errorf("trending = %+v, want go first with 2 posts", trending.Tags)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	_, saraID := registerTestUser(t, ts, "sara")
+
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "Searching for bubbletea tips"}, akramToken)
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "Unrelated"}, akramToken)
+
+	rec := ts.do("GET", "/api/v1/search?q=bubbletea", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("search posts: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var posts models.TimelineResponse
+	parseJSON(t, rec, &posts)
+	if len(posts.Posts) != 1 || posts.Posts[0].Content != "Searching for bubbletea tips" {
+		t.Errorf("post results = %+v, want the bubbletea post", posts.Posts)
+	}
+
+	rec = ts.do("GET", "/api/v1/search?q=sar&type=users", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("search users: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var users models.UserSearchResponse
+	parseJSON(t, rec, &users)
+	if len(users.Users) != 1 || users.Users[0].ID != saraID {
+		t.Errorf("user results = %+v, want sara", users.Users)
+	}
+
+	rec = ts.do("GET", "/api/v1/search?q=", nil, akramToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("empty query: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	rec = ts.do("GET", "/api/v1/search?q=go&type=tags", nil, akramToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("bad type: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, akramID := registerTestUser(t, ts, "akram")
+	saraToken, saraID := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "First post"}, akramToken)
+	var created struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &created)
+
+	ts.do("POST", "/api/v1/posts/"+created.Post.ID+"/like", nil, saraToken)
+	ts.do("POST", "/api/v1/posts/"+created.Post.ID+"/replies", map[string]string{"content": "Nice one"}, saraToken)
+	ts.do("POST", "/api/v1/users/"+akramID+"/follow", nil, saraToken)
+
+	rec = ts.do("GET", "/api/v1/notifications", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("notifications: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var list models.NotificationListResponse
+	parseJSON(t, rec, &list)
+	if len(list.Notifications) != 3 || list.UnreadCount != 3 {
+		t.Fatalf("notifications = %+v (unread %d), want 3 unread", list.Notifications, list.UnreadCount)
+	}
+	kinds := map[string]bool{}
+	for _, n := range list.Notifications {
+		kinds[n.Kind] = true
+		if n.Actor.ID != saraID {
+			t.Errorf("actor = %+v, want sara", n.Actor)
+		}
+	}
+	for _, kind := range []string{"like", "reply", "follow"} {
+		if !kinds[kind] {
+			t.Errorf("missing %s notification", kind)
+		}
+	}
+
+	newest := list.Notifications[0].CreatedAt
+	rec = ts.do("POST", "/api/v1/notifications/read", map[string]any{"cursor": newest}, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mark read: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = ts.do("GET", "/api/v1/notifications/unread_count", nil, akramToken)
+	var count struct {
+		UnreadCount int `json:"unread_count"`
+	}
+	parseJSON(t, rec, &count)
+	if count.UnreadCount != 0 {
+		t.Errorf("unread = %d, want 0 after marking all read", count.UnreadCount)
+	}
+
+	rec = ts.do("POST", "/api/v1/notifications/read", map[string]any{}, akramToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("missing cursor: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestConversations(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, _ := registerTestUser(t, ts, "sara")
+	omarToken, _ := registerTestUser(t, ts, "omar")
+
+	rec := ts.do("POST", "/api/v1/conversations", map[string]any{"usernames": []string{"sara"}}, akramToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var created struct {
+		Conversation models.Conversation `json:"conversation"`
+	}
+	parseJSON(t, rec, &created)
+	convPath := "/api/v1/conversations/" + created.Conversation.ID
+
+	// Starting it again from the other side reuses the conversation
+	rec = ts.do("POST", "/api/v1/conversations", map[string]any{"usernames": []string{"akram"}}, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reuse: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = ts.do("POST", convPath+"/messages", map[string]string{"content": "Hi sara"}, akramToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("send: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+
+	rec = ts.do("GET", "/api/v1/conversations", nil, saraToken)
+	var inbox models.ConversationListResponse
+	parseJSON(t, rec, &inbox)
+	if len(inbox.Conversations) != 1 || !inbox.Conversations[0].Unread {
+		t.Fatalf("sara's inbox = %+v, want one unread conversation", inbox.Conversations)
+	}
+
+	rec = ts.do("GET", convPath+"/messages", nil, saraToken)
+	var messages models.MessageListResponse
+	parseJSON(t, rec, &messages)
+	if len(messages.Messages) != 1 || messages.Messages[0].Sender.Username != "akram" {
+		t.Errorf("messages = %+v", messages.Messages)
+	}
+
+	rec = ts.do("POST", convPath+"/read", nil, saraToken)
+	if rec.Code != http.StatusOK {
+		t.Errorf("read: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// Outsiders get a 404 for everything
+	for _, req := range []struct{ method, path string }{
+		{"GET", convPath},
+		{"GET", convPath + "/messages"},
+		{"POST", convPath + "/read"},
+	} {
+		if rec := ts.do(req.method, req.path, nil, omarToken); rec.Code != http.StatusNotFound {
+			t.Errorf("%s %s by outsider: status = %d, want %d", req.method, req.path, rec.Code, http.StatusNotFound)
+		}
+	}
+	rec = ts.do("POST", convPath+"/messages", map[string]string{"content": "hello?"}, omarToken)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("send by outsider: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	// Messages never appear in timelines
+	rec = ts.do("GET", "/api/v1/timeline", nil, saraToken)
+	var timeline models.TimelineResponse
+	parseJSON(t, rec, &timeline)
+	if len(timeline.Posts) != 0 {
+		t.Errorf("timeline = %+v, want no posts", timeline.Posts)
+	}
+}
+
+func TestBlockAndMute(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, saraID := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Hello from sara"}, saraToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create post: status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+
+	rec = ts.do("POST", "/api/v1/users/"+saraID+"/block", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("block: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var status struct {
+		Blocking bool `json:"blocking"`
+		Muting   bool `json:"muting"`
+	}
+	rec = ts.do("GET", "/api/v1/users/"+saraID+"/block", nil, akramToken)
+	parseJSON(t, rec, &status)
+	if !status.Blocking {
+		t.Error("expected akram to be blocking sara")
+	}
+
+	rec = ts.do("GET", "/api/v1/timeline", nil, akramToken)
+	var timeline models.TimelineResponse
+	parseJSON(t, rec, &timeline)
+	if len(timeline.Posts) != 0 {
+		t.Errorf("timeline = %+v, want blocked posts hidden", timeline.Posts)
+	}
+
+	rec = ts.do("POST", "/api/v1/users/"+saraID+"/follow", nil, akramToken)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("follow blocked user: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = ts.do("GET", "/api/v1/users/me/blocks", nil, akramToken)
+	var blocks models.RelationListResponse
+	parseJSON(t, rec, &blocks)
+	if len(blocks.Users) != 1 || blocks.Users[0].User.Username != "sara" {
+		t.Errorf("blocks = %+v, want sara", blocks.Users)
+	}
+
+	rec = ts.do("DELETE", "/api/v1/users/"+saraID+"/block", nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unblock: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	rec = ts.do("POST", "/api/v1/users/"+saraID+"/mute", nil, akramToken)
+	parseJSON(t, rec, &status)
+	if !status.Muting {
+		t.Errorf("mute response = %s, want muting true", rec.Body.String())
+	}
+	rec = ts.do("GET", "/api/v1/users/me/mutes", nil, akramToken)
+	var mutes models.RelationListResponse
+	parseJSON(t, rec, &mutes)
+	if len(mutes.Users) != 1 {
+		t.Errorf("mutes = %+v, want sara", mutes.Users)
+	}
+
+	rec = ts.do("POST", "/api/v1/users/"+saraID+"/mute", nil, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous mute: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, _ := registerTestUser(t, ts, "sara")
+
+	for _, content := range []string{"Big spoiler inside", "Lunch was great"} {
+		rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": content}, saraToken)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create post: status = %d, want %d", rec.Code, http.StatusCreated)
+		}
+	}
+
+	rec := ts.do("POST", "/api/v1/filters", map[string]any{"phrase": "spoiler", "action": "flag", "expires_in": 3600}, akramToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create filter: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	var created struct {
+		Filter models.Filter `json:"filter"`
+	}
+	parseJSON(t, rec, &created)
+	if created.Filter.ExpiresAt == nil {
+		t.Error("expected filter to carry an expiry")
+	}
+
+	rec = ts.do("POST", "/api/v1/filters", map[string]any{"phrase": "(", "match": "regex"}, akramToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid regex: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	rec = ts.do("POST", "/api/v1/filters", map[string]any{"phrase": "spoiler", "expires_in": int64(math.MaxInt64)}, akramToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("overflowing expiry: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = ts.do("GET", "/api/v1/timeline", nil, akramToken)
+	var timeline models.TimelineResponse
+	parseJSON(t, rec, &timeline)
+	if len(timeline.Posts) != 2 || timeline.Posts[1].Filtered != "spoiler" || timeline.Posts[0].Filtered != "" {
+		t.Errorf("timeline = %+v, want the spoiler post flagged", timeline.Posts)
+	}
+
+	rec = ts.do("GET", "/api/v1/filters", nil, akramToken)
+	var list models.FilterListResponse
+	parseJSON(t, rec, &list)
+	if len(list.Filters) != 1 {
+		t.Fatalf("filters = %+v, want 1", list.Filters)
+	}
+
+	rec = ts.do("DELETE", "/api/v1/filters/"+created.Filter.ID, nil, saraToken)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("delete other user's filter: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	rec = ts.do("DELETE", "/api/v1/filters/"+created.Filter.ID, nil, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Errorf("delete filter: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestReportsAndModeration(t *testing.T) {
+	ts := setupTestServer(t)
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, saraID := registerTestUser(t, ts, "sara")
+	modToken, _ := registerTestUser(t, ts, "mod")
+
+	rec := ts.do("POST", "/api/v1/posts", map[string]string{"content": "Buy followers now"}, saraToken)
+	var created struct {
+		Post models.Post `json:"post"`
+	}
+	parseJSON(t, rec, &created)
+	post := created.Post
+
+	rec = ts.do("POST", "/api/v1/posts/"+post.ID+"/report", map[string]string{"reason": "spam"}, akramToken)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("report post: status = %d, want %d\nbody: %s", rec.Code, http.StatusCreated, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/users/"+saraID+"/report", map[string]string{"reason": "bad vibes"}, akramToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("report with unknown reason: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = ts.do("GET", "/api/v1/moderation/reports", nil, akramToken)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("queue as non-moderator: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	rec = ts.do("GET", "/api/v1/moderation/reports", nil, modToken)
+	var queue models.ReportListResponse
+	parseJSON(t, rec, &queue)
+	if len(queue.Reports) != 1 || queue.Reports[0].Post == nil {
+		t.Fatalf("queue = %+v, want the post report", queue.Reports)
+	}
+
+	rec = ts.do("POST", "/api/v1/moderation/posts/"+post.ID+"/hide", nil, modToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("hide post: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/moderation/users/"+saraID+"/suspend", map[string]string{"reason": "spam"}, modToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("suspend: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "sara@example.com", Password: "securepass123"}, "")
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("login while suspended: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	rec = ts.do("GET", "/api/v1/moderation/log", nil, modToken)
+	var log models.ModerationLogResponse
+	parseJSON(t, rec, &log)
+	if len(log.Actions) != 2 || log.Actions[0].Action != models.ModerationSuspendUser {
+		t.Errorf("log = %+v, want hide and suspend", log.Actions)
+	}
+}
+
+func TestAdminAPI(t *testing.T) {
+	ts := setupTestServer(t)
+
+	_, adminID := registerTestUser(t, ts, "akram")
+	saraToken, saraID := registerTestUser(t, ts, "sara")
+
+	rec := ts.do("GET", "/api/v1/admin/users", nil, saraToken)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("list users as non-admin: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	rec = ts.do("GET", "/api/v1/admin/users", nil, "")
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("list users anonymously: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// The role is read at login, so promote first and then sign in again
+	if err := ts.users.SetRole(context.Background(), adminID, models.RoleAdmin); err != nil {
+		t.Fatalf("SetRole: %v", err)
+	}
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}, "")
+	var login models.AuthResponse
+	parseJSON(t, rec, &login)
+	if login.User.Role != models.RoleAdmin {
+		t.Fatalf("login user role = %q, want %q", login.User.Role, models.RoleAdmin)
+	}
+	adminToken := login.Tokens.AccessToken
+
+	rec = ts.do("GET", "/api/v1/admin/users", nil, adminToken)
+	var list models.AdminUserListResponse
+	parseJSON(t, rec, &list)
+	if len(list.Users) != 2 || list.Users[0].Email != "sara@example.com" {
+		t.Errorf("users = %+v, want sara then akram", list.Users)
+	}
+
+	rec = ts.do("PUT", "/api/v1/admin/users/"+saraID+"/role", map[string]string{"role": "owner"}, adminToken)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("set unknown role: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	rec = ts.do("PUT", "/api/v1/admin/users/"+saraID+"/role", map[string]string{"role": "moderator"}, adminToken)
+	if rec.Code != http.StatusOK {
+		t.Errorf("set role: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = ts.do("POST", "/api/v1/admin/users/"+saraID+"/logout", nil, adminToken)
+	if rec.Code != http.StatusOK {
+		t.Errorf("force logout: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = ts.do("POST", "/api/v1/admin/users/"+saraID+"/suspend", map[string]string{"reason": "spam"}, adminToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("suspend: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "sara@example.com", Password: "securepass123"}, "")
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("login while suspended: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	// A demoted admin loses the admin API at once, while their access
+	// token still says admin
+	_, omarID := registerTestUser(t, ts, "omar")
+	if err := ts.users.SetRole(context.Background(), omarID, models.RoleAdmin); err != nil {
+		t.Fatalf("SetRole: %v", err)
+	}
+	rec = ts.do("POST", "/api/v1/auth/login", models.LoginRequest{Email: "omar@example.com", Password: "securepass123"}, "")
+	parseJSON(t, rec, &login)
+	omarToken := login.Tokens.AccessToken
+	if rec := ts.do("GET", "/api/v1/admin/users", nil, omarToken); rec.Code != http.StatusOK {
+		t.Fatalf("list users as omar: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	rec = ts.do("PUT", "/api/v1/admin/users/"+omarID+"/role", map[string]string{"role": "user"}, adminToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("demote: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if rec := ts.do("GET", "/api/v1/admin/users", nil, omarToken); rec.Code != http.StatusForbidden {
+		t.Errorf("list users after demotion: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestAccountStatus(t *testing.T) {
+	ts := setupTestServer(t)
+	ctx := context.Background()
+
+	akramToken, _ := registerTestUser(t, ts, "akram")
+	saraToken, saraID := registerTestUser(t, ts, "sara")
+	akramLogin := models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}
+
+	rec := ts.do("POST", "/api/v1/users/me/deactivate", map[string]string{"password": "wrongpass123"}, akramToken)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("deactivate with wrong password: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("POST", "/api/v1/users/me/deactivate", map[string]string{"password": "securepass123"}, akramToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("deactivate: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/login", akramLogin, "")
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), models.ErrCodeAccountDeactivated) {
+		t.Errorf("login while deactivated: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	// Existing access tokens stop working once the denylist reloads
+	if err := ts.users.SetStatus(ctx, saraID, models.AccountActive, models.AccountSuspended); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if err := ts.denylist.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	rec = ts.do("GET", "/api/v1/timeline", nil, saraToken)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), models.ErrCodeAccountSuspended) {
+		t.Errorf("request while suspended: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	rec = ts.do("GET", "/api/v1/timeline", nil, akramToken)
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), models.ErrCodeAccountDeactivated) {
+		t.Errorf("request while deactivated: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/reactivate", akramLogin, "")
+	var resp models.AuthResponse
+	parseJSON(t, rec, &resp)
+	if err := ts.denylist.Reload(ctx); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	rec = ts.do("GET", "/api/v1/timeline", nil, resp.Tokens.AccessToken)
+	if rec.Code != http.StatusOK {
+		t.Errorf("request after reactivation: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestAccountDeletion(t *testing.T) {
+	ts := setupTestServer(t)
+
+	token, _ := registerTestUser(t, ts, "akram")
+	login := models.LoginRequest{Email: "akram@example.com", Password: "securepass123"}
+
+	rec := ts.do("DELETE", "/api/v1/users/me", map[string]string{"password": "wrongpass123"}, token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("delete with wrong password: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	rec = ts.do("DELETE", "/api/v1/users/me", map[string]string{"password": "securepass123"}, token)
+	var scheduled struct {
+		DeleteAfter time.Time `json:"delete_after"`
+	}
+	parseJSON(t, rec, &scheduled)
+	if time.Until(scheduled.DeleteAfter) < 59*time.Minute {
+		t.Errorf("delete_after = %v, want an hour from now", scheduled.DeleteAfter)
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/login", login, "")
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), models.ErrCodeAccountPendingDeletion) {
+		t.Errorf("login while pending deletion: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+
+	rec = ts.do("POST", "/api/v1/auth/cancel-deletion", login, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("cancel deletion: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	rec = ts.do("POST", "/api/v1/auth/login", login, "")
+	if rec.Code != http.StatusOK {
+		t.Errorf("login after cancelling: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestDataExport(t *testing.T) {
+	ts := setupTestServer(t)
+
+	token, _ := registerTestUser(t, ts, "akram")
+	otherToken, _ := registerTestUser(t, ts, "sara")
+	ts.do("POST", "/api/v1/posts", map[string]string{"content": "For the archive"}, token)
+
+	rec := ts.do("POST", "/api/v1/users/me/export", nil, token)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("request export: status = %d, want %d\nbody: %s", rec.Code, http.StatusAccepted, rec.Body.String())
+	}
+	var started struct {
+		Export models.Export `json:"export"`
+	}
+	parseJSON(t, rec, &started)
+	path := "/api/v1/users/me/export/" + started.Export.ID
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		rec = ts.do("GET", path, nil, token)
+		var status struct {
+			Export models.Export `json:"export"`
+		}
+		parseJSON(t, rec, &status)
+		if status.Export.Status == models.ExportReady {
+			break
+		}
+		if status.Export.Status != models.ExportPending || time.Now().After(deadline) {
+			t.Fatalf("export status = %q, want ready", status.Export.Status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if rec := ts.do("GET", path, nil, otherToken); rec.Code != http.StatusNotFound {
+		t.Errorf("another user's export: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = ts.do("GET", path+"/archive", nil, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("download: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.Contains(cd, "attachment") {
+		t.Errorf("Content-Disposition = %q, want an attachment", cd)
+	}
+	var archive models.ExportArchive
+	parseJSON(t, rec, &archive)
+	if archive.User == nil || archive.User.Username != "akram" {
+		t.Errorf("archive user = %+v, want akram", archive.User)
+	}
+	if len(archive.Posts) != 1 || archive.Posts[0].Content != "For the archive" {
+		t.Errorf("archive posts = %+v, want the one post", archive.Posts)
+	}
+}
+
+func TestImportPosts(t *testing.T) {
+	ts := setupTestServer(t)
+
+	token, userID := registerTestUser(t, ts, "akram")
+	archive := map[string]any{
+		"posts": []map[string]any{
+			{"kind": "post", "content": "Imported", "created_at": "2025-03-01T10:00:00Z"},
+			{"kind": "post", "content": "", "created_at": "2025-03-01T09:00:00Z"},
+		},
+	}
+
+	rec := ts.do("POST", "/api/v1/users/me/import", archive, token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import: status = %d, want %d\nbody: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var resp struct {
+		Result models.ImportResult `json:"result"`
+	}
+	parseJSON(t, rec, &resp)
+	if resp.Result.Imported != 1 || len(resp.Result.Errors) != 1 || resp.Result.Errors[0].Row != 2 {
+		t.Errorf("result = %+v, want 1 imported and row 2 rejected", resp.Result)
+	}
+
+	rec = ts.do("GET", "/api/v1/users/"+userID+"/posts", nil, token)
+	var timeline models.TimelineResponse
+	parseJSON(t, rec, &timeline)
+	want := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	if len(timeline.Posts) != 1 || !timeline.Posts[0].CreatedAt.Equal(want) {
+		t.Errorf("posts = %+v, want the imported post dated %v", timeline.Posts, want)
+	}
+
+	if rec := ts.do("POST", "/api/v1/users/me/import?format=xml", archive, token); rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown format: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if rec := ts.do("POST", "/api/v1/users/me/import", archive, ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous import: status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	// When posting needs a verified email, so does importing
+	ts.importSvc.RequireVerifiedEmail(ts.users)
+	if rec := ts.do("POST", "/api/v1/users/me/import", archive, token); rec.Code != http.StatusForbidden {
+		t.Errorf("import when unverified: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
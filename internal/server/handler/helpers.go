@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
 )
 
 func writeJSON(w http.ResponseWriter, status int, data any) {
@@ -35,7 +38,9 @@ func errorCodeToHTTPStatus(code string) int {
 		return http.StatusBadRequest
 	case models.ErrCodeUnauthorized, models.ErrCodeTokenExpired:
 		return http.StatusUnauthorized
-	case models.ErrCodeForbidden:
+	case models.ErrCodeForbidden, models.ErrCodeAccountDeactivated,
+		models.ErrCodeAccountSuspended, models.ErrCodeAccountPendingDeletion,
+		models.ErrCodeEmailUnverified:
 		return http.StatusForbidden
 	case models.ErrCodeNotFound:
 		return http.StatusNotFound
@@ -54,3 +59,98 @@ func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
 	dec.DisallowUnknownFields()
 	return dec.Decode(v)
 }
+
+// parsePageParams reads the cursor and limit query parameters shared by all
+// paginated endpoints. The cursor defaults to now and the limit to 50.
+func parsePageParams(r *http.Request) (time.Time, int, error) {
+	cursor := time.Now()
+	if c := r.URL.Query().Get("cursor"); c != "" {
+		parsed, err := time.Parse(time.RFC3339, c)
+		if err != nil {
+			return time.Time{}, 0, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid cursor format, expected RFC3339",
+			}
+		}
+		cursor = parsed
+	}
+
+	limit := 50
+	if l := r.URL.Query().Get("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
+		if err != nil || parsed < 1 || parsed > 100 {
+			return time.Time{}, 0, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "limit must be between 1 and 100",
+			}
+		}
+		limit = parsed
+	}
+
+	return cursor, limit, nil
+}
+
+// nextCursor formats the timestamp of the last item in a page as the cursor
+// for the following page.
+func nextCursor(last time.Time) *string {
+	c := last.Format(time.RFC3339Nano)
+	return &c
+}
+
+// newTimelineResponse builds a paginated post list response.
+func newTimelineResponse(posts []models.Post, limit int) models.TimelineResponse {
+	resp := models.TimelineResponse{
+		Posts:   posts,
+		HasMore: len(posts) == limit,
+	}
+	if len(posts) > 0 {
+		resp.NextCursor = nextCursor(posts[len(posts)-1].CreatedAt)
+	}
+	return resp
+}
+
+// newFeedResponse builds a paginated response for a filtered feed, whose
+// next cursor comes from the service because filters can hide the posts
+// at the end of a page.
+func newFeedResponse(posts []models.Post, next time.Time) models.TimelineResponse {
+	resp := models.TimelineResponse{Posts: posts, HasMore: !next.IsZero()}
+	switch {
+	case resp.HasMore:
+		resp.NextCursor = nextCursor(next)
+	case len(posts) > 0:
+		resp.NextCursor = nextCursor(posts[len(posts)-1].CreatedAt)
+	}
+	return resp
+}
+
+// requireUserID returns the authenticated user's ID, writing a 401 and
+// returning false when the request carries no user.
+func requireUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := middleware.UserIDFromContext(r.Context())
+	if userID == "" {
+		writeAPIError(w, &models.APIError{
+			Code:    models.ErrCodeUnauthorized,
+			Message: "authentication required",
+		})
+		return "", false
+	}
+	return userID, true
+}
+
+// pathUserID returns the {id} path value, resolving "me" to the
+// authenticated user.
+func pathUserID(r *http.Request) string {
+	id := r.PathValue("id")
+	if id == "me" {
+		return middleware.UserIDFromContext(r.Context())
+	}
+	return id
+}
+
+// requestDevice completes the device a client described with the user
+// agent and address the request came from.
+func requestDevice(r *http.Request, device models.Device) models.Device {
+	device.UserAgent = r.UserAgent()
+	device.IP = middleware.ClientIP(r)
+	return device
+}
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
 )
@@ -86,6 +87,7 @@ func TestErrorCodeToHTTPStatus(t *testing.T) {
 		{models.ErrCodeUnauthorized, http.StatusUnauthorized},
 		{models.ErrCodeTokenExpired, http.StatusUnauthorized},
 		{models.ErrCodeForbidden, http.StatusForbidden},
+		{models.ErrCodeAccountSuspended, http.StatusForbidden},
 		{models.ErrCodeNotFound, http.StatusNotFound},
 		{models.ErrCodeConflict, http.StatusConflict},
 		{models.ErrCodeRateLimited, http.StatusTooManyRequests},
@@ -142,3 +144,65 @@ func TestDecodeBodyRejectsOversizedBody(t *testing.T) {
 		t.Error("expected error for oversized body, got nil")
 	}
 }
+
+func TestParsePageParamsDefaults(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/timeline", nil)
+	cursor, limit, err := parsePageParams(req)
+	if err != nil {
+		t.Fatalf("parsePageParams: %v", err)
+	}
+	if limit != 50 {
+		t.Errorf("limit = %d, want 50", limit)
+	}
+	if cursor.IsZero() {
+		t.Error("expected cursor to default to now")
+	}
+}
+
+func TestParsePageParamsInvalid(t *testing.T) {
+	tests := []string{
+		"/api/v1/timeline?cursor=not-a-date",
+		"/api/v1/timeline?limit=0",
+		"/api/v1/timeline?limit=101",
+		"/api/v1/timeline?limit=abc",
+	}
+	for _, path := range tests {
+		req := httptest.NewRequest("GET", path, nil)
+		if _, _, err := parsePageParams(req); err == nil {
+			t.Errorf("parsePageParams(%q): expected error", path)
+		}
+	}
+}
+
+func TestNewTimelineResponse(t *testing.T) {
+	posts := []models.Post{
+		{ID: "1", CreatedAt: time.Date(2026, 2, 15, 23, 30, 0, 0, time.UTC)},
+		{ID: "2", CreatedAt: time.Date(2026, 2, 15, 23, 0, 0, 0, time.UTC)},
+	}
+
+	resp := newTimelineResponse(posts, 2)
+	if !resp.HasMore {
+		t.Error("expected has_more when page is full")
+	}
+	if resp.NextCursor == nil || *resp.NextCursor != "2026-02-15T23:00:00Z" {
+		t.Errorf("next_cursor = %v, want last post's created_at", resp.NextCursor)
+	}
+
+	empty := newTimelineResponse(nil, 50)
+	if empty.HasMore || empty.NextCursor != nil {
+		t.Errorf("empty page = %+v, want no cursor and has_more false", empty)
+	}
+}
+
+func TestNewFeedResponse(t *testing.T) {
+	next := time.Date(2026, 2, 15, 22, 0, 0, 0, time.UTC)
+	resp := newFeedResponse([]models.Post{{ID: "1"}}, next)
+	if !resp.HasMore || resp.NextCursor == nil || *resp.NextCursor != "2026-02-15T22:00:00Z" {
+		t.Errorf("short filtered page = %+v, want has_more and the scanned cursor", resp)
+	}
+
+	last := newFeedResponse(nil, time.Time{})
+	if last.HasMore || last.NextCursor != nil {
+		t.Errorf("empty last page = %+v, want no cursor and has_more false", last)
+	}
+}
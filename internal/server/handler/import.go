@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// HandleImport creates the caller's posts from the archive in the request
+// body. The format query parameter selects json (the default), jsonl or
+// csv. The response reports how many posts were imported and why any rows
+// were not.
+func HandleImport(importSvc *service.ImportService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		archive, err := io.ReadAll(http.MaxBytesReader(w, r.Body, service.MaxImportBytes))
+		if err != nil {
+			message := "invalid request body"
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				message = fmt.Sprintf("archive must be %d bytes or smaller", service.MaxImportBytes)
+			}
+			writeAPIError(w, &models.APIError{Code: models.ErrCodeValidation, Message: message})
+			return
+		}
+
+		result, err := importSvc.ImportPosts(r.Context(), userID, r.URL.Query().Get("format"), bytes.NewReader(archive))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"result": result})
+	}
+}
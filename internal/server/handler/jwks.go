@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+)
+
+// HandleJWKS publishes the public keys access tokens are signed with, so
+// other services can verify them without sharing a secret.
+func HandleJWKS(keys *jwtkeys.KeySet) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		writeJSON(w, http.StatusOK, keys.JWKS())
+	}
+}
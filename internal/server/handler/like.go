@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleLike(likeSvc *service.LikeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		count, err := likeSvc.Like(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"liked": true, "like_count": count})
+	}
+}
+
+func HandleUnlike(likeSvc *service.LikeService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		count, err := likeSvc.Unlike(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"liked": false, "like_count": count})
+	}
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleGetConversations(msgSvc *service.MessageService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		conversations, err := msgSvc.GetConversations(r.Context(), userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		resp := models.ConversationListResponse{
+			Conversations: conversations,
+			HasMore:       len(conversations) == limit,
+		}
+		if len(conversations) > 0 {
+			resp.NextCursor = nextCursor(conversations[len(conversations)-1].LastMessageAt)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// HandleCreateConversation starts a conversation with the users in the
+// request body. An existing one-to-one conversation is returned with 200
+// instead of creating a duplicate.
+func HandleCreateConversation(msgSvc *service.MessageService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Usernames []string `json:"usernames"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		conversation, created, err := msgSvc.StartConversation(r.Context(), userID, body.Usernames)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		writeJSON(w, status, map[string]any{"conversation": conversation})
+	}
+}
+
+func HandleGetConversation(msgSvc *service.MessageService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		conversation, err := msgSvc.GetConversation(r.Context(), userID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"conversation": conversation})
+	}
+}
+
+func HandleGetMessages(msgSvc *service.MessageService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		messages, err := msgSvc.GetMessages(r.Context(), userID, r.PathValue("id"), cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		resp := models.MessageListResponse{
+			Messages: messages,
+			HasMore:  len(messages) == limit,
+		}
+		if len(messages) > 0 {
+			resp.NextCursor = nextCursor(messages[len(messages)-1].CreatedAt)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func HandleSendMessage(msgSvc *service.MessageService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Content string `json:"content"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		msg, err := msgSvc.SendMessage(r.Context(), userID, r.PathValue("id"), body.Content)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, map[string]any{"message": msg})
+	}
+}
+
+func HandleMarkConversationRead(msgSvc *service.MessageService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := msgSvc.MarkRead(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"read": true})
+	}
+}
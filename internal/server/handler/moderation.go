@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleReportPost(modSvc *service.ModerationService) http.HandlerFunc {
+	return handleReport(func(r *http.Request, userID, reason, details string) (*models.Report, error) {
+		return modSvc.ReportPost(r.Context(), userID, r.PathValue("id"), reason, details)
+	})
+}
+
+func HandleReportUser(modSvc *service.ModerationService) http.HandlerFunc {
+	return handleReport(func(r *http.Request, userID, reason, details string) (*models.Report, error) {
+		return modSvc.ReportUser(r.Context(), userID, r.PathValue("id"), reason, details)
+	})
+}
+
+// handleReport decodes a report request body and files it with report.
+func handleReport(report func(r *http.Request, userID, reason, details string) (*models.Report, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Reason  string `json:"reason"`
+			Details string `json:"details"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		created, err := report(r, userID, body.Reason, body.Details)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, map[string]any{"report": created})
+	}
+}
+
+// HandleGetReports serves the moderation queue. The status query parameter
+// selects open (the default), resolved or dismissed reports.
+func HandleGetReports(modSvc *service.ModerationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		reports, err := modSvc.GetReports(r.Context(), userID, r.URL.Query().Get("status"), cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		resp := models.ReportListResponse{
+			Reports: reports,
+			HasMore: len(reports) == limit,
+		}
+		if len(reports) > 0 {
+			resp.NextCursor = nextCursor(reports[len(reports)-1].CreatedAt)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func HandleGetModerationLog(modSvc *service.ModerationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		actions, err := modSvc.GetLog(r.Context(), userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		resp := models.ModerationLogResponse{
+			Actions: actions,
+			HasMore: len(actions) == limit,
+		}
+		if len(actions) > 0 {
+			resp.NextCursor = nextCursor(actions[len(actions)-1].CreatedAt)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func HandleHidePost(modSvc *service.ModerationService) http.HandlerFunc {
+	return handleModerationAction(modSvc.HidePost, "hidden")
+}
+
+func HandleSuspendUser(modSvc *service.ModerationService) http.HandlerFunc {
+	return handleModerationAction(modSvc.SuspendUser, "suspended")
+}
+
+func HandleUnsuspendUser(modSvc *service.ModerationService) http.HandlerFunc {
+	return handleModerationAction(modSvc.UnsuspendUser, "unsuspended")
+}
+
+func HandleDismissReport(modSvc *service.ModerationService) http.HandlerFunc {
+	return handleModerationAction(modSvc.DismissReport, "dismissed")
+}
+
+// handleModerationAction applies act to the {id} in the path, passing the
+// optional reason from the request body, and responds with {result: true}.
+func handleModerationAction(act func(ctx context.Context, moderatorID, targetID, reason string) error, result string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Reason string `json:"reason"`
+		}
+		if err := decodeBody(w, r, &body); err != nil && !errors.Is(err, io.EOF) {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := act(r.Context(), userID, r.PathValue("id"), body.Reason); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{result: true})
+	}
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleGetNotifications(notificationSvc *service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		notifications, err := notificationSvc.GetNotifications(r.Context(), userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		unread, err := notificationSvc.UnreadCount(r.Context(), userID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		resp := models.NotificationListResponse{
+			Notifications: notifications,
+			HasMore:       len(notifications) == limit,
+			UnreadCount:   unread,
+		}
+		if len(notifications) > 0 {
+			resp.NextCursor = nextCursor(notifications[len(notifications)-1].CreatedAt)
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func HandleUnreadNotificationCount(notificationSvc *service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		unread, err := notificationSvc.UnreadCount(r.Context(), userID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"unread_count": unread})
+	}
+}
+
+// HandleMarkNotificationsRead marks notifications read up to and including
+// the cursor in the request body, typically the newest notification's
+// created_at.
+func HandleMarkNotificationsRead(notificationSvc *service.NotificationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Cursor *time.Time `json:"cursor"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+		if body.Cursor == nil {
+			writeAPIError(w, &models.APIError{
+				Code: models.ErrCodeValidation, Field: "cursor",
+				Message: "cursor is required",
+			})
+			return
+		}
+
+		unread, err := notificationSvc.MarkRead(r.Context(), userID, *body.Cursor)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"unread_count": unread})
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// HandleChangePassword changes the caller's password and signs out their
+// other devices.
+func HandleChangePassword(passwordSvc *service.PasswordService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.ChangePasswordRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		sessionID := middleware.SessionIDFromContext(r.Context())
+		if err := passwordSvc.ChangePassword(r.Context(), userID, sessionID, &req); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"changed": true})
+	}
+}
+
+// HandleRequestPasswordReset emails a reset token. It answers the same
+// whether or not the email has an account.
+func HandleRequestPasswordReset(passwordSvc *service.PasswordService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.PasswordResetRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := passwordSvc.RequestReset(r.Context(), req.Email); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, map[string]any{"requested": true})
+	}
+}
+
+// HandleConfirmPasswordReset sets a new password with an emailed reset
+// token.
+func HandleConfirmPasswordReset(passwordSvc *service.PasswordService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.ConfirmPasswordResetRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := passwordSvc.ConfirmReset(r.Context(), &req); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"reset": true})
+	}
+}
@@ -10,12 +10,8 @@ import (
 
 func HandleCreatePost(postSvc *service.PostService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID := middleware.UserIDFromContext(r.Context())
-		if userID == "" {
-			writeAPIError(w, &models.APIError{
-				Code:    models.ErrCodeUnauthorized,
-				Message: "authentication required",
-			})
+		userID, ok := requireUserID(w, r)
+		if !ok {
 			return
 		}
 
@@ -51,7 +47,8 @@ func HandleGetPost(postSvc *service.PostService) http.HandlerFunc {
 			return
 		}
 
-		post, err := postSvc.GetPostByID(r.Context(), id)
+		viewerID := middleware.UserIDFromContext(r.Context())
+		post, err := postSvc.GetPostByID(r.Context(), viewerID, id)
 		if err != nil {
 			writeAPIError(w, err)
 			return
@@ -60,3 +57,101 @@ func HandleGetPost(postSvc *service.PostService) http.HandlerFunc {
 		writeJSON(w, http.StatusOK, map[string]any{"post": post})
 	}
 }
+
+func HandleCreateReply(postSvc *service.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Content string `json:"content"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		post, err := postSvc.CreateReply(r.Context(), userID, r.PathValue("id"), body.Content)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, map[string]any{"post": post})
+	}
+}
+
+func HandleGetThread(postSvc *service.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		viewerID := middleware.UserIDFromContext(r.Context())
+		thread, err := postSvc.GetThread(r.Context(), viewerID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, thread)
+	}
+}
+
+func HandleEditPost(postSvc *service.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			Content string `json:"content"`
+		}
+		if err := decodeBody(w, r, &body); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		post, err := postSvc.EditPost(r.Context(), userID, r.PathValue("id"), body.Content)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"post": post})
+	}
+}
+
+func HandleDeletePost(postSvc *service.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		if err := postSvc.DeletePost(r.Context(), userID, r.PathValue("id")); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"deleted": true})
+	}
+}
+
+func HandleGetPostHistory(postSvc *service.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		viewerID := middleware.UserIDFromContext(r.Context())
+		history, err := postSvc.GetHistory(r.Context(), viewerID, r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, history)
+	}
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// HandleSearch serves GET /api/v1/search?q=&type=posts|users. The type
+// defaults to posts.
+func HandleSearch(searchSvc *service.SearchService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		query := r.URL.Query().Get("q")
+		viewerID := middleware.UserIDFromContext(r.Context())
+		switch r.URL.Query().Get("type") {
+		case "", models.SearchTypePosts:
+			posts, err := searchSvc.SearchPosts(r.Context(), viewerID, query, cursor, limit)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, newTimelineResponse(posts, limit))
+
+		case models.SearchTypeUsers:
+			users, err := searchSvc.SearchUsers(r.Context(), viewerID, query, cursor, limit)
+			if err != nil {
+				writeAPIError(w, err)
+				return
+			}
+			resp := models.UserSearchResponse{
+				Users:   users,
+				HasMore: len(users) == limit,
+			}
+			if len(users) > 0 {
+				resp.NextCursor = nextCursor(users[len(users)-1].CreatedAt)
+			}
+			writeJSON(w, http.StatusOK, resp)
+
+		default:
+			writeAPIError(w, &models.APIError{
+				Code: models.ErrCodeValidation, Field: "type",
+				Message: "type must be posts or users",
+			})
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func HandleGetTagPosts(postSvc *service.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cursor, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		viewerID := middleware.UserIDFromContext(r.Context())
+		posts, err := postSvc.GetTagPosts(r.Context(), viewerID, r.PathValue("tag"), cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newTimelineResponse(posts, limit))
+	}
+}
+
+func HandleTrendingTags(postSvc *service.PostService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, limit, err := parsePageParams(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		tags, err := postSvc.GetTrendingTags(r.Context(), limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"tags": tags})
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// HandleTwoFactorStatus reports whether the caller has two-factor
+// authentication on.
+func HandleTwoFactorStatus(twoFactorSvc *service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		status, err := twoFactorSvc.Status(r.Context(), userID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, status)
+	}
+}
+
+// HandleSetupTwoFactor creates a TOTP secret for the caller to add to an
+// authenticator app. Signing in is unchanged until it is enabled.
+func HandleSetupTwoFactor(twoFactorSvc *service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		setup, err := twoFactorSvc.Setup(r.Context(), userID)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, setup)
+	}
+}
+
+// HandleEnableTwoFactor turns on two-factor authentication with a code
+// from the app and returns the caller's recovery codes.
+func HandleEnableTwoFactor(twoFactorSvc *service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.TwoFactorCodeRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		codes, err := twoFactorSvc.Enable(r.Context(), userID, req.Code)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"recovery_codes": codes})
+	}
+}
+
+// HandleDisableTwoFactor turns off two-factor authentication after checking
+// the caller's password and a code.
+func HandleDisableTwoFactor(twoFactorSvc *service.TwoFactorService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := requireUserID(w, r)
+		if !ok {
+			return
+		}
+
+		var req models.DisableTwoFactorRequest
+		if err := decodeBody(w, r, &req); err != nil {
+			writeAPIError(w, &models.APIError{
+				Code:    models.ErrCodeValidation,
+				Message: "invalid request body",
+			})
+			return
+		}
+
+		if err := twoFactorSvc.Disable(r.Context(), userID, &req); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, map[string]any{"disabled": true})
+	}
+}
@@ -2,8 +2,6 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
-	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
@@ -48,60 +46,27 @@ func HandleGetUserPosts(postSvc *service.PostService) http.HandlerFunc {
 			return
 		}
 
-		cursor := time.Now()
-		if c := r.URL.Query().Get("cursor"); c != "" {
-			parsed, err := time.Parse(time.RFC3339, c)
-			if err != nil {
-				writeAPIError(w, &models.APIError{
-					Code:    models.ErrCodeValidation,
-					Message: "invalid cursor format, expected RFC3339",
-				})
-				return
-			}
-			cursor = parsed
-		}
-
-		limit := 50
-		if l := r.URL.Query().Get("limit"); l != "" {
-			parsed, err := strconv.Atoi(l)
-			if err != nil || parsed < 1 || parsed > 100 {
-				writeAPIError(w, &models.APIError{
-					Code:    models.ErrCodeValidation,
-					Message: "limit must be between 1 and 100",
-				})
-				return
-			}
-			limit = parsed
-		}
-
-		posts, err := postSvc.GetUserPosts(r.Context(), userID, cursor, limit)
+		cursor, limit, err := parsePageParams(r)
 		if err != nil {
 			writeAPIError(w, err)
 			return
 		}
 
-		resp := models.TimelineResponse{
-			Posts:   posts,
-			HasMore: len(posts) == limit,
-		}
-
-		if len(posts) > 0 {
-			c := posts[len(posts)-1].CreatedAt.Format(time.RFC3339Nano)
-			resp.NextCursor = &c
+		viewerID := middleware.UserIDFromContext(r.Context())
+		posts, next, err := postSvc.GetUserPosts(r.Context(), viewerID, userID, cursor, limit)
+		if err != nil {
+			writeAPIError(w, err)
+			return
 		}
 
-		writeJSON(w, http.StatusOK, resp)
+		writeJSON(w, http.StatusOK, newFeedResponse(posts, next))
 	}
 }
 
 func HandleUpdateUser(userSvc *service.UserService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		userID := middleware.UserIDFromContext(r.Context())
-		if userID == "" {
-			writeAPIError(w, &models.APIError{
-				Code:    models.ErrCodeUnauthorized,
-				Message: "authentication required",
-			})
+		userID, ok := requireUserID(w, r)
+		if !ok {
 			return
 		}
 
@@ -0,0 +1,241 @@
+// Package jwtkeys holds the keys access tokens are signed and verified
+// with.
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ActiveFile is the file in a key directory naming the key that signs new
+// tokens.
+const ActiveFile = "active"
+
+// minRSABits is the smallest RSA key accepted.
+const minRSABits = 2048
+
+type key struct {
+	id     string
+	method jwt.SigningMethod
+	// private is nil for keys that only verify.
+	private any
+	public  any
+}
+
+// KeySet signs tokens with its active key and verifies tokens signed by
+// any of its keys, chosen by the token's kid header. Keys other than the
+// active one are retiring: they verify tokens issued before a rotation
+// until those expire.
+type KeySet struct {
+	active *key
+	keys   map[string]*key
+}
+
+// FromSecret returns a key set that signs and verifies HS256 tokens with
+// secret. Its tokens carry no kid.
+func FromSecret(secret string) *KeySet {
+	k := &key{method: jwt.SigningMethodHS256, private: []byte(secret), public: []byte(secret)}
+	return &KeySet{active: k, keys: map[string]*key{"": k}}
+}
+
+// LoadDir loads a key directory. Each key is a PEM file named <kid>.pem
+// holding an Ed25519 or RSA key, as a PKCS#8 private key or, for retiring
+// keys, a PKIX public key. The file named by ActiveFile holds the kid of
+// the key to sign with, which must be a private key.
+func LoadDir(dir string) (*KeySet, error) {
+	activeID, err := os.ReadFile(filepath.Join(dir, ActiveFile))
+	if err != nil {
+		return nil, fmt.Errorf("read active key id: %w", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+
+	ks := &KeySet{keys: make(map[string]*key, len(paths))}
+	for _, path := range paths {
+		id := strings.TrimSuffix(filepath.Base(path), ".pem")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read key %s: %w", id, err)
+		}
+		k, err := parseKey(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("parse key %s: %w", id, err)
+		}
+		ks.keys[id] = k
+	}
+
+	id := strings.TrimSpace(string(activeID))
+	active, ok := ks.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found in %s", id, dir)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active key %q is a public key", id)
+	}
+	ks.active = active
+	return ks, nil
+}
+
+// AcceptSecret makes the set also verify HS256 tokens without a kid signed
+// with secret, so tokens issued before moving to a key directory stay valid
+// until they expire. The secret never signs.
+func (ks *KeySet) AcceptSecret(secret string) {
+	ks.keys[""] = &key{method: jwt.SigningMethodHS256, public: []byte(secret)}
+}
+
+// Sign returns claims signed with the active key.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.active.method, claims)
+	if ks.active.id != "" {
+		token.Header["kid"] = ks.active.id
+	}
+	return token.SignedString(ks.active.private)
+}
+
+// Parse verifies tokenStr with the key its kid names and returns the
+// token. Tokens whose algorithm differs from their key's are rejected.
+func (ks *KeySet) Parse(tokenStr string) (*jwt.Token, error) {
+	return jwt.Parse(tokenStr, ks.keyFor, jwt.WithValidMethods(ks.methods()))
+}
+
+func (ks *KeySet) keyFor(t *jwt.Token) (any, error) {
+	id, _ := t.Header["kid"].(string)
+	k, ok := ks.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", id)
+	}
+	if t.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("key %q does not sign %s", id, t.Method.Alg())
+	}
+	return k.public, nil
+}
+
+func (ks *KeySet) methods() []string {
+	seen := make(map[string]bool)
+	var methods []string
+	for _, k := range ks.keys {
+		if alg := k.method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// JWK is a public key in JSON Web Key form.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the set, active key first. Shared
+// secrets are never published, so a set built from a secret has none.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, k := range ks.sorted() {
+		var jwk JWK
+		switch pub := k.public.(type) {
+		case ed25519.PublicKey:
+			jwk = JWK{KeyType: "OKP", Curve: "Ed25519", X: b64(pub)}
+		case *rsa.PublicKey:
+			jwk = JWK{KeyType: "RSA", N: b64(pub.N.Bytes()), E: b64(big.NewInt(int64(pub.E)).Bytes())}
+		default:
+			continue
+		}
+		jwk.KeyID = k.id
+		jwk.Algorithm = k.method.Alg()
+		jwk.Use = "sig"
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// sorted returns the active key followed by the others by kid.
+func (ks *KeySet) sorted() []*key {
+	keys := []*key{ks.active}
+	var rest []*key
+	for _, k := range ks.keys {
+		if k != ks.active {
+			rest = append(rest, k)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i].id < rest[j].id })
+	return append(keys, rest...)
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// parseKey reads a PEM encoded private or public key.
+func parseKey(id string, data []byte) (*key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	k := &key{id: id}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		k.private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		k.private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		k.public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch priv := k.private.(type) {
+	case ed25519.PrivateKey:
+		k.public = priv.Public()
+	case *rsa.PrivateKey:
+		k.public = priv.Public()
+	case nil:
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+
+	switch pub := k.public.(type) {
+	case ed25519.PublicKey:
+		k.method = jwt.SigningMethodEdDSA
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+		k.method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", pub)
+	}
+	return k, nil
+}
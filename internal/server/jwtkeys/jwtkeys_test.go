@@ -0,0 +1,196 @@
+package jwtkeys_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret-32-bytes-long-xxxxx"
+
+func writePrivateKey(t *testing.T, dir, id string, priv any) {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", id, err)
+	}
+	writePEM(t, dir, id, "PRIVATE KEY", der)
+}
+
+func writePublicKey(t *testing.T, dir, id string, pub any) {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("marshal %s: %v", id, err)
+	}
+	writePEM(t, dir, id, "PUBLIC KEY", der)
+}
+
+func writePEM(t *testing.T, dir, id, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, id+".pem"), data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", id, err)
+	}
+}
+
+func setActive(t *testing.T, dir, id string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, jwtkeys.ActiveFile), []byte(id+"\n"), 0o600); err != nil {
+		t.Fatalf("write active: %v", err)
+	}
+}
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{"sub": "user-123", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestLoadDirRotation(t *testing.T) {
+	dir := t.TempDir()
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	writePrivateKey(t, dir, "2026-09", oldKey)
+	setActive(t, dir, "2026-09")
+
+	before, err := jwtkeys.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	oldToken, err := before.Sign(testClaims())
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	// Rotate: a new RSA key signs, the old key is kept only to verify
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	writePrivateKey(t, dir, "2026-10", rsaKey)
+	writePublicKey(t, dir, "2026-09", oldKey.Public())
+	setActive(t, dir, "2026-10")
+
+	after, err := jwtkeys.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir after rotation: %v", err)
+	}
+	newToken, err := after.Sign(testClaims())
+	if err != nil {
+		t.Fatalf("Sign after rotation: %v", err)
+	}
+
+	parsed, err := after.Parse(newToken)
+	if err != nil {
+		t.Fatalf("Parse new token: %v", err)
+	}
+	if parsed.Header["kid"] != "2026-10" || parsed.Method.Alg() != "RS256" {
+		t.Errorf("new token kid = %v, alg = %s, want 2026-10 RS256", parsed.Header["kid"], parsed.Method.Alg())
+	}
+	if _, err := after.Parse(oldToken); err != nil {
+		t.Errorf("token signed before the rotation should still verify: %v", err)
+	}
+	if _, err := before.Parse(newToken); err == nil {
+		t.Error("expected a token with an unknown kid to be rejected")
+	}
+}
+
+func TestLoadDirErrors(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	t.Run("missing active file", func(t *testing.T) {
+		dir := t.TempDir()
+		writePrivateKey(t, dir, "k1", priv)
+		if _, err := jwtkeys.LoadDir(dir); err == nil {
+			t.Error("expected an error without an active file")
+		}
+	})
+	t.Run("unknown active key", func(t *testing.T) {
+		dir := t.TempDir()
+		writePrivateKey(t, dir, "k1", priv)
+		setActive(t, dir, "k2")
+		if _, err := jwtkeys.LoadDir(dir); err == nil {
+			t.Error("expected an error for an active key that does not exist")
+		}
+	})
+	t.Run("public active key", func(t *testing.T) {
+		dir := t.TempDir()
+		writePublicKey(t, dir, "k1", priv.Public())
+		setActive(t, dir, "k1")
+		if _, err := jwtkeys.LoadDir(dir); err == nil {
+			t.Error("expected an error for an active key that cannot sign")
+		}
+	})
+	t.Run("short RSA key", func(t *testing.T) {
+		dir := t.TempDir()
+		small, _ := rsa.GenerateKey(rand.Reader, 1024)
+		writePrivateKey(t, dir, "k1", small)
+		setActive(t, dir, "k1")
+		if _, err := jwtkeys.LoadDir(dir); err == nil {
+			t.Error("expected an error for a 1024-bit RSA key")
+		}
+	})
+}
+
+func TestParseRejectsAlgorithmMismatch(t *testing.T) {
+	dir := t.TempDir()
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	writePrivateKey(t, dir, "k1", priv)
+	setActive(t, dir, "k1")
+	ks, err := jwtkeys.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	ks.AcceptSecret(testSecret)
+
+	// An HS256 token naming the Ed25519 key must not verify
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	forged.Header["kid"] = "k1"
+	signed, _ := forged.SignedString([]byte(testSecret))
+	if _, err := ks.Parse(signed); err == nil {
+		t.Error("expected an HS256 token with an Ed25519 kid to be rejected")
+	}
+
+	// Tokens from before the move to keys still verify
+	legacy, _ := jwtkeys.FromSecret(testSecret).Sign(testClaims())
+	if _, err := ks.Parse(legacy); err != nil {
+		t.Errorf("legacy HS256 token should verify with AcceptSecret: %v", err)
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	dir := t.TempDir()
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	writePrivateKey(t, dir, "a-retiring", rsaKey)
+	writePrivateKey(t, dir, "b-active", edKey)
+	setActive(t, dir, "b-active")
+	ks, err := jwtkeys.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	ks.AcceptSecret(testSecret)
+
+	set := ks.JWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("got %d keys, want 2 without the shared secret", len(set.Keys))
+	}
+	active, retiring := set.Keys[0], set.Keys[1]
+	if active.KeyID != "b-active" || active.KeyType != "OKP" || active.Curve != "Ed25519" || active.Algorithm != "EdDSA" || active.X == "" {
+		t.Errorf("active key = %+v, want the Ed25519 key first", active)
+	}
+	if retiring.KeyID != "a-retiring" || retiring.KeyType != "RSA" || retiring.N == "" || retiring.E != "AQAB" {
+		t.Errorf("retiring key = %+v, want the RSA key", retiring)
+	}
+
+	if keys := jwtkeys.FromSecret(testSecret).JWKS().Keys; len(keys) != 0 {
+		t.Errorf("secret key set published %d keys, want none", len(keys))
+	}
+}
@@ -0,0 +1,95 @@
+// Package mailer sends email to users. The server picks an implementation
+// at startup: SMTP in production, or a file or the log when developing and
+// testing, where messages only need to be read back.
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Message is a plain text email to one recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// format renders msg as an RFC 5322 message from from.
+func format(from string, msg Message, now time.Time) ([]byte, error) {
+	for _, v := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("mail header contains a line break")
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String()), nil
+}
+
+// File appends every message to a file, separated by blank lines, for
+// development and for tests that read messages back.
+type File struct {
+	mu   sync.Mutex
+	path string
+	from string
+}
+
+// NewFile returns a Mailer that appends messages to path.
+func NewFile(path, from string) *File {
+	return &File{path: path, from: from}
+}
+
+func (f *File) Send(_ context.Context, msg Message) error {
+	data, err := format(f.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open mail file: %w", err)
+	}
+	if _, err := file.Write(append(data, "\r\n"...)); err != nil {
+		file.Close()
+		return fmt.Errorf("write mail file: %w", err)
+	}
+	return file.Close()
+}
+
+// Log writes every message to the server log instead of sending it. The
+// body is logged in full, so it is only fit for development.
+type Log struct{}
+
+// NewLog returns a Mailer that logs messages.
+func NewLog() Log {
+	return Log{}
+}
+
+func (Log) Send(_ context.Context, msg Message) error {
+	slog.Info("mail not sent, logging it instead", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
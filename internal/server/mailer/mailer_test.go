@@ -0,0 +1,140 @@
+package mailer_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/mailer"
+)
+
+var testMessage = mailer.Message{
+	To:      "akram@example.com",
+	Subject: "Reset your niotebook password",
+	Body:    "Enter this code:\n\n    abc123\n",
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.txt")
+	m := mailer.NewFile(path, "niotebook <noreply@example.com>")
+
+	for range 2 {
+		if err := m.Send(context.Background(), testMessage); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read mail file: %v", err)
+	}
+	got := string(data)
+	if n := strings.Count(got, "To: akram@example.com\r\n"); n != 2 {
+		t.Errorf("file holds %d messages, want 2:\n%s", n, got)
+	}
+	for _, want := range []string{"From: niotebook <noreply@example.com>\r\n", "Subject: Reset your niotebook password\r\n", "\r\n    abc123\r\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("file missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestSendRejectsHeaderInjection(t *testing.T) {
+	m := mailer.NewFile(filepath.Join(t.TempDir(), "mail.txt"), "noreply@example.com")
+	msg := testMessage
+	msg.To = "akram@example.com\r\nBcc: everyone@example.com"
+	if err := m.Send(context.Background(), msg); err == nil {
+		t.Error("expected a line break in a header to be rejected")
+	}
+}
+
+// fakeSMTP accepts one message on a local port and sends its envelope and
+// data on the returned channel.
+func fakeSMTP(t *testing.T) (string, <-chan []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		var lines []string
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM"), strings.HasPrefix(cmd, "RCPT TO"):
+				lines = append(lines, line)
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					data = strings.TrimRight(data, "\r\n")
+					if data == "." {
+						break
+					}
+					lines = append(lines, data)
+				}
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestSMTP(t *testing.T) {
+	addr, received := fakeSMTP(t)
+	m := mailer.NewSMTP(addr, "", "", "niotebook <noreply@example.com>")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Send(ctx, testMessage); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	var lines []string
+	select {
+	case lines = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake server received no message")
+	}
+	got := strings.Join(lines, "\n")
+	for _, want := range []string{"MAIL FROM:<noreply@example.com>", "RCPT TO:<akram@example.com>", "From: niotebook <noreply@example.com>", "Subject: Reset your niotebook password", "    abc123"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("server missing %q:\n%s", want, got)
+		}
+	}
+}
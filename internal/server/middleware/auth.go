@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -18,6 +20,9 @@ const userCtxKey contextKey = "user_claims"
 type UserClaims struct {
 	UserID   string
 	Username string
+	Role     string
+	// SessionID is the session the access token was issued to, if any.
+	SessionID string
 }
 
 func UserIDFromContext(ctx context.Context) string {
@@ -36,14 +41,45 @@ func UsernameFromContext(ctx context.Context) string {
 	return claims.Username
 }
 
+// RoleFromContext returns the authenticated user's role, or "" for
+// anonymous requests.
+func RoleFromContext(ctx context.Context) string {
+	claims, ok := ctx.Value(userCtxKey).(*UserClaims)
+	if !ok {
+		return ""
+	}
+	return claims.Role
+}
+
+// SessionIDFromContext returns the session the request's access token
+// belongs to, or "" if it carries none.
+func SessionIDFromContext(ctx context.Context) string {
+	claims, ok := ctx.Value(userCtxKey).(*UserClaims)
+	if !ok {
+		return ""
+	}
+	return claims.SessionID
+}
+
 var exemptPaths = map[string]bool{
-	"/api/v1/auth/login":    true,
-	"/api/v1/auth/register": true,
-	"/api/v1/auth/refresh":  true,
-	"/health":               true,
+	"/api/v1/auth/login":                  true,
+	"/api/v1/auth/login/2fa":              true,
+	"/api/v1/auth/register":               true,
+	"/api/v1/auth/refresh":                true,
+	"/api/v1/auth/logout":                 true,
+	"/api/v1/auth/reactivate":             true,
+	"/api/v1/auth/cancel-deletion":        true,
+	"/api/v1/auth/password-reset/request": true,
+	"/api/v1/auth/password-reset/confirm": true,
+	"/api/v1/auth/verify-email":           true,
+	"/health":                             true,
+	"/.well-known/jwks.json":              true,
 }
 
-func Auth(jwtSecret string) func(http.Handler) http.Handler {
+// Auth verifies the bearer token on every non-exempt request with the key
+// of keys that its kid names. If denylist is not nil, tokens of accounts on
+// it are rejected with the account's status error.
+func Auth(keys *jwtkeys.KeySet, denylist *Denylist) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if exemptPaths[r.URL.Path] {
@@ -58,9 +94,7 @@ func Auth(jwtSecret string) func(http.Handler) http.Handler {
 			}
 
 			tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
-			token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (any, error) {
-				return []byte(jwtSecret), nil
-			}, jwt.WithValidMethods([]string{"HS256"}))
+			token, err := keys.Parse(tokenStr)
 
 			if err != nil || !token.Valid {
 				code := models.ErrCodeUnauthorized
@@ -89,9 +123,27 @@ func Auth(jwtSecret string) func(http.Handler) http.Handler {
 				return
 			}
 
+			if denylist != nil {
+				if apiErr := models.AccountStatusError(denylist.Status(sub)); apiErr != nil {
+					writeError(w, http.StatusForbidden, apiErr.Code, apiErr.Message)
+					return
+				}
+			}
+
+			// Tokens issued before roles existed carry none
+			role, _ := claims["role"].(string)
+			if role == "" {
+				role = models.RoleUser
+			}
+
+			// Tokens issued before sessions were tracked carry no sid
+			sid, _ := claims["sid"].(string)
+
 			userClaims := &UserClaims{
-				UserID:   sub,
-				Username: uname,
+				UserID:    sub,
+				Username:  uname,
+				Role:      role,
+				SessionID: sid,
 			}
 
 			ctx := context.WithValue(r.Context(), userCtxKey, userClaims)
@@ -100,6 +152,47 @@ func Auth(jwtSecret string) func(http.Handler) http.Handler {
 	}
 }
 
+// RequireRole rejects requests from users who do not have one of roles. It
+// must run after Auth. The role is read with lookup on every request rather
+// than trusted from the token, so a demotion applies at once instead of when
+// the user's access token expires.
+func RequireRole(lookup func(ctx context.Context, userID string) (string, error), roles ...string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := r.Context().Value(userCtxKey).(*UserClaims)
+			if !ok {
+				writeError(w, http.StatusUnauthorized, models.ErrCodeUnauthorized, "authentication required")
+				return
+			}
+			role, err := lookup(r.Context(), claims.UserID)
+			if err != nil {
+				var apiErr *models.APIError
+				if errors.As(err, &apiErr) && apiErr.Code == models.ErrCodeNotFound {
+					writeError(w, http.StatusForbidden, models.ErrCodeForbidden, "insufficient permissions")
+					return
+				}
+				slog.Error("role lookup failed", "user_id", claims.UserID, "err", err)
+				writeError(w, http.StatusInternalServerError, models.ErrCodeInternal, "something went wrong, please try again")
+				return
+			}
+			if !allowed[role] {
+				writeError(w, http.StatusForbidden, models.ErrCodeForbidden, "insufficient permissions")
+				return
+			}
+
+			// Handlers see the current role, not the one in the token
+			current := *claims
+			current.Role = role
+			ctx := context.WithValue(r.Context(), userCtxKey, &current)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 func writeError(w http.ResponseWriter, status int, code, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
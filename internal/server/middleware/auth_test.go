@@ -1,11 +1,21 @@
 package middleware_test
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
 	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -26,7 +36,7 @@ func TestAuthMiddlewareValidToken(t *testing.T) {
 		"iat":      time.Now().Unix(),
 	})
 
-	handler := middleware.Auth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID := middleware.UserIDFromContext(r.Context())
 		if userID != "user-123" {
 			t.Errorf("userID = %q, want %q", userID, "user-123")
@@ -45,7 +55,7 @@ func TestAuthMiddlewareValidToken(t *testing.T) {
 }
 
 func TestAuthMiddlewareMissingToken(t *testing.T) {
-	handler := middleware.Auth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called")
 	}))
 
@@ -65,7 +75,7 @@ func TestAuthMiddlewareExpiredToken(t *testing.T) {
 		"iat": time.Now().Add(-2 * time.Hour).Unix(),
 	})
 
-	handler := middleware.Auth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called")
 	}))
 
@@ -80,7 +90,7 @@ func TestAuthMiddlewareExpiredToken(t *testing.T) {
 }
 
 func TestAuthMiddlewareExemptPaths(t *testing.T) {
-	handler := middleware.Auth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -88,7 +98,13 @@ func TestAuthMiddlewareExemptPaths(t *testing.T) {
 		"/api/v1/auth/login",
 		"/api/v1/auth/register",
 		"/api/v1/auth/refresh",
+		"/api/v1/auth/logout",
+		"/api/v1/auth/password-reset/request",
+		"/api/v1/auth/password-reset/confirm",
+		"/api/v1/auth/verify-email",
+		"/api/v1/auth/login/2fa",
 		"/health",
+		"/.well-known/jwks.json",
 	}
 
 	for _, path := range exemptPaths {
@@ -102,8 +118,55 @@ func TestAuthMiddlewareExemptPaths(t *testing.T) {
 	}
 }
 
+func TestAuthMiddlewareKeyDir(t *testing.T) {
+	dir := t.TempDir()
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(priv)
+	if err := os.WriteFile(filepath.Join(dir, "k1.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, jwtkeys.ActiveFile), []byte("k1"), 0o600); err != nil {
+		t.Fatalf("write active: %v", err)
+	}
+	keys, err := jwtkeys.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+
+	handler := middleware.Auth(keys, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	claims := jwt.MapClaims{
+		"sub":      "user-123",
+		"username": "akram",
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	}
+	signed, _ := keys.Sign(claims)
+	tests := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"signed with the active key", signed, http.StatusOK},
+		{"signed with a secret the set does not accept", makeToken(testSecret, claims), http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
 func TestUsernameFromContext(t *testing.T) {
-	handler := middleware.Auth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		username := middleware.UsernameFromContext(r.Context())
 		if username != "akram" {
 			t.Errorf("username = %q, want %q", username, "akram")
@@ -135,6 +198,34 @@ func TestUsernameFromContextEmpty(t *testing.T) {
 	}
 }
 
+func TestSessionIDFromContext(t *testing.T) {
+	var sid string
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sid = middleware.SessionIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	token := makeToken(testSecret, jwt.MapClaims{
+		"sub":      "user-123",
+		"username": "akram",
+		"sid":      "session-1",
+		"exp":      time.Now().Add(time.Hour).Unix(),
+		"iat":      time.Now().Unix(),
+	})
+
+	req := httptest.NewRequest("GET", "/api/v1/auth/sessions", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if sid != "session-1" {
+		t.Errorf("session ID = %q, want %q", sid, "session-1")
+	}
+}
+
 func TestAuthMiddlewareMissingUsername(t *testing.T) {
 	// Token with sub but missing username claim
 	token := makeToken(testSecret, jwt.MapClaims{
@@ -143,7 +234,7 @@ func TestAuthMiddlewareMissingUsername(t *testing.T) {
 		"iat": time.Now().Unix(),
 	})
 
-	handler := middleware.Auth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called for missing username")
 	}))
 
@@ -165,7 +256,7 @@ func TestAuthMiddlewareMalformedClaims(t *testing.T) {
 		"iat": time.Now().Unix(),
 	})
 
-	handler := middleware.Auth(testSecret)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Error("handler should not be called for malformed claims")
 	}))
 
@@ -178,3 +269,92 @@ func TestAuthMiddlewareMalformedClaims(t *testing.T) {
 		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
 	}
 }
+
+func TestRequireRole(t *testing.T) {
+	roles := map[string]string{"admin-1": "admin", "user-1": "user", "demoted-1": "user"}
+	lookup := func(_ context.Context, userID string) (string, error) {
+		role, ok := roles[userID]
+		if !ok {
+			return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		}
+		return role, nil
+	}
+
+	tests := []struct {
+		name   string
+		sub    string
+		claims jwt.MapClaims
+		want   int
+	}{
+		{"admin", "admin-1", jwt.MapClaims{"role": "admin"}, http.StatusOK},
+		{"plain user", "user-1", jwt.MapClaims{"role": "user"}, http.StatusForbidden},
+		{"token without role", "user-1", jwt.MapClaims{}, http.StatusForbidden},
+		// The token still says admin, but the role was taken away since
+		{"demoted admin", "demoted-1", jwt.MapClaims{"role": "admin"}, http.StatusForbidden},
+		{"deleted user", "gone-1", jwt.MapClaims{"role": "admin"}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.claims["sub"] = tt.sub
+			tt.claims["username"] = "akram"
+			tt.claims["exp"] = time.Now().Add(time.Hour).Unix()
+			token := makeToken(testSecret, tt.claims)
+
+			var role string
+			handler := middleware.Auth(jwtkeys.FromSecret(testSecret), nil)(middleware.RequireRole(lookup, "admin")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				role = middleware.RoleFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})))
+
+			req := httptest.NewRequest("GET", "/api/v1/admin/users", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusOK && role != "admin" {
+				t.Errorf("role = %q, want %q", role, "admin")
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareDenylist(t *testing.T) {
+	statuses := map[string]string{"user-123": "suspended"}
+	denylist := middleware.NewDenylist(func(context.Context) (map[string]string, error) {
+		return statuses, nil
+	}, time.Hour)
+	defer denylist.Stop()
+	if err := denylist.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	handler := middleware.Auth(jwtkeys.FromSecret(testSecret), denylist)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(sub string) *httptest.ResponseRecorder {
+		token := makeToken(testSecret, jwt.MapClaims{
+			"sub":      sub,
+			"username": "akram",
+			"exp":      time.Now().Add(time.Hour).Unix(),
+		})
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("user-123")
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("suspended user: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if !strings.Contains(rec.Body.String(), "account_suspended") {
+		t.Errorf("suspended user: body = %s, want account_suspended", rec.Body.String())
+	}
+	if rec := serve("user-456"); rec.Code != http.StatusOK {
+		t.Errorf("active user: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Denylist caches the status of every account that is not active, so Auth
+// can reject their tokens without a database query per request. It reloads
+// in the background, so a status change takes up to one interval to apply.
+type Denylist struct {
+	mu       sync.RWMutex
+	statuses map[string]string
+	load     func(ctx context.Context) (map[string]string, error)
+	done     chan struct{}
+}
+
+// NewDenylist creates a denylist filled by load and starts reloading it
+// every interval, beginning immediately.
+func NewDenylist(load func(ctx context.Context) (map[string]string, error), interval time.Duration) *Denylist {
+	d := &Denylist{
+		statuses: make(map[string]string),
+		load:     load,
+		done:     make(chan struct{}),
+	}
+	go d.refresh(interval)
+	return d
+}
+
+// Stop terminates the background reload goroutine.
+func (d *Denylist) Stop() {
+	close(d.done)
+}
+
+// Reload replaces the cached statuses with a fresh load. On error the
+// previous statuses are kept.
+func (d *Denylist) Reload(ctx context.Context) error {
+	statuses, err := d.load(ctx)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	d.statuses = statuses
+	d.mu.Unlock()
+	return nil
+}
+
+// Status returns userID's cached status, or "" if they are not denied.
+func (d *Denylist) Status(userID string) string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.statuses[userID]
+}
+
+func (d *Denylist) refresh(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		if err := d.Reload(ctx); err != nil {
+			slog.Error("denylist reload failed", "err", err)
+		}
+		cancel()
+
+		select {
+		case <-ticker.C:
+		case <-d.done:
+			return
+		}
+	}
+}
@@ -113,14 +113,15 @@ func categorize(r *http.Request) RateLimitCategory {
 		return categoryAuth
 	}
 
-	if r.Method == http.MethodPost && strings.HasPrefix(path, "/api/v1/posts") {
+	if r.Method == http.MethodPost && (strings.HasPrefix(path, "/api/v1/posts") || path == "/api/v1/users/me/import") {
 		return categoryWrite
 	}
 
 	return categoryRead
 }
 
-func extractIP(r *http.Request) string {
+// ClientIP returns the address the request came from, without its port.
+func ClientIP(r *http.Request) string {
 	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		return r.RemoteAddr
@@ -137,7 +138,7 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		ip := extractIP(r)
+		ip := ClientIP(r)
 		limiter := rl.getVisitor(ip, cat)
 
 		if !limiter.Allow() {
@@ -152,3 +152,10 @@ func TestRateLimiter_DifferentIPsIndependent(t *testing.T) {
 		t.Errorf("different IP should not be rate limited, got %d", rr.Code)
 	}
 }
+
+func TestRateLimiter_ImportIsWrite(t *testing.T) {
+	req := httptest.NewRequest("POST", "/api/v1/users/me/import", nil)
+	if cat := categorize(req); cat != categoryWrite {
+		t.Errorf("import category = %v, want write", cat)
+	}
+}
@@ -5,30 +5,61 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/server/handler"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+	"github.com/Akram012388/niotebook-tui/internal/server/mailer"
 	"github.com/Akram012388/niotebook-tui/internal/server/middleware"
 	"github.com/Akram012388/niotebook-tui/internal/server/service"
 	"github.com/Akram012388/niotebook-tui/internal/server/store"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// denylistInterval is how often the auth denylist reloads, and so how long
+// a suspension or deactivation can take to lock out existing tokens.
+const denylistInterval = 15 * time.Second
+
 // Server wraps http.Server and manages background resources like the rate limiter.
 type Server struct {
 	HTTP        *http.Server
 	rateLimiter *middleware.RateLimiter
+	denylist    *middleware.Denylist
+	exports     *service.ExportService
+	passwords   *service.PasswordService
 }
 
-// Shutdown stops the rate limiter background goroutine and gracefully shuts down the HTTP server.
+// Shutdown stops the rate limiter and denylist background goroutines,
+// gracefully shuts down the HTTP server and waits for data exports being
+// built and password reset emails being sent.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.rateLimiter.Stop()
-	return s.HTTP.Shutdown(ctx)
+	s.denylist.Stop()
+	if err := s.HTTP.Shutdown(ctx); err != nil {
+		return err
+	}
+	if err := s.passwords.Wait(ctx); err != nil {
+		return err
+	}
+	return s.exports.Wait(ctx)
 }
 
 type Config struct {
-	JWTSecret  string
+	// Keys sign and verify access tokens.
+	Keys       *jwtkeys.KeySet
 	Host       string
 	Port       string
 	CORSOrigin string
+	// Moderators are usernames allowed to work the moderation queue in
+	// addition to users with the moderator or admin role.
+	Moderators []string
+	// DeletionGrace is how long an account waits in pending_deletion
+	// before it is purged.
+	DeletionGrace time.Duration
+	// Mailer delivers verification and password reset emails.
+	Mailer mailer.Mailer
+	// RequireVerifiedEmail stops users from posting until they verify
+	// their email address.
+	RequireVerifiedEmail bool
 }
 
 func NewServer(cfg *Config, pool *pgxpool.Pool) *Server {
@@ -36,11 +67,48 @@ func NewServer(cfg *Config, pool *pgxpool.Pool) *Server {
 	userStore := store.NewUserStore(pool)
 	postStore := store.NewPostStore(pool)
 	tokenStore := store.NewRefreshTokenStore(pool)
+	followStore := store.NewFollowStore(pool)
+	likeStore := store.NewLikeStore(pool)
+	mentionStore := store.NewMentionStore(pool)
+	tagStore := store.NewTagStore(pool)
+	searchStore := store.NewSearchStore(pool)
+	notificationStore := store.NewNotificationStore(pool)
+	conversationStore := store.NewConversationStore(pool)
+	blockStore := store.NewBlockStore(pool)
+	muteStore := store.NewMuteStore(pool)
+	filterStore := store.NewFilterStore(pool)
+	reportStore := store.NewReportStore(pool)
+	moderationStore := store.NewModerationStore(pool)
+	exportStore := store.NewExportStore(pool)
+	resetStore := store.NewPasswordResetStore(pool)
+	verificationStore := store.NewEmailVerificationStore(pool)
+	twoFactorStore := store.NewTwoFactorStore(pool)
+	challengeStore := store.NewLoginChallengeStore(pool)
 
 	// Services
-	authSvc := service.NewAuthService(userStore, tokenStore, cfg.JWTSecret)
-	postSvc := service.NewPostService(postStore)
+	authSvc := service.NewAuthService(userStore, tokenStore, verificationStore, twoFactorStore, challengeStore, cfg.Keys, cfg.Mailer)
+	twoFactorSvc := service.NewTwoFactorService(userStore, twoFactorStore)
+	passwordSvc := service.NewPasswordService(userStore, tokenStore, resetStore, cfg.Mailer)
+	postSvc := service.NewPostService(postStore, mentionStore, tagStore, notificationStore, filterStore)
+	if cfg.RequireVerifiedEmail {
+		postSvc.RequireVerifiedEmail(userStore)
+	}
 	userSvc := service.NewUserService(userStore)
+	followSvc := service.NewFollowService(followStore, blockStore, notificationStore)
+	likeSvc := service.NewLikeService(likeStore, notificationStore)
+	searchSvc := service.NewSearchService(searchStore)
+	notificationSvc := service.NewNotificationService(notificationStore)
+	msgSvc := service.NewMessageService(conversationStore, userStore, blockStore)
+	blockSvc := service.NewBlockService(blockStore, muteStore)
+	filterSvc := service.NewFilterService(filterStore)
+	modSvc := service.NewModerationService(reportStore, moderationStore, userStore, tokenStore, cfg.Moderators)
+	adminSvc := service.NewAdminService(userStore, tokenStore, moderationStore)
+	accountSvc := service.NewAccountService(userStore, tokenStore, twoFactorStore, cfg.DeletionGrace)
+	exportSvc := service.NewExportService(exportStore, userStore, postStore, followStore, likeStore)
+	importSvc := service.NewImportService(postStore)
+	if cfg.RequireVerifiedEmail {
+		importSvc.RequireVerifiedEmail(userStore)
+	}
 
 	// Router (Go 1.22 pattern matching)
 	mux := http.NewServeMux()
@@ -49,26 +117,121 @@ func NewServer(cfg *Config, pool *pgxpool.Pool) *Server {
 	mux.HandleFunc("POST /api/v1/auth/register", handler.HandleRegister(authSvc))
 	mux.HandleFunc("POST /api/v1/auth/login", handler.HandleLogin(authSvc))
 	mux.HandleFunc("POST /api/v1/auth/refresh", handler.HandleRefresh(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/logout", handler.HandleLogout(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/logout-all", handler.HandleLogoutAll(authSvc))
+	mux.HandleFunc("GET /api/v1/auth/sessions", handler.HandleListSessions(authSvc))
+	mux.HandleFunc("DELETE /api/v1/auth/sessions/{id}", handler.HandleDeleteSession(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/reactivate", handler.HandleReactivate(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/cancel-deletion", handler.HandleCancelDeletion(accountSvc))
+	mux.HandleFunc("POST /api/v1/auth/password", handler.HandleChangePassword(passwordSvc))
+	mux.HandleFunc("POST /api/v1/auth/password-reset/request", handler.HandleRequestPasswordReset(passwordSvc))
+	mux.HandleFunc("POST /api/v1/auth/password-reset/confirm", handler.HandleConfirmPasswordReset(passwordSvc))
+	mux.HandleFunc("POST /api/v1/auth/verify-email", handler.HandleVerifyEmail(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/verify-email/resend", handler.HandleResendVerification(authSvc))
+	mux.HandleFunc("POST /api/v1/auth/login/2fa", handler.HandleCompleteLogin(authSvc))
+	mux.HandleFunc("GET /api/v1/auth/2fa", handler.HandleTwoFactorStatus(twoFactorSvc))
+	mux.HandleFunc("POST /api/v1/auth/2fa/setup", handler.HandleSetupTwoFactor(twoFactorSvc))
+	mux.HandleFunc("POST /api/v1/auth/2fa/enable", handler.HandleEnableTwoFactor(twoFactorSvc))
+	mux.HandleFunc("POST /api/v1/auth/2fa/disable", handler.HandleDisableTwoFactor(twoFactorSvc))
 
 	// Post routes
 	mux.HandleFunc("POST /api/v1/posts", handler.HandleCreatePost(postSvc))
 	mux.HandleFunc("GET /api/v1/posts/{id}", handler.HandleGetPost(postSvc))
+	mux.HandleFunc("PATCH /api/v1/posts/{id}", handler.HandleEditPost(postSvc))
+	mux.HandleFunc("DELETE /api/v1/posts/{id}", handler.HandleDeletePost(postSvc))
+	mux.HandleFunc("GET /api/v1/posts/{id}/history", handler.HandleGetPostHistory(postSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/replies", handler.HandleCreateReply(postSvc))
+	mux.HandleFunc("GET /api/v1/posts/{id}/thread", handler.HandleGetThread(postSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/like", handler.HandleLike(likeSvc))
+	mux.HandleFunc("DELETE /api/v1/posts/{id}/like", handler.HandleUnlike(likeSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/repost", handler.HandleRepost(postSvc))
+	mux.HandleFunc("DELETE /api/v1/posts/{id}/repost", handler.HandleUnrepost(postSvc))
+	mux.HandleFunc("POST /api/v1/posts/{id}/quote", handler.HandleQuote(postSvc))
 
 	// Timeline
 	mux.HandleFunc("GET /api/v1/timeline", handler.HandleTimeline(postSvc))
+	mux.HandleFunc("GET /api/v1/timeline/home", handler.HandleHomeTimeline(postSvc))
 
 	// User routes
 	mux.HandleFunc("GET /api/v1/users/{id}", handler.HandleGetUser(userSvc))
 	mux.HandleFunc("GET /api/v1/users/{id}/posts", handler.HandleGetUserPosts(postSvc))
 	mux.HandleFunc("PATCH /api/v1/users/me", handler.HandleUpdateUser(userSvc))
+	mux.HandleFunc("POST /api/v1/users/me/deactivate", handler.HandleDeactivate(authSvc))
+	mux.HandleFunc("DELETE /api/v1/users/me", handler.HandleDeleteAccount(accountSvc))
+	mux.HandleFunc("POST /api/v1/users/me/export", handler.HandleRequestExport(exportSvc))
+	mux.HandleFunc("GET /api/v1/users/me/export/{id}", handler.HandleGetExport(exportSvc))
+	mux.HandleFunc("GET /api/v1/users/me/export/{id}/archive", handler.HandleDownloadExport(exportSvc))
+	mux.HandleFunc("POST /api/v1/users/me/import", handler.HandleImport(importSvc))
+	mux.HandleFunc("GET /api/v1/users/me/mentions", handler.HandleGetMentions(postSvc))
+	mux.HandleFunc("GET /api/v1/tags/trending", handler.HandleTrendingTags(postSvc))
+	mux.HandleFunc("GET /api/v1/tags/{tag}/posts", handler.HandleGetTagPosts(postSvc))
+	mux.HandleFunc("GET /api/v1/search", handler.HandleSearch(searchSvc))
+
+	// Notification routes
+	mux.HandleFunc("GET /api/v1/notifications", handler.HandleGetNotifications(notificationSvc))
+	mux.HandleFunc("GET /api/v1/notifications/unread_count", handler.HandleUnreadNotificationCount(notificationSvc))
+	mux.HandleFunc("POST /api/v1/notifications/read", handler.HandleMarkNotificationsRead(notificationSvc))
+
+	// Conversation routes
+	mux.HandleFunc("GET /api/v1/conversations", handler.HandleGetConversations(msgSvc))
+	mux.HandleFunc("POST /api/v1/conversations", handler.HandleCreateConversation(msgSvc))
+	mux.HandleFunc("GET /api/v1/conversations/{id}", handler.HandleGetConversation(msgSvc))
+	mux.HandleFunc("GET /api/v1/conversations/{id}/messages", handler.HandleGetMessages(msgSvc))
+	mux.HandleFunc("POST /api/v1/conversations/{id}/messages", handler.HandleSendMessage(msgSvc))
+	mux.HandleFunc("POST /api/v1/conversations/{id}/read", handler.HandleMarkConversationRead(msgSvc))
+
+	// Follow routes
+	mux.HandleFunc("POST /api/v1/users/{id}/follow", handler.HandleFollow(followSvc))
+	mux.HandleFunc("DELETE /api/v1/users/{id}/follow", handler.HandleUnfollow(followSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/follow", handler.HandleGetFollowStatus(followSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/followers", handler.HandleGetFollowers(followSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/following", handler.HandleGetFollowing(followSvc))
+
+	// Block and mute routes
+	mux.HandleFunc("POST /api/v1/users/{id}/block", handler.HandleBlock(blockSvc))
+	mux.HandleFunc("DELETE /api/v1/users/{id}/block", handler.HandleUnblock(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/block", handler.HandleGetBlockStatus(blockSvc))
+	mux.HandleFunc("POST /api/v1/users/{id}/mute", handler.HandleMute(blockSvc))
+	mux.HandleFunc("DELETE /api/v1/users/{id}/mute", handler.HandleUnmute(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/{id}/mute", handler.HandleGetMuteStatus(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/me/blocks", handler.HandleGetBlocked(blockSvc))
+	mux.HandleFunc("GET /api/v1/users/me/mutes", handler.HandleGetMuted(blockSvc))
+
+	// Filter routes
+	mux.HandleFunc("GET /api/v1/filters", handler.HandleGetFilters(filterSvc))
+	mux.HandleFunc("POST /api/v1/filters", handler.HandleCreateFilter(filterSvc))
+	mux.HandleFunc("DELETE /api/v1/filters/{id}", handler.HandleDeleteFilter(filterSvc))
+
+	// Report and moderation routes
+	mux.HandleFunc("POST /api/v1/posts/{id}/report", handler.HandleReportPost(modSvc))
+	mux.HandleFunc("POST /api/v1/users/{id}/report", handler.HandleReportUser(modSvc))
+	mux.HandleFunc("GET /api/v1/moderation/reports", handler.HandleGetReports(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/reports/{id}/dismiss", handler.HandleDismissReport(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/posts/{id}/hide", handler.HandleHidePost(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/users/{id}/suspend", handler.HandleSuspendUser(modSvc))
+	mux.HandleFunc("POST /api/v1/moderation/users/{id}/unsuspend", handler.HandleUnsuspendUser(modSvc))
+	mux.HandleFunc("GET /api/v1/moderation/log", handler.HandleGetModerationLog(modSvc))
+
+	// Admin routes, only reachable with the admin role
+	adminMux := http.NewServeMux()
+	adminMux.HandleFunc("GET /api/v1/admin/users", handler.HandleAdminListUsers(adminSvc))
+	adminMux.HandleFunc("POST /api/v1/admin/users/{id}/suspend", handler.HandleAdminSuspendUser(adminSvc))
+	adminMux.HandleFunc("POST /api/v1/admin/users/{id}/unsuspend", handler.HandleAdminUnsuspendUser(adminSvc))
+	adminMux.HandleFunc("POST /api/v1/admin/users/{id}/logout", handler.HandleAdminLogoutUser(adminSvc))
+	adminMux.HandleFunc("PUT /api/v1/admin/users/{id}/role", handler.HandleAdminSetRole(adminSvc))
+	mux.Handle("/api/v1/admin/", middleware.RequireRole(userStore.GetRole, models.RoleAdmin)(adminMux))
 
 	// Health
 	mux.HandleFunc("GET /health", handler.HandleHealth(pool))
 
+	// Public keys for verifying access tokens
+	mux.HandleFunc("GET /.well-known/jwks.json", handler.HandleJWKS(cfg.Keys))
+
 	// Middleware chain: Recovery → Logging → RateLimit → CORS → Auth → Handler
 	rateLimiter := middleware.NewRateLimiter()
+	denylist := middleware.NewDenylist(userStore.ListInactive, denylistInterval)
 	var h http.Handler = mux
-	h = middleware.Auth(cfg.JWTSecret)(h)
+	h = middleware.Auth(cfg.Keys, denylist)(h)
 	h = middleware.CORS(cfg.CORSOrigin)(h)
 	h = rateLimiter.Middleware(h)
 	h = middleware.Logging(h)
@@ -83,5 +246,8 @@ func NewServer(cfg *Config, pool *pgxpool.Pool) *Server {
 			IdleTimeout:  60 * time.Second,
 		},
 		rateLimiter: rateLimiter,
+		denylist:    denylist,
+		exports:     exportSvc,
+		passwords:   passwordSvc,
 	}
 }
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+// AccountService handles users deleting their own accounts. Deletion is
+// scheduled rather than immediate: the account sits in pending_deletion
+// for the grace window, during which the owner can cancel, and is purged
+// by the server's background worker afterwards.
+type AccountService struct {
+	users     store.UserStore
+	tokens    store.RefreshTokenStore
+	twoFactor store.TwoFactorStore
+	grace     time.Duration
+}
+
+func NewAccountService(users store.UserStore, tokens store.RefreshTokenStore, twoFactor store.TwoFactorStore, grace time.Duration) *AccountService {
+	return &AccountService{users: users, tokens: tokens, twoFactor: twoFactor, grace: grace}
+}
+
+// ScheduleDeletion confirms userID's password, marks their account for
+// deletion and ends their sessions. It returns when the account will be
+// purged.
+func (s *AccountService) ScheduleDeletion(ctx context.Context, userID, password string) (time.Time, error) {
+	if err := checkPassword(ctx, s.users, userID, password); err != nil {
+		return time.Time{}, err
+	}
+	if err := s.users.SetStatus(ctx, userID, models.AccountActive, models.AccountPendingDeletion); err != nil {
+		return time.Time{}, err
+	}
+	if err := s.tokens.DeleteAllForUser(ctx, userID); err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(s.grace), nil
+}
+
+// CancelDeletion restores an account pending deletion. The owner cannot
+// sign in while deletion is pending, so it takes their credentials and,
+// with two-factor authentication, a code as well.
+func (s *AccountService) CancelDeletion(ctx context.Context, req *models.CancelDeletionRequest) error {
+	user, err := checkCredentials(ctx, s.users, &models.LoginRequest{Email: req.Email, Password: req.Password})
+	if err != nil {
+		return err
+	}
+	enabled, err := twoFactorEnabled(ctx, s.twoFactor, user.ID)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		if err := checkSecondFactor(ctx, s.twoFactor, user.ID, req.Code); err != nil {
+			return err
+		}
+	}
+	return s.users.SetStatus(ctx, user.ID, models.AccountPendingDeletion, models.AccountActive)
+}
@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestScheduleAndCancelDeletion(t *testing.T) {
+	users := newMockUserStore()
+	tokens := newMockRefreshTokenStore()
+	auth := newAuthService(users, tokens)
+	accounts := service.NewAccountService(users, tokens, newMockTwoFactorStore(), 30*24*time.Hour)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram")
+	login := &models.LoginRequest{Email: "akram@example.com", Password: "password123"}
+	cancel := &models.CancelDeletionRequest{Email: login.Email, Password: login.Password}
+
+	if _, err := accounts.ScheduleDeletion(ctx, akram.User.ID, "wrongpassword"); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("ScheduleDeletion with wrong password error = %v, want unauthorized", err)
+	}
+
+	deleteAfter, err := accounts.ScheduleDeletion(ctx, akram.User.ID, "password123")
+	if err != nil {
+		t.Fatalf("ScheduleDeletion: %v", err)
+	}
+	if until := time.Until(deleteAfter); until < 29*24*time.Hour {
+		t.Errorf("deletion in %v, want about 30 days", until)
+	}
+	if _, err := auth.Login(ctx, login); apiErrorCode(err) != models.ErrCodeAccountPendingDeletion {
+		t.Errorf("Login while pending deletion error = %v, want account_pending_deletion", err)
+	}
+	if _, err := auth.Refresh(ctx, akram.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected scheduling deletion to revoke refresh tokens")
+	}
+
+	if err := accounts.CancelDeletion(ctx, &models.CancelDeletionRequest{Email: login.Email, Password: "wrongpassword"}); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("CancelDeletion with wrong password error = %v, want unauthorized", err)
+	}
+	if err := accounts.CancelDeletion(ctx, cancel); err != nil {
+		t.Fatalf("CancelDeletion: %v", err)
+	}
+	if _, err := auth.Login(ctx, login); err != nil {
+		t.Errorf("Login after cancelling deletion: %v", err)
+	}
+	if err := accounts.CancelDeletion(ctx, cancel); apiErrorCode(err) != models.ErrCodeConflict {
+		t.Errorf("CancelDeletion on active account error = %v, want conflict", err)
+	}
+}
+
+func TestCancelDeletionRequiresTwoFactor(t *testing.T) {
+	users := newMockUserStore()
+	tokens := newMockRefreshTokenStore()
+	twoFactor := newMockTwoFactorStore()
+	auth := newAuthService(users, tokens)
+	tf := service.NewTwoFactorService(users, twoFactor)
+	accounts := service.NewAccountService(users, tokens, twoFactor, 30*24*time.Hour)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	secret, recoveryCodes := enableTwoFactor(t, tf, akram)
+
+	if _, err := accounts.ScheduleDeletion(ctx, akram, "password123"); err != nil {
+		t.Fatalf("ScheduleDeletion: %v", err)
+	}
+
+	req := &models.CancelDeletionRequest{Email: "akram@example.com", Password: "password123"}
+	if err := accounts.CancelDeletion(ctx, req); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("CancelDeletion without a code error = %v, want validation error", err)
+	}
+	req.Code = codeAt(t, secret, 5)
+	if err := accounts.CancelDeletion(ctx, req); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("CancelDeletion with a wrong code error = %v, want unauthorized", err)
+	}
+	if status, _ := users.GetStatus(ctx, akram); status != models.AccountPendingDeletion {
+		t.Fatalf("status after failed cancellation = %q, want %q", status, models.AccountPendingDeletion)
+	}
+
+	req.Code = recoveryCodes[0]
+	if err := accounts.CancelDeletion(ctx, req); err != nil {
+		t.Fatalf("CancelDeletion with a recovery code: %v", err)
+	}
+	if status, _ := users.GetStatus(ctx, akram); status != models.AccountActive {
+		t.Errorf("status = %q, want %q", status, models.AccountActive)
+	}
+}
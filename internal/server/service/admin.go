@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+// AdminService backs the admin API. Callers are expected to have checked
+// the admin role already; the service only guards against admins locking
+// themselves out.
+type AdminService struct {
+	users      store.UserStore
+	tokens     store.RefreshTokenStore
+	moderation store.ModerationStore
+}
+
+func NewAdminService(users store.UserStore, tokens store.RefreshTokenStore, moderation store.ModerationStore) *AdminService {
+	return &AdminService{users: users, tokens: tokens, moderation: moderation}
+}
+
+// ListUsers returns every account, newest first.
+func (s *AdminService) ListUsers(ctx context.Context, cursor time.Time, limit int) ([]models.AdminUserEntry, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.users.ListUsers(ctx, cursor, limit)
+}
+
+// SuspendUser suspends userID and ends their sessions. The suspension is
+// recorded in the moderation log under adminID.
+func (s *AdminService) SuspendUser(ctx context.Context, adminID, userID, reason string) error {
+	if adminID == userID {
+		return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot suspend yourself"}
+	}
+	reason = strings.TrimSpace(reason)
+	if err := validateModerationNote("reason", reason); err != nil {
+		return err
+	}
+	if err := s.moderation.SuspendUser(ctx, adminID, userID, reason); err != nil {
+		return err
+	}
+	return s.tokens.DeleteAllForUser(ctx, userID)
+}
+
+func (s *AdminService) UnsuspendUser(ctx context.Context, adminID, userID, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if err := validateModerationNote("reason", reason); err != nil {
+		return err
+	}
+	return s.moderation.UnsuspendUser(ctx, adminID, userID, reason)
+}
+
+// ForceLogout revokes all of userID's refresh tokens, so every session ends
+// once its access token expires.
+func (s *AdminService) ForceLogout(ctx context.Context, userID string) error {
+	if _, err := s.users.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+	return s.tokens.DeleteAllForUser(ctx, userID)
+}
+
+// SetRole changes userID's role. The admin API checks the stored role, so
+// the change applies there at once; the role claim in the user's tokens is
+// updated the next time they refresh.
+func (s *AdminService) SetRole(ctx context.Context, adminID, userID, role string) error {
+	switch role {
+	case models.RoleUser, models.RoleModerator, models.RoleAdmin:
+	default:
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "role",
+			Message: "role must be user, moderator or admin",
+		}
+	}
+	if adminID == userID {
+		return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot change your own role"}
+	}
+	return s.users.SetRole(ctx, userID, role)
+}
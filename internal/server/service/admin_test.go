@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestAdminSetRole(t *testing.T) {
+	users := newMockUserStore()
+	tokens := newMockRefreshTokenStore()
+	auth := newAuthService(users, tokens)
+	admin := service.NewAdminService(users, tokens, newMockModerationStore(users))
+	mod := service.NewModerationService(newMockReportStore(), newMockModerationStore(users), users, tokens, nil)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	sara := registerUser(t, auth, "sara").User.ID
+
+	if err := admin.SetRole(ctx, akram, sara, "owner"); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("unknown role error = %v, want validation error", err)
+	}
+	if err := admin.SetRole(ctx, akram, akram, models.RoleUser); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("own role error = %v, want validation error", err)
+	}
+
+	if ok, _ := mod.IsModerator(ctx, sara); ok {
+		t.Fatal("plain user should not be a moderator")
+	}
+	if err := admin.SetRole(ctx, akram, sara, models.RoleModerator); err != nil {
+		t.Fatalf("SetRole: %v", err)
+	}
+	if ok, _ := mod.IsModerator(ctx, sara); !ok {
+		t.Error("user with the moderator role should be a moderator")
+	}
+}
+
+func TestAdminForceLogoutAndSuspend(t *testing.T) {
+	users := newMockUserStore()
+	tokens := newMockRefreshTokenStore()
+	auth := newAuthService(users, tokens)
+	admin := service.NewAdminService(users, tokens, newMockModerationStore(users))
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	sara := registerUser(t, auth, "sara")
+
+	if err := admin.ForceLogout(ctx, "user-missing"); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("ForceLogout unknown user error = %v, want not_found", err)
+	}
+	if err := admin.ForceLogout(ctx, sara.User.ID); err != nil {
+		t.Fatalf("ForceLogout: %v", err)
+	}
+	if _, err := auth.Refresh(ctx, sara.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("Refresh after ForceLogout should fail")
+	}
+
+	if err := admin.SuspendUser(ctx, akram, akram, ""); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("self suspend error = %v, want validation error", err)
+	}
+	if err := admin.SuspendUser(ctx, akram, sara.User.ID, "spam"); err != nil {
+		t.Fatalf("SuspendUser: %v", err)
+	}
+	_, err := auth.Login(ctx, &models.LoginRequest{Email: "sara@example.com", Password: "password123"})
+	if apiErrorCode(err) != models.ErrCodeAccountSuspended {
+		t.Errorf("Login after suspension error = %v, want account_suspended", err)
+	}
+
+	entries, err := admin.ListUsers(ctx, time.Now().Add(time.Second), 0)
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Email == "" {
+		t.Errorf("ListUsers = %+v, want both users with emails", entries)
+	}
+}
@@ -5,30 +5,58 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+	"github.com/Akram012388/niotebook-tui/internal/server/mailer"
 	"github.com/Akram012388/niotebook-tui/internal/server/store"
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// bcryptCost is the work factor passwords are hashed with.
+const bcryptCost = 12
+
+// emailVerificationTTL is how long an emailed verification code can be
+// used.
+const emailVerificationTTL = 24 * time.Hour
+
+const (
+	// loginChallengeTTL is how long a user with two-factor authentication
+	// has to enter a code after their password.
+	loginChallengeTTL = 5 * time.Minute
+	// maxChallengeAttempts is how many codes can be tried against one
+	// challenge before the password has to be entered again.
+	maxChallengeAttempts = 5
+)
+
 type AuthService struct {
-	users      store.UserStore
-	tokens     store.RefreshTokenStore
-	jwtSecret  []byte
-	accessTTL  time.Duration
-	refreshTTL time.Duration
+	users         store.UserStore
+	tokens        store.RefreshTokenStore
+	verifications store.EmailVerificationStore
+	twoFactor     store.TwoFactorStore
+	challenges    store.LoginChallengeStore
+	keys          *jwtkeys.KeySet
+	mail          mailer.Mailer
+	accessTTL     time.Duration
+	refreshTTL    time.Duration
 }
 
-func NewAuthService(users store.UserStore, tokens store.RefreshTokenStore, jwtSecret string) *AuthService {
+func NewAuthService(users store.UserStore, tokens store.RefreshTokenStore, verifications store.EmailVerificationStore, twoFactor store.TwoFactorStore, challenges store.LoginChallengeStore, keys *jwtkeys.KeySet, mail mailer.Mailer) *AuthService {
 	return &AuthService{
-		users:      users,
-		tokens:     tokens,
-		jwtSecret:  []byte(jwtSecret),
-		accessTTL:  24 * time.Hour,
-		refreshTTL: 7 * 24 * time.Hour,
+		users:         users,
+		tokens:        tokens,
+		verifications: verifications,
+		twoFactor:     twoFactor,
+		challenges:    challenges,
+		keys:          keys,
+		mail:          mail,
+		accessTTL:     24 * time.Hour,
+		refreshTTL:    7 * 24 * time.Hour,
 	}
 }
 
@@ -43,7 +71,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 		return nil, err
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), 12)
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcryptCost)
 	if err != nil {
 		return nil, fmt.Errorf("hash password: %w", err)
 	}
@@ -53,7 +81,13 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 		return nil, err
 	}
 
-	tokens, err := s.generateTokenPair(ctx, user)
+	// The account exists either way, so a failure to send doesn't fail
+	// the registration; the user can ask for another code.
+	if err := s.sendVerification(ctx, user, req.Email); err != nil {
+		slog.Error("sending verification email failed", "user_id", user.ID, "err", err)
+	}
+
+	tokens, err := s.generateTokenPair(ctx, user, req.Device)
 	if err != nil {
 		return nil, err
 	}
@@ -61,79 +95,349 @@ func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest)
 	return &models.AuthResponse{User: user, Tokens: tokens}, nil
 }
 
+// Login signs a user in with their email and password. If they have
+// two-factor authentication, it returns only a challenge token to pass to
+// CompleteLogin with a code.
 func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
-	user, hash, err := s.users.GetUserByEmail(ctx, req.Email)
+	user, err := checkCredentials(ctx, s.users, req)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkActive(ctx, user.ID); err != nil {
+		return nil, err
+	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password)); err != nil {
-		return nil, &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid email or password"}
+	enabled, err := twoFactorEnabled(ctx, s.twoFactor, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return s.challenge(ctx, user.ID, false)
+	}
+
+	return s.signIn(ctx, user, req.Device)
+}
+
+// Deactivate lets a user switch off their own account after confirming
+// their password. Their posts drop out of feeds and their sessions end
+// until they reactivate.
+func (s *AuthService) Deactivate(ctx context.Context, userID, password string) error {
+	if err := checkPassword(ctx, s.users, userID, password); err != nil {
+		return err
+	}
+	if err := s.users.SetStatus(ctx, userID, models.AccountActive, models.AccountDeactivated); err != nil {
+		return err
+	}
+	return s.tokens.DeleteAllForUser(ctx, userID)
+}
+
+// Reactivate signs in a deactivated user and makes their account active
+// again. With two-factor authentication, that waits for CompleteLogin.
+func (s *AuthService) Reactivate(ctx context.Context, req *models.LoginRequest) (*models.AuthResponse, error) {
+	user, err := checkCredentials(ctx, s.users, req)
+	if err != nil {
+		return nil, err
 	}
 
-	tokens, err := s.generateTokenPair(ctx, user)
+	enabled, err := twoFactorEnabled(ctx, s.twoFactor, user.ID)
 	if err != nil {
 		return nil, err
 	}
+	if enabled {
+		return s.challenge(ctx, user.ID, true)
+	}
+
+	if err := s.users.SetStatus(ctx, user.ID, models.AccountDeactivated, models.AccountActive); err != nil {
+		return nil, err
+	}
 
-	return &models.AuthResponse{User: user, Tokens: tokens}, nil
+	return s.signIn(ctx, user, req.Device)
 }
 
-func (s *AuthService) Refresh(ctx context.Context, rawToken string) (*models.TokenPair, error) {
+// challenge starts the second step of signing in as userID. reactivate
+// makes completing it reactivate their account.
+func (s *AuthService) challenge(ctx context.Context, userID string, reactivate bool) (*models.AuthResponse, error) {
+	rawToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.challenges.CreateChallenge(ctx, userID, hashRefreshToken(rawToken), reactivate, time.Now().Add(loginChallengeTTL)); err != nil {
+		return nil, err
+	}
+	return &models.AuthResponse{ChallengeToken: rawToken}, nil
+}
+
+// CompleteLogin finishes a sign-in that Login or Reactivate answered with
+// a challenge token, given a code from the user's authenticator app or a
+// recovery code.
+func (s *AuthService) CompleteLogin(ctx context.Context, req *models.TwoFactorLoginRequest) (*models.AuthResponse, error) {
+	if req.ChallengeToken == "" {
+		return nil, &models.APIError{Code: models.ErrCodeValidation, Field: "challenge_token", Message: "challenge token is required"}
+	}
+	tokenHash := hashRefreshToken(req.ChallengeToken)
+	userID, reactivate, err := s.challenges.AttemptChallenge(ctx, tokenHash, maxChallengeAttempts)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSecondFactor(ctx, s.twoFactor, userID, req.Code); err != nil {
+		return nil, err
+	}
+	if err := s.challenges.DeleteChallenge(ctx, tokenHash); err != nil {
+		return nil, err
+	}
+
+	if reactivate {
+		err = s.users.SetStatus(ctx, userID, models.AccountDeactivated, models.AccountActive)
+	} else {
+		err = s.checkActive(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.signIn(ctx, user, req.Device)
+}
+
+// signIn starts a session for an existing user on device.
+func (s *AuthService) signIn(ctx context.Context, user *models.User, device models.Device) (*models.AuthResponse, error) {
+	_, verified, err := s.users.GetEmail(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.generateTokenPair(ctx, user, device)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthResponse{User: user, Tokens: tokens, EmailVerified: verified}, nil
+}
+
+// VerifyEmail confirms the email address that code was sent to.
+func (s *AuthService) VerifyEmail(ctx context.Context, code string) error {
+	if code == "" {
+		return &models.APIError{Code: models.ErrCodeValidation, Field: "token", Message: "verification code is required"}
+	}
+	_, err := s.verifications.VerifyEmail(ctx, hashRefreshToken(code))
+	return err
+}
+
+// ResendVerification emails userID a new verification code, replacing any
+// earlier one.
+func (s *AuthService) ResendVerification(ctx context.Context, userID string) error {
+	email, verified, err := s.users.GetEmail(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if verified {
+		return &models.APIError{Code: models.ErrCodeConflict, Message: "email is already verified"}
+	}
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.sendVerification(ctx, user, email)
+}
+
+// sendVerification stores a new verification code for user and emails it
+// to email.
+func (s *AuthService) sendVerification(ctx context.Context, user *models.User, email string) error {
+	rawCode, err := generateRefreshToken()
+	if err != nil {
+		return err
+	}
+	if err := s.verifications.CreateVerification(ctx, user.ID, hashRefreshToken(rawCode), time.Now().Add(emailVerificationTTL)); err != nil {
+		return err
+	}
+
+	return s.mail.Send(ctx, mailer.Message{
+		To:      email,
+		Subject: "Confirm your niotebook email address",
+		Body: fmt.Sprintf("Welcome to niotebook, @%s!\n\n"+
+			"To confirm this address, enter this code in niotebook within 24 hours:\n\n"+
+			"    %s\n\n"+
+			"If you didn't create an account, ignore this email.\n",
+			user.Username, rawCode),
+	})
+}
+
+// Refresh exchanges a refresh token for a new token pair. The token is
+// rotated within its family, which keeps the session's ID, and the
+// session's device details are updated from device. Presenting a token
+// that was already rotated means it was copied, so the whole family is
+// revoked and a security event is logged.
+func (s *AuthService) Refresh(ctx context.Context, rawToken string, device models.Device) (*models.TokenPair, error) {
 	tokenHash := hashRefreshToken(rawToken)
 
-	id, userID, expiresAt, err := s.tokens.GetByHash(ctx, tokenHash)
+	token, err := s.tokens.GetByHash(ctx, tokenHash)
 	if err != nil {
 		return nil, &models.APIError{Code: models.ErrCodeTokenExpired, Message: "refresh token has expired"}
 	}
-	_ = id
 
-	if time.Now().After(expiresAt) {
+	if token.RotatedAt != nil {
+		return nil, s.revokeReused(ctx, token, device)
+	}
+
+	if time.Now().After(token.ExpiresAt) {
 		_ = s.tokens.DeleteByHash(ctx, tokenHash)
 		return nil, &models.APIError{Code: models.ErrCodeTokenExpired, Message: "refresh token has expired"}
 	}
 
-	// Single-use: delete the consumed token
-	if err := s.tokens.DeleteByHash(ctx, tokenHash); err != nil {
-		return nil, fmt.Errorf("delete consumed token: %w", err)
+	user, err := s.users.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkActive(ctx, user.ID); err != nil {
+		return nil, err
 	}
 
-	user, err := s.users.GetUserByID(ctx, userID)
+	// Single-use: the consumed token is replaced by the next in its family
+	rawRefresh, err := generateRefreshToken()
 	if err != nil {
 		return nil, err
 	}
+	if err := s.tokens.RotateToken(ctx, token.ID, hashRefreshToken(rawRefresh), time.Now().Add(s.refreshTTL), device); err != nil {
+		var apiErr *models.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == models.ErrCodeUnauthorized {
+			// Another request rotated the token first
+			return nil, s.revokeReused(ctx, token, device)
+		}
+		return nil, err
+	}
 
-	return s.generateTokenPair(ctx, user)
+	return s.signTokenPair(user, token.FamilyID, rawRefresh)
 }
 
-func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User) (*models.TokenPair, error) {
-	now := time.Now()
-	expiresAt := now.Add(s.accessTTL)
+// revokeReused ends the session of a rotated token that was presented
+// again and returns the error to report. Either the legitimate client or
+// whoever copied the token holds the latest one, and there is no telling
+// which, so neither may keep the session.
+func (s *AuthService) revokeReused(ctx context.Context, token *models.RefreshToken, device models.Device) error {
+	slog.Warn("refresh token reuse detected, revoking session",
+		"user_id", token.UserID,
+		"family_id", token.FamilyID,
+		"ip", device.IP,
+		"user_agent", device.UserAgent,
+	)
+	if err := s.tokens.DeleteFamily(ctx, token.FamilyID); err != nil {
+		return err
+	}
+	return &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid refresh token"}
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub":      user.ID,
-		"username": user.Username,
-		"iat":      now.Unix(),
-		"exp":      expiresAt.Unix(),
-	})
+// Logout revokes the refresh token of one session. A token that is
+// unknown or already revoked is not an error, so logging out twice is
+// harmless.
+func (s *AuthService) Logout(ctx context.Context, rawToken string) error {
+	if rawToken == "" {
+		return &models.APIError{Code: models.ErrCodeValidation, Field: "refresh_token", Message: "refresh token is required"}
+	}
+	return s.tokens.DeleteByHash(ctx, hashRefreshToken(rawToken))
+}
+
+// LogoutAll revokes every refresh token of userID, ending their sessions
+// on all devices once the current access tokens expire.
+func (s *AuthService) LogoutAll(ctx context.Context, userID string) error {
+	return s.tokens.DeleteAllForUser(ctx, userID)
+}
 
-	accessToken, err := token.SignedString(s.jwtSecret)
+// ListSessions returns userID's sessions, marking currentID as the one
+// the request came from.
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentID string) ([]models.Session, error) {
+	sessions, err := s.tokens.ListSessions(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("sign access token: %w", err)
+		return nil, err
 	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentID
+	}
+	return sessions, nil
+}
 
-	rawRefresh, err := generateRefreshToken()
+// DeleteSession ends one of userID's sessions. The device can no longer
+// refresh its tokens, though its current access token stays valid until it
+// expires.
+func (s *AuthService) DeleteSession(ctx context.Context, userID, id string) error {
+	return s.tokens.DeleteSession(ctx, userID, id)
+}
+
+// checkActive returns the matching ErrCodeAccount* error unless userID's
+// account is active.
+func (s *AuthService) checkActive(ctx context.Context, userID string) error {
+	status, err := s.users.GetStatus(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if apiErr := models.AccountStatusError(status); apiErr != nil {
+		return apiErr
+	}
+	return nil
+}
+
+// checkCredentials returns the user req signs in as, or an unauthorized
+// error if the email or password is wrong.
+func checkCredentials(ctx context.Context, users store.UserStore, req *models.LoginRequest) (*models.User, error) {
+	user, hash, err := users.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		return nil, err
 	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password)); err != nil {
+		return nil, &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid email or password"}
+	}
+	return user, nil
+}
 
-	refreshHash := hashRefreshToken(rawRefresh)
-	refreshExpiry := now.Add(s.refreshTTL)
+// checkPassword returns an unauthorized error unless password is userID's
+// current password.
+func checkPassword(ctx context.Context, users store.UserStore, userID, password string) error {
+	hash, err := users.GetPasswordHash(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "password", Message: "incorrect password"}
+	}
+	return nil
+}
+
+// generateTokenPair starts a new session for user on device.
+func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User, device models.Device) (*models.TokenPair, error) {
+	rawRefresh, err := generateRefreshToken()
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.tokens.StoreToken(ctx, user.ID, refreshHash, refreshExpiry); err != nil {
+	refreshExpiry := time.Now().Add(s.refreshTTL)
+	sessionID, err := s.tokens.StoreToken(ctx, user.ID, hashRefreshToken(rawRefresh), refreshExpiry, device)
+	if err != nil {
 		return nil, fmt.Errorf("store refresh token: %w", err)
 	}
 
+	return s.signTokenPair(user, sessionID, rawRefresh)
+}
+
+// signTokenPair issues an access token for user's session sessionID and
+// pairs it with that session's refresh token.
+func (s *AuthService) signTokenPair(user *models.User, sessionID, rawRefresh string) (*models.TokenPair, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.accessTTL)
+
+	accessToken, err := s.keys.Sign(jwt.MapClaims{
+		"sub":      user.ID,
+		"username": user.Username,
+		"role":     roleOrDefault(user.Role),
+		"sid":      sessionID,
+		"iat":      now.Unix(),
+		"exp":      expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign access token: %w", err)
+	}
+
 	return &models.TokenPair{
 		AccessToken:  accessToken,
 		RefreshToken: rawRefresh,
@@ -141,6 +445,14 @@ func (s *AuthService) generateTokenPair(ctx context.Context, user *models.User)
 	}, nil
 }
 
+// roleOrDefault returns role, or the plain user role if it is unset.
+func roleOrDefault(role string) string {
+	if role == "" {
+		return models.RoleUser
+	}
+	return role
+}
+
 func generateRefreshToken() (string, error) {
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
@@ -5,13 +5,21 @@ import (
 	"testing"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
 	"github.com/Akram012388/niotebook-tui/internal/server/service"
 )
 
+// newAuthService builds an AuthService over the given mocks, with email
+// verification codes and two-factor state kept in memory and mail
+// discarded.
+func newAuthService(users *mockUserStore, tokens *mockRefreshTokenStore) *service.AuthService {
+	return service.NewAuthService(users, tokens, newMockEmailVerificationStore(users), newMockTwoFactorStore(), newMockLoginChallengeStore(), jwtkeys.FromSecret("test-secret-32-bytes-long-xxxxx"), &recordingMailer{})
+}
+
 func TestRegister(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	resp, err := auth.Register(context.Background(), &models.RegisterRequest{
 		Username: "akram",
@@ -35,7 +43,7 @@ func TestRegister(t *testing.T) {
 func TestRegisterInvalidUsername(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	_, err := auth.Register(context.Background(), &models.RegisterRequest{
 		Username: "a",
@@ -50,7 +58,7 @@ func TestRegisterInvalidUsername(t *testing.T) {
 func TestRegisterShortPassword(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	_, err := auth.Register(context.Background(), &models.RegisterRequest{
 		Username: "akram",
@@ -65,7 +73,7 @@ func TestRegisterShortPassword(t *testing.T) {
 func TestLogin(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	// Register first
 	if _, err := auth.Register(context.Background(), &models.RegisterRequest{
@@ -89,7 +97,7 @@ func TestLogin(t *testing.T) {
 func TestLoginWrongPassword(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	if _, err := auth.Register(context.Background(), &models.RegisterRequest{
 		Username: "akram", Email: "akram@example.com", Password: "password123",
@@ -108,7 +116,7 @@ func TestLoginWrongPassword(t *testing.T) {
 func TestRegisterDuplicateEmail(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	// Register first user
 	if _, err := auth.Register(context.Background(), &models.RegisterRequest{
@@ -136,7 +144,7 @@ func TestRegisterDuplicateEmail(t *testing.T) {
 func TestLoginNonexistentEmail(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	_, err := auth.Login(context.Background(), &models.LoginRequest{
 		Email: "nonexistent@example.com", Password: "password123",
@@ -149,13 +157,13 @@ func TestLoginNonexistentEmail(t *testing.T) {
 func TestRefreshToken(t *testing.T) {
 	userStore := newMockUserStore()
 	tokenStore := newMockRefreshTokenStore()
-	auth := service.NewAuthService(userStore, tokenStore, "test-secret-32-bytes-long-xxxxx")
+	auth := newAuthService(userStore, tokenStore)
 
 	resp, _ := auth.Register(context.Background(), &models.RegisterRequest{
 		Username: "akram", Email: "akram@example.com", Password: "password123",
 	})
 
-	newTokens, err := auth.Refresh(context.Background(), resp.Tokens.RefreshToken)
+	newTokens, err := auth.Refresh(context.Background(), resp.Tokens.RefreshToken, models.Device{})
 	if err != nil {
 		t.Fatalf("Refresh: %v", err)
 	}
@@ -163,8 +171,157 @@ func TestRefreshToken(t *testing.T) {
 		t.Error("expected new access token")
 	}
 	// Old refresh token should be consumed (single-use)
-	_, err = auth.Refresh(context.Background(), resp.Tokens.RefreshToken)
+	_, err = auth.Refresh(context.Background(), resp.Tokens.RefreshToken, models.Device{})
 	if err == nil {
 		t.Fatal("expected error for reused refresh token")
 	}
 }
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	auth := newAuthService(newMockUserStore(), newMockRefreshTokenStore())
+	ctx := context.Background()
+	laptop := registerUser(t, auth, "akram")
+	phone, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	// The legitimate client rotates; then a stolen copy of the old token
+	// is replayed
+	rotated, err := auth.Refresh(ctx, laptop.Tokens.RefreshToken, models.Device{})
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if _, err := auth.Refresh(ctx, laptop.Tokens.RefreshToken, models.Device{IP: "203.0.113.9"}); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Fatalf("replayed Refresh error = %v, want unauthorized", err)
+	}
+
+	if _, err := auth.Refresh(ctx, rotated.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected reuse to revoke the latest token of the family")
+	}
+	if _, err := auth.Refresh(ctx, phone.Tokens.RefreshToken, models.Device{}); err != nil {
+		t.Errorf("other sessions should survive reuse: %v", err)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	auth := newAuthService(newMockUserStore(), newMockRefreshTokenStore())
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram")
+
+	if err := auth.Logout(ctx, ""); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("Logout without token error = %v, want validation_error", err)
+	}
+	if err := auth.Logout(ctx, akram.Tokens.RefreshToken); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+	if _, err := auth.Refresh(ctx, akram.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected logout to revoke the refresh token")
+	}
+	if err := auth.Logout(ctx, akram.Tokens.RefreshToken); err != nil {
+		t.Errorf("second Logout: %v, want no error", err)
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	auth := newAuthService(newMockUserStore(), newMockRefreshTokenStore())
+	ctx := context.Background()
+	laptop := registerUser(t, auth, "akram")
+	phone, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	if err := auth.LogoutAll(ctx, laptop.User.ID); err != nil {
+		t.Fatalf("LogoutAll: %v", err)
+	}
+	for _, token := range []string{laptop.Tokens.RefreshToken, phone.Tokens.RefreshToken} {
+		if _, err := auth.Refresh(ctx, token, models.Device{}); err == nil {
+			t.Error("expected LogoutAll to revoke every refresh token")
+		}
+	}
+}
+
+func TestSessions(t *testing.T) {
+	auth := newAuthService(newMockUserStore(), newMockRefreshTokenStore())
+	ctx := context.Background()
+	laptop, err := auth.Register(ctx, &models.RegisterRequest{
+		Username: "akram", Email: "akram@example.com", Password: "password123",
+		Device: models.Device{DeviceName: "laptop", UserAgent: "niotebook-tui/1.0", IP: "10.0.0.1"},
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	phone, err := auth.Login(ctx, &models.LoginRequest{
+		Email: "akram@example.com", Password: "password123",
+		Device: models.Device{DeviceName: "phone"},
+	})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	// Refreshing keeps the session and its device name
+	if _, err := auth.Refresh(ctx, laptop.Tokens.RefreshToken, models.Device{IP: "10.0.0.2"}); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	sessions, err := auth.ListSessions(ctx, laptop.User.ID, "")
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want 2", len(sessions))
+	}
+	latest := sessions[0]
+	if latest.DeviceName != "laptop" || latest.IP != "10.0.0.2" {
+		t.Errorf("refreshed session = %+v, want laptop at 10.0.0.2", latest)
+	}
+
+	sessions, _ = auth.ListSessions(ctx, laptop.User.ID, latest.ID)
+	if !sessions[0].Current || sessions[1].Current {
+		t.Errorf("current flags = %v, %v, want only the laptop current", sessions[0].Current, sessions[1].Current)
+	}
+
+	if err := auth.DeleteSession(ctx, "someone-else", sessions[1].ID); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("DeleteSession of another user's session error = %v, want not_found", err)
+	}
+	if err := auth.DeleteSession(ctx, laptop.User.ID, sessions[1].ID); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	if _, err := auth.Refresh(ctx, phone.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected DeleteSession to revoke the phone's refresh token")
+	}
+}
+
+func TestDeactivateAndReactivate(t *testing.T) {
+	userStore := newMockUserStore()
+	tokenStore := newMockRefreshTokenStore()
+	auth := newAuthService(userStore, tokenStore)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram")
+	login := &models.LoginRequest{Email: "akram@example.com", Password: "password123"}
+
+	if err := auth.Deactivate(ctx, akram.User.ID, "wrongpassword"); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("Deactivate with wrong password error = %v, want unauthorized", err)
+	}
+	if _, err := auth.Reactivate(ctx, login); apiErrorCode(err) != models.ErrCodeConflict {
+		t.Errorf("Reactivate active account error = %v, want conflict", err)
+	}
+
+	if err := auth.Deactivate(ctx, akram.User.ID, "password123"); err != nil {
+		t.Fatalf("Deactivate: %v", err)
+	}
+	if _, err := auth.Login(ctx, login); apiErrorCode(err) != models.ErrCodeAccountDeactivated {
+		t.Errorf("Login while deactivated error = %v, want account_deactivated", err)
+	}
+	if _, err := auth.Refresh(ctx, akram.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected deactivation to revoke refresh tokens")
+	}
+
+	if _, err := auth.Reactivate(ctx, login); err != nil {
+		t.Fatalf("Reactivate: %v", err)
+	}
+	if _, err := auth.Login(ctx, login); err != nil {
+		t.Errorf("Login after reactivation: %v", err)
+	}
+}
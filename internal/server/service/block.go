@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+// BlockService manages blocks and mutes. A block cuts both users off from
+// each other's posts, follows, mentions, notifications and new
+// conversations; a mute quietly hides the muted user from the muter only.
+type BlockService struct {
+	blocks store.BlockStore
+	mutes  store.MuteStore
+}
+
+func NewBlockService(blocks store.BlockStore, mutes store.MuteStore) *BlockService {
+	return &BlockService{blocks: blocks, mutes: mutes}
+}
+
+func (s *BlockService) Block(ctx context.Context, blockerID, blockedID string) error {
+	if blockerID == blockedID {
+		return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot block yourself"}
+	}
+	return s.blocks.Block(ctx, blockerID, blockedID)
+}
+
+func (s *BlockService) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	return s.blocks.Unblock(ctx, blockerID, blockedID)
+}
+
+func (s *BlockService) IsBlocking(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	return s.blocks.IsBlocking(ctx, blockerID, blockedID)
+}
+
+func (s *BlockService) GetBlocked(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.blocks.GetBlocked(ctx, userID, cursor, limit)
+}
+
+func (s *BlockService) Mute(ctx context.Context, muterID, mutedID string) error {
+	if muterID == mutedID {
+		return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot mute yourself"}
+	}
+	return s.mutes.Mute(ctx, muterID, mutedID)
+}
+
+func (s *BlockService) Unmute(ctx context.Context, muterID, mutedID string) error {
+	return s.mutes.Unmute(ctx, muterID, mutedID)
+}
+
+func (s *BlockService) IsMuting(ctx context.Context, muterID, mutedID string) (bool, error) {
+	return s.mutes.IsMuting(ctx, muterID, mutedID)
+}
+
+func (s *BlockService) GetMuted(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.mutes.GetMuted(ctx, userID, cursor, limit)
+}
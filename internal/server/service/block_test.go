@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestBlockAndMuteSelf(t *testing.T) {
+	svc := service.NewBlockService(newMockBlockStore(), newMockBlockStore())
+	ctx := context.Background()
+
+	if err := svc.Block(ctx, "user-1", "user-1"); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("Block self error = %v, want validation error", err)
+	}
+	if err := svc.Mute(ctx, "user-1", "user-1"); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("Mute self error = %v, want validation error", err)
+	}
+}
+
+func TestBlockedLists(t *testing.T) {
+	relations := newMockBlockStore()
+	svc := service.NewBlockService(relations, relations)
+	ctx := context.Background()
+
+	if err := svc.Block(ctx, "user-1", "user-2"); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+	if err := svc.Mute(ctx, "user-1", "user-3"); err != nil {
+		t.Fatalf("Mute: %v", err)
+	}
+
+	blocked, err := svc.GetBlocked(ctx, "user-1", time.Now().Add(time.Second), 0)
+	if err != nil {
+		t.Fatalf("GetBlocked: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].User.ID != "user-2" {
+		t.Errorf("blocked = %+v, want user-2", blocked)
+	}
+	muted, err := svc.GetMuted(ctx, "user-1", time.Now().Add(time.Second), 0)
+	if err != nil {
+		t.Fatalf("GetMuted: %v", err)
+	}
+	if len(muted) != 1 || muted[0].User.ID != "user-3" {
+		t.Errorf("muted = %+v, want user-3", muted)
+	}
+
+	if err := svc.Unblock(ctx, "user-1", "user-2"); err != nil {
+		t.Fatalf("Unblock: %v", err)
+	}
+	if blocking, _ := svc.IsBlocking(ctx, "user-1", "user-2"); blocking {
+		t.Error("expected block to be removed")
+	}
+}
+
+func TestBlockPreventsFollowAndMessages(t *testing.T) {
+	blocks := newMockBlockStore()
+	ctx := context.Background()
+	if err := blocks.Block(ctx, "user-2", "user-1"); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+
+	follows := service.NewFollowService(newMockFollowStore(), blocks, newMockNotificationStore())
+	if err := follows.Follow(ctx, "user-1", "user-2"); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("Follow blocker error = %v, want forbidden", err)
+	}
+	if err := follows.Follow(ctx, "user-2", "user-1"); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("Follow blocked user error = %v, want forbidden", err)
+	}
+
+	users := newMockUserStore()
+	alice, _ := users.CreateUser(ctx, "alice", "alice@example.com", "hash", "alice")
+	bob, _ := users.CreateUser(ctx, "bob", "bob@example.com", "hash", "bob")
+	if err := blocks.Block(ctx, bob.ID, alice.ID); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+	messages := service.NewMessageService(newMockConversationStore(), users, blocks)
+	if _, _, err := messages.StartConversation(ctx, alice.ID, []string{"bob"}); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("StartConversation error = %v, want forbidden", err)
+	}
+}
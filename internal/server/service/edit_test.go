@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestEditPostRecordsHistory(t *testing.T) {
+	svc := service.NewPostService(newMockPostStore(), newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	post, _ := svc.CreatePost(ctx, "user-1", "first draft")
+	edited, err := svc.EditPost(ctx, "user-1", post.ID, "  second draft  ")
+	if err != nil {
+		t.Fatalf("EditPost: %v", err)
+	}
+	if edited.Content != "second draft" || edited.EditedAt == nil {
+		t.Errorf("edited = %+v, want trimmed content with edited_at", edited)
+	}
+
+	history, err := svc.GetHistory(ctx, "", post.ID)
+	if err != nil {
+		t.Fatalf("GetHistory: %v", err)
+	}
+	if len(history.Edits) != 1 || history.Edits[0].Content != "first draft" {
+		t.Errorf("edits = %+v, want the first draft", history.Edits)
+	}
+}
+
+func TestEditPostUpdatesMentions(t *testing.T) {
+	mentions := newMockMentionStore()
+	notifications := newMockNotificationStore()
+	svc := service.NewPostService(newMockPostStore(), mentions, newMockTagStore(), notifications, newMockFilterStore())
+	ctx := context.Background()
+
+	post, _ := svc.CreatePost(ctx, "user-1", "hi @sara")
+	if _, err := svc.EditPost(ctx, "user-1", post.ID, "hi @omar"); err != nil {
+		t.Fatalf("EditPost: %v", err)
+	}
+	if got := mentions.mentioned[post.ID]; len(got) != 1 || got[0] != "omar" {
+		t.Errorf("mentions = %v, want only omar", got)
+	}
+	if len(notifications.events) != 2 || notifications.events[1] != "mention:"+post.ID {
+		t.Errorf("events = %v, want the edit to notify mentions", notifications.events)
+	}
+}
+
+func TestEditPostReplacesTags(t *testing.T) {
+	tags := newMockTagStore()
+	svc := service.NewPostService(newMockPostStore(), newMockMentionStore(), tags, newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	post, _ := svc.CreatePost(ctx, "user-1", "learning #go and #tui")
+	edited, err := svc.EditPost(ctx, "user-1", post.ID, "learning #rust")
+	if err != nil {
+		t.Fatalf("EditPost: %v", err)
+	}
+	if got := tags.tagged[post.ID]; len(got) != 1 || got[0] != "rust" {
+		t.Errorf("stored tags = %v, want only rust", got)
+	}
+	if len(edited.Tags) != 1 || edited.Tags[0] != "rust" {
+		t.Errorf("edited tags = %v, want only rust", edited.Tags)
+	}
+}
+
+func TestEditPostRequiresAuthor(t *testing.T) {
+	svc := service.NewPostService(newMockPostStore(), newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	post, _ := svc.CreatePost(ctx, "user-1", "mine")
+	_, err := svc.EditPost(ctx, "user-2", post.ID, "yours now")
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeForbidden {
+		t.Errorf("error = %v, want forbidden", err)
+	}
+}
+
+func TestDeletePostIsSoft(t *testing.T) {
+	svc := service.NewPostService(newMockPostStore(), newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	post, _ := svc.CreatePost(ctx, "user-1", "regrettable")
+	reply, _ := svc.CreateReply(ctx, "user-2", post.ID, "reply")
+
+	err := svc.DeletePost(ctx, "user-2", post.ID)
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeForbidden {
+		t.Errorf("delete by non-author error = %v, want forbidden", err)
+	}
+
+	if err := svc.DeletePost(ctx, "user-1", post.ID); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+	thread, err := svc.GetThread(ctx, "", reply.ID)
+	if err != nil {
+		t.Fatalf("GetThread: %v", err)
+	}
+	if len(thread.Ancestors) != 1 || !thread.Ancestors[0].Deleted || thread.Ancestors[0].Content != "" {
+		t.Errorf("ancestors = %+v, want a deleted placeholder", thread.Ancestors)
+	}
+
+	if err := svc.DeletePost(ctx, "user-1", post.ID); err == nil {
+		t.Error("expected error deleting twice")
+	}
+}
+
+func TestDeleteRepostWithdrawsIt(t *testing.T) {
+	postStore := newMockPostStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	post, _ := svc.CreatePost(ctx, "user-1", "original")
+	repost, _ := postStore.CreateRepost(ctx, "user-2", post.ID)
+
+	if err := svc.DeletePost(ctx, "user-2", repost.ID); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+	if count, _ := postStore.CountReposts(ctx, post.ID); count != 0 {
+		t.Errorf("repost count = %d, want 0", count)
+	}
+}
@@ -0,0 +1,126 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func newTestVerifyingAuthService(t *testing.T) (*service.AuthService, *mockUserStore, *recordingMailer) {
+	t.Helper()
+	users := newMockUserStore()
+	mail := &recordingMailer{}
+	auth := service.NewAuthService(users, newMockRefreshTokenStore(), newMockEmailVerificationStore(users), newMockTwoFactorStore(), newMockLoginChallengeStore(), jwtkeys.FromSecret("test-secret-32-bytes-long-xxxxx"), mail)
+	return auth, users, mail
+}
+
+func TestVerifyEmail(t *testing.T) {
+	auth, _, mail := newTestVerifyingAuthService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram")
+
+	if akram.EmailVerified {
+		t.Error("a new account should not be verified")
+	}
+	if len(mail.sent) != 1 || mail.sent[0].To != "akram@example.com" {
+		t.Fatalf("sent = %+v, want one email to akram@example.com", mail.sent)
+	}
+	code := mail.resetCode(t)
+
+	if err := auth.VerifyEmail(ctx, ""); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("empty code error = %v, want validation error", err)
+	}
+	if err := auth.VerifyEmail(ctx, "not-a-code"); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("wrong code error = %v, want unauthorized", err)
+	}
+	if err := auth.VerifyEmail(ctx, code); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if err := auth.VerifyEmail(ctx, code); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("reused code error = %v, want unauthorized", err)
+	}
+
+	resp, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if !resp.EmailVerified {
+		t.Error("login after verifying should report a verified email")
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	auth, _, mail := newTestVerifyingAuthService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram")
+	first := mail.resetCode(t)
+
+	if err := auth.ResendVerification(ctx, akram.User.ID); err != nil {
+		t.Fatalf("ResendVerification: %v", err)
+	}
+	second := mail.resetCode(t)
+
+	if err := auth.VerifyEmail(ctx, first); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("superseded code error = %v, want unauthorized", err)
+	}
+	if err := auth.VerifyEmail(ctx, second); err != nil {
+		t.Fatalf("latest code: %v", err)
+	}
+
+	if err := auth.ResendVerification(ctx, akram.User.ID); apiErrorCode(err) != models.ErrCodeConflict {
+		t.Errorf("resend when verified error = %v, want conflict", err)
+	}
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	auth, users, mail := newTestVerifyingAuthService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	posts := service.NewPostService(newMockPostStore(), newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+
+	// Without the option unverified users can post
+	post, err := posts.CreatePost(ctx, akram, "hello")
+	if err != nil {
+		t.Fatalf("CreatePost without the option: %v", err)
+	}
+
+	posts.RequireVerifiedEmail(users)
+	if _, err := posts.CreatePost(ctx, akram, "hello again"); apiErrorCode(err) != models.ErrCodeEmailUnverified {
+		t.Errorf("CreatePost error = %v, want email_unverified", err)
+	}
+	if _, err := posts.CreateReply(ctx, akram, post.ID, "a reply"); apiErrorCode(err) != models.ErrCodeEmailUnverified {
+		t.Errorf("CreateReply error = %v, want email_unverified", err)
+	}
+	if _, err := posts.Quote(ctx, akram, post.ID, "a quote"); apiErrorCode(err) != models.ErrCodeEmailUnverified {
+		t.Errorf("Quote error = %v, want email_unverified", err)
+	}
+	if _, err := posts.EditPost(ctx, akram, post.ID, "hello, edited"); apiErrorCode(err) != models.ErrCodeEmailUnverified {
+		t.Errorf("EditPost error = %v, want email_unverified", err)
+	}
+
+	// Importing an archive is posting too
+	imports := service.NewImportService(newMockPostStore())
+	imports.RequireVerifiedEmail(users)
+	archive := `{"content": "imported"}`
+	if _, err := imports.ImportPosts(ctx, akram, models.ImportJSONL, strings.NewReader(archive)); apiErrorCode(err) != models.ErrCodeEmailUnverified {
+		t.Errorf("ImportPosts error = %v, want email_unverified", err)
+	}
+
+	if err := auth.VerifyEmail(ctx, mail.resetCode(t)); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if _, err := posts.CreatePost(ctx, akram, "verified now"); err != nil {
+		t.Errorf("CreatePost after verifying: %v", err)
+	}
+	if _, err := posts.EditPost(ctx, akram, post.ID, "hello, edited"); err != nil {
+		t.Errorf("EditPost after verifying: %v", err)
+	}
+	result, err := imports.ImportPosts(ctx, akram, models.ImportJSONL, strings.NewReader(archive))
+	if err != nil || result.Imported != 1 {
+		t.Errorf("ImportPosts after verifying = %+v, %v, want 1 imported", result, err)
+	}
+}
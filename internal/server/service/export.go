@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+const (
+	// exportPageSize is how many rows an export reads per query.
+	exportPageSize = 100
+	// exportTimeout bounds how long building one archive may take.
+	exportTimeout = 5 * time.Minute
+)
+
+// ExportService builds copies of users' data. Archives are assembled in the
+// background: RequestExport returns a pending export straight away, and
+// the caller polls GetExport until it is ready.
+type ExportService struct {
+	exports store.ExportStore
+	users   store.UserStore
+	posts   store.PostStore
+	follows store.FollowStore
+	likes   store.LikeStore
+
+	mu      sync.Mutex
+	running map[string]bool // user IDs with an export being built
+	wg      sync.WaitGroup
+}
+
+func NewExportService(exports store.ExportStore, users store.UserStore, posts store.PostStore, follows store.FollowStore, likes store.LikeStore) *ExportService {
+	return &ExportService{
+		exports: exports,
+		users:   users,
+		posts:   posts,
+		follows: follows,
+		likes:   likes,
+		running: make(map[string]bool),
+	}
+}
+
+// RequestExport starts building an archive of userID's data. A user can
+// only have one export in progress at a time.
+func (s *ExportService) RequestExport(ctx context.Context, userID string) (*models.Export, error) {
+	s.mu.Lock()
+	if s.running[userID] {
+		s.mu.Unlock()
+		return nil, &models.APIError{Code: models.ErrCodeConflict, Message: "an export is already in progress"}
+	}
+	s.running[userID] = true
+	s.mu.Unlock()
+
+	export, err := s.exports.CreateExport(ctx, userID)
+	if err != nil {
+		s.finish(userID)
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go s.build(export.ID, userID)
+	return export, nil
+}
+
+func (s *ExportService) GetExport(ctx context.Context, userID, id string) (*models.Export, error) {
+	return s.exports.GetExport(ctx, userID, id)
+}
+
+// GetArchive returns the JSON archive of a ready export.
+func (s *ExportService) GetArchive(ctx context.Context, userID, id string) ([]byte, error) {
+	return s.exports.GetArchive(ctx, userID, id)
+}
+
+// Wait blocks until exports being built have finished or ctx is done.
+// Exports cut short by shutdown are marked failed the next time their
+// owner requests one.
+func (s *ExportService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// build assembles and stores the archive for export id, marking the export
+// failed if anything goes wrong. It outlives the request that started it.
+func (s *ExportService) build(id, userID string) {
+	defer s.wg.Done()
+	defer s.finish(userID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	archive, err := s.assemble(ctx, userID)
+	if err == nil {
+		var data []byte
+		data, err = json.Marshal(archive)
+		if err == nil {
+			err = s.exports.CompleteExport(ctx, id, data)
+		}
+	}
+	if err != nil {
+		slog.Error("data export failed", "export_id", id, "user_id", userID, "err", err)
+		if err := s.exports.FailExport(context.Background(), id); err != nil {
+			slog.Error("mark data export failed", "export_id", id, "err", err)
+		}
+	}
+}
+
+func (s *ExportService) finish(userID string) {
+	s.mu.Lock()
+	delete(s.running, userID)
+	s.mu.Unlock()
+}
+
+// assemble reads everything that goes into userID's archive.
+func (s *ExportService) assemble(ctx context.Context, userID string) (*models.ExportArchive, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := collectPages(func(cursor time.Time, cursorID string, limit int) ([]models.Post, error) {
+		return s.posts.GetExportPosts(ctx, userID, cursor, cursorID, limit)
+	}, func(p models.Post) (time.Time, string) { return p.CreatedAt, p.ID })
+	if err != nil {
+		return nil, fmt.Errorf("export posts: %w", err)
+	}
+
+	following, err := collectPages(func(cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error) {
+		return s.follows.GetExportFollowing(ctx, userID, cursor, cursorID, limit)
+	}, func(e models.FollowEntry) (time.Time, string) { return e.FollowedAt, e.User.ID })
+	if err != nil {
+		return nil, fmt.Errorf("export following: %w", err)
+	}
+
+	followers, err := collectPages(func(cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error) {
+		return s.follows.GetExportFollowers(ctx, userID, cursor, cursorID, limit)
+	}, func(e models.FollowEntry) (time.Time, string) { return e.FollowedAt, e.User.ID })
+	if err != nil {
+		return nil, fmt.Errorf("export followers: %w", err)
+	}
+
+	likes, err := collectPages(func(cursor time.Time, cursorID string, limit int) ([]models.LikeEntry, error) {
+		return s.likes.GetExportLikes(ctx, userID, cursor, cursorID, limit)
+	}, func(e models.LikeEntry) (time.Time, string) { return e.LikedAt, e.Post.ID })
+	if err != nil {
+		return nil, fmt.Errorf("export likes: %w", err)
+	}
+
+	return &models.ExportArchive{
+		ExportedAt: time.Now(),
+		User:       user,
+		Posts:      posts,
+		Following:  following,
+		Followers:  followers,
+		Likes:      likes,
+	}, nil
+}
+
+// collectPages reads every page of a list, newest first. cursorOf gives
+// the cursor that follows an item: its time and ID, so that items sharing
+// a time are neither skipped nor repeated. The first page is read with an
+// empty cursor ID.
+func collectPages[T any](fetch func(cursor time.Time, cursorID string, limit int) ([]T, error), cursorOf func(T) (time.Time, string)) ([]T, error) {
+	all := []T{}
+	var cursor time.Time
+	var cursorID string
+	for {
+		page, err := fetch(cursor, cursorID, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < exportPageSize {
+			return all, nil
+		}
+		cursor, cursorID = cursorOf(page[len(page)-1])
+	}
+}
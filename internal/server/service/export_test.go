@@ -0,0 +1,140 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// gatedPostStore holds GetExportPosts until release is closed, keeping an
+// export in progress.
+type gatedPostStore struct {
+	*mockPostStore
+	release chan struct{}
+}
+
+func (g *gatedPostStore) GetExportPosts(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.Post, error) {
+	<-g.release
+	return g.mockPostStore.GetExportPosts(ctx, userID, cursor, cursorID, limit)
+}
+
+// waitForExport polls until the export leaves pending.
+func waitForExport(t *testing.T, exports *service.ExportService, userID, id string) *models.Export {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		export, err := exports.GetExport(context.Background(), userID, id)
+		if err != nil {
+			t.Fatalf("GetExport: %v", err)
+		}
+		if export.Status != models.ExportPending {
+			return export
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("export still pending")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestExportArchive(t *testing.T) {
+	users := newMockUserStore()
+	auth := newAuthService(users, newMockRefreshTokenStore())
+	posts := &gatedPostStore{mockPostStore: newMockPostStore(), release: make(chan struct{})}
+	follows := newMockFollowStore()
+	likes := newMockLikeStore()
+	exports := service.NewExportService(newMockExportStore(), users, posts, follows, likes)
+	ctx := context.Background()
+
+	akram := registerUser(t, auth, "akram").User
+	sara := registerUser(t, auth, "sara").User
+	posts.AddPost("p1", akram.ID, "Hello", time.Now().Add(-2*time.Minute))
+	posts.AddPost("p2", sara.ID, "Hi", time.Now().Add(-time.Minute))
+	_ = follows.Follow(ctx, akram.ID, sara.ID)
+	_ = likes.Like(ctx, akram.ID, "p2")
+
+	export, err := exports.RequestExport(ctx, akram.ID)
+	if err != nil {
+		t.Fatalf("RequestExport: %v", err)
+	}
+	if export.Status != models.ExportPending {
+		t.Errorf("status = %q, want pending", export.Status)
+	}
+	if _, err := exports.RequestExport(ctx, akram.ID); apiErrorCode(err) != models.ErrCodeConflict {
+		t.Errorf("second RequestExport error = %v, want conflict", err)
+	}
+	if _, err := exports.GetArchive(ctx, akram.ID, export.ID); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("GetArchive while pending error = %v, want not_found", err)
+	}
+
+	close(posts.release)
+	if done := waitForExport(t, exports, akram.ID, export.ID); done.Status != models.ExportReady {
+		t.Fatalf("status = %q, want ready", done.Status)
+	}
+
+	data, err := exports.GetArchive(ctx, akram.ID, export.ID)
+	if err != nil {
+		t.Fatalf("GetArchive: %v", err)
+	}
+	var archive models.ExportArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("decode archive: %v", err)
+	}
+	if archive.User == nil || archive.User.ID != akram.ID {
+		t.Errorf("archive user = %+v, want akram", archive.User)
+	}
+	if len(archive.Posts) != 1 || archive.Posts[0].ID != "p1" {
+		t.Errorf("archive posts = %+v, want only akram's post", archive.Posts)
+	}
+	if len(archive.Following) != 1 || archive.Following[0].User.ID != sara.ID || len(archive.Followers) != 0 {
+		t.Errorf("archive follows = %+v / %+v, want following sara only", archive.Following, archive.Followers)
+	}
+	if len(archive.Likes) != 1 || archive.Likes[0].Post.ID != "p2" {
+		t.Errorf("archive likes = %+v, want p2", archive.Likes)
+	}
+
+	if _, err := exports.GetExport(ctx, sara.ID, export.ID); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("GetExport by another user error = %v, want not_found", err)
+	}
+}
+
+func TestExportIncludesItemsSharingATimestamp(t *testing.T) {
+	users := newMockUserStore()
+	auth := newAuthService(users, newMockRefreshTokenStore())
+	posts := newMockPostStore()
+	follows := newMockFollowStore()
+	exports := service.NewExportService(newMockExportStore(), users, posts, follows, newMockLikeStore())
+	ctx := context.Background()
+
+	// More posts and follows than one export page, all on the same time,
+	// as an import or a bulk follow leaves them
+	akram := registerUser(t, auth, "akram").User
+	at := time.Now().Add(-time.Hour)
+	for i := 0; i < 150; i++ {
+		posts.AddPost(fmt.Sprintf("p%03d", i), akram.ID, "imported", at)
+		follows.AddFollow(akram.ID, fmt.Sprintf("u%03d", i), at)
+		follows.AddFollow(fmt.Sprintf("f%03d", i), akram.ID, at)
+	}
+
+	export, err := exports.RequestExport(ctx, akram.ID)
+	if err != nil {
+		t.Fatalf("RequestExport: %v", err)
+	}
+	if done := waitForExport(t, exports, akram.ID, export.ID); done.Status != models.ExportReady {
+		t.Fatalf("status = %q, want ready", done.Status)
+	}
+	data, _ := exports.GetArchive(ctx, akram.ID, export.ID)
+	var archive models.ExportArchive
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("decode archive: %v", err)
+	}
+	if len(archive.Posts) != 150 || len(archive.Following) != 150 || len(archive.Followers) != 150 {
+		t.Errorf("archive has %d posts, %d following, %d followers, want 150 of each",
+			len(archive.Posts), len(archive.Following), len(archive.Followers))
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+// maxFilters is the most active filters a user may keep at once.
+const maxFilters = 50
+
+// maxFilterExpiry is the furthest ahead a filter's expiry may be set.
+const maxFilterExpiry = 10 * 365 * 24 * time.Hour
+
+// FilterService manages users' keyword filters. The filters themselves are
+// applied by PostService when reading feeds.
+type FilterService struct {
+	filters store.FilterStore
+}
+
+func NewFilterService(filters store.FilterStore) *FilterService {
+	return &FilterService{filters: filters}
+}
+
+// CreateFilter adds a filter for userID. match defaults to a phrase match and
+// action to hiding; an expiresIn of zero keeps the filter until it is deleted.
+func (s *FilterService) CreateFilter(ctx context.Context, userID, phrase, match, action string, expiresIn time.Duration) (*models.Filter, error) {
+	phrase = strings.TrimSpace(phrase)
+	if match == "" {
+		match = models.FilterMatchPhrase
+	}
+	if action == "" {
+		action = models.FilterActionHide
+	}
+	if err := ValidateFilter(phrase, match, action); err != nil {
+		return nil, err
+	}
+	if err := ValidateFilterExpiry(int64(expiresIn / time.Second)); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.filters.GetFilters(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxFilters {
+		return nil, &models.APIError{
+			Code:    models.ErrCodeValidation,
+			Message: "you can have at most 50 filters",
+		}
+	}
+
+	var expiresAt *time.Time
+	if expiresIn > 0 {
+		t := time.Now().Add(expiresIn)
+		expiresAt = &t
+	}
+	return s.filters.CreateFilter(ctx, userID, phrase, match, action, expiresAt)
+}
+
+func (s *FilterService) GetFilters(ctx context.Context, userID string) ([]models.Filter, error) {
+	return s.filters.GetFilters(ctx, userID)
+}
+
+func (s *FilterService) DeleteFilter(ctx context.Context, userID, id string) error {
+	return s.filters.DeleteFilter(ctx, userID, id)
+}
+
+// ValidateFilterExpiry checks an expiry given in seconds before it is turned
+// into a time.Duration, which would overflow for very large values.
+func ValidateFilterExpiry(seconds int64) error {
+	switch {
+	case seconds < 0:
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "expires_in",
+			Message: "expiry must be in the future",
+		}
+	case seconds > int64(maxFilterExpiry/time.Second):
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "expires_in",
+			Message: "expiry must be at most 10 years away",
+		}
+	}
+	return nil
+}
+
+// ValidateFilter checks a filter's phrase, match type and action.
+func ValidateFilter(phrase, match, action string) error {
+	if length := utf8.RuneCountInString(phrase); length < 1 || length > 100 {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "phrase",
+			Message: "phrase must be 1-100 characters",
+		}
+	}
+	switch match {
+	case models.FilterMatchPhrase, models.FilterMatchWord, models.FilterMatchRegex:
+	default:
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "match",
+			Message: "match must be phrase, word or regex",
+		}
+	}
+	switch action {
+	case models.FilterActionHide, models.FilterActionFlag:
+	default:
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "action",
+			Message: "action must be hide or flag",
+		}
+	}
+	if _, err := compileFilter(phrase, match); err != nil {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "phrase",
+			Message: "phrase is not a valid regular expression",
+		}
+	}
+	return nil
+}
+
+// compileFilter turns a filter phrase into a case-insensitive expression. A
+// word match only matches when the phrase is not part of a longer word.
+func compileFilter(phrase, match string) (*regexp.Regexp, error) {
+	switch match {
+	case models.FilterMatchWord:
+		return regexp.Compile(`(?i)(^|[^\pL\pN_])` + regexp.QuoteMeta(phrase) + `($|[^\pL\pN_])`)
+	case models.FilterMatchRegex:
+		return regexp.Compile(`(?i)` + phrase)
+	default:
+		return regexp.Compile(`(?i)` + regexp.QuoteMeta(phrase))
+	}
+}
+
+// compiledFilter is a filter ready to be matched against posts.
+type compiledFilter struct {
+	filter models.Filter
+	re     *regexp.Regexp
+}
+
+// compileFilters compiles filters, skipping any that no longer compile.
+func compileFilters(filters []models.Filter) []compiledFilter {
+	compiled := make([]compiledFilter, 0, len(filters))
+	for _, f := range filters {
+		re, err := compileFilter(f.Phrase, f.Match)
+		if err != nil {
+			continue
+		}
+		compiled = append(compiled, compiledFilter{filter: f, re: re})
+	}
+	return compiled
+}
+
+// matchFilters returns the filter that applies to post, preferring one that
+// hides it over one that only flags it. The content of a reposted or quoted
+// original counts as part of the post.
+func matchFilters(filters []compiledFilter, post *models.Post) *models.Filter {
+	text := post.Content
+	if post.Original != nil {
+		text += "\n" + post.Original.Content
+	}
+	var flagged *models.Filter
+	for i := range filters {
+		if !filters[i].re.MatchString(text) {
+			continue
+		}
+		if filters[i].filter.Action == models.FilterActionHide {
+			return &filters[i].filter
+		}
+		if flagged == nil {
+			flagged = &filters[i].filter
+		}
+	}
+	return flagged
+}
@@ -0,0 +1,219 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestCreateFilterValidation(t *testing.T) {
+	svc := service.NewFilterService(newMockFilterStore())
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		phrase    string
+		match     string
+		action    string
+		expiresIn time.Duration
+	}{
+		{"empty phrase", "   ", "", "", 0},
+		{"unknown match", "spoiler", "glob", "", 0},
+		{"unknown action", "spoiler", "", "delete", 0},
+		{"invalid regex", "spoil(er", models.FilterMatchRegex, "", 0},
+		{"expiry in the past", "spoiler", "", "", -time.Hour},
+		{"expiry past ten years", "spoiler", "", "", 11 * 365 * 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateFilter(ctx, "user-1", tt.phrase, tt.match, tt.action, tt.expiresIn)
+			if apiErrorCode(err) != models.ErrCodeValidation {
+				t.Errorf("error = %v, want validation error", err)
+			}
+		})
+	}
+}
+
+func TestValidateFilterExpiry(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		valid   bool
+	}{
+		{0, true},
+		{3600, true},
+		{10 * 365 * 24 * 3600, true},
+		{-1, false},
+		{10*365*24*3600 + 1, false},
+		{math.MaxInt64, false},
+		{math.MinInt64, false},
+	}
+	for _, tt := range tests {
+		err := service.ValidateFilterExpiry(tt.seconds)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateFilterExpiry(%d) = %v, want nil", tt.seconds, err)
+		}
+		if !tt.valid {
+			var apiErr *models.APIError
+			if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeValidation || apiErr.Field != "expires_in" {
+				t.Errorf("ValidateFilterExpiry(%d) = %v, want validation error on expires_in", tt.seconds, err)
+			}
+		}
+	}
+}
+
+func TestCreateFilterDefaults(t *testing.T) {
+	svc := service.NewFilterService(newMockFilterStore())
+
+	f, err := svc.CreateFilter(context.Background(), "user-1", "  spoiler  ", "", "", time.Hour)
+	if err != nil {
+		t.Fatalf("CreateFilter: %v", err)
+	}
+	if f.Phrase != "spoiler" || f.Match != models.FilterMatchPhrase || f.Action != models.FilterActionHide {
+		t.Errorf("filter = %+v, want trimmed phrase hide filter", f)
+	}
+	if f.ExpiresAt == nil || time.Until(*f.ExpiresAt) <= 0 {
+		t.Errorf("expires_at = %v, want an hour from now", f.ExpiresAt)
+	}
+}
+
+func TestGetTimelineFilters(t *testing.T) {
+	postStore := newMockPostStore()
+	filterStore := newMockFilterStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), filterStore)
+	filters := service.NewFilterService(filterStore)
+	ctx := context.Background()
+
+	now := time.Now()
+	postStore.AddPost("1", "user-2", "Big SPOILERS ahead", now.Add(-1*time.Minute))
+	postStore.AddPost("2", "user-2", "the catalog is out", now.Add(-2*time.Minute))
+	postStore.AddPost("3", "user-2", "a cat on the keyboard", now.Add(-3*time.Minute))
+	postStore.AddPost("4", "user-2", "release v1.2.3 tonight", now.Add(-4*time.Minute))
+	postStore.AddPost("5", "user-1", "my own spoiler", now.Add(-5*time.Minute))
+
+	if _, err := filters.CreateFilter(ctx, "user-1", "spoiler", models.FilterMatchPhrase, models.FilterActionHide, 0); err != nil {
+		t.Fatalf("CreateFilter phrase: %v", err)
+	}
+	if _, err := filters.CreateFilter(ctx, "user-1", "cat", models.FilterMatchWord, models.FilterActionFlag, 0); err != nil {
+		t.Fatalf("CreateFilter word: %v", err)
+	}
+	if _, err := filters.CreateFilter(ctx, "user-1", `v\d+\.\d+`, models.FilterMatchRegex, models.FilterActionFlag, 0); err != nil {
+		t.Fatalf("CreateFilter regex: %v", err)
+	}
+
+	posts, _, err := svc.GetTimeline(ctx, "user-1", now, 10)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+
+	want := map[string]string{"2": "", "3": "cat", "4": `v\d+\.\d+`, "5": ""}
+	if len(posts) != len(want) {
+		t.Fatalf("got %d posts, want %d", len(posts), len(want))
+	}
+	for _, p := range posts {
+		filtered, ok := want[p.ID]
+		if !ok {
+			t.Errorf("post %s should have been hidden", p.ID)
+			continue
+		}
+		if p.Filtered != filtered {
+			t.Errorf("post %s filtered = %q, want %q", p.ID, p.Filtered, filtered)
+		}
+	}
+
+	// Other viewers are unaffected
+	posts, _, _ = svc.GetTimeline(ctx, "user-3", now, 10)
+	if len(posts) != 5 {
+		t.Errorf("unfiltered viewer got %d posts, want 5", len(posts))
+	}
+}
+
+func TestGetTimelineFiltersRefillPage(t *testing.T) {
+	postStore := newMockPostStore()
+	filterStore := newMockFilterStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), filterStore)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i := 1; i <= 6; i++ {
+		content := "plain post"
+		if i <= 3 {
+			content = "spoiler post"
+		}
+		postStore.AddPost(fmt.Sprintf("%d", i), "user-2", content, now.Add(-time.Duration(i)*time.Minute))
+	}
+	if _, err := service.NewFilterService(filterStore).CreateFilter(ctx, "user-1", "spoiler", "", "", 0); err != nil {
+		t.Fatalf("CreateFilter: %v", err)
+	}
+
+	posts, _, err := svc.GetTimeline(ctx, "user-1", now, 3)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	if len(posts) != 3 {
+		t.Fatalf("got %d posts, want a full page of 3", len(posts))
+	}
+	for i, p := range posts {
+		if want := fmt.Sprintf("%d", i+4); p.ID != want {
+			t.Errorf("posts[%d] = %s, want %s", i, p.ID, want)
+		}
+	}
+}
+
+func TestGetTimelineFiltersKeepPaging(t *testing.T) {
+	postStore := newMockPostStore()
+	filterStore := newMockFilterStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), filterStore)
+	ctx := context.Background()
+
+	// More hidden posts than the refills can scan past in one page
+	now := time.Now()
+	for i := 1; i <= 10; i++ {
+		content := "spoiler post"
+		if i > 8 {
+			content = "plain post"
+		}
+		postStore.AddPost(fmt.Sprintf("%d", i), "user-2", content, now.Add(-time.Duration(i)*time.Minute))
+	}
+	if _, err := service.NewFilterService(filterStore).CreateFilter(ctx, "user-1", "spoiler", "", "", 0); err != nil {
+		t.Fatalf("CreateFilter: %v", err)
+	}
+
+	posts, next, err := svc.GetTimeline(ctx, "user-1", now, 2)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	if len(posts) != 0 || next.IsZero() {
+		t.Fatalf("first page = %d posts, next %v, want none and a cursor to continue from", len(posts), next)
+	}
+	posts, _, err = svc.GetTimeline(ctx, "user-1", next, 2)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	if len(posts) != 2 || posts[0].ID != "9" || posts[1].ID != "10" {
+		t.Errorf("second page = %+v, want posts 9 and 10", posts)
+	}
+}
+
+func TestExpiredFilterIgnored(t *testing.T) {
+	postStore := newMockPostStore()
+	filterStore := newMockFilterStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), filterStore)
+	ctx := context.Background()
+
+	postStore.AddPost("1", "user-2", "spoiler", time.Now().Add(-time.Minute))
+	expired := time.Now().Add(-time.Hour)
+	if _, err := filterStore.CreateFilter(ctx, "user-1", "spoiler", models.FilterMatchPhrase, models.FilterActionHide, &expired); err != nil {
+		t.Fatalf("CreateFilter: %v", err)
+	}
+
+	posts, _, _ := svc.GetUserPosts(ctx, "user-1", "user-2", time.Now(), 10)
+	if len(posts) != 1 {
+		t.Errorf("got %d posts, want the post once its filter expired", len(posts))
+	}
+}
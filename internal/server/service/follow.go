@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+type FollowService struct {
+	follows       store.FollowStore
+	blocks        store.BlockStore
+	notifications store.NotificationStore
+}
+
+func NewFollowService(follows store.FollowStore, blocks store.BlockStore, notifications store.NotificationStore) *FollowService {
+	return &FollowService{follows: follows, blocks: blocks, notifications: notifications}
+}
+
+func (s *FollowService) Follow(ctx context.Context, followerID, followeeID string) error {
+	if followerID == followeeID {
+		return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot follow yourself"}
+	}
+	blocked, err := s.blocks.IsBlockedBetween(ctx, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return &models.APIError{Code: models.ErrCodeForbidden, Message: "you cannot follow this user"}
+	}
+	if err := s.follows.Follow(ctx, followerID, followeeID); err != nil {
+		return err
+	}
+	return s.notifications.NotifyUser(ctx, followeeID, followerID, models.NotificationKindFollow, nil)
+}
+
+func (s *FollowService) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	return s.follows.Unfollow(ctx, followerID, followeeID)
+}
+
+func (s *FollowService) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	return s.follows.IsFollowing(ctx, followerID, followeeID)
+}
+
+func (s *FollowService) GetFollowers(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.follows.GetFollowers(ctx, userID, cursor, limit)
+}
+
+func (s *FollowService) GetFollowing(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.follows.GetFollowing(ctx, userID, cursor, limit)
+}
@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestFollow(t *testing.T) {
+	followStore := newMockFollowStore()
+	svc := service.NewFollowService(followStore, newMockBlockStore(), newMockNotificationStore())
+	ctx := context.Background()
+
+	if err := svc.Follow(ctx, "user-1", "user-2"); err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+
+	following, err := svc.IsFollowing(ctx, "user-1", "user-2")
+	if err != nil {
+		t.Fatalf("IsFollowing: %v", err)
+	}
+	if !following {
+		t.Error("expected user-1 to follow user-2")
+	}
+}
+
+func TestFollowSelf(t *testing.T) {
+	followStore := newMockFollowStore()
+	svc := service.NewFollowService(followStore, newMockBlockStore(), newMockNotificationStore())
+
+	err := svc.Follow(context.Background(), "user-1", "user-1")
+	if err == nil {
+		t.Fatal("expected error for self-follow")
+	}
+	apiErr, ok := err.(*models.APIError)
+	if !ok {
+		t.Fatalf("expected *models.APIError, got %T", err)
+	}
+	if apiErr.Code != models.ErrCodeValidation {
+		t.Errorf("code = %q, want %q", apiErr.Code, models.ErrCodeValidation)
+	}
+}
+
+func TestUnfollow(t *testing.T) {
+	followStore := newMockFollowStore()
+	svc := service.NewFollowService(followStore, newMockBlockStore(), newMockNotificationStore())
+	ctx := context.Background()
+
+	_ = svc.Follow(ctx, "user-1", "user-2")
+	if err := svc.Unfollow(ctx, "user-1", "user-2"); err != nil {
+		t.Fatalf("Unfollow: %v", err)
+	}
+
+	following, _ := svc.IsFollowing(ctx, "user-1", "user-2")
+	if following {
+		t.Error("expected follow to be removed")
+	}
+}
+
+func TestGetFollowersDefaultsLimit(t *testing.T) {
+	followStore := newMockFollowStore()
+	svc := service.NewFollowService(followStore, newMockBlockStore(), newMockNotificationStore())
+	ctx := context.Background()
+
+	_ = svc.Follow(ctx, "user-2", "user-1")
+	_ = svc.Follow(ctx, "user-3", "user-1")
+
+	followers, err := svc.GetFollowers(ctx, "user-1", time.Now().Add(time.Second), 0)
+	if err != nil {
+		t.Fatalf("GetFollowers: %v", err)
+	}
+	if len(followers) != 2 {
+		t.Errorf("got %d followers, want 2", len(followers))
+	}
+
+	following, err := svc.GetFollowing(ctx, "user-2", time.Now().Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("GetFollowing: %v", err)
+	}
+	if len(following) != 1 || following[0].User.ID != "user-1" {
+		t.Errorf("following = %+v, want only user-1", following)
+	}
+}
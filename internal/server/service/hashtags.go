@@ -0,0 +1,67 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+)
+
+const maxTagLength = 50
+
+// ParseHashtags returns the distinct tags referenced as #tag in content,
+// lowercased and in order of first appearance. Like a mention, a hashtag must
+// start the content or follow a character that cannot appear in a tag. Tags
+// made only of digits, such as "#1", and tags longer than 50 characters are
+// ignored.
+func ParseHashtags(content string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '#' || (i > 0 && isUsernameRune(runes[i-1])) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isUsernameRune(runes[end]) {
+			end++
+		}
+		tag := strings.ToLower(string(runes[i+1 : end]))
+		i = end - 1
+		if seen[tag] || ValidateTag(tag) != nil {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// ValidateTag checks that tag, without its leading '#', is a well-formed
+// lowercase hashtag.
+func ValidateTag(tag string) error {
+	if tag == "" || len(tag) > maxTagLength {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "tag",
+			Message: "tag must be 1-50 characters",
+		}
+	}
+	hasLetter := false
+	for _, r := range tag {
+		if !isUsernameRune(r) || (r >= 'A' && r <= 'Z') {
+			return &models.APIError{
+				Code: models.ErrCodeValidation, Field: "tag",
+				Message: "tag must be lowercase letters, digits and underscores only",
+			}
+		}
+		if r >= 'a' && r <= 'z' {
+			hasLetter = true
+		}
+	}
+	if !hasLetter {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "tag",
+			Message: "tag must contain a letter",
+		}
+	}
+	return nil
+}
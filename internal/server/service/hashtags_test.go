@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestParseHashtags(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"hello #golang", []string{"golang"}},
+		{"#Go and #TUI, more #go", []string{"go", "tui"}},
+		{"shipping #v2.", []string{"v2"}},
+		{"(#bubbletea)", []string{"bubbletea"}},
+		{"issue#42 is not a tag", nil},
+		{"#1 is only digits", nil},
+		{"#snake_case works", []string{"snake_case"}},
+		{"#" + strings.Repeat("a", 51), nil},
+		{"#", nil},
+		{"no tags here", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			got := service.ParseHashtags(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseHashtags(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePostAttachesTags(t *testing.T) {
+	tagStore := newMockTagStore()
+	svc := service.NewPostService(newMockPostStore(), newMockMentionStore(), tagStore, newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	post, err := svc.CreatePost(ctx, "user-1", "learning #Go with #bubbletea")
+	if err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+	want := []string{"go", "bubbletea"}
+	if got := tagStore.tagged[post.ID]; !reflect.DeepEqual(got, want) {
+		t.Errorf("tagged = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(post.Tags, want) {
+		t.Errorf("post.Tags = %v, want %v", post.Tags, want)
+	}
+}
+
+func TestGetTagPostsValidatesTag(t *testing.T) {
+	svc := service.NewPostService(newMockPostStore(), newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	for _, tag := range []string{"", "#", "123", "no spaces", "dash-tag"} {
+		_, err := svc.GetTagPosts(ctx, "user-1", tag, time.Now(), 20)
+		var apiErr *models.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeValidation {
+			t.Errorf("GetTagPosts(%q) error = %v, want validation error", tag, err)
+		}
+	}
+
+	if _, err := svc.GetTagPosts(ctx, "user-1", "#GoLang", time.Now(), 20); err != nil {
+		t.Errorf("GetTagPosts(#GoLang) error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+const (
+	// MaxImportBytes bounds the size of an import archive, and so of any
+	// one line of a JSONL archive.
+	MaxImportBytes = 8 << 20
+	// maxImportItems bounds how many posts one import may contain.
+	maxImportItems = 10000
+	// importClockSkew is how far in the future an imported post's
+	// creation time may be, to allow for clocks that disagree.
+	importClockSkew = 5 * time.Minute
+)
+
+// ImportService creates posts from archives, such as those made by a data
+// export on another instance.
+type ImportService struct {
+	posts store.PostStore
+	// users is only set when authors must have verified their email.
+	users store.UserStore
+}
+
+func NewImportService(posts store.PostStore) *ImportService {
+	return &ImportService{posts: posts}
+}
+
+// RequireVerifiedEmail makes imports fail for users who have not verified
+// their email address, like posting does. Call it before the service is
+// used.
+func (s *ImportService) RequireVerifiedEmail(users store.UserStore) {
+	s.users = users
+}
+
+// importRow is one item read from an archive, or the reason it could not
+// be read. skip marks items the archive holds that are not imported on
+// purpose.
+type importRow struct {
+	row  int
+	item models.ImportItem
+	err  string
+	skip bool
+}
+
+// ImportPosts reads an archive in format from r and creates userID's posts
+// from it, keeping their original creation times. Items that fail to parse
+// or validate are reported in the result's errors and the rest are still
+// imported. Items imported before are skipped, so an import can be re-run,
+// and so are reposts in an export archive.
+// Imported posts get their hashtags, but mentions are not recorded so that
+// old posts do not notify anyone.
+func (s *ImportService) ImportPosts(ctx context.Context, userID, format string, r io.Reader) (*models.ImportResult, error) {
+	if err := checkVerifiedEmail(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	rows, err := parseImport(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ImportResult{Errors: []models.ImportRowError{}}
+	fail := func(row int, message string) {
+		result.Errors = append(result.Errors, models.ImportRowError{Row: row, Message: message})
+	}
+
+	now := time.Now()
+	posts := make([]models.Post, 0, len(rows))
+	for _, row := range rows {
+		if row.skip {
+			result.Skipped++
+			continue
+		}
+		if row.err != "" {
+			fail(row.row, row.err)
+			continue
+		}
+
+		content := strings.TrimSpace(row.item.Content)
+		if err := ValidatePostContent(content); err != nil {
+			fail(row.row, importErrorMessage(err))
+			continue
+		}
+		createdAt := row.item.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = now
+		}
+		if createdAt.After(now.Add(importClockSkew)) {
+			fail(row.row, "created_at is in the future")
+			continue
+		}
+
+		posts = append(posts, models.Post{Content: content, CreatedAt: createdAt, Tags: ParseHashtags(content)})
+	}
+
+	imported, err := s.posts.ImportPosts(ctx, userID, posts)
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = imported
+	result.Skipped += len(posts) - imported
+	return result, nil
+}
+
+// importErrorMessage gives the message to report for an item that failed
+// validation.
+func importErrorMessage(err error) string {
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Message
+	}
+	return err.Error()
+}
+
+// parseImport reads every item of an archive. Problems with single items
+// are recorded on their rows; an error is returned only when the archive as
+// a whole cannot be read.
+func parseImport(format string, r io.Reader) ([]importRow, error) {
+	var rows []importRow
+	var err error
+	switch format {
+	case models.ImportJSON, "":
+		rows, err = parseImportJSON(r)
+	case models.ImportJSONL:
+		rows, err = parseImportJSONL(r)
+	case models.ImportCSV:
+		rows, err = parseImportCSV(r)
+	default:
+		return nil, &models.APIError{
+			Code:    models.ErrCodeValidation,
+			Field:   "format",
+			Message: "format must be json, jsonl or csv",
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > maxImportItems {
+		return nil, &models.APIError{
+			Code:    models.ErrCodeValidation,
+			Message: fmt.Sprintf("an import can contain at most %d posts", maxImportItems),
+		}
+	}
+	return rows, nil
+}
+
+// parseImportJSON reads the posts of a data export archive. Reposts have no
+// content of their own and are skipped.
+func parseImportJSON(r io.Reader) ([]importRow, error) {
+	var archive struct {
+		Posts []struct {
+			Kind      string    `json:"kind"`
+			Content   string    `json:"content"`
+			CreatedAt time.Time `json:"created_at"`
+		} `json:"posts"`
+	}
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, importReadError(err, "archive is not valid JSON")
+	}
+
+	rows := make([]importRow, 0, len(archive.Posts))
+	for i, p := range archive.Posts {
+		row := importRow{row: i + 1, item: models.ImportItem{Content: p.Content, CreatedAt: p.CreatedAt}}
+		row.skip = p.Kind == models.PostKindRepost
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseImportJSONL reads one item per line. Rows are line numbers and
+// blank lines are ignored.
+func parseImportJSONL(r io.Reader) ([]importRow, error) {
+	var rows []importRow
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, MaxImportBytes)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		row := importRow{row: line}
+		if err := json.Unmarshal([]byte(text), &row.item); err != nil {
+			row.err = "invalid JSON: " + err.Error()
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, importReadError(err, "archive could not be read")
+	}
+	return rows, nil
+}
+
+// parseImportCSV reads a header row naming a content column and an
+// optional created_at column, then one item per record. Rows count records
+// after the header.
+func parseImportCSV(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, importReadError(err, "archive must start with a header row")
+	}
+	contentCol, createdCol := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "content":
+			contentCol = i
+		case "created_at":
+			createdCol = i
+		}
+	}
+	if contentCol < 0 {
+		return nil, &models.APIError{Code: models.ErrCodeValidation, Message: "header row must have a content column"}
+	}
+
+	var rows []importRow
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		row := importRow{row: n}
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			row.err = "invalid CSV: " + parseErr.Err.Error()
+		case err != nil:
+			return nil, importReadError(err, "archive could not be read")
+		case contentCol >= len(record):
+			row.err = "missing content"
+		default:
+			row.item.Content = record[contentCol]
+			if createdCol >= 0 && createdCol < len(record) && strings.TrimSpace(record[createdCol]) != "" {
+				createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(record[createdCol]))
+				if err != nil {
+					row.err = "created_at must be an RFC3339 timestamp"
+				}
+				row.item.CreatedAt = createdAt
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// importReadError reports an archive that could not be read as a whole.
+func importReadError(err error, message string) error {
+	return &models.APIError{Code: models.ErrCodeValidation, Message: message + ": " + err.Error()}
+}
@@ -0,0 +1,139 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestImportJSONArchive(t *testing.T) {
+	posts := newMockPostStore()
+	imports := service.NewImportService(posts)
+	ctx := context.Background()
+
+	archive := `{"user": {"username": "akram"}, "posts": [
+		{"kind": "post", "content": "Second #go", "created_at": "2025-03-02T10:00:00Z"},
+		{"kind": "repost", "content": "", "created_at": "2025-03-01T12:00:00Z"},
+		{"kind": "post", "content": "First", "created_at": "2025-03-01T10:00:00Z"}
+	]}`
+
+	result, err := imports.ImportPosts(ctx, "u1", models.ImportJSON, strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ImportPosts: %v", err)
+	}
+	if result.Imported != 2 || result.Skipped != 1 || len(result.Errors) != 0 {
+		t.Fatalf("result = %+v, want 2 imported and the repost skipped", result)
+	}
+	if len(posts.posts) != 2 {
+		t.Fatalf("stored %d posts, want 2", len(posts.posts))
+	}
+	want := time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)
+	if !posts.posts[0].CreatedAt.Equal(want) || posts.posts[0].AuthorID != "u1" {
+		t.Errorf("imported post = %+v, want created at %v by u1", posts.posts[0], want)
+	}
+	if got := posts.posts[0].Tags; len(got) != 1 || got[0] != "go" {
+		t.Errorf("tags = %v, want [go]", got)
+	}
+
+	// Importing the same archive again skips what is already there
+	result, err = imports.ImportPosts(ctx, "u1", models.ImportJSON, strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("second ImportPosts: %v", err)
+	}
+	if result.Imported != 0 || result.Skipped != 3 {
+		t.Errorf("re-import result = %+v, want all 3 skipped", result)
+	}
+}
+
+func TestImportJSONLReportsRowErrors(t *testing.T) {
+	posts := newMockPostStore()
+	imports := service.NewImportService(posts)
+
+	archive := strings.Join([]string{
+		`{"content": "Fine", "created_at": "2025-01-01T00:00:00Z"}`,
+		``,
+		`{"content": "` + strings.Repeat("a", 141) + `"}`,
+		`not json`,
+		`{"content": "From the future", "created_at": "2999-01-01T00:00:00Z"}`,
+		`{"content": "   "}`,
+	}, "\n")
+
+	result, err := imports.ImportPosts(context.Background(), "u1", models.ImportJSONL, strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ImportPosts: %v", err)
+	}
+	if result.Imported != 1 {
+		t.Errorf("imported = %d, want 1", result.Imported)
+	}
+	var rows []int
+	for _, e := range result.Errors {
+		rows = append(rows, e.Row)
+	}
+	if len(rows) != 4 || rows[0] != 3 || rows[1] != 4 || rows[2] != 5 || rows[3] != 6 {
+		t.Errorf("error rows = %v, want [3 4 5 6]", rows)
+	}
+}
+
+func TestImportJSONLLongLines(t *testing.T) {
+	posts := newMockPostStore()
+	imports := service.NewImportService(posts)
+
+	// Lines longer than bufio.Scanner's default limit still parse
+	archive := `{"content": "Fine", "note": "` + strings.Repeat("a", 100<<10) + `"}`
+	result, err := imports.ImportPosts(context.Background(), "u1", models.ImportJSONL, strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ImportPosts: %v", err)
+	}
+	if result.Imported != 1 {
+		t.Errorf("result = %+v, want the long line imported", result)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	posts := newMockPostStore()
+	imports := service.NewImportService(posts)
+
+	archive := "created_at,content\n" +
+		"2025-01-01T00:00:00Z,\"Hello, CSV\"\n" +
+		"yesterday,Bad time\n" +
+		",No time given\n"
+
+	result, err := imports.ImportPosts(context.Background(), "u1", models.ImportCSV, strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("ImportPosts: %v", err)
+	}
+	if result.Imported != 2 || len(result.Errors) != 1 || result.Errors[0].Row != 2 {
+		t.Fatalf("result = %+v, want 2 imported and row 2 rejected", result)
+	}
+	if posts.posts[0].Content != "Hello, CSV" {
+		t.Errorf("content = %q, want %q", posts.posts[0].Content, "Hello, CSV")
+	}
+	if time.Since(posts.posts[1].CreatedAt) > time.Minute {
+		t.Errorf("post without created_at dated %v, want now", posts.posts[1].CreatedAt)
+	}
+}
+
+func TestImportRejectsBadArchives(t *testing.T) {
+	imports := service.NewImportService(newMockPostStore())
+	ctx := context.Background()
+
+	tests := []struct {
+		name, format, archive string
+	}{
+		{"unknown format", "xml", "<posts/>"},
+		{"malformed json", models.ImportJSON, "{"},
+		{"csv without content column", models.ImportCSV, "text,created_at\nhi,\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := imports.ImportPosts(ctx, "u1", tt.format, strings.NewReader(tt.archive))
+			if apiErrorCode(err) != models.ErrCodeValidation {
+				t.Errorf("error = %v, want validation_error", err)
+			}
+		})
+	}
+}
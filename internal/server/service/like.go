@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+type LikeService struct {
+	likes         store.LikeStore
+	notifications store.NotificationStore
+}
+
+func NewLikeService(likes store.LikeStore, notifications store.NotificationStore) *LikeService {
+	return &LikeService{likes: likes, notifications: notifications}
+}
+
+// Like records userID's like on postID, notifies the post's author and
+// returns the post's new like count. Liking an already-liked post is a no-op.
+func (s *LikeService) Like(ctx context.Context, userID, postID string) (int, error) {
+	if err := s.likes.Like(ctx, userID, postID); err != nil {
+		return 0, err
+	}
+	if err := s.notifications.NotifyPostAuthor(ctx, userID, models.NotificationKindLike, postID, postID); err != nil {
+		return 0, err
+	}
+	return s.likes.CountLikes(ctx, postID)
+}
+
+// Unlike removes userID's like on postID and returns the post's new like count.
+func (s *LikeService) Unlike(ctx context.Context, userID, postID string) (int, error) {
+	if err := s.likes.Unlike(ctx, userID, postID); err != nil {
+		return 0, err
+	}
+	return s.likes.CountLikes(ctx, postID)
+}
@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestLikeReturnsCount(t *testing.T) {
+	svc := service.NewLikeService(newMockLikeStore(), newMockNotificationStore())
+	ctx := context.Background()
+
+	count, err := svc.Like(ctx, "user-1", "post-1")
+	if err != nil {
+		t.Fatalf("Like: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+
+	count, _ = svc.Like(ctx, "user-1", "post-1")
+	if count != 1 {
+		t.Errorf("count after duplicate like = %d, want 1", count)
+	}
+
+	count, _ = svc.Like(ctx, "user-2", "post-1")
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+}
+
+func TestUnlikeReturnsCount(t *testing.T) {
+	svc := service.NewLikeService(newMockLikeStore(), newMockNotificationStore())
+	ctx := context.Background()
+
+	_, _ = svc.Like(ctx, "user-1", "post-1")
+	count, err := svc.Unlike(ctx, "user-1", "post-1")
+	if err != nil {
+		t.Fatalf("Unlike: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count = %d, want 0", count)
+	}
+}
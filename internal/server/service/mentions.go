@@ -0,0 +1,34 @@
+package service
+
+import "strings"
+
+// ParseMentions returns the distinct usernames referenced as @username in
+// content, lowercased and in order of first appearance. A mention must start
+// the content or follow a character that cannot appear in a username, so
+// email addresses are not matched, and must name a valid username.
+func ParseMentions(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isUsernameRune(runes[i-1])) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isUsernameRune(runes[end]) {
+			end++
+		}
+		name := strings.ToLower(string(runes[i+1 : end]))
+		i = end - 1
+		if seen[name] || ValidateUsername(name) != nil {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+func isUsernameRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
@@ -0,0 +1,60 @@
+package service_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"hello @sara", []string{"sara"}},
+		{"@Sara and @omar_k, meet @sara", []string{"sara", "omar_k"}},
+		{"cc @sara.", []string{"sara"}},
+		{"(@sara)", []string{"sara"}},
+		{"mail me at akram@example.com", nil},
+		{"@ab is too short", nil},
+		{"@_sara starts with underscore", nil},
+		{"@admin is reserved", nil},
+		{"@a__b has consecutive underscores", nil},
+		{"@thisusernameistoolong", nil},
+		{"@", nil},
+		{"no mentions here", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			got := service.ParseMentions(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseMentions(%q) = %v, want %v", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePostRecordsMentions(t *testing.T) {
+	mentionStore := newMockMentionStore()
+	svc := service.NewPostService(newMockPostStore(), mentionStore, newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	post, err := svc.CreatePost(ctx, "user-1", "hey @sara and @omar")
+	if err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+	if got := mentionStore.mentioned[post.ID]; !reflect.DeepEqual(got, []string{"sara", "omar"}) {
+		t.Errorf("mentioned = %v, want [sara omar]", got)
+	}
+
+	reply, err := svc.CreateReply(ctx, "user-2", post.ID, "@akram thanks")
+	if err != nil {
+		t.Fatalf("CreateReply: %v", err)
+	}
+	if got := mentionStore.mentioned[reply.ID]; !reflect.DeepEqual(got, []string{"akram"}) {
+		t.Errorf("reply mentioned = %v, want [akram]", got)
+	}
+}
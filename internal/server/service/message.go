@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+// maxConversationMembers caps the size of a group conversation, including
+// the user who starts it.
+const maxConversationMembers = 8
+
+// MessageService manages private conversations. Only members can read or
+// write a conversation; to everyone else it does not exist.
+type MessageService struct {
+	conversations store.ConversationStore
+	users         store.UserStore
+	blocks        store.BlockStore
+}
+
+func NewMessageService(conversations store.ConversationStore, users store.UserStore, blocks store.BlockStore) *MessageService {
+	return &MessageService{conversations: conversations, users: users, blocks: blocks}
+}
+
+// StartConversation returns a conversation between userID and usernames.
+// Starting a one-to-one conversation that already exists returns the
+// existing one, and created reports whether a new one was made. Users
+// blocked either way by userID cannot be added.
+func (s *MessageService) StartConversation(ctx context.Context, userID string, usernames []string) (conversation *models.Conversation, created bool, err error) {
+	memberIDs := []string{userID}
+	seen := map[string]bool{userID: true}
+	for _, name := range usernames {
+		name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+		if name == "" {
+			continue
+		}
+		user, err := s.users.GetUserByUsername(ctx, name)
+		if err != nil {
+			var apiErr *models.APIError
+			if errors.As(err, &apiErr) && apiErr.Code == models.ErrCodeNotFound {
+				return nil, false, &models.APIError{
+					Code: models.ErrCodeNotFound, Field: "usernames",
+					Message: "user @" + name + " not found",
+				}
+			}
+			return nil, false, err
+		}
+		if seen[user.ID] {
+			continue
+		}
+		blocked, err := s.blocks.IsBlockedBetween(ctx, userID, user.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		if blocked {
+			return nil, false, &models.APIError{
+				Code: models.ErrCodeForbidden, Field: "usernames",
+				Message: "you cannot message @" + name,
+			}
+		}
+		seen[user.ID] = true
+		memberIDs = append(memberIDs, user.ID)
+	}
+
+	if len(memberIDs) < 2 {
+		return nil, false, &models.APIError{
+			Code: models.ErrCodeValidation, Field: "usernames",
+			Message: "add at least one other user",
+		}
+	}
+	if len(memberIDs) > maxConversationMembers {
+		return nil, false, &models.APIError{
+			Code: models.ErrCodeValidation, Field: "usernames",
+			Message: "conversations can have at most 8 members",
+		}
+	}
+
+	if len(memberIDs) == 2 {
+		id, err := s.conversations.FindDirectConversation(ctx, userID, memberIDs[1])
+		if err == nil {
+			conversation, err := s.conversations.GetConversation(ctx, userID, id)
+			return conversation, false, err
+		}
+		var apiErr *models.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+			return nil, false, err
+		}
+	}
+
+	id, err := s.conversations.CreateConversation(ctx, memberIDs)
+	if err != nil {
+		return nil, false, err
+	}
+	conversation, err = s.conversations.GetConversation(ctx, userID, id)
+	return conversation, true, err
+}
+
+// GetConversations returns userID's conversations, most recently active
+// first.
+func (s *MessageService) GetConversations(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Conversation, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.conversations.GetConversations(ctx, userID, cursor, limit)
+}
+
+func (s *MessageService) GetConversation(ctx context.Context, userID, conversationID string) (*models.Conversation, error) {
+	if err := s.requireMember(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+	return s.conversations.GetConversation(ctx, userID, conversationID)
+}
+
+// GetMessages returns the messages in conversationID, newest first.
+func (s *MessageService) GetMessages(ctx context.Context, userID, conversationID string, cursor time.Time, limit int) ([]models.Message, error) {
+	if err := s.requireMember(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.conversations.GetMessages(ctx, conversationID, cursor, limit)
+}
+
+// SendMessage posts content to conversationID. A block either way between
+// userID and another member, made after the conversation started, stops
+// userID from sending to it.
+func (s *MessageService) SendMessage(ctx context.Context, userID, conversationID, content string) (*models.Message, error) {
+	content = strings.TrimSpace(content)
+	if err := ValidateMessageContent(content); err != nil {
+		return nil, err
+	}
+	if err := s.requireMember(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.requireNoBlocks(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+	return s.conversations.CreateMessage(ctx, conversationID, userID, content)
+}
+
+// MarkRead marks conversationID read for userID.
+func (s *MessageService) MarkRead(ctx context.Context, userID, conversationID string) error {
+	if err := s.requireMember(ctx, conversationID, userID); err != nil {
+		return err
+	}
+	return s.conversations.MarkRead(ctx, conversationID, userID)
+}
+
+// requireNoBlocks returns a forbidden error if userID has blocked, or been
+// blocked by, another member of conversationID.
+func (s *MessageService) requireNoBlocks(ctx context.Context, conversationID, userID string) error {
+	conversation, err := s.conversations.GetConversation(ctx, userID, conversationID)
+	if err != nil {
+		return err
+	}
+	for _, member := range conversation.Members {
+		if member.ID == userID {
+			continue
+		}
+		blocked, err := s.blocks.IsBlockedBetween(ctx, userID, member.ID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return &models.APIError{Code: models.ErrCodeForbidden, Message: "you cannot message @" + member.Username}
+		}
+	}
+	return nil
+}
+
+// requireMember checks that userID belongs to conversationID. Non-members
+// get the same error as for a missing conversation.
+func (s *MessageService) requireMember(ctx context.Context, conversationID, userID string) error {
+	member, err := s.conversations.IsMember(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "conversation not found"}
+	}
+	return nil
+}
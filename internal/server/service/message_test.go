@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func newTestMessageService(t *testing.T, usernames ...string) (*service.MessageService, map[string]string) {
+	t.Helper()
+	users := newMockUserStore()
+	ids := make(map[string]string)
+	for _, name := range usernames {
+		u, err := users.CreateUser(context.Background(), name, name+"@example.com", "hash", name)
+		if err != nil {
+			t.Fatalf("CreateUser(%s): %v", name, err)
+		}
+		ids[name] = u.ID
+	}
+	return service.NewMessageService(newMockConversationStore(), users, newMockBlockStore()), ids
+}
+
+func apiErrorCode(err error) string {
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+func TestStartConversationReusesDirectConversation(t *testing.T) {
+	svc, ids := newTestMessageService(t, "alice", "bob", "carol")
+	ctx := context.Background()
+
+	first, created, err := svc.StartConversation(ctx, ids["alice"], []string{"@Bob"})
+	if err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+	if !created || len(first.Members) != 2 {
+		t.Fatalf("created = %v, members = %d, want a new two-member conversation", created, len(first.Members))
+	}
+
+	// Either side starting the same one-to-one gets the existing conversation
+	again, created, err := svc.StartConversation(ctx, ids["bob"], []string{"alice"})
+	if err != nil {
+		t.Fatalf("StartConversation again: %v", err)
+	}
+	if created || again.ID != first.ID {
+		t.Errorf("got %s (created %v), want existing %s", again.ID, created, first.ID)
+	}
+
+	group, created, err := svc.StartConversation(ctx, ids["alice"], []string{"bob", "carol", "bob"})
+	if err != nil {
+		t.Fatalf("StartConversation group: %v", err)
+	}
+	if !created || group.ID == first.ID || len(group.Members) != 3 {
+		t.Errorf("group = %+v (created %v), want a new three-member conversation", group, created)
+	}
+}
+
+func TestStartConversationValidation(t *testing.T) {
+	names := []string{"alice"}
+	for i := 1; i <= 8; i++ {
+		names = append(names, fmt.Sprintf("user%d", i))
+	}
+	svc, ids := newTestMessageService(t, names...)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		usernames []string
+		wantCode  string
+	}{
+		{"no one else", nil, models.ErrCodeValidation},
+		{"only yourself", []string{"alice"}, models.ErrCodeValidation},
+		{"unknown user", []string{"nobody"}, models.ErrCodeNotFound},
+		{"too many members", names[1:], models.ErrCodeValidation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.StartConversation(ctx, ids["alice"], tt.usernames)
+			if got := apiErrorCode(err); got != tt.wantCode {
+				t.Errorf("error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestMessageServiceRequiresMembership(t *testing.T) {
+	svc, ids := newTestMessageService(t, "alice", "bob", "carol")
+	ctx := context.Background()
+
+	conv, _, err := svc.StartConversation(ctx, ids["alice"], []string{"bob"})
+	if err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+
+	msg, err := svc.SendMessage(ctx, ids["bob"], conv.ID, "  hi alice  ")
+	if err != nil {
+		t.Fatalf("SendMessage: %v", err)
+	}
+	if msg.Content != "hi alice" {
+		t.Errorf("content = %q, want trimmed", msg.Content)
+	}
+	messages, err := svc.GetMessages(ctx, ids["alice"], conv.ID, time.Now(), 50)
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("GetMessages = %d, %v; want 1 message", len(messages), err)
+	}
+
+	// Outsiders cannot tell the conversation exists
+	if _, err := svc.GetConversation(ctx, ids["carol"], conv.ID); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("GetConversation by outsider: %v, want not_found", err)
+	}
+	if _, err := svc.GetMessages(ctx, ids["carol"], conv.ID, time.Now(), 50); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("GetMessages by outsider: %v, want not_found", err)
+	}
+	if _, err := svc.SendMessage(ctx, ids["carol"], conv.ID, "let me in"); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("SendMessage by outsider: %v, want not_found", err)
+	}
+	if err := svc.MarkRead(ctx, ids["carol"], conv.ID); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("MarkRead by outsider: %v, want not_found", err)
+	}
+
+	if _, err := svc.SendMessage(ctx, ids["alice"], conv.ID, "   "); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("empty message: %v, want validation_error", err)
+	}
+	if _, err := svc.SendMessage(ctx, ids["alice"], conv.ID, strings.Repeat("a", 501)); apiErrorCode(err) != models.ErrCodeContentLong {
+		t.Errorf("long message: %v, want content_too_long", err)
+	}
+}
+
+func TestSendMessageBlocked(t *testing.T) {
+	users := newMockUserStore()
+	blocks := newMockBlockStore()
+	svc := service.NewMessageService(newMockConversationStore(), users, blocks)
+	ctx := context.Background()
+	alice, _ := users.CreateUser(ctx, "alice", "alice@example.com", "hash", "alice")
+	bob, _ := users.CreateUser(ctx, "bob", "bob@example.com", "hash", "bob")
+
+	conversation, _, err := svc.StartConversation(ctx, alice.ID, []string{"bob"})
+	if err != nil {
+		t.Fatalf("StartConversation: %v", err)
+	}
+	if err := blocks.Block(ctx, bob.ID, alice.ID); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+
+	if _, err := svc.SendMessage(ctx, alice.ID, conversation.ID, "still there?"); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("SendMessage to a blocker error = %v, want forbidden", err)
+	}
+	if _, err := svc.SendMessage(ctx, bob.ID, conversation.ID, "bye"); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("SendMessage to a blocked user error = %v, want forbidden", err)
+	}
+}
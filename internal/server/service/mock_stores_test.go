@@ -3,6 +3,8 @@ package service_test
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,6 +17,8 @@ type mockUserStore struct {
 	users    map[string]*models.User
 	emails   map[string]string // email -> user ID
 	hashes   map[string]string // user ID -> password hash
+	statuses map[string]string // user ID -> account status, if not active
+	verified map[string]bool   // user ID -> whether their email is verified
 	nextID   int
 }
 
@@ -23,6 +27,8 @@ func newMockUserStore() *mockUserStore {
 		users:  make(map[string]*models.User),
 		emails: make(map[string]string),
 		hashes: make(map[string]string),
+		statuses: make(map[string]string),
+		verified: make(map[string]bool),
 	}
 }
 
@@ -45,6 +51,7 @@ func (m *mockUserStore) CreateUser(_ context.Context, username, email, passwordH
 		ID:          id,
 		Username:    username,
 		DisplayName: displayName,
+		Role:        models.RoleUser,
 		CreatedAt:   time.Now(),
 	}
 	m.users[id] = user
@@ -59,7 +66,7 @@ func (m *mockUserStore) GetUserByEmail(_ context.Context, email string) (*models
 
 	id, exists := m.emails[email]
 	if !exists {
-		return nil, "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		return nil, "", &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid email or password"}
 	}
 	return m.users[id], m.hashes[id], nil
 }
@@ -104,6 +111,150 @@ func (m *mockUserStore) UpdateUser(_ context.Context, id string, updates *models
 	return user, nil
 }
 
+func (m *mockUserStore) GetPasswordHash(_ context.Context, id string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hash, exists := m.hashes[id]
+	if !exists {
+		return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	return hash, nil
+}
+
+func (m *mockUserStore) SetPasswordHash(_ context.Context, id, hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.hashes[id]; !exists {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	m.hashes[id] = hash
+	return nil
+}
+
+func (m *mockUserStore) GetEmail(_ context.Context, id string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for email, userID := range m.emails {
+		if userID == id {
+			return email, m.verified[id], nil
+		}
+	}
+	return "", false, &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+}
+
+func (m *mockUserStore) GetStatus(_ context.Context, id string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.status(id)
+}
+
+// status returns id's status; callers must hold m.mu
+func (m *mockUserStore) status(id string) (string, error) {
+	if _, exists := m.users[id]; !exists {
+		return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	if status, ok := m.statuses[id]; ok {
+		return status, nil
+	}
+	return models.AccountActive, nil
+}
+
+func (m *mockUserStore) SetStatus(_ context.Context, id, from, to string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, err := m.status(id)
+	if err != nil {
+		return err
+	}
+	if current != from {
+		return &models.APIError{Code: models.ErrCodeConflict, Message: "account is " + current}
+	}
+	m.setStatus(id, to)
+	return nil
+}
+
+// setStatus records id's status; callers must hold m.mu
+func (m *mockUserStore) setStatus(id, status string) {
+	if status == models.AccountActive {
+		delete(m.statuses, id)
+		return
+	}
+	m.statuses[id] = status
+}
+
+// DeletePendingUsers is only called by the server's purge worker
+func (m *mockUserStore) DeletePendingUsers(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockUserStore) ListInactive(_ context.Context) (map[string]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make(map[string]string, len(m.statuses))
+	for id, status := range m.statuses {
+		statuses[id] = status
+	}
+	return statuses, nil
+}
+
+func (m *mockUserStore) GetRole(_ context.Context, id string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, exists := m.users[id]
+	if !exists {
+		return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	return user.Role, nil
+}
+
+func (m *mockUserStore) SetRole(_ context.Context, id, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, exists := m.users[id]
+	if !exists {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	user.Role = role
+	return nil
+}
+
+func (m *mockUserStore) ListUsers(_ context.Context, cursor time.Time, limit int) ([]models.AdminUserEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var entries []models.AdminUserEntry
+	for id, u := range m.users {
+		if !u.CreatedAt.Before(cursor) {
+			continue
+		}
+		entry := models.AdminUserEntry{User: *u, Status: models.AccountActive}
+		if status, ok := m.statuses[id]; ok {
+			entry.Status = status
+		}
+		for email, uid := range m.emails {
+			if uid == id {
+				entry.Email = email
+			}
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].User.CreatedAt.After(entries[j].User.CreatedAt)
+	})
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
 // mockRefreshTokenStore implements store.RefreshTokenStore with in-memory maps
 type mockRefreshTokenStore struct {
 	mu     sync.Mutex
@@ -112,9 +263,14 @@ type mockRefreshTokenStore struct {
 }
 
 type refreshTokenEntry struct {
-	id        string
-	userID    string
-	expiresAt time.Time
+	id         string
+	userID     string
+	familyID   string
+	expiresAt  time.Time
+	rotatedAt  *time.Time
+	device     models.Device
+	createdAt  time.Time
+	lastUsedAt time.Time
 }
 
 func newMockRefreshTokenStore() *mockRefreshTokenStore {
@@ -123,35 +279,139 @@ func newMockRefreshTokenStore() *mockRefreshTokenStore {
 	}
 }
 
-func (m *mockRefreshTokenStore) StoreToken(_ context.Context, userID, tokenHash string, expiresAt time.Time) error {
+func (m *mockRefreshTokenStore) StoreToken(_ context.Context, userID, tokenHash string, expiresAt time.Time, device models.Device) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.nextID++
-	m.tokens[tokenHash] = refreshTokenEntry{
-		id:        fmt.Sprintf("token-%d", m.nextID),
-		userID:    userID,
-		expiresAt: expiresAt,
+	now := time.Now()
+	entry := refreshTokenEntry{
+		id:         fmt.Sprintf("token-%d", m.nextID),
+		userID:     userID,
+		familyID:   fmt.Sprintf("family-%d", m.nextID),
+		expiresAt:  expiresAt,
+		device:     device,
+		createdAt:  now,
+		lastUsedAt: now,
+	}
+	m.tokens[tokenHash] = entry
+	return entry.familyID, nil
+}
+
+func (m *mockRefreshTokenStore) RotateToken(_ context.Context, id, newHash string, expiresAt time.Time, device models.Device) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, old := range m.tokens {
+		if old.id != id || old.rotatedAt != nil {
+			continue
+		}
+		now := time.Now()
+		old.rotatedAt = &now
+		m.tokens[hash] = old
+
+		if device.DeviceName == "" {
+			device.DeviceName = old.device.DeviceName
+		}
+		m.nextID++
+		m.tokens[newHash] = refreshTokenEntry{
+			id:         fmt.Sprintf("token-%d", m.nextID),
+			userID:     old.userID,
+			familyID:   old.familyID,
+			expiresAt:  expiresAt,
+			device:     device,
+			createdAt:  old.createdAt,
+			lastUsedAt: now,
+		}
+		return nil
+	}
+	return &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid refresh token"}
+}
+
+func (m *mockRefreshTokenStore) DeleteFamily(_ context.Context, familyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, entry := range m.tokens {
+		if entry.familyID == familyID {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
+
+func (m *mockRefreshTokenStore) ListSessions(_ context.Context, userID string) ([]models.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions := []models.Session{}
+	now := time.Now()
+	for _, entry := range m.tokens {
+		if entry.userID != userID || entry.rotatedAt != nil || !entry.expiresAt.After(now) {
+			continue
+		}
+		sessions = append(sessions, models.Session{
+			ID:         entry.familyID,
+			DeviceName: entry.device.DeviceName,
+			UserAgent:  entry.device.UserAgent,
+			IP:         entry.device.IP,
+			CreatedAt:  entry.createdAt,
+			LastUsedAt: entry.lastUsedAt,
+		})
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+	return sessions, nil
+}
+
+func (m *mockRefreshTokenStore) DeleteSession(_ context.Context, userID, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	found := false
+	for hash, entry := range m.tokens {
+		if entry.familyID == id && entry.userID == userID {
+			delete(m.tokens, hash)
+			found = true
+		}
+	}
+	if !found {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "session not found"}
 	}
 	return nil
 }
 
-func (m *mockRefreshTokenStore) GetByHash(_ context.Context, tokenHash string) (id, userID string, expiresAt time.Time, err error) {
+func (m *mockRefreshTokenStore) GetByHash(_ context.Context, tokenHash string) (*models.RefreshToken, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	entry, exists := m.tokens[tokenHash]
 	if !exists {
-		return "", "", time.Time{}, &models.APIError{Code: models.ErrCodeTokenExpired, Message: "token not found"}
+		return nil, &models.APIError{Code: models.ErrCodeTokenExpired, Message: "token not found"}
 	}
-	return entry.id, entry.userID, entry.expiresAt, nil
+	return &models.RefreshToken{
+		ID:        entry.id,
+		UserID:    entry.userID,
+		FamilyID:  entry.familyID,
+		ExpiresAt: entry.expiresAt,
+		RotatedAt: entry.rotatedAt,
+	}, nil
 }
 
 func (m *mockRefreshTokenStore) DeleteByHash(_ context.Context, tokenHash string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	delete(m.tokens, tokenHash)
+	entry, exists := m.tokens[tokenHash]
+	if !exists {
+		return nil
+	}
+	for hash, e := range m.tokens {
+		if e.familyID == entry.familyID {
+			delete(m.tokens, hash)
+		}
+	}
 	return nil
 }
 
@@ -167,6 +427,18 @@ func (m *mockRefreshTokenStore) DeleteAllForUser(_ context.Context, userID strin
 	return nil
 }
 
+func (m *mockRefreshTokenStore) DeleteOtherSessions(_ context.Context, userID, keepID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for hash, entry := range m.tokens {
+		if entry.userID == userID && entry.familyID != keepID {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
+
 func (m *mockRefreshTokenStore) DeleteExpired(_ context.Context) (int64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -182,83 +454,1413 @@ func (m *mockRefreshTokenStore) DeleteExpired(_ context.Context) (int64, error)
 	return count, nil
 }
 
-// mockPostStore implements store.PostStore with in-memory slices
-type mockPostStore struct {
-	mu    sync.Mutex
-	posts []models.Post
+// mockPasswordResetStore implements store.PasswordResetStore with an
+// in-memory map
+type mockPasswordResetStore struct {
+	mu     sync.Mutex
+	resets map[string]emailedTokenEntry // hash -> entry
 }
 
-func newMockPostStore() *mockPostStore {
-	return &mockPostStore{
-		posts: make([]models.Post, 0),
-	}
+type emailedTokenEntry struct {
+	userID    string
+	expiresAt time.Time
 }
 
-func (m *mockPostStore) AddPost(id, authorID, content string, createdAt time.Time) {
+func newMockPasswordResetStore() *mockPasswordResetStore {
+	return &mockPasswordResetStore{resets: make(map[string]emailedTokenEntry)}
+}
+
+func (m *mockPasswordResetStore) CreateReset(_ context.Context, userID, tokenHash string, expiresAt time.Time) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.posts = append(m.posts, models.Post{
-		ID:        id,
-		AuthorID:  authorID,
-		Content:   content,
-		CreatedAt: createdAt,
-	})
+
+	for hash, entry := range m.resets {
+		if entry.userID == userID {
+			delete(m.resets, hash)
+		}
+	}
+	m.resets[tokenHash] = emailedTokenEntry{userID: userID, expiresAt: expiresAt}
+	return nil
 }
 
-func (m *mockPostStore) CreatePost(_ context.Context, authorID, content string) (*models.Post, error) {
+func (m *mockPasswordResetStore) ConsumeReset(_ context.Context, tokenHash string) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	post := models.Post{
-		ID:        fmt.Sprintf("post-%d", len(m.posts)+1),
-		AuthorID:  authorID,
-		Content:   content,
-		CreatedAt: time.Now(),
+	entry, exists := m.resets[tokenHash]
+	if !exists || !entry.expiresAt.After(time.Now()) {
+		return "", &models.APIError{Code: models.ErrCodeUnauthorized, Field: "token", Message: "invalid or expired reset token"}
 	}
-	m.posts = append(m.posts, post)
-	return &post, nil
+	delete(m.resets, tokenHash)
+	return entry.userID, nil
 }
 
-func (m *mockPostStore) GetPostByID(_ context.Context, id string) (*models.Post, error) {
+func (m *mockPasswordResetStore) DeleteExpired(_ context.Context) (int64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	for i := range m.posts {
-		if m.posts[i].ID == id {
-			return &m.posts[i], nil
+	var count int64
+	now := time.Now()
+	for hash, entry := range m.resets {
+		if now.After(entry.expiresAt) {
+			delete(m.resets, hash)
+			count++
 		}
 	}
-	return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	return count, nil
+}
+
+// mockEmailVerificationStore implements store.EmailVerificationStore with
+// an in-memory map, marking users verified in users
+type mockEmailVerificationStore struct {
+	mu    sync.Mutex
+	users *mockUserStore
+	codes map[string]emailedTokenEntry // hash -> entry
+}
+
+func newMockEmailVerificationStore(users *mockUserStore) *mockEmailVerificationStore {
+	return &mockEmailVerificationStore{users: users, codes: make(map[string]emailedTokenEntry)}
 }
 
-func (m *mockPostStore) GetTimeline(_ context.Context, cursor time.Time, limit int) ([]models.Post, error) {
+func (m *mockEmailVerificationStore) CreateVerification(_ context.Context, userID, tokenHash string, expiresAt time.Time) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	var result []models.Post
-	for _, p := range m.posts {
-		if p.CreatedAt.Before(cursor) {
-			result = append(result, p)
+	for hash, entry := range m.codes {
+		if entry.userID == userID {
+			delete(m.codes, hash)
 		}
 	}
-	if len(result) > limit {
-		result = result[:limit]
+	m.codes[tokenHash] = emailedTokenEntry{userID: userID, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *mockEmailVerificationStore) VerifyEmail(_ context.Context, tokenHash string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.codes[tokenHash]
+	if !exists || !entry.expiresAt.After(time.Now()) {
+		return "", &models.APIError{Code: models.ErrCodeUnauthorized, Field: "token", Message: "invalid or expired verification code"}
 	}
-	return result, nil
+	delete(m.codes, tokenHash)
+
+	m.users.mu.Lock()
+	m.users.verified[entry.userID] = true
+	m.users.mu.Unlock()
+	return entry.userID, nil
 }
 
-func (m *mockPostStore) GetUserPosts(_ context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+func (m *mockEmailVerificationStore) DeleteExpired(_ context.Context) (int64, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	var result []models.Post
-	for _, p := range m.posts {
-		if p.AuthorID == userID && p.CreatedAt.Before(cursor) {
-			result = append(result, p)
+	var count int64
+	now := time.Now()
+	for hash, entry := range m.codes {
+		if now.After(entry.expiresAt) {
+			delete(m.codes, hash)
+			count++
 		}
 	}
-	if len(result) > limit {
-		result = result[:limit]
+	return count, nil
+}
+
+// mockTwoFactorStore implements store.TwoFactorStore with in-memory maps
+type mockTwoFactorStore struct {
+	mu       sync.Mutex
+	secrets  map[string]*mockTOTP       // user ID -> secret
+	recovery map[string]map[string]bool // user ID -> code hashes
+}
+
+type mockTOTP struct {
+	secret   string
+	enabled  bool
+	lastStep int64
+}
+
+func newMockTwoFactorStore() *mockTwoFactorStore {
+	return &mockTwoFactorStore{secrets: make(map[string]*mockTOTP), recovery: make(map[string]map[string]bool)}
+}
+
+func (m *mockTwoFactorStore) SetPendingSecret(_ context.Context, userID, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if t, ok := m.secrets[userID]; ok && t.enabled {
+		return &models.APIError{Code: models.ErrCodeConflict, Message: "two-factor authentication is already enabled"}
 	}
-	return result, nil
+	m.secrets[userID] = &mockTOTP{secret: secret}
+	return nil
+}
+
+func (m *mockTwoFactorStore) GetSecret(_ context.Context, userID string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.secrets[userID]
+	if !ok {
+		return "", false, &models.APIError{Code: models.ErrCodeNotFound, Message: "two-factor authentication is not set up"}
+	}
+	return t.secret, t.enabled, nil
+}
+
+func (m *mockTwoFactorStore) Enable(_ context.Context, userID string, step int64, recoveryHashes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.secrets[userID]
+	if !ok || t.enabled {
+		return &models.APIError{Code: models.ErrCodeConflict, Message: "two-factor authentication is already enabled"}
+	}
+	t.enabled = true
+	t.lastStep = step
+	m.recovery[userID] = make(map[string]bool)
+	for _, hash := range recoveryHashes {
+		m.recovery[userID][hash] = true
+	}
+	return nil
+}
+
+func (m *mockTwoFactorStore) Disable(_ context.Context, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.secrets, userID)
+	delete(m.recovery, userID)
+	return nil
+}
+
+func (m *mockTwoFactorStore) UseStep(_ context.Context, userID string, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t, ok := m.secrets[userID]
+	if !ok || !t.enabled || t.lastStep >= step {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "code", Message: "code was already used"}
+	}
+	t.lastStep = step
+	return nil
+}
+
+func (m *mockTwoFactorStore) UseRecoveryCode(_ context.Context, userID, codeHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.recovery[userID][codeHash] {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "code", Message: "invalid two-factor code"}
+	}
+	delete(m.recovery[userID], codeHash)
+	return nil
+}
+
+func (m *mockTwoFactorStore) CountRecoveryCodes(_ context.Context, userID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.recovery[userID]), nil
+}
+
+// mockLoginChallengeStore implements store.LoginChallengeStore with an
+// in-memory map
+type mockLoginChallengeStore struct {
+	mu         sync.Mutex
+	challenges map[string]*mockChallenge // hash -> challenge
+}
+
+type mockChallenge struct {
+	userID     string
+	reactivate bool
+	attempts   int
+	expiresAt  time.Time
+}
+
+func newMockLoginChallengeStore() *mockLoginChallengeStore {
+	return &mockLoginChallengeStore{challenges: make(map[string]*mockChallenge)}
+}
+
+func (m *mockLoginChallengeStore) CreateChallenge(_ context.Context, userID, tokenHash string, reactivate bool, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.challenges[tokenHash] = &mockChallenge{userID: userID, reactivate: reactivate, expiresAt: expiresAt}
+	return nil
+}
+
+func (m *mockLoginChallengeStore) AttemptChallenge(_ context.Context, tokenHash string, maxAttempts int) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.challenges[tokenHash]
+	if !ok || !c.expiresAt.After(time.Now()) || c.attempts >= maxAttempts {
+		return "", false, &models.APIError{Code: models.ErrCodeUnauthorized, Field: "challenge_token", Message: "login challenge is invalid or expired; sign in again"}
+	}
+	c.attempts++
+	return c.userID, c.reactivate, nil
+}
+
+func (m *mockLoginChallengeStore) DeleteChallenge(_ context.Context, tokenHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.challenges[tokenHash]; !ok {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "challenge_token", Message: "login challenge is invalid or expired; sign in again"}
+	}
+	delete(m.challenges, tokenHash)
+	return nil
+}
+
+func (m *mockLoginChallengeStore) DeleteExpired(_ context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	now := time.Now()
+	for hash, c := range m.challenges {
+		if now.After(c.expiresAt) {
+			delete(m.challenges, hash)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// mockPostStore implements store.PostStore with in-memory slices
+type mockPostStore struct {
+	mu        sync.Mutex
+	posts     []models.Post
+	following map[string]map[string]bool   // user ID -> followed author IDs
+	edits     map[string][]models.PostEdit // post ID -> edits, newest first
+}
+
+func newMockPostStore() *mockPostStore {
+	return &mockPostStore{
+		posts: make([]models.Post, 0),
+	}
+}
+
+func (m *mockPostStore) AddPost(id, authorID, content string, createdAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.posts = append(m.posts, models.Post{
+		ID:        id,
+		AuthorID:  authorID,
+		Content:   content,
+		CreatedAt: createdAt,
+	})
+}
+
+func (m *mockPostStore) CreatePost(_ context.Context, authorID, content string) (*models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	post := models.Post{
+		ID:        fmt.Sprintf("post-%d", len(m.posts)+1),
+		AuthorID:  authorID,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+	m.posts = append(m.posts, post)
+	return &post, nil
+}
+
+func (m *mockPostStore) ImportPosts(_ context.Context, authorID string, posts []models.Post) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	imported := 0
+	for _, post := range posts {
+		if slices.ContainsFunc(m.posts, func(p models.Post) bool {
+			return p.AuthorID == authorID && p.Content == post.Content && p.CreatedAt.Equal(post.CreatedAt)
+		}) {
+			continue
+		}
+		post.ID = fmt.Sprintf("post-%d", len(m.posts)+1)
+		post.AuthorID = authorID
+		m.posts = append(m.posts, post)
+		imported++
+	}
+	return imported, nil
+}
+
+func (m *mockPostStore) GetPostByID(_ context.Context, _, id string) (*models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.posts {
+		if m.posts[i].ID == id {
+			post := m.posts[i]
+			if post.OriginalID != nil {
+				for j := range m.posts {
+					if m.posts[j].ID == *post.OriginalID {
+						original := m.posts[j]
+						post.Original = &original
+					}
+				}
+			}
+			return &post, nil
+		}
+	}
+	return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+}
+
+func (m *mockPostStore) GetTimeline(_ context.Context, _ string, cursor time.Time, limit int) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.Post
+	for _, p := range m.posts {
+		if p.CreatedAt.Before(cursor) {
+			result = append(result, p)
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockPostStore) GetUserPosts(_ context.Context, _, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.Post
+	for _, p := range m.posts {
+		if p.AuthorID == userID && p.CreatedAt.Before(cursor) {
+			result = append(result, p)
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// GetExportPosts pages on (created_at, id) like the real store, so tests can
+// check that posts sharing a timestamp are all exported.
+func (m *mockPostStore) GetExportPosts(_ context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.Post
+	for _, p := range m.posts {
+		if p.AuthorID == userID {
+			result = append(result, p)
+		}
+	}
+	return exportPage(result, func(p models.Post) (time.Time, string) { return p.CreatedAt, p.ID }, cursor, cursorID, limit), nil
+}
+
+// exportPage sorts items newest first by (time, id) and returns the page of
+// up to limit items after the cursor, as the export store queries do. An
+// empty cursorID starts at the newest item.
+func exportPage[T any](items []T, key func(T) (time.Time, string), cursor time.Time, cursorID string, limit int) []T {
+	sort.Slice(items, func(i, j int) bool {
+		ti, idi := key(items[i])
+		tj, idj := key(items[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return idi > idj
+	})
+	var page []T
+	for _, item := range items {
+		t, id := key(item)
+		if cursorID == "" || t.Before(cursor) || (t.Equal(cursor) && id < cursorID) {
+			page = append(page, item)
+		}
+		if len(page) == limit {
+			break
+		}
+	}
+	return page
+}
+
+// GetHomeTimeline returns posts by authors registered via Follow on the mock.
+func (m *mockPostStore) GetHomeTimeline(_ context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.Post
+	for _, p := range m.posts {
+		if m.following[userID][p.AuthorID] && p.CreatedAt.Before(cursor) {
+			result = append(result, p)
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockPostStore) CreateReply(_ context.Context, authorID, parentID, content string) (*models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var parent *models.Post
+	for i := range m.posts {
+		if m.posts[i].ID == parentID {
+			parent = &m.posts[i]
+		}
+	}
+	if parent == nil {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	rootID := parent.ID
+	if parent.RootID != nil {
+		rootID = *parent.RootID
+	}
+	post := models.Post{
+		ID:        fmt.Sprintf("post-%d", len(m.posts)+1),
+		AuthorID:  authorID,
+		ParentID:  &parent.ID,
+		RootID:    &rootID,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+	m.posts = append(m.posts, post)
+	return &post, nil
+}
+
+func (m *mockPostStore) GetAncestors(_ context.Context, _, postID string) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	byID := make(map[string]models.Post, len(m.posts))
+	for _, p := range m.posts {
+		byID[p.ID] = p
+	}
+	var result []models.Post
+	current, ok := byID[postID]
+	for ok && current.ParentID != nil {
+		current, ok = byID[*current.ParentID]
+		if ok {
+			result = append([]models.Post{current}, result...)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPostStore) GetDescendants(_ context.Context, _, postID string, limit int) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inTree := map[string]bool{postID: true}
+	var result []models.Post
+	for _, p := range m.posts {
+		if p.ParentID != nil && inTree[*p.ParentID] {
+			inTree[p.ID] = true
+			result = append(result, p)
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockPostStore) CreateRepost(_ context.Context, authorID, originalID string) (*models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range m.posts {
+		if p.Kind == models.PostKindRepost && p.AuthorID == authorID && *p.OriginalID == originalID {
+			return nil, &models.APIError{Code: models.ErrCodeConflict, Message: "you already reposted this post"}
+		}
+	}
+	post := models.Post{
+		ID:         fmt.Sprintf("post-%d", len(m.posts)+1),
+		AuthorID:   authorID,
+		Kind:       models.PostKindRepost,
+		OriginalID: &originalID,
+		CreatedAt:  time.Now(),
+	}
+	m.posts = append(m.posts, post)
+	return &post, nil
+}
+
+func (m *mockPostStore) DeleteRepost(_ context.Context, authorID, originalID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kept := m.posts[:0]
+	for _, p := range m.posts {
+		if p.Kind == models.PostKindRepost && p.AuthorID == authorID && *p.OriginalID == originalID {
+			continue
+		}
+		kept = append(kept, p)
+	}
+	m.posts = kept
+	return nil
+}
+
+func (m *mockPostStore) CountReposts(_ context.Context, postID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, p := range m.posts {
+		if p.Kind == models.PostKindRepost && *p.OriginalID == postID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockPostStore) CreateQuote(_ context.Context, authorID, originalID, content string) (*models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	post := models.Post{
+		ID:         fmt.Sprintf("post-%d", len(m.posts)+1),
+		AuthorID:   authorID,
+		Kind:       models.PostKindQuote,
+		OriginalID: &originalID,
+		Content:    content,
+		CreatedAt:  time.Now(),
+	}
+	m.posts = append(m.posts, post)
+	return &post, nil
+}
+
+func (m *mockPostStore) EditPost(_ context.Context, id, content string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.posts {
+		if m.posts[i].ID == id && !m.posts[i].Deleted {
+			if m.edits == nil {
+				m.edits = make(map[string][]models.PostEdit)
+			}
+			now := time.Now()
+			edit := models.PostEdit{Content: m.posts[i].Content, EditedAt: now}
+			m.edits[id] = append([]models.PostEdit{edit}, m.edits[id]...)
+			m.posts[i].Content = content
+			m.posts[i].EditedAt = &now
+			return nil
+		}
+	}
+	return &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+}
+
+func (m *mockPostStore) DeletePost(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := range m.posts {
+		if m.posts[i].ID == id && !m.posts[i].Deleted {
+			m.posts[i].Deleted = true
+			m.posts[i].Content = ""
+			return nil
+		}
+	}
+	return &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+}
+
+func (m *mockPostStore) GetEdits(_ context.Context, postID string) ([]models.PostEdit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]models.PostEdit{}, m.edits[postID]...), nil
+}
+
+// Follow records that userID follows authorID for GetHomeTimeline.
+func (m *mockPostStore) Follow(userID, authorID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.following == nil {
+		m.following = make(map[string]map[string]bool)
+	}
+	if m.following[userID] == nil {
+		m.following[userID] = make(map[string]bool)
+	}
+	m.following[userID][authorID] = true
+}
+
+// mockMentionStore implements store.MentionStore by recording the usernames
+// mentioned in each post
+type mockMentionStore struct {
+	mu        sync.Mutex
+	mentioned map[string][]string // post ID -> usernames
+}
+
+func newMockMentionStore() *mockMentionStore {
+	return &mockMentionStore{mentioned: make(map[string][]string)}
+}
+
+func (m *mockMentionStore) SetMentions(_ context.Context, postID string, usernames []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mentioned[postID] = usernames
+	return nil
+}
+
+func (m *mockMentionStore) GetMentions(_ context.Context, _ string, _ time.Time, _ int) ([]models.Post, error) {
+	return nil, nil
+}
+
+// mockTagStore implements store.TagStore by recording the tags attached to
+// each post
+type mockTagStore struct {
+	mu     sync.Mutex
+	tagged map[string][]string // post ID -> tags
+}
+
+func newMockTagStore() *mockTagStore {
+	return &mockTagStore{tagged: make(map[string][]string)}
+}
+
+func (m *mockTagStore) SetTags(_ context.Context, postID string, tags []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tagged[postID] = tags
+	return nil
+}
+
+func (m *mockTagStore) GetTagPosts(_ context.Context, _, _ string, _ time.Time, _ int) ([]models.Post, error) {
+	return nil, nil
+}
+
+func (m *mockTagStore) GetTrending(_ context.Context, _ time.Time, _ int) ([]models.TrendingTag, error) {
+	return nil, nil
+}
+
+// mockSearchStore implements store.SearchStore by recording the last query
+type mockSearchStore struct {
+	mu        sync.Mutex
+	lastQuery string
+}
+
+func (m *mockSearchStore) SearchPosts(_ context.Context, _, query string, _ time.Time, _ int) ([]models.Post, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastQuery = query
+	return nil, nil
+}
+
+func (m *mockSearchStore) SearchUsers(_ context.Context, _, query string, _ time.Time, _ int) ([]models.User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastQuery = query
+	return nil, nil
+}
+
+// mockNotificationStore implements store.NotificationStore by recording
+// each notification as "kind:recipient", with the post author standing in as
+// the recipient for NotifyPostAuthor
+type mockNotificationStore struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func newMockNotificationStore() *mockNotificationStore {
+	return &mockNotificationStore{}
+}
+
+func (m *mockNotificationStore) NotifyUser(_ context.Context, userID, actorID, kind string, _ *string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if userID != actorID {
+		m.events = append(m.events, kind+":"+userID)
+	}
+	return nil
+}
+
+func (m *mockNotificationStore) NotifyPostAuthor(_ context.Context, _, kind, authorOfID, _ string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, kind+":author-of-"+authorOfID)
+	return nil
+}
+
+func (m *mockNotificationStore) NotifyMentioned(_ context.Context, postID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.events = append(m.events, "mention:"+postID)
+	return nil
+}
+
+func (m *mockNotificationStore) GetNotifications(_ context.Context, _ string, _ time.Time, _ int) ([]models.Notification, error) {
+	return nil, nil
+}
+
+func (m *mockNotificationStore) CountUnread(_ context.Context, _ string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockNotificationStore) MarkRead(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+
+// mockConversationStore implements store.ConversationStore with in-memory
+// member lists and message logs
+type mockConversationStore struct {
+	mu       sync.Mutex
+	members  map[string][]string // conversation ID -> user IDs
+	messages map[string][]models.Message
+	nextID   int
+}
+
+func newMockConversationStore() *mockConversationStore {
+	return &mockConversationStore{
+		members:  make(map[string][]string),
+		messages: make(map[string][]models.Message),
+	}
+}
+
+func (m *mockConversationStore) CreateConversation(_ context.Context, memberIDs []string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	id := fmt.Sprintf("conv-%d", m.nextID)
+	m.members[id] = memberIDs
+	return id, nil
+}
+
+func (m *mockConversationStore) FindDirectConversation(_ context.Context, userID, otherID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, members := range m.members {
+		if len(members) == 2 && members[0] == userID && members[1] == otherID ||
+			len(members) == 2 && members[0] == otherID && members[1] == userID {
+			return id, nil
+		}
+	}
+	return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "conversation not found"}
+}
+
+func (m *mockConversationStore) IsMember(_ context.Context, conversationID, userID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range m.members[conversationID] {
+		if id == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockConversationStore) GetConversation(_ context.Context, _, conversationID string) (*models.Conversation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	members, exists := m.members[conversationID]
+	if !exists {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "conversation not found"}
+	}
+	c := &models.Conversation{ID: conversationID}
+	for _, id := range members {
+		c.Members = append(c.Members, models.User{ID: id})
+	}
+	return c, nil
+}
+
+func (m *mockConversationStore) GetConversations(_ context.Context, _ string, _ time.Time, _ int) ([]models.Conversation, error) {
+	return nil, nil
+}
+
+func (m *mockConversationStore) CreateMessage(_ context.Context, conversationID, senderID, content string) (*models.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	msg := models.Message{
+		ID:             fmt.Sprintf("msg-%d", len(m.messages[conversationID])+1),
+		ConversationID: conversationID,
+		Sender:         models.User{ID: senderID},
+		Content:        content,
+		CreatedAt:      time.Now(),
+	}
+	m.messages[conversationID] = append(m.messages[conversationID], msg)
+	return &msg, nil
+}
+
+func (m *mockConversationStore) GetMessages(_ context.Context, conversationID string, _ time.Time, _ int) ([]models.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.messages[conversationID], nil
+}
+
+func (m *mockConversationStore) MarkRead(_ context.Context, _, _ string) error {
+	return nil
+}
+
+// mockLikeStore implements store.LikeStore with an in-memory set
+type mockLikeStore struct {
+	mu    sync.Mutex
+	likes map[string]map[string]bool // post ID -> user IDs
+}
+
+func newMockLikeStore() *mockLikeStore {
+	return &mockLikeStore{likes: make(map[string]map[string]bool)}
+}
+
+func (m *mockLikeStore) Like(_ context.Context, userID, postID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.likes[postID] == nil {
+		m.likes[postID] = make(map[string]bool)
+	}
+	m.likes[postID][userID] = true
+	return nil
+}
+
+func (m *mockLikeStore) Unlike(_ context.Context, userID, postID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.likes[postID], userID)
+	return nil
+}
+
+func (m *mockLikeStore) CountLikes(_ context.Context, postID string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.likes[postID]), nil
+}
+
+func (m *mockLikeStore) GetLikes(_ context.Context, userID string, cursor time.Time, limit int) ([]models.LikeEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.LikeEntry
+	for postID, users := range m.likes {
+		if users[userID] {
+			result = append(result, models.LikeEntry{Post: models.Post{ID: postID}})
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockLikeStore) GetExportLikes(_ context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.LikeEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.LikeEntry
+	for postID, users := range m.likes {
+		if users[userID] {
+			result = append(result, models.LikeEntry{Post: models.Post{ID: postID}})
+		}
+	}
+	return exportPage(result, func(e models.LikeEntry) (time.Time, string) { return e.LikedAt, e.Post.ID }, cursor, cursorID, limit), nil
+}
+
+// mockFollowStore implements store.FollowStore with an in-memory edge list
+type mockFollowStore struct {
+	mu    sync.Mutex
+	edges []followEdge
+}
+
+type followEdge struct {
+	followerID string
+	followeeID string
+	createdAt  time.Time
+}
+
+func newMockFollowStore() *mockFollowStore {
+	return &mockFollowStore{}
+}
+
+func (m *mockFollowStore) Follow(_ context.Context, followerID, followeeID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.edges {
+		if e.followerID == followerID && e.followeeID == followeeID {
+			return nil
+		}
+	}
+	m.edges = append(m.edges, followEdge{followerID: followerID, followeeID: followeeID, createdAt: time.Now()})
+	return nil
+}
+
+// AddFollow records a follow made at createdAt.
+func (m *mockFollowStore) AddFollow(followerID, followeeID string, createdAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.edges = append(m.edges, followEdge{followerID: followerID, followeeID: followeeID, createdAt: createdAt})
+}
+
+func (m *mockFollowStore) Unfollow(_ context.Context, followerID, followeeID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, e := range m.edges {
+		if e.followerID == followerID && e.followeeID == followeeID {
+			m.edges = append(m.edges[:i], m.edges[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockFollowStore) IsFollowing(_ context.Context, followerID, followeeID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.edges {
+		if e.followerID == followerID && e.followeeID == followeeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockFollowStore) GetFollowers(_ context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.FollowEntry
+	for _, e := range m.edges {
+		if e.followeeID == userID && e.createdAt.Before(cursor) {
+			result = append(result, models.FollowEntry{User: models.User{ID: e.followerID}, FollowedAt: e.createdAt})
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockFollowStore) GetFollowing(_ context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.FollowEntry
+	for _, e := range m.edges {
+		if e.followerID == userID && e.createdAt.Before(cursor) {
+			result = append(result, models.FollowEntry{User: models.User{ID: e.followeeID}, FollowedAt: e.createdAt})
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (m *mockFollowStore) GetExportFollowers(_ context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.FollowEntry
+	for _, e := range m.edges {
+		if e.followeeID == userID {
+			result = append(result, models.FollowEntry{User: models.User{ID: e.followerID}, FollowedAt: e.createdAt})
+		}
+	}
+	return exportPage(result, followEntryKey, cursor, cursorID, limit), nil
+}
+
+func (m *mockFollowStore) GetExportFollowing(_ context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.FollowEntry
+	for _, e := range m.edges {
+		if e.followerID == userID {
+			result = append(result, models.FollowEntry{User: models.User{ID: e.followeeID}, FollowedAt: e.createdAt})
+		}
+	}
+	return exportPage(result, followEntryKey, cursor, cursorID, limit), nil
+}
+
+func followEntryKey(e models.FollowEntry) (time.Time, string) { return e.FollowedAt, e.User.ID }
+
+// mockBlockStore implements store.BlockStore and store.MuteStore with
+// in-memory edge lists
+type mockBlockStore struct {
+	mu     sync.Mutex
+	blocks []relationEdge
+	mutes  []relationEdge
+}
+
+type relationEdge struct {
+	userID    string
+	otherID   string
+	createdAt time.Time
+}
+
+func newMockBlockStore() *mockBlockStore {
+	return &mockBlockStore{}
+}
+
+func addEdge(edges []relationEdge, userID, otherID string) []relationEdge {
+	for _, e := range edges {
+		if e.userID == userID && e.otherID == otherID {
+			return edges
+		}
+	}
+	return append(edges, relationEdge{userID: userID, otherID: otherID, createdAt: time.Now()})
+}
+
+func removeEdge(edges []relationEdge, userID, otherID string) []relationEdge {
+	for i, e := range edges {
+		if e.userID == userID && e.otherID == otherID {
+			return append(edges[:i], edges[i+1:]...)
+		}
+	}
+	return edges
+}
+
+func hasEdge(edges []relationEdge, userID, otherID string) bool {
+	for _, e := range edges {
+		if e.userID == userID && e.otherID == otherID {
+			return true
+		}
+	}
+	return false
+}
+
+func listEdges(edges []relationEdge, userID string, cursor time.Time, limit int) []models.RelationEntry {
+	var result []models.RelationEntry
+	for _, e := range edges {
+		if e.userID == userID && e.createdAt.Before(cursor) {
+			result = append(result, models.RelationEntry{User: models.User{ID: e.otherID}, CreatedAt: e.createdAt})
+		}
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func (m *mockBlockStore) Block(_ context.Context, blockerID, blockedID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blocks = addEdge(m.blocks, blockerID, blockedID)
+	return nil
+}
+
+func (m *mockBlockStore) Unblock(_ context.Context, blockerID, blockedID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blocks = removeEdge(m.blocks, blockerID, blockedID)
+	return nil
+}
+
+func (m *mockBlockStore) IsBlocking(_ context.Context, blockerID, blockedID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return hasEdge(m.blocks, blockerID, blockedID), nil
+}
+
+func (m *mockBlockStore) IsBlockedBetween(_ context.Context, userID, otherID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return hasEdge(m.blocks, userID, otherID) || hasEdge(m.blocks, otherID, userID), nil
+}
+
+func (m *mockBlockStore) GetBlocked(_ context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return listEdges(m.blocks, userID, cursor, limit), nil
+}
+
+func (m *mockBlockStore) Mute(_ context.Context, muterID, mutedID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mutes = addEdge(m.mutes, muterID, mutedID)
+	return nil
+}
+
+func (m *mockBlockStore) Unmute(_ context.Context, muterID, mutedID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mutes = removeEdge(m.mutes, muterID, mutedID)
+	return nil
+}
+
+func (m *mockBlockStore) IsMuting(_ context.Context, muterID, mutedID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return hasEdge(m.mutes, muterID, mutedID), nil
+}
+
+func (m *mockBlockStore) GetMuted(_ context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return listEdges(m.mutes, userID, cursor, limit), nil
+}
+
+// mockFilterStore implements store.FilterStore with an in-memory list
+type mockFilterStore struct {
+	mu      sync.Mutex
+	filters map[string][]models.Filter // user ID -> filters, newest first
+	nextID  int
+}
+
+func newMockFilterStore() *mockFilterStore {
+	return &mockFilterStore{filters: make(map[string][]models.Filter)}
+}
+
+func (m *mockFilterStore) CreateFilter(_ context.Context, userID, phrase, match, action string, expiresAt *time.Time) (*models.Filter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	f := models.Filter{
+		ID:        fmt.Sprintf("filter-%d", m.nextID),
+		Phrase:    phrase,
+		Match:     match,
+		Action:    action,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	}
+	m.filters[userID] = append([]models.Filter{f}, m.filters[userID]...)
+	return &f, nil
+}
+
+func (m *mockFilterStore) GetFilters(_ context.Context, userID string) ([]models.Filter, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := []models.Filter{}
+	for _, f := range m.filters[userID] {
+		if f.ExpiresAt == nil || f.ExpiresAt.After(time.Now()) {
+			active = append(active, f)
+		}
+	}
+	return active, nil
+}
+
+func (m *mockFilterStore) DeleteFilter(_ context.Context, userID, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, f := range m.filters[userID] {
+		if f.ID == id {
+			m.filters[userID] = append(m.filters[userID][:i], m.filters[userID][i+1:]...)
+			return nil
+		}
+	}
+	return &models.APIError{Code: models.ErrCodeNotFound, Message: "filter not found"}
+}
+
+// mockReportStore implements store.ReportStore with an in-memory list
+type mockReportStore struct {
+	mu      sync.Mutex
+	reports []models.Report
+}
+
+func newMockReportStore() *mockReportStore {
+	return &mockReportStore{}
+}
+
+func (m *mockReportStore) CreatePostReport(_ context.Context, _, postID, reason, details string) (*models.Report, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := models.Report{
+		ID:        fmt.Sprintf("report-%d", len(m.reports)+1),
+		PostID:    &postID,
+		Reason:    reason,
+		Details:   details,
+		Status:    models.ReportStatusOpen,
+		CreatedAt: time.Now(),
+	}
+	m.reports = append(m.reports, r)
+	return &r, nil
+}
+
+func (m *mockReportStore) CreateUserReport(_ context.Context, _, userID, reason, details string) (*models.Report, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := models.Report{
+		ID:        fmt.Sprintf("report-%d", len(m.reports)+1),
+		UserID:    userID,
+		Reason:    reason,
+		Details:   details,
+		Status:    models.ReportStatusOpen,
+		CreatedAt: time.Now(),
+	}
+	m.reports = append(m.reports, r)
+	return &r, nil
+}
+
+func (m *mockReportStore) GetReports(_ context.Context, status string, _ time.Time, _ int) ([]models.Report, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result []models.Report
+	for _, r := range m.reports {
+		if r.Status == status {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// mockModerationStore implements store.ModerationStore, suspending users in
+// the given mockUserStore and recording every action in its log. Posts it
+// can hide are registered in posts, keyed by post ID with the author's ID.
+type mockModerationStore struct {
+	mu    sync.Mutex
+	users *mockUserStore
+	posts map[string]string
+	log   []models.ModerationAction
+}
+
+func newMockModerationStore(users *mockUserStore) *mockModerationStore {
+	return &mockModerationStore{users: users, posts: make(map[string]string)}
+}
+
+func (m *mockModerationStore) GetPostAuthor(_ context.Context, postID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	authorID, ok := m.posts[postID]
+	if !ok {
+		return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	return authorID, nil
+}
+
+func (m *mockModerationStore) record(moderatorID, action, targetID, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.log = append([]models.ModerationAction{{
+		ID:          fmt.Sprintf("action-%d", len(m.log)+1),
+		ModeratorID: &moderatorID,
+		Action:      action,
+		UserID:      &targetID,
+		Reason:      reason,
+		CreatedAt:   time.Now(),
+	}}, m.log...)
+}
+
+func (m *mockModerationStore) HidePost(_ context.Context, moderatorID, postID, reason string) error {
+	m.mu.Lock()
+	delete(m.posts, postID)
+	m.mu.Unlock()
+	m.record(moderatorID, models.ModerationHidePost, postID, reason)
+	return nil
+}
+
+func (m *mockModerationStore) SuspendUser(_ context.Context, moderatorID, userID, reason string) error {
+	m.users.mu.Lock()
+	m.users.setStatus(userID, models.AccountSuspended)
+	m.users.mu.Unlock()
+	m.record(moderatorID, models.ModerationSuspendUser, userID, reason)
+	return nil
+}
+
+func (m *mockModerationStore) UnsuspendUser(_ context.Context, moderatorID, userID, reason string) error {
+	m.users.mu.Lock()
+	m.users.setStatus(userID, models.AccountActive)
+	m.users.mu.Unlock()
+	m.record(moderatorID, models.ModerationUnsuspendUser, userID, reason)
+	return nil
+}
+
+func (m *mockModerationStore) DismissReport(_ context.Context, moderatorID, reportID, reason string) error {
+	m.record(moderatorID, models.ModerationDismissReport, reportID, reason)
+	return nil
+}
+
+func (m *mockModerationStore) GetLog(_ context.Context, _ time.Time, _ int) ([]models.ModerationAction, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.log, nil
+}
+
+// mockExportStore implements store.ExportStore in memory
+type mockExportStore struct {
+	mu       sync.Mutex
+	exports  map[string]*models.Export
+	owners   map[string]string // export ID -> user ID
+	archives map[string][]byte
+}
+
+func newMockExportStore() *mockExportStore {
+	return &mockExportStore{
+		exports:  make(map[string]*models.Export),
+		owners:   make(map[string]string),
+		archives: make(map[string][]byte),
+	}
+}
+
+func (m *mockExportStore) CreateExport(_ context.Context, userID string) (*models.Export, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &models.Export{
+		ID:        fmt.Sprintf("export-%d", len(m.exports)+1),
+		Status:    models.ExportPending,
+		CreatedAt: time.Now(),
+	}
+	m.exports[e.ID] = e
+	m.owners[e.ID] = userID
+	copied := *e
+	return &copied, nil
+}
+
+func (m *mockExportStore) GetExport(_ context.Context, userID, id string) (*models.Export, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.exports[id]
+	if !ok || m.owners[id] != userID {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "export not found"}
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (m *mockExportStore) GetArchive(_ context.Context, userID, id string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	archive, ok := m.archives[id]
+	if !ok || m.owners[id] != userID {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "export not found or not ready"}
+	}
+	return archive, nil
+}
+
+func (m *mockExportStore) CompleteExport(_ context.Context, id string, archive []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.exports[id].Status = models.ExportReady
+	m.exports[id].CompletedAt = &now
+	m.archives[id] = archive
+	return nil
+}
+
+func (m *mockExportStore) FailExport(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.exports[id].Status = models.ExportFailed
+	m.exports[id].CompletedAt = &now
+	return nil
 }
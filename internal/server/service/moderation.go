@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+// maxReportDetails is the longest explanation a report or moderation
+// action may carry, in characters.
+const maxReportDetails = 500
+
+// ModerationService files users' reports and carries out moderators'
+// actions on them. Moderators are users with the moderator or admin role,
+// plus any configured by username.
+type ModerationService struct {
+	reports    store.ReportStore
+	moderation store.ModerationStore
+	users      store.UserStore
+	tokens     store.RefreshTokenStore
+	moderators map[string]bool
+}
+
+func NewModerationService(reports store.ReportStore, moderation store.ModerationStore, users store.UserStore, tokens store.RefreshTokenStore, moderators []string) *ModerationService {
+	set := make(map[string]bool, len(moderators))
+	for _, name := range moderators {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			set[name] = true
+		}
+	}
+	return &ModerationService{reports: reports, moderation: moderation, users: users, tokens: tokens, moderators: set}
+}
+
+// ReportPost reports postID for reason, with optional details.
+func (s *ModerationService) ReportPost(ctx context.Context, reporterID, postID, reason, details string) (*models.Report, error) {
+	details = strings.TrimSpace(details)
+	if err := ValidateReport(reason, details); err != nil {
+		return nil, err
+	}
+	return s.reports.CreatePostReport(ctx, reporterID, postID, reason, details)
+}
+
+// ReportUser reports the account userID for reason, with optional details.
+func (s *ModerationService) ReportUser(ctx context.Context, reporterID, userID, reason, details string) (*models.Report, error) {
+	details = strings.TrimSpace(details)
+	if err := ValidateReport(reason, details); err != nil {
+		return nil, err
+	}
+	if reporterID == userID {
+		return nil, &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot report yourself"}
+	}
+	return s.reports.CreateUserReport(ctx, reporterID, userID, reason, details)
+}
+
+// ValidateReport checks a report's reason and details.
+func ValidateReport(reason, details string) error {
+	switch reason {
+	case models.ReportReasonSpam, models.ReportReasonHarassment, models.ReportReasonHate,
+		models.ReportReasonViolence, models.ReportReasonSexual, models.ReportReasonOther:
+	default:
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "reason",
+			Message: "reason must be one of spam, harassment, hate, violence, sexual, other",
+		}
+	}
+	return validateModerationNote("details", details)
+}
+
+// validateModerationNote checks the free text attached to a report or a
+// moderation action.
+func validateModerationNote(field, note string) error {
+	if utf8.RuneCountInString(note) > maxReportDetails {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: field,
+			Message: field + " must be 500 characters or fewer",
+		}
+	}
+	if containsControlChars(note, true) {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: field,
+			Message: field + " contains invalid characters",
+		}
+	}
+	return nil
+}
+
+// IsModerator reports whether userID may moderate.
+func (s *ModerationService) IsModerator(ctx context.Context, userID string) (bool, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		var apiErr *models.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == models.ErrCodeNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return s.roleRank(user) > 0, nil
+}
+
+// roleRank orders users by authority: admins above moderators above
+// everyone else. Users configured as moderators by username rank as
+// moderators.
+func (s *ModerationService) roleRank(user *models.User) int {
+	switch {
+	case user.Role == models.RoleAdmin:
+		return 2
+	case user.Role == models.RoleModerator, s.moderators[strings.ToLower(user.Username)]:
+		return 1
+	}
+	return 0
+}
+
+// requireOutranks returns a forbidden error unless moderatorID's role is
+// higher than userID's, so moderators cannot act against each other or
+// against admins.
+func (s *ModerationService) requireOutranks(ctx context.Context, moderatorID, userID string) error {
+	moderator, err := s.users.GetUserByID(ctx, moderatorID)
+	if err != nil {
+		return err
+	}
+	target, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if s.roleRank(target) >= s.roleRank(moderator) {
+		return &models.APIError{Code: models.ErrCodeForbidden, Message: "you cannot act against a user whose role is equal to or higher than yours"}
+	}
+	return nil
+}
+
+// requireModerator returns a forbidden error unless userID is a moderator.
+func (s *ModerationService) requireModerator(ctx context.Context, userID string) error {
+	ok, err := s.IsModerator(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &models.APIError{Code: models.ErrCodeForbidden, Message: "moderator access required"}
+	}
+	return nil
+}
+
+// GetReports returns the moderation queue: reports with the given status,
+// open ones by default, newest first.
+func (s *ModerationService) GetReports(ctx context.Context, moderatorID, status string, cursor time.Time, limit int) ([]models.Report, error) {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+	switch status {
+	case "":
+		status = models.ReportStatusOpen
+	case models.ReportStatusOpen, models.ReportStatusResolved, models.ReportStatusDismissed:
+	default:
+		return nil, &models.APIError{
+			Code: models.ErrCodeValidation, Field: "status",
+			Message: "status must be open, resolved or dismissed",
+		}
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.reports.GetReports(ctx, status, cursor, limit)
+}
+
+// HidePost removes postID from every feed and thread and resolves its
+// reports. Only posts by users with a lower role than the moderator's can
+// be hidden.
+func (s *ModerationService) HidePost(ctx context.Context, moderatorID, postID, reason string) error {
+	if err := s.checkAction(ctx, moderatorID, reason); err != nil {
+		return err
+	}
+	authorID, err := s.moderation.GetPostAuthor(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if err := s.requireOutranks(ctx, moderatorID, authorID); err != nil {
+		return err
+	}
+	return s.moderation.HidePost(ctx, moderatorID, postID, strings.TrimSpace(reason))
+}
+
+// SuspendUser suspends userID, resolves the reports about them and ends
+// their sessions. Suspended users cannot log in or refresh their tokens.
+// Only users with a lower role than the moderator's can be suspended.
+func (s *ModerationService) SuspendUser(ctx context.Context, moderatorID, userID, reason string) error {
+	if err := s.checkAction(ctx, moderatorID, reason); err != nil {
+		return err
+	}
+	if moderatorID == userID {
+		return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot suspend yourself"}
+	}
+	if err := s.requireOutranks(ctx, moderatorID, userID); err != nil {
+		return err
+	}
+	if err := s.moderation.SuspendUser(ctx, moderatorID, userID, strings.TrimSpace(reason)); err != nil {
+		return err
+	}
+	return s.tokens.DeleteAllForUser(ctx, userID)
+}
+
+func (s *ModerationService) UnsuspendUser(ctx context.Context, moderatorID, userID, reason string) error {
+	if err := s.checkAction(ctx, moderatorID, reason); err != nil {
+		return err
+	}
+	if err := s.requireOutranks(ctx, moderatorID, userID); err != nil {
+		return err
+	}
+	return s.moderation.UnsuspendUser(ctx, moderatorID, userID, strings.TrimSpace(reason))
+}
+
+// DismissReport closes reportID without acting on it.
+func (s *ModerationService) DismissReport(ctx context.Context, moderatorID, reportID, reason string) error {
+	if err := s.checkAction(ctx, moderatorID, reason); err != nil {
+		return err
+	}
+	return s.moderation.DismissReport(ctx, moderatorID, reportID, strings.TrimSpace(reason))
+}
+
+// checkAction checks that moderatorID may act and that the reason they gave
+// is acceptable.
+func (s *ModerationService) checkAction(ctx context.Context, moderatorID, reason string) error {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return err
+	}
+	return validateModerationNote("reason", strings.TrimSpace(reason))
+}
+
+// GetLog returns the moderation log, newest first.
+func (s *ModerationService) GetLog(ctx context.Context, moderatorID string, cursor time.Time, limit int) ([]models.ModerationAction, error) {
+	if err := s.requireModerator(ctx, moderatorID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.moderation.GetLog(ctx, cursor, limit)
+}
@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// newTestModerationService returns a moderation service whose only
+// moderator is "mod", and an auth service sharing its stores.
+func newTestModerationService(t *testing.T) (*service.ModerationService, *service.AuthService, *mockModerationStore) {
+	t.Helper()
+	users := newMockUserStore()
+	tokens := newMockRefreshTokenStore()
+	moderation := newMockModerationStore(users)
+	auth := newAuthService(users, tokens)
+	mod := service.NewModerationService(newMockReportStore(), moderation, users, tokens, []string{" Mod "})
+	return mod, auth, moderation
+}
+
+func registerUser(t *testing.T, auth *service.AuthService, username string) *models.AuthResponse {
+	t.Helper()
+	resp, err := auth.Register(context.Background(), &models.RegisterRequest{
+		Username: username,
+		Email:    username + "@example.com",
+		Password: "password123",
+	})
+	if err != nil {
+		t.Fatalf("Register %s: %v", username, err)
+	}
+	return resp
+}
+
+func TestReportValidation(t *testing.T) {
+	mod, auth, _ := newTestModerationService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+
+	if _, err := mod.ReportPost(ctx, akram, "post-1", "rude", ""); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("unknown reason error = %v, want validation error", err)
+	}
+	if _, err := mod.ReportPost(ctx, akram, "post-1", models.ReportReasonSpam, strings.Repeat("a", 501)); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("long details error = %v, want validation error", err)
+	}
+	if _, err := mod.ReportUser(ctx, akram, akram, models.ReportReasonSpam, ""); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("self report error = %v, want validation error", err)
+	}
+
+	report, err := mod.ReportPost(ctx, akram, "post-1", models.ReportReasonSpam, "  buy now links  ")
+	if err != nil {
+		t.Fatalf("ReportPost: %v", err)
+	}
+	if report.Details != "buy now links" || report.Status != models.ReportStatusOpen {
+		t.Errorf("report = %+v, want trimmed open report", report)
+	}
+}
+
+func TestModerationRequiresModerator(t *testing.T) {
+	mod, auth, _ := newTestModerationService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	sara := registerUser(t, auth, "sara").User.ID
+
+	checks := map[string]error{
+		"GetReports":  func() error { _, err := mod.GetReports(ctx, akram, "", time.Now(), 50); return err }(),
+		"GetLog":      func() error { _, err := mod.GetLog(ctx, akram, time.Now(), 50); return err }(),
+		"HidePost":    mod.HidePost(ctx, akram, "post-1", ""),
+		"SuspendUser": mod.SuspendUser(ctx, akram, sara, ""),
+	}
+	for name, err := range checks {
+		if apiErrorCode(err) != models.ErrCodeForbidden {
+			t.Errorf("%s by non-moderator error = %v, want forbidden", name, err)
+		}
+	}
+}
+
+func TestSuspendUserBlocksLogin(t *testing.T) {
+	mod, auth, moderation := newTestModerationService(t)
+	ctx := context.Background()
+	modID := registerUser(t, auth, "mod").User.ID
+	sara := registerUser(t, auth, "sara")
+
+	if err := mod.SuspendUser(ctx, modID, modID, ""); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("self suspension error = %v, want validation error", err)
+	}
+	if err := mod.SuspendUser(ctx, modID, sara.User.ID, "spam wave"); err != nil {
+		t.Fatalf("SuspendUser: %v", err)
+	}
+
+	_, err := auth.Login(ctx, &models.LoginRequest{Email: "sara@example.com", Password: "password123"})
+	if apiErrorCode(err) != models.ErrCodeAccountSuspended {
+		t.Errorf("Login while suspended error = %v, want account_suspended", err)
+	}
+	if _, err := auth.Refresh(ctx, sara.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected suspension to revoke refresh tokens")
+	}
+
+	log, err := mod.GetLog(ctx, modID, time.Now(), 50)
+	if err != nil {
+		t.Fatalf("GetLog: %v", err)
+	}
+	if len(log) != 1 || log[0].Action != models.ModerationSuspendUser || log[0].Reason != "spam wave" {
+		t.Errorf("log = %+v, want the suspension", moderation.log)
+	}
+
+	if err := mod.UnsuspendUser(ctx, modID, sara.User.ID, ""); err != nil {
+		t.Fatalf("UnsuspendUser: %v", err)
+	}
+	if _, err := auth.Login(ctx, &models.LoginRequest{Email: "sara@example.com", Password: "password123"}); err != nil {
+		t.Errorf("Login after unsuspension: %v", err)
+	}
+}
+
+func TestSuspendUserRespectsRoles(t *testing.T) {
+	mod, auth, moderation := newTestModerationService(t)
+	ctx := context.Background()
+	modID := registerUser(t, auth, "mod").User.ID
+	omar := registerUser(t, auth, "omar").User.ID
+	admin := registerUser(t, auth, "akram").User.ID
+	_ = moderation.users.SetRole(ctx, omar, models.RoleModerator)
+	_ = moderation.users.SetRole(ctx, admin, models.RoleAdmin)
+
+	if err := mod.SuspendUser(ctx, modID, omar, ""); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("moderator suspending a moderator error = %v, want forbidden", err)
+	}
+	if err := mod.SuspendUser(ctx, omar, admin, ""); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("moderator suspending an admin error = %v, want forbidden", err)
+	}
+	if err := mod.SuspendUser(ctx, admin, omar, ""); err != nil {
+		t.Errorf("admin suspending a moderator: %v", err)
+	}
+	if err := mod.UnsuspendUser(ctx, modID, omar, ""); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("moderator unsuspending a moderator error = %v, want forbidden", err)
+	}
+}
+
+func TestHidePostRespectsRoles(t *testing.T) {
+	mod, auth, moderation := newTestModerationService(t)
+	ctx := context.Background()
+	modID := registerUser(t, auth, "mod").User.ID
+	omar := registerUser(t, auth, "omar").User.ID
+	sara := registerUser(t, auth, "sara").User.ID
+	admin := registerUser(t, auth, "akram").User.ID
+	_ = moderation.users.SetRole(ctx, omar, models.RoleModerator)
+	_ = moderation.users.SetRole(ctx, admin, models.RoleAdmin)
+	moderation.posts["omar-post"] = omar
+	moderation.posts["admin-post"] = admin
+	moderation.posts["sara-post"] = sara
+
+	if err := mod.HidePost(ctx, modID, "omar-post", ""); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("moderator hiding a moderator's post error = %v, want forbidden", err)
+	}
+	if err := mod.HidePost(ctx, omar, "admin-post", ""); apiErrorCode(err) != models.ErrCodeForbidden {
+		t.Errorf("moderator hiding an admin's post error = %v, want forbidden", err)
+	}
+	if err := mod.HidePost(ctx, modID, "missing-post", ""); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("hiding a missing post error = %v, want not found", err)
+	}
+	if err := mod.HidePost(ctx, modID, "sara-post", ""); err != nil {
+		t.Errorf("moderator hiding a user's post: %v", err)
+	}
+	if err := mod.HidePost(ctx, admin, "omar-post", ""); err != nil {
+		t.Errorf("admin hiding a moderator's post: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+type NotificationService struct {
+	notifications store.NotificationStore
+}
+
+func NewNotificationService(notifications store.NotificationStore) *NotificationService {
+	return &NotificationService{notifications: notifications}
+}
+
+// GetNotifications returns userID's notifications, newest first.
+func (s *NotificationService) GetNotifications(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Notification, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.notifications.GetNotifications(ctx, userID, cursor, limit)
+}
+
+// UnreadCount returns how many of userID's notifications are unread.
+func (s *NotificationService) UnreadCount(ctx context.Context, userID string) (int, error) {
+	return s.notifications.CountUnread(ctx, userID)
+}
+
+// MarkRead marks userID's notifications up to and including upTo as read and
+// returns the number still unread.
+func (s *NotificationService) MarkRead(ctx context.Context, userID string, upTo time.Time) (int, error) {
+	if err := s.notifications.MarkRead(ctx, userID, upTo); err != nil {
+		return 0, err
+	}
+	return s.notifications.CountUnread(ctx, userID)
+}
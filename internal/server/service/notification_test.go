@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestServicesWriteNotifications(t *testing.T) {
+	notifications := newMockNotificationStore()
+	ctx := context.Background()
+
+	posts := service.NewPostService(newMockPostStore(), newMockMentionStore(), newMockTagStore(), notifications, newMockFilterStore())
+	post, err := posts.CreatePost(ctx, "user-1", "hello @sara")
+	if err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+	reply, err := posts.CreateReply(ctx, "user-2", post.ID, "hi back")
+	if err != nil {
+		t.Fatalf("CreateReply: %v", err)
+	}
+
+	likes := service.NewLikeService(newMockLikeStore(), notifications)
+	if _, err := likes.Like(ctx, "user-1", reply.ID); err != nil {
+		t.Fatalf("Like: %v", err)
+	}
+
+	follows := service.NewFollowService(newMockFollowStore(), newMockBlockStore(), notifications)
+	if err := follows.Follow(ctx, "user-2", "user-1"); err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+
+	want := []string{
+		"mention:" + post.ID,
+		"reply:author-of-" + post.ID,
+		"mention:" + reply.ID,
+		"like:author-of-" + reply.ID,
+		"follow:user-1",
+	}
+	if !reflect.DeepEqual(notifications.events, want) {
+		t.Errorf("notifications = %v, want %v", notifications.events, want)
+	}
+}
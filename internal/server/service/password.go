@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/mailer"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordResetTTL is how long an emailed reset token can be used.
+const passwordResetTTL = time.Hour
+
+// passwordResetTimeout bounds the background work of one reset request.
+const passwordResetTimeout = 30 * time.Second
+
+// PasswordService handles users changing a password they know and
+// resetting one they forgot. A reset token is emailed, stored only as its
+// hash, and works once.
+type PasswordService struct {
+	users  store.UserStore
+	tokens store.RefreshTokenStore
+	resets store.PasswordResetStore
+	mail   mailer.Mailer
+
+	wg sync.WaitGroup
+}
+
+func NewPasswordService(users store.UserStore, tokens store.RefreshTokenStore, resets store.PasswordResetStore, mail mailer.Mailer) *PasswordService {
+	return &PasswordService{users: users, tokens: tokens, resets: resets, mail: mail}
+}
+
+// ChangePassword replaces userID's password after confirming the current
+// one, and ends their sessions on every device but sessionID.
+func (s *PasswordService) ChangePassword(ctx context.Context, userID, sessionID string, req *models.ChangePasswordRequest) error {
+	if err := checkPassword(ctx, s.users, userID, req.Password); err != nil {
+		return err
+	}
+	if err := s.setPassword(ctx, userID, req.NewPassword); err != nil {
+		return err
+	}
+	return s.tokens.DeleteOtherSessions(ctx, userID, sessionID)
+}
+
+// RequestReset emails a reset token to the account registered with email.
+// It succeeds whether or not there is one, so it cannot be used to find
+// out which addresses have accounts. The lookup and the email happen after
+// it returns, so its timing gives nothing away either, and failures are
+// only logged.
+func (s *PasswordService) RequestReset(ctx context.Context, email string) error {
+	if err := ValidateEmail(email); err != nil {
+		return err
+	}
+
+	s.wg.Add(1)
+	go s.sendReset(email)
+	return nil
+}
+
+// Wait blocks until reset emails being sent have finished or ctx is done.
+func (s *PasswordService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sendReset emails a reset token to the account registered with email, if
+// there is one. It outlives the request that started it.
+func (s *PasswordService) sendReset(email string) {
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), passwordResetTimeout)
+	defer cancel()
+
+	user, _, err := s.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		// An unknown email fails like a wrong login
+		var apiErr *models.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+			slog.Error("looking up password reset email failed", "err", err)
+		}
+		return
+	}
+
+	rawToken, err := generateRefreshToken()
+	if err == nil {
+		err = s.resets.CreateReset(ctx, user.ID, hashRefreshToken(rawToken), time.Now().Add(passwordResetTTL))
+	}
+	if err != nil {
+		slog.Error("creating password reset failed", "user_id", user.ID, "err", err)
+		return
+	}
+
+	msg := mailer.Message{
+		To:      email,
+		Subject: "Reset your niotebook password",
+		Body: fmt.Sprintf("Someone asked to reset the password of @%s on niotebook.\n\n"+
+			"To choose a new password, enter this code in niotebook within the next hour:\n\n"+
+			"    %s\n\n"+
+			"If it wasn't you, ignore this email. Your password has not changed.\n",
+			user.Username, rawToken),
+	}
+	if err := s.mail.Send(ctx, msg); err != nil {
+		slog.Error("sending password reset email failed", "user_id", user.ID, "err", err)
+	}
+}
+
+// ConfirmReset sets a new password with an emailed reset token and ends
+// every session of the account, since whoever held the old password may
+// be signed in.
+func (s *PasswordService) ConfirmReset(ctx context.Context, req *models.ConfirmPasswordResetRequest) error {
+	if req.Token == "" {
+		return &models.APIError{Code: models.ErrCodeValidation, Field: "token", Message: "reset token is required"}
+	}
+	// Check the password first so a rejected one doesn't use up the token
+	if err := validateNewPassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	userID, err := s.resets.ConsumeReset(ctx, hashRefreshToken(req.Token))
+	if err != nil {
+		return err
+	}
+	if err := s.setPassword(ctx, userID, req.NewPassword); err != nil {
+		return err
+	}
+	return s.tokens.DeleteAllForUser(ctx, userID)
+}
+
+func (s *PasswordService) setPassword(ctx context.Context, userID, password string) error {
+	if err := validateNewPassword(password); err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	return s.users.SetPasswordHash(ctx, userID, string(hash))
+}
+
+// validateNewPassword is ValidatePassword for the new_password field.
+func validateNewPassword(password string) error {
+	err := ValidatePassword(password)
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) {
+		apiErr.Field = "new_password"
+	}
+	return err
+}
@@ -0,0 +1,226 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/mailer"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+// recordingMailer keeps sent messages instead of delivering them
+type recordingMailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+	err  error
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, msg)
+	return m.err
+}
+
+var resetCodeRegex = regexp.MustCompile(`\n    (\S+)\n`)
+
+// resetCode returns the code in the last message sent, for both password
+// resets and email verification.
+func (m *recordingMailer) resetCode(t *testing.T) string {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.sent) == 0 {
+		t.Fatal("no reset email was sent")
+	}
+	match := resetCodeRegex.FindStringSubmatch(m.sent[len(m.sent)-1].Body)
+	if match == nil {
+		t.Fatalf("no reset code in email:\n%s", m.sent[len(m.sent)-1].Body)
+	}
+	return match[1]
+}
+
+func newTestPasswordService(t *testing.T) (*service.PasswordService, *service.AuthService, *recordingMailer) {
+	t.Helper()
+	users := newMockUserStore()
+	tokens := newMockRefreshTokenStore()
+	mail := &recordingMailer{}
+	auth := newAuthService(users, tokens)
+	return service.NewPasswordService(users, tokens, newMockPasswordResetStore(), mail), auth, mail
+}
+
+func TestChangePassword(t *testing.T) {
+	passwords, auth, _ := newTestPasswordService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram")
+	other, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	sessions, _ := auth.ListSessions(ctx, akram.User.ID, "")
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want 2", len(sessions))
+	}
+	// Sessions are listed most recently used first, so the registration is last
+	current := sessions[1].ID
+
+	wrong := &models.ChangePasswordRequest{Password: "wrongpassword", NewPassword: "newpassword1"}
+	if err := passwords.ChangePassword(ctx, akram.User.ID, current, wrong); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("wrong current password error = %v, want unauthorized", err)
+	}
+	short := &models.ChangePasswordRequest{Password: "password123", NewPassword: "short"}
+	var apiErr *models.APIError
+	if err := passwords.ChangePassword(ctx, akram.User.ID, current, short); !errors.As(err, &apiErr) || apiErr.Field != "new_password" {
+		t.Errorf("short new password error = %v, want a new_password validation error", err)
+	}
+
+	req := &models.ChangePasswordRequest{Password: "password123", NewPassword: "newpassword1"}
+	if err := passwords.ChangePassword(ctx, akram.User.ID, current, req); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+
+	if _, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "password123"}); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("login with old password error = %v, want unauthorized", err)
+	}
+	if _, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "newpassword1"}); err != nil {
+		t.Errorf("login with new password: %v", err)
+	}
+
+	// The other device is signed out, this one is not
+	if _, err := auth.Refresh(ctx, other.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected the other session to be revoked")
+	}
+	if _, err := auth.Refresh(ctx, akram.Tokens.RefreshToken, models.Device{}); err != nil {
+		t.Errorf("current session should survive a password change: %v", err)
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	passwords, auth, mail := newTestPasswordService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram")
+
+	if err := passwords.RequestReset(ctx, "akram@example.com"); err != nil {
+		t.Fatalf("RequestReset: %v", err)
+	}
+	_ = passwords.Wait(ctx)
+	if len(mail.sent) != 1 || mail.sent[0].To != "akram@example.com" {
+		t.Fatalf("sent = %+v, want one email to akram@example.com", mail.sent)
+	}
+	code := mail.resetCode(t)
+
+	// A rejected password leaves the token usable
+	if err := passwords.ConfirmReset(ctx, &models.ConfirmPasswordResetRequest{Token: code, NewPassword: "short"}); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("short password error = %v, want validation error", err)
+	}
+	if err := passwords.ConfirmReset(ctx, &models.ConfirmPasswordResetRequest{Token: code, NewPassword: "newpassword1"}); err != nil {
+		t.Fatalf("ConfirmReset: %v", err)
+	}
+
+	if _, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "newpassword1"}); err != nil {
+		t.Errorf("login with new password: %v", err)
+	}
+	if _, err := auth.Refresh(ctx, akram.Tokens.RefreshToken, models.Device{}); err == nil {
+		t.Error("expected existing sessions to be revoked by a reset")
+	}
+
+	// Tokens are single-use
+	if err := passwords.ConfirmReset(ctx, &models.ConfirmPasswordResetRequest{Token: code, NewPassword: "another123"}); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("reused token error = %v, want unauthorized", err)
+	}
+}
+
+func TestPasswordResetOnlyLatestTokenWorks(t *testing.T) {
+	passwords, auth, mail := newTestPasswordService(t)
+	ctx := context.Background()
+	registerUser(t, auth, "akram")
+
+	_ = passwords.RequestReset(ctx, "akram@example.com")
+	_ = passwords.Wait(ctx)
+	first := mail.resetCode(t)
+	_ = passwords.RequestReset(ctx, "akram@example.com")
+	_ = passwords.Wait(ctx)
+	second := mail.resetCode(t)
+
+	if err := passwords.ConfirmReset(ctx, &models.ConfirmPasswordResetRequest{Token: first, NewPassword: "newpassword1"}); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("superseded token error = %v, want unauthorized", err)
+	}
+	if err := passwords.ConfirmReset(ctx, &models.ConfirmPasswordResetRequest{Token: second, NewPassword: "newpassword1"}); err != nil {
+		t.Errorf("latest token: %v", err)
+	}
+}
+
+func TestRequestResetHidesUnknownEmails(t *testing.T) {
+	passwords, auth, mail := newTestPasswordService(t)
+	ctx := context.Background()
+	registerUser(t, auth, "akram")
+
+	if err := passwords.RequestReset(ctx, "nobody@example.com"); err != nil {
+		t.Errorf("unknown email error = %v, want nil", err)
+	}
+	_ = passwords.Wait(ctx)
+	if len(mail.sent) != 0 {
+		t.Errorf("sent %d emails for an unknown address, want 0", len(mail.sent))
+	}
+
+	// A mail failure is not reported either
+	mail.mu.Lock()
+	mail.err = errors.New("smtp down")
+	mail.mu.Unlock()
+	if err := passwords.RequestReset(ctx, "akram@example.com"); err != nil {
+		t.Errorf("failed send error = %v, want nil", err)
+	}
+	_ = passwords.Wait(ctx)
+
+	if err := passwords.RequestReset(ctx, "not-an-email"); apiErrorCode(err) != models.ErrCodeValidation {
+		t.Errorf("invalid email error = %v, want validation error", err)
+	}
+}
+
+// blockingMailer holds every message until release is closed
+type blockingMailer struct {
+	release chan struct{}
+}
+
+func (m *blockingMailer) Send(ctx context.Context, _ mailer.Message) error {
+	select {
+	case <-m.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRequestResetDoesNotWaitForEmail(t *testing.T) {
+	users := newMockUserStore()
+	tokens := newMockRefreshTokenStore()
+	mail := &blockingMailer{release: make(chan struct{})}
+	passwords := service.NewPasswordService(users, tokens, newMockPasswordResetStore(), mail)
+	ctx := context.Background()
+	registerUser(t, newAuthService(users, tokens), "akram")
+
+	// Known and unknown addresses both return while the email is pending,
+	// so the response time does not reveal which one has an account
+	for _, email := range []string{"akram@example.com", "nobody@example.com"} {
+		if err := passwords.RequestReset(ctx, email); err != nil {
+			t.Fatalf("RequestReset %s: %v", email, err)
+		}
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := passwords.Wait(waitCtx); err == nil {
+		t.Error("Wait returned before the blocked email was sent")
+	}
+	close(mail.release)
+	if err := passwords.Wait(ctx); err != nil {
+		t.Errorf("Wait after release: %v", err)
+	}
+}
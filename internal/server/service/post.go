@@ -10,11 +10,47 @@ import (
 )
 
 type PostService struct {
-	posts store.PostStore
+	posts         store.PostStore
+	mentions      store.MentionStore
+	tags          store.TagStore
+	notifications store.NotificationStore
+	filters       store.FilterStore
+	// users is only set when authors must have verified their email.
+	users store.UserStore
 }
 
-func NewPostService(posts store.PostStore) *PostService {
-	return &PostService{posts: posts}
+func NewPostService(posts store.PostStore, mentions store.MentionStore, tags store.TagStore, notifications store.NotificationStore, filters store.FilterStore) *PostService {
+	return &PostService{posts: posts, mentions: mentions, tags: tags, notifications: notifications, filters: filters}
+}
+
+// RequireVerifiedEmail makes posting, replying and quoting fail for users
+// who have not verified their email address, looked up in users. Call it
+// before the service is used.
+func (s *PostService) RequireVerifiedEmail(users store.UserStore) {
+	s.users = users
+}
+
+// checkCanPost returns an email_unverified error if verified email is
+// required and authorID hasn't verified theirs.
+func (s *PostService) checkCanPost(ctx context.Context, authorID string) error {
+	return checkVerifiedEmail(ctx, s.users, authorID)
+}
+
+// checkVerifiedEmail returns an email_unverified error if authorID hasn't
+// verified their email address. A nil users means verification isn't
+// required, so every author passes.
+func checkVerifiedEmail(ctx context.Context, users store.UserStore, authorID string) error {
+	if users == nil {
+		return nil
+	}
+	_, verified, err := users.GetEmail(ctx, authorID)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return &models.APIError{Code: models.ErrCodeEmailUnverified, Message: "verify your email address before posting"}
+	}
+	return nil
 }
 
 func (s *PostService) CreatePost(ctx context.Context, authorID, content string) (*models.Post, error) {
@@ -22,23 +58,367 @@ func (s *PostService) CreatePost(ctx context.Context, authorID, content string)
 	if err := ValidatePostContent(content); err != nil {
 		return nil, err
 	}
-	return s.posts.CreatePost(ctx, authorID, content)
+	if err := s.checkCanPost(ctx, authorID); err != nil {
+		return nil, err
+	}
+	post, err := s.posts.CreatePost(ctx, authorID, content)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordReferences(ctx, post)
+}
+
+// recordReferences persists the @mentions and #hashtags in a new or edited
+// post, notifies the mentioned users, and sets the post's tags to match.
+// Users already notified about the post are not notified again, so after an
+// edit only the newly mentioned users hear of it.
+func (s *PostService) recordReferences(ctx context.Context, post *models.Post) (*models.Post, error) {
+	if err := s.mentions.SetMentions(ctx, post.ID, ParseMentions(post.Content)); err != nil {
+		return nil, err
+	}
+	if err := s.notifications.NotifyMentioned(ctx, post.ID); err != nil {
+		return nil, err
+	}
+	tags := ParseHashtags(post.Content)
+	if err := s.tags.SetTags(ctx, post.ID, tags); err != nil {
+		return nil, err
+	}
+	post.Tags = tags
+	return post, nil
 }
 
-func (s *PostService) GetPostByID(ctx context.Context, id string) (*models.Post, error) {
-	return s.posts.GetPostByID(ctx, id)
+// GetMentions returns posts that mention userID, newest first.
+func (s *PostService) GetMentions(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.mentions.GetMentions(ctx, userID, cursor, limit)
+}
+
+// trendingWindow is how far back GetTrendingTags counts tag uses.
+const trendingWindow = 24 * time.Hour
+
+// GetTagPosts returns posts tagged with tag, newest first. A leading '#' on
+// tag is ignored.
+func (s *PostService) GetTagPosts(ctx context.Context, viewerID, tag string, cursor time.Time, limit int) ([]models.Post, error) {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	if err := ValidateTag(tag); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.tags.GetTagPosts(ctx, viewerID, tag, cursor, limit)
 }
 
-func (s *PostService) GetTimeline(ctx context.Context, cursor time.Time, limit int) ([]models.Post, error) {
+// GetTrendingTags returns the tags used most over the trending window.
+func (s *PostService) GetTrendingTags(ctx context.Context, limit int) ([]models.TrendingTag, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 50
 	}
-	return s.posts.GetTimeline(ctx, cursor, limit)
+	return s.tags.GetTrending(ctx, time.Now().Add(-trendingWindow), limit)
 }
 
-func (s *PostService) GetUserPosts(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+func (s *PostService) GetPostByID(ctx context.Context, viewerID, id string) (*models.Post, error) {
+	return s.posts.GetPostByID(ctx, viewerID, id)
+}
+
+// GetTimeline returns a page of the global timeline and the cursor for the
+// following page, which is zero when there are no more posts.
+func (s *PostService) GetTimeline(ctx context.Context, viewerID string, cursor time.Time, limit int) ([]models.Post, time.Time, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 50
 	}
-	return s.posts.GetUserPosts(ctx, userID, cursor, limit)
+	return s.filterFeed(ctx, viewerID, cursor, limit, func(cursor time.Time, limit int) ([]models.Post, error) {
+		return s.posts.GetTimeline(ctx, viewerID, cursor, limit)
+	})
+}
+
+// GetUserPosts returns a page of userID's posts and the cursor for the
+// following page, which is zero when there are no more posts.
+func (s *PostService) GetUserPosts(ctx context.Context, viewerID, userID string, cursor time.Time, limit int) ([]models.Post, time.Time, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.filterFeed(ctx, viewerID, cursor, limit, func(cursor time.Time, limit int) ([]models.Post, error) {
+		return s.posts.GetUserPosts(ctx, viewerID, userID, cursor, limit)
+	})
+}
+
+// GetHomeTimeline returns a page of userID's home timeline and the cursor
+// for the following page, which is zero when there are no more posts.
+func (s *PostService) GetHomeTimeline(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, time.Time, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.filterFeed(ctx, userID, cursor, limit, func(cursor time.Time, limit int) ([]models.Post, error) {
+		return s.posts.GetHomeTimeline(ctx, userID, cursor, limit)
+	})
+}
+
+// maxFilterRefills bounds how many extra pages filterFeed reads to replace
+// posts that the viewer's filters hid.
+const maxFilterRefills = 3
+
+// filterFeed reads a page of posts with fetch and applies viewerID's
+// filters to it: posts matching a hide filter are dropped and posts
+// matching a flag filter are returned with Filtered set. Dropped posts are
+// replaced from the following pages so a full page stays full. The viewer's
+// own posts are never filtered.
+//
+// It also returns the cursor for the following page: the last post it
+// scanned, which can be past the last post it returned when the refills ran
+// out, or zero once fetch has no more posts.
+func (s *PostService) filterFeed(ctx context.Context, viewerID string, cursor time.Time, limit int, fetch func(cursor time.Time, limit int) ([]models.Post, error)) ([]models.Post, time.Time, error) {
+	posts, err := fetch(cursor, limit)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if viewerID == "" {
+		return posts, pageCursor(posts, limit), nil
+	}
+	filters, err := s.filters.GetFilters(ctx, viewerID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if len(filters) == 0 {
+		return posts, pageCursor(posts, limit), nil
+	}
+	compiled := compileFilters(filters)
+
+	kept := make([]models.Post, 0, len(posts))
+	for refills := 0; ; refills++ {
+		for _, post := range posts {
+			if post.AuthorID != viewerID {
+				if f := matchFilters(compiled, &post); f != nil {
+					if f.Action == models.FilterActionHide {
+						continue
+					}
+					post.Filtered = f.Phrase
+				}
+			}
+			kept = append(kept, post)
+			if len(kept) == limit {
+				return kept, post.CreatedAt, nil
+			}
+		}
+		next := pageCursor(posts, limit)
+		if next.IsZero() || refills == maxFilterRefills {
+			return kept, next, nil
+		}
+		posts, err = fetch(next, limit)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+}
+
+// pageCursor returns the cursor following a page read with limit: the last
+// post's time when the page is full, or zero when it was the last page.
+func pageCursor(posts []models.Post, limit int) time.Time {
+	if len(posts) < limit {
+		return time.Time{}
+	}
+	return posts[len(posts)-1].CreatedAt
+}
+
+// Repost shares postID on userID's timelines and returns the original's new
+// repost count. Reposting a repost shares its original instead.
+func (s *PostService) Repost(ctx context.Context, userID, postID string) (int, error) {
+	original, err := s.resolveOriginal(ctx, userID, postID)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.posts.CreateRepost(ctx, userID, original.ID); err != nil {
+		return 0, err
+	}
+	return s.posts.CountReposts(ctx, original.ID)
+}
+
+// Unrepost removes userID's repost of postID and returns the original's new
+// repost count. Removing a repost that does not exist is a no-op.
+func (s *PostService) Unrepost(ctx context.Context, userID, postID string) (int, error) {
+	original, err := s.resolveOriginal(ctx, userID, postID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.posts.DeleteRepost(ctx, userID, original.ID); err != nil {
+		return 0, err
+	}
+	return s.posts.CountReposts(ctx, original.ID)
+}
+
+// Quote publishes a post by authorID that embeds postID. Quoting a repost
+// embeds its original instead.
+func (s *PostService) Quote(ctx context.Context, authorID, postID, content string) (*models.Post, error) {
+	content = strings.TrimSpace(content)
+	if err := ValidatePostContent(content); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanPost(ctx, authorID); err != nil {
+		return nil, err
+	}
+	original, err := s.resolveOriginal(ctx, authorID, postID)
+	if err != nil {
+		return nil, err
+	}
+	quote, err := s.posts.CreateQuote(ctx, authorID, original.ID, content)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.recordReferences(ctx, quote); err != nil {
+		return nil, err
+	}
+	return s.posts.GetPostByID(ctx, authorID, quote.ID)
+}
+
+// resolveOriginal returns the post that reposts and quotes of postID should
+// point at: the post itself, or the original it reposts.
+func (s *PostService) resolveOriginal(ctx context.Context, viewerID, postID string) (*models.Post, error) {
+	post, err := s.posts.GetPostByID(ctx, viewerID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Kind == models.PostKindRepost {
+		post = post.Original
+	}
+	if post == nil || post.Deleted {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	return post, nil
+}
+
+// EditPost replaces the content of userID's post id and returns the updated
+// post. The previous content is kept in the post's edit history, and the
+// post's mentions and tags are updated to match the new content.
+func (s *PostService) EditPost(ctx context.Context, userID, id, content string) (*models.Post, error) {
+	content = strings.TrimSpace(content)
+	if err := ValidatePostContent(content); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanPost(ctx, userID); err != nil {
+		return nil, err
+	}
+	post, err := s.ownPost(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if post.Kind == models.PostKindRepost {
+		return nil, &models.APIError{Code: models.ErrCodeValidation, Message: "reposts cannot be edited"}
+	}
+	if err := s.posts.EditPost(ctx, id, content); err != nil {
+		return nil, err
+	}
+	edited, err := s.posts.GetPostByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.recordReferences(ctx, edited)
+}
+
+// DeletePost removes userID's post id. Posts are soft-deleted so replies keep
+// their place in the thread; reposts are simply withdrawn.
+func (s *PostService) DeletePost(ctx context.Context, userID, id string) error {
+	post, err := s.ownPost(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if post.Kind == models.PostKindRepost && post.OriginalID != nil {
+		return s.posts.DeleteRepost(ctx, userID, *post.OriginalID)
+	}
+	return s.posts.DeletePost(ctx, id)
+}
+
+// GetHistory returns a post with the content it had before each edit.
+func (s *PostService) GetHistory(ctx context.Context, viewerID, id string) (*models.PostHistoryResponse, error) {
+	post, err := s.posts.GetPostByID(ctx, viewerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if post.Deleted {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	edits, err := s.posts.GetEdits(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PostHistoryResponse{Post: *post, Edits: edits}, nil
+}
+
+// ownPost fetches a live post and checks that userID wrote it.
+func (s *PostService) ownPost(ctx context.Context, userID, id string) (*models.Post, error) {
+	post, err := s.posts.GetPostByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if post.Deleted {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	if post.AuthorID != userID {
+		return nil, &models.APIError{Code: models.ErrCodeForbidden, Message: "you can only change your own posts"}
+	}
+	return post, nil
+}
+
+// maxThreadReplies caps how many descendants a thread response includes.
+const maxThreadReplies = 500
+
+func (s *PostService) CreateReply(ctx context.Context, authorID, parentID, content string) (*models.Post, error) {
+	content = strings.TrimSpace(content)
+	if err := ValidatePostContent(content); err != nil {
+		return nil, err
+	}
+	if err := s.checkCanPost(ctx, authorID); err != nil {
+		return nil, err
+	}
+	post, err := s.posts.CreateReply(ctx, authorID, parentID, content)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.notifications.NotifyPostAuthor(ctx, authorID, models.NotificationKindReply, parentID, post.ID); err != nil {
+		return nil, err
+	}
+	return s.recordReferences(ctx, post)
+}
+
+// GetThread returns a post with its ancestors and its replies ordered
+// depth-first, so each reply directly follows its parent's subtree.
+func (s *PostService) GetThread(ctx context.Context, viewerID, id string) (*models.ThreadResponse, error) {
+	post, err := s.posts.GetPostByID(ctx, viewerID, id)
+	if err != nil {
+		return nil, err
+	}
+	ancestors, err := s.posts.GetAncestors(ctx, viewerID, id)
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := s.posts.GetDescendants(ctx, viewerID, id, maxThreadReplies)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]models.Post)
+	for _, d := range descendants {
+		if d.ParentID != nil {
+			children[*d.ParentID] = append(children[*d.ParentID], d)
+		}
+	}
+	replies := make([]models.Post, 0, len(descendants))
+	var walk func(parentID string)
+	walk = func(parentID string) {
+		for _, child := range children[parentID] {
+			replies = append(replies, child)
+			walk(child.ID)
+		}
+	}
+	walk(post.ID)
+
+	if ancestors == nil {
+		ancestors = []models.Post{}
+	}
+	return &models.ThreadResponse{
+		Ancestors: ancestors,
+		Post:      *post,
+		Replies:   replies,
+	}, nil
 }
@@ -11,7 +11,7 @@ import (
 
 func TestCreatePost(t *testing.T) {
 	postStore := newMockPostStore()
-	svc := service.NewPostService(postStore)
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
 
 	post, err := svc.CreatePost(context.Background(), "user-123", "Hello, Niotebook!")
 	if err != nil {
@@ -24,7 +24,7 @@ func TestCreatePost(t *testing.T) {
 
 func TestCreatePostTrimmed(t *testing.T) {
 	postStore := newMockPostStore()
-	svc := service.NewPostService(postStore)
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
 
 	post, _ := svc.CreatePost(context.Background(), "user-123", "  Hello  ")
 	if post.Content != "Hello" {
@@ -34,7 +34,7 @@ func TestCreatePostTrimmed(t *testing.T) {
 
 func TestCreatePostTooLong(t *testing.T) {
 	postStore := newMockPostStore()
-	svc := service.NewPostService(postStore)
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
 
 	_, err := svc.CreatePost(context.Background(), "user-123", strings.Repeat("a", 141))
 	if err == nil {
@@ -44,7 +44,7 @@ func TestCreatePostTooLong(t *testing.T) {
 
 func TestCreatePostEmpty(t *testing.T) {
 	postStore := newMockPostStore()
-	svc := service.NewPostService(postStore)
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
 
 	_, err := svc.CreatePost(context.Background(), "user-123", "   ")
 	if err == nil {
@@ -54,13 +54,13 @@ func TestCreatePostEmpty(t *testing.T) {
 
 func TestGetTimeline(t *testing.T) {
 	postStore := newMockPostStore()
-	svc := service.NewPostService(postStore)
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
 
 	// Add posts via mock
 	postStore.AddPost("1", "user-1", "First", time.Now().Add(-2*time.Minute))
 	postStore.AddPost("2", "user-1", "Second", time.Now().Add(-1*time.Minute))
 
-	posts, err := svc.GetTimeline(context.Background(), time.Now(), 50)
+	posts, _, err := svc.GetTimeline(context.Background(), "", time.Now(), 50)
 	if err != nil {
 		t.Fatalf("GetTimeline: %v", err)
 	}
@@ -68,3 +68,92 @@ func TestGetTimeline(t *testing.T) {
 		t.Errorf("got %d posts, want 2", len(posts))
 	}
 }
+
+func TestGetHomeTimeline(t *testing.T) {
+	postStore := newMockPostStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+
+	postStore.AddPost("1", "user-2", "Followed", time.Now().Add(-2*time.Minute))
+	postStore.AddPost("2", "user-3", "Not followed", time.Now().Add(-1*time.Minute))
+	postStore.Follow("user-1", "user-2")
+
+	posts, _, err := svc.GetHomeTimeline(context.Background(), "user-1", time.Now(), 50)
+	if err != nil {
+		t.Fatalf("GetHomeTimeline: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("got %d posts, want 1", len(posts))
+	}
+	if posts[0].AuthorID != "user-2" {
+		t.Errorf("author_id = %q, want %q", posts[0].AuthorID, "user-2")
+	}
+}
+
+func TestCreateReplyInheritsRoot(t *testing.T) {
+	postStore := newMockPostStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	root, _ := svc.CreatePost(ctx, "user-1", "root")
+	reply, err := svc.CreateReply(ctx, "user-2", root.ID, "reply")
+	if err != nil {
+		t.Fatalf("CreateReply: %v", err)
+	}
+	nested, err := svc.CreateReply(ctx, "user-1", reply.ID, "nested")
+	if err != nil {
+		t.Fatalf("CreateReply nested: %v", err)
+	}
+	if nested.ParentID == nil || *nested.ParentID != reply.ID {
+		t.Errorf("parent = %v, want %q", nested.ParentID, reply.ID)
+	}
+	if nested.RootID == nil || *nested.RootID != root.ID {
+		t.Errorf("root = %v, want %q", nested.RootID, root.ID)
+	}
+}
+
+func TestCreateReplyValidatesContent(t *testing.T) {
+	postStore := newMockPostStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	root, _ := svc.CreatePost(ctx, "user-1", "root")
+	if _, err := svc.CreateReply(ctx, "user-2", root.ID, "   "); err == nil {
+		t.Error("expected error for empty reply")
+	}
+}
+
+func TestGetThreadDepthFirst(t *testing.T) {
+	postStore := newMockPostStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	root, _ := svc.CreatePost(ctx, "user-1", "root")
+	a, _ := svc.CreateReply(ctx, "user-2", root.ID, "a")
+	b, _ := svc.CreateReply(ctx, "user-3", root.ID, "b")
+	a1, _ := svc.CreateReply(ctx, "user-1", a.ID, "a1")
+
+	thread, err := svc.GetThread(ctx, "", root.ID)
+	if err != nil {
+		t.Fatalf("GetThread: %v", err)
+	}
+	if len(thread.Ancestors) != 0 {
+		t.Errorf("ancestors = %d, want 0 for root", len(thread.Ancestors))
+	}
+	want := []string{a.ID, a1.ID, b.ID}
+	if len(thread.Replies) != len(want) {
+		t.Fatalf("replies = %d, want %d", len(thread.Replies), len(want))
+	}
+	for i, id := range want {
+		if thread.Replies[i].ID != id {
+			t.Errorf("replies[%d] = %q, want %q", i, thread.Replies[i].ID, id)
+		}
+	}
+
+	thread, err = svc.GetThread(ctx, "", a1.ID)
+	if err != nil {
+		t.Fatalf("GetThread leaf: %v", err)
+	}
+	if len(thread.Ancestors) != 2 || thread.Ancestors[0].ID != root.ID || thread.Ancestors[1].ID != a.ID {
+		t.Errorf("ancestors = %+v, want [root, a]", thread.Ancestors)
+	}
+}
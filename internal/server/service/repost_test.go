@@ -0,0 +1,79 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestRepostReturnsCount(t *testing.T) {
+	svc := service.NewPostService(newMockPostStore(), newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	original, _ := svc.CreatePost(ctx, "user-1", "original")
+	count, err := svc.Repost(ctx, "user-2", original.ID)
+	if err != nil {
+		t.Fatalf("Repost: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+
+	_, err = svc.Repost(ctx, "user-2", original.ID)
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeConflict {
+		t.Errorf("duplicate repost error = %v, want conflict", err)
+	}
+
+	count, err = svc.Unrepost(ctx, "user-2", original.ID)
+	if err != nil {
+		t.Fatalf("Unrepost: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count after unrepost = %d, want 0", count)
+	}
+}
+
+func TestRepostOfRepostTargetsOriginal(t *testing.T) {
+	postStore := newMockPostStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	original, _ := svc.CreatePost(ctx, "user-1", "original")
+	repost, _ := postStore.CreateRepost(ctx, "user-2", original.ID)
+
+	count, err := svc.Repost(ctx, "user-3", repost.ID)
+	if err != nil {
+		t.Fatalf("Repost: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("original repost count = %d, want 2", count)
+	}
+}
+
+func TestQuoteEmbedsOriginal(t *testing.T) {
+	postStore := newMockPostStore()
+	svc := service.NewPostService(postStore, newMockMentionStore(), newMockTagStore(), newMockNotificationStore(), newMockFilterStore())
+	ctx := context.Background()
+
+	original, _ := svc.CreatePost(ctx, "user-1", "original")
+	repost, _ := postStore.CreateRepost(ctx, "user-2", original.ID)
+
+	quote, err := svc.Quote(ctx, "user-3", repost.ID, "  so true  ")
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	if quote.Kind != models.PostKindQuote || quote.Content != "so true" {
+		t.Errorf("quote = %+v", quote)
+	}
+	if quote.Original == nil || quote.Original.ID != original.ID {
+		t.Errorf("quote embeds %v, want %q", quote.Original, original.ID)
+	}
+
+	if _, err := svc.Quote(ctx, "user-3", original.ID, " "); err == nil {
+		t.Error("expected error for empty quote")
+	}
+}
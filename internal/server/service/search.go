@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+const maxQueryLength = 100
+
+type SearchService struct {
+	search store.SearchStore
+}
+
+func NewSearchService(search store.SearchStore) *SearchService {
+	return &SearchService{search: search}
+}
+
+// SearchPosts returns posts matching query, newest first.
+func (s *SearchService) SearchPosts(ctx context.Context, viewerID, query string, cursor time.Time, limit int) ([]models.Post, error) {
+	query, err := normalizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.search.SearchPosts(ctx, viewerID, query, cursor, limit)
+}
+
+// SearchUsers returns users whose username or display name matches query,
+// newest first. A leading '@' on query is ignored.
+func (s *SearchService) SearchUsers(ctx context.Context, viewerID, query string, cursor time.Time, limit int) ([]models.User, error) {
+	query, err := normalizeQuery(strings.TrimPrefix(strings.TrimSpace(query), "@"))
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.search.SearchUsers(ctx, viewerID, query, cursor, limit)
+}
+
+func normalizeQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return "", &models.APIError{
+			Code: models.ErrCodeValidation, Field: "q",
+			Message: "search query is required",
+		}
+	}
+	if utf8.RuneCountInString(query) > maxQueryLength {
+		return "", &models.APIError{
+			Code: models.ErrCodeValidation, Field: "q",
+			Message: "search query must be at most 100 characters",
+		}
+	}
+	return query, nil
+}
@@ -0,0 +1,37 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+)
+
+func TestSearchRejectsBadQueries(t *testing.T) {
+	svc := service.NewSearchService(&mockSearchStore{})
+	ctx := context.Background()
+
+	for _, q := range []string{"", "   ", strings.Repeat("a", 101)} {
+		_, err := svc.SearchPosts(ctx, "user-1", q, time.Now(), 20)
+		var apiErr *models.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeValidation || apiErr.Field != "q" {
+			t.Errorf("SearchPosts(%q) error = %v, want validation error on q", q, err)
+		}
+	}
+}
+
+func TestSearchUsersTrimsQuery(t *testing.T) {
+	searchStore := &mockSearchStore{}
+	svc := service.NewSearchService(searchStore)
+
+	if _, err := svc.SearchUsers(context.Background(), "", "  @sara ", time.Now(), 20); err != nil {
+		t.Fatalf("SearchUsers: %v", err)
+	}
+	if searchStore.lastQuery != "sara" {
+		t.Errorf("query = %q, want %q", searchStore.lastQuery, "sara")
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+	"github.com/Akram012388/niotebook-tui/internal/server/totp"
+)
+
+const (
+	// totpIssuer names the service in authenticator apps.
+	totpIssuer = "niotebook"
+	// recoveryCodeCount is how many recovery codes enabling two-factor
+	// authentication hands out.
+	recoveryCodeCount = 10
+)
+
+// recoveryEncoding spells recovery codes in lowercase base32, which has no
+// easily confused characters like 0/o or 1/l.
+var recoveryEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// TwoFactorService enrolls users in TOTP two-factor authentication and
+// turns it off again. Signing in with it is part of AuthService.
+type TwoFactorService struct {
+	users     store.UserStore
+	twoFactor store.TwoFactorStore
+}
+
+func NewTwoFactorService(users store.UserStore, twoFactor store.TwoFactorStore) *TwoFactorService {
+	return &TwoFactorService{users: users, twoFactor: twoFactor}
+}
+
+// Status reports whether userID has two-factor authentication on and how
+// many recovery codes they have left.
+func (s *TwoFactorService) Status(ctx context.Context, userID string) (*models.TwoFactorStatus, error) {
+	enabled, err := twoFactorEnabled(ctx, s.twoFactor, userID)
+	if err != nil || !enabled {
+		return &models.TwoFactorStatus{}, err
+	}
+	left, err := s.twoFactor.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: left}, nil
+}
+
+// Setup creates a new secret for userID to add to their authenticator app.
+// It has no effect on signing in until Enable confirms a code from the app.
+func (s *TwoFactorService) Setup(ctx context.Context, userID string) (*models.TwoFactorSetup, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := totp.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactor.SetPendingSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+	return &models.TwoFactorSetup{Secret: secret, URI: totp.URI(totpIssuer, user.Username, secret)}, nil
+}
+
+// Enable turns on two-factor authentication once code shows the user's app
+// has the secret from Setup. It returns recovery codes, each of which can
+// stand in for a code once; only their hashes are kept.
+func (s *TwoFactorService) Enable(ctx context.Context, userID, code string) ([]string, error) {
+	secret, enabled, err := s.twoFactor.GetSecret(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, &models.APIError{Code: models.ErrCodeConflict, Message: "two-factor authentication is already enabled"}
+	}
+	step, ok := totp.Validate(secret, normalizeCode(code), time.Now())
+	if !ok {
+		return nil, errInvalidTwoFactorCode()
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashRefreshToken(raw)
+	}
+
+	if err := s.twoFactor.Enable(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// Disable turns off two-factor authentication after checking the user's
+// password and a code, so a stolen session alone can't remove it.
+func (s *TwoFactorService) Disable(ctx context.Context, userID string, req *models.DisableTwoFactorRequest) error {
+	if err := checkPassword(ctx, s.users, userID, req.Password); err != nil {
+		return err
+	}
+	if err := checkSecondFactor(ctx, s.twoFactor, userID, req.Code); err != nil {
+		return err
+	}
+	return s.twoFactor.Disable(ctx, userID)
+}
+
+// twoFactorEnabled reports whether userID must pass a second factor to
+// sign in.
+func twoFactorEnabled(ctx context.Context, twoFactor store.TwoFactorStore, userID string) (bool, error) {
+	_, enabled, err := twoFactor.GetSecret(ctx, userID)
+	var apiErr *models.APIError
+	if errors.As(err, &apiErr) && apiErr.Code == models.ErrCodeNotFound {
+		return false, nil
+	}
+	return enabled, err
+}
+
+// checkSecondFactor accepts a current code from userID's authenticator app
+// or one of their unused recovery codes, and uses it up.
+func checkSecondFactor(ctx context.Context, twoFactor store.TwoFactorStore, userID, code string) error {
+	code = normalizeCode(code)
+	if code == "" {
+		return &models.APIError{Code: models.ErrCodeValidation, Field: "code", Message: "code is required"}
+	}
+	secret, enabled, err := twoFactor.GetSecret(ctx, userID)
+	if err != nil {
+		var apiErr *models.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == models.ErrCodeNotFound {
+			return errInvalidTwoFactorCode()
+		}
+		return err
+	}
+	if !enabled {
+		return errInvalidTwoFactorCode()
+	}
+
+	if len(code) == totp.Digits && strings.Trim(code, "0123456789") == "" {
+		step, ok := totp.Validate(secret, code, time.Now())
+		if !ok {
+			return errInvalidTwoFactorCode()
+		}
+		return twoFactor.UseStep(ctx, userID, step)
+	}
+	return twoFactor.UseRecoveryCode(ctx, userID, hashRefreshToken(code))
+}
+
+// normalizeCode drops the spaces and dashes people type or paste with
+// codes, and lowercases recovery codes.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+func errInvalidTwoFactorCode() error {
+	return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "code", Message: "invalid two-factor code"}
+}
+
+// generateRecoveryCode returns ten random lowercase base32 characters.
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate recovery code: %w", err)
+	}
+	return recoveryEncoding.EncodeToString(b)[:10], nil
+}
@@ -0,0 +1,237 @@
+package service_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/jwtkeys"
+	"github.com/Akram012388/niotebook-tui/internal/server/service"
+	"github.com/Akram012388/niotebook-tui/internal/server/totp"
+)
+
+var recoveryCodeRegex = regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+
+func newTestTwoFactorService(t *testing.T) (*service.TwoFactorService, *service.AuthService) {
+	t.Helper()
+	users := newMockUserStore()
+	twoFactor := newMockTwoFactorStore()
+	auth := service.NewAuthService(users, newMockRefreshTokenStore(), newMockEmailVerificationStore(users), twoFactor, newMockLoginChallengeStore(), jwtkeys.FromSecret("test-secret-32-bytes-long-xxxxx"), &recordingMailer{})
+	return service.NewTwoFactorService(users, twoFactor), auth
+}
+
+// codeAt returns the authenticator code for secret offset steps from now.
+func codeAt(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+	code, err := totp.Code(secret, totp.Step(time.Now())+offset)
+	if err != nil {
+		t.Fatalf("Code: %v", err)
+	}
+	return code
+}
+
+// enableTwoFactor turns on two-factor authentication for userID with the
+// current code and returns the secret and recovery codes.
+func enableTwoFactor(t *testing.T, tf *service.TwoFactorService, userID string) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+	setup, err := tf.Setup(ctx, userID)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	codes, err := tf.Enable(ctx, userID, codeAt(t, setup.Secret, 0))
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	return setup.Secret, codes
+}
+
+func TestTwoFactorEnrollment(t *testing.T) {
+	tf, auth := newTestTwoFactorService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+
+	if status, err := tf.Status(ctx, akram); err != nil || status.Enabled {
+		t.Fatalf("Status before setup = %+v, %v, want disabled", status, err)
+	}
+	if _, err := tf.Enable(ctx, akram, "123456"); apiErrorCode(err) != models.ErrCodeNotFound {
+		t.Errorf("enable before setup error = %v, want not found", err)
+	}
+
+	setup, err := tf.Setup(ctx, akram)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if !strings.HasPrefix(setup.URI, "otpauth://totp/niotebook:akram?") || !strings.Contains(setup.URI, "secret="+setup.Secret) {
+		t.Errorf("uri = %q, want an otpauth URI for akram with the secret", setup.URI)
+	}
+	// A pending secret doesn't change signing in
+	if status, _ := tf.Status(ctx, akram); status.Enabled {
+		t.Error("a pending secret should not enable two-factor authentication")
+	}
+
+	wrong := codeAt(t, setup.Secret, 5)
+	if _, err := tf.Enable(ctx, akram, wrong); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("enable with a wrong code error = %v, want unauthorized", err)
+	}
+	codes, err := tf.Enable(ctx, akram, codeAt(t, setup.Secret, 0))
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	if len(codes) != 10 {
+		t.Errorf("got %d recovery codes, want 10", len(codes))
+	}
+	for _, code := range codes {
+		if !recoveryCodeRegex.MatchString(code) {
+			t.Errorf("recovery code %q has the wrong format", code)
+		}
+	}
+
+	status, err := tf.Status(ctx, akram)
+	if err != nil || !status.Enabled || status.RecoveryCodesLeft != 10 {
+		t.Errorf("Status = %+v, %v, want enabled with 10 recovery codes", status, err)
+	}
+	if _, err := tf.Setup(ctx, akram); apiErrorCode(err) != models.ErrCodeConflict {
+		t.Errorf("setup when enabled error = %v, want conflict", err)
+	}
+	if _, err := tf.Enable(ctx, akram, codeAt(t, setup.Secret, 1)); apiErrorCode(err) != models.ErrCodeConflict {
+		t.Errorf("enable twice error = %v, want conflict", err)
+	}
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	tf, auth := newTestTwoFactorService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	setup, _ := tf.Setup(ctx, akram)
+	secret, enableCode := setup.Secret, codeAt(t, setup.Secret, 0)
+	recovery, err := tf.Enable(ctx, akram, enableCode)
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	login := &models.LoginRequest{Email: "akram@example.com", Password: "password123"}
+
+	resp, err := auth.Login(ctx, login)
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if resp.ChallengeToken == "" || resp.Tokens != nil || resp.User != nil {
+		t.Fatalf("Login = %+v, want only a challenge token", resp)
+	}
+
+	complete := func(code string) (*models.AuthResponse, error) {
+		return auth.CompleteLogin(ctx, &models.TwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: code})
+	}
+	if _, err := complete(codeAt(t, secret, 5)); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("wrong code error = %v, want unauthorized", err)
+	}
+	// The code that enabled two-factor authentication can't be replayed
+	if _, err := complete(enableCode); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("reused code error = %v, want unauthorized", err)
+	}
+	signedIn, err := complete(codeAt(t, secret, 1))
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if signedIn.Tokens == nil || signedIn.User == nil || signedIn.User.ID != akram {
+		t.Errorf("CompleteLogin = %+v, want tokens for akram", signedIn)
+	}
+	if _, err := complete(codeAt(t, secret, 1)); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("reused challenge error = %v, want unauthorized", err)
+	}
+
+	// A recovery code works once, however it is typed
+	resp, _ = auth.Login(ctx, login)
+	if _, err := complete(" " + strings.ToUpper(recovery[0]) + " "); err != nil {
+		t.Fatalf("CompleteLogin with a recovery code: %v", err)
+	}
+	resp, _ = auth.Login(ctx, login)
+	if _, err := complete(recovery[0]); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("reused recovery code error = %v, want unauthorized", err)
+	}
+	if status, _ := tf.Status(ctx, akram); status.RecoveryCodesLeft != 9 {
+		t.Errorf("recovery codes left = %d, want 9", status.RecoveryCodesLeft)
+	}
+}
+
+func TestTwoFactorChallengeAttempts(t *testing.T) {
+	tf, auth := newTestTwoFactorService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	secret, _ := enableTwoFactor(t, tf, akram)
+
+	resp, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	for range 5 {
+		_, _ = auth.CompleteLogin(ctx, &models.TwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: "000000"})
+	}
+	req := &models.TwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: codeAt(t, secret, 1)}
+	_, err = auth.CompleteLogin(ctx, req)
+	if apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("correct code after too many attempts error = %v, want unauthorized", err)
+	}
+}
+
+func TestTwoFactorReactivate(t *testing.T) {
+	tf, auth := newTestTwoFactorService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	secret, _ := enableTwoFactor(t, tf, akram)
+	login := &models.LoginRequest{Email: "akram@example.com", Password: "password123"}
+
+	if err := auth.Deactivate(ctx, akram, "password123"); err != nil {
+		t.Fatalf("Deactivate: %v", err)
+	}
+	resp, err := auth.Reactivate(ctx, login)
+	if err != nil {
+		t.Fatalf("Reactivate: %v", err)
+	}
+	if resp.ChallengeToken == "" || resp.Tokens != nil {
+		t.Fatalf("Reactivate = %+v, want only a challenge token", resp)
+	}
+	// The password alone doesn't reactivate the account
+	if _, err := auth.Login(ctx, login); apiErrorCode(err) != models.ErrCodeAccountDeactivated {
+		t.Errorf("login before the second step error = %v, want account_deactivated", err)
+	}
+
+	signedIn, err := auth.CompleteLogin(ctx, &models.TwoFactorLoginRequest{ChallengeToken: resp.ChallengeToken, Code: codeAt(t, secret, 1)})
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if signedIn.Tokens == nil {
+		t.Error("expected tokens after reactivating")
+	}
+	if resp, err := auth.Login(ctx, login); err != nil || resp.ChallengeToken == "" {
+		t.Errorf("login after reactivating = %+v, %v, want a challenge", resp, err)
+	}
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	tf, auth := newTestTwoFactorService(t)
+	ctx := context.Background()
+	akram := registerUser(t, auth, "akram").User.ID
+	_, recovery := enableTwoFactor(t, tf, akram)
+
+	if err := tf.Disable(ctx, akram, &models.DisableTwoFactorRequest{Password: "wrongpassword", Code: recovery[0]}); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("wrong password error = %v, want unauthorized", err)
+	}
+	if err := tf.Disable(ctx, akram, &models.DisableTwoFactorRequest{Password: "password123", Code: "aaaaa-aaaaa"}); apiErrorCode(err) != models.ErrCodeUnauthorized {
+		t.Errorf("wrong code error = %v, want unauthorized", err)
+	}
+	if err := tf.Disable(ctx, akram, &models.DisableTwoFactorRequest{Password: "password123", Code: recovery[0]}); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+
+	resp, err := auth.Login(ctx, &models.LoginRequest{Email: "akram@example.com", Password: "password123"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if resp.Tokens == nil || resp.ChallengeToken != "" {
+		t.Errorf("Login = %+v, want tokens without a challenge", resp)
+	}
+}
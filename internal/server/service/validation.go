@@ -77,6 +77,32 @@ func ValidatePostContent(content string) error {
 	return nil
 }
 
+// maxMessageLength is the longest direct message, in characters.
+const maxMessageLength = 500
+
+func ValidateMessageContent(content string) error {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "content",
+			Message: "message cannot be empty",
+		}
+	}
+	if utf8.RuneCountInString(trimmed) > maxMessageLength {
+		return &models.APIError{
+			Code:    models.ErrCodeContentLong,
+			Message: "message must be 500 characters or fewer",
+		}
+	}
+	if containsControlChars(trimmed, true) {
+		return &models.APIError{
+			Code: models.ErrCodeValidation, Field: "content",
+			Message: "message contains invalid characters",
+		}
+	}
+	return nil
+}
+
 func ValidateEmail(email string) error {
 	if email == "" {
 		return &models.APIError{
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// blockedBetween holds when either of the users a and b, given as SQL
+// expressions, has blocked the other.
+func blockedBetween(a, b string) string {
+	return `EXISTS (
+		       SELECT 1 FROM blocks
+		       WHERE (blocker_id = ` + a + ` AND blocked_id = ` + b + `)
+		          OR (blocker_id = ` + b + ` AND blocked_id = ` + a + `)
+		   )`
+}
+
+type blockStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewBlockStore(pool *pgxpool.Pool) BlockStore {
+	return &blockStore{pool: pool}
+}
+
+// Block records that blockerID blocked blockedID and removes any follows
+// between them in the same statement.
+func (s *blockStore) Block(ctx context.Context, blockerID, blockedID string) error {
+	_, err := s.pool.Exec(ctx,
+		`WITH unfollowed AS (
+		     DELETE FROM follows
+		     WHERE (follower_id = $1 AND followee_id = $2)
+		        OR (follower_id = $2 AND followee_id = $1)
+		 )
+		 INSERT INTO blocks (blocker_id, blocked_id)
+		 VALUES ($1, $2)
+		 ON CONFLICT (blocker_id, blocked_id) DO NOTHING`,
+		blockerID, blockedID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503":
+				return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+			case "23514":
+				return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot block yourself"}
+			}
+		}
+		return fmt.Errorf("block: %w", err)
+	}
+	return nil
+}
+
+func (s *blockStore) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`,
+		blockerID, blockedID,
+	)
+	if err != nil {
+		return fmt.Errorf("unblock: %w", err)
+	}
+	return nil
+}
+
+func (s *blockStore) IsBlocking(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM blocks WHERE blocker_id = $1 AND blocked_id = $2
+		 )`, blockerID, blockedID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("is blocking: %w", err)
+	}
+	return exists, nil
+}
+
+// IsBlockedBetween reports whether either user has blocked the other.
+func (s *blockStore) IsBlockedBetween(ctx context.Context, userID, otherID string) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT `+blockedBetween(`$1::uuid`, `$2::uuid`), userID, otherID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("is blocked between: %w", err)
+	}
+	return exists, nil
+}
+
+// GetBlocked returns the users userID has blocked, most recent first.
+func (s *blockStore) GetBlocked(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT u.id, u.username, u.display_name, u.bio, u.created_at, b.created_at
+		 FROM blocks b
+		 JOIN users u ON b.blocked_id = u.id
+		 WHERE b.blocker_id = $1
+		   AND b.created_at < $2
+		 ORDER BY b.created_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get blocked: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRelationEntries(rows)
+}
+
+func scanRelationEntries(rows pgx.Rows) ([]models.RelationEntry, error) {
+	var entries []models.RelationEntry
+	for rows.Next() {
+		var e models.RelationEntry
+		err := rows.Scan(
+			&e.User.ID, &e.User.Username, &e.User.DisplayName, &e.User.Bio, &e.User.CreatedAt,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan relation entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate relation entries: %w", err)
+	}
+	return entries, nil
+}
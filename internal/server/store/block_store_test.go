@@ -0,0 +1,82 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestBlockRemovesFollowsAndHidesPosts(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	fs := store.NewFollowStore(pool)
+	bs := store.NewBlockStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	_ = fs.Follow(ctx, akram, sara)
+	_ = fs.Follow(ctx, sara, akram)
+	post, _ := ps.CreatePost(ctx, sara, "Hello from sara")
+	if _, err := ps.CreateRepost(ctx, akram, post.ID); err != nil {
+		t.Fatalf("CreateRepost: %v", err)
+	}
+
+	if err := bs.Block(ctx, akram, sara); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+	if err := bs.Block(ctx, akram, akram); err == nil {
+		t.Error("expected error for self-block")
+	}
+
+	if following, _ := fs.IsFollowing(ctx, akram, sara); following {
+		t.Error("block should remove akram's follow")
+	}
+	if following, _ := fs.IsFollowing(ctx, sara, akram); following {
+		t.Error("block should remove sara's follow")
+	}
+	if blocked, _ := bs.IsBlockedBetween(ctx, sara, akram); !blocked {
+		t.Error("IsBlockedBetween should hold in both directions")
+	}
+
+	cursor := time.Now().Add(time.Second)
+	for _, viewer := range []string{akram, sara} {
+		posts, err := ps.GetTimeline(ctx, viewer, cursor, 50)
+		if err != nil {
+			t.Fatalf("GetTimeline: %v", err)
+		}
+		if viewer == akram && len(posts) != 0 {
+			t.Errorf("blocker timeline = %+v, want empty", posts)
+		}
+		if viewer == sara && (len(posts) != 1 || posts[0].AuthorID != sara) {
+			t.Errorf("blocked user's timeline = %+v, want only her own post", posts)
+		}
+	}
+	if posts, _ := ps.GetUserPosts(ctx, akram, sara, cursor, 50); len(posts) != 0 {
+		t.Errorf("blocked user's posts = %+v, want none", posts)
+	}
+
+	// Anonymous viewers are unaffected
+	if posts, _ := ps.GetTimeline(ctx, "", cursor, 50); len(posts) != 1 {
+		t.Errorf("anonymous timeline has %d posts, want 1", len(posts))
+	}
+
+	blocked, err := bs.GetBlocked(ctx, akram, cursor, 50)
+	if err != nil {
+		t.Fatalf("GetBlocked: %v", err)
+	}
+	if len(blocked) != 1 || blocked[0].User.ID != sara {
+		t.Errorf("blocked = %+v, want sara", blocked)
+	}
+
+	if err := bs.Unblock(ctx, akram, sara); err != nil {
+		t.Fatalf("Unblock: %v", err)
+	}
+	if posts, _ := ps.GetUserPosts(ctx, akram, sara, cursor, 50); len(posts) != 1 {
+		t.Errorf("after unblock sara has %d posts, want 1", len(posts))
+	}
+}
@@ -0,0 +1,289 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type conversationStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewConversationStore(pool *pgxpool.Pool) ConversationStore {
+	return &conversationStore{pool: pool}
+}
+
+// conversationColumns selects a conversation as seen by the member passed
+// as $1, along with its latest message. The FROM clause joins the member
+// row as "me", so callers only ever see conversations they belong to.
+const conversationColumns = `c.id, c.created_at, c.last_message_at,
+	lm.id, lm.content, lm.created_at,
+	lm.sender_id, lm.username, lm.display_name, lm.bio, lm.user_created_at,
+	COALESCE(lm.created_at > me.last_read_at AND lm.sender_id <> me.user_id, false)`
+
+const conversationFrom = `conversation_members me
+	JOIN conversations c ON c.id = me.conversation_id
+	LEFT JOIN LATERAL (
+	    SELECT m.id, m.content, m.created_at, m.sender_id,
+	           u.username, u.display_name, u.bio, u.created_at AS user_created_at
+	    FROM messages m
+	    JOIN users u ON u.id = m.sender_id
+	    WHERE m.conversation_id = c.id
+	    ORDER BY m.created_at DESC
+	    LIMIT 1
+	) lm ON true`
+
+// CreateConversation creates a conversation between memberIDs and returns
+// its ID.
+func (s *conversationStore) CreateConversation(ctx context.Context, memberIDs []string) (string, error) {
+	var id string
+	err := s.pool.QueryRow(ctx,
+		`WITH c AS (
+		     INSERT INTO conversations DEFAULT VALUES RETURNING id
+		 ), members AS (
+		     INSERT INTO conversation_members (conversation_id, user_id)
+		     SELECT c.id, m FROM c, unnest($1::uuid[]) AS m
+		 )
+		 SELECT id FROM c`, memberIDs,
+	).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		}
+		return "", fmt.Errorf("create conversation: %w", err)
+	}
+	return id, nil
+}
+
+// FindDirectConversation returns the ID of the one-to-one conversation
+// between userID and otherID.
+func (s *conversationStore) FindDirectConversation(ctx context.Context, userID, otherID string) (string, error) {
+	var id string
+	err := s.pool.QueryRow(ctx,
+		`SELECT me.conversation_id
+		 FROM conversation_members me
+		 JOIN conversation_members other
+		   ON other.conversation_id = me.conversation_id AND other.user_id = $2
+		 WHERE me.user_id = $1
+		   AND (SELECT COUNT(*) FROM conversation_members cm
+		        WHERE cm.conversation_id = me.conversation_id) = 2
+		 LIMIT 1`, userID, otherID,
+	).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "conversation not found"}
+		}
+		return "", fmt.Errorf("find direct conversation: %w", err)
+	}
+	return id, nil
+}
+
+func (s *conversationStore) IsMember(ctx context.Context, conversationID, userID string) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM conversation_members WHERE conversation_id = $1 AND user_id = $2
+		 )`, conversationID, userID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("is member: %w", err)
+	}
+	return exists, nil
+}
+
+// GetConversation returns conversationID as seen by its member userID.
+func (s *conversationStore) GetConversation(ctx context.Context, userID, conversationID string) (*models.Conversation, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+conversationColumns+`
+		 FROM `+conversationFrom+`
+		 WHERE me.user_id = $1 AND c.id = $2`, userID, conversationID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get conversation: %w", err)
+	}
+	conversations, err := s.scanConversations(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(conversations) == 0 {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "conversation not found"}
+	}
+	return &conversations[0], nil
+}
+
+// GetConversations returns userID's conversations, most recently active
+// first.
+func (s *conversationStore) GetConversations(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Conversation, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+conversationColumns+`
+		 FROM `+conversationFrom+`
+		 WHERE me.user_id = $1 AND c.last_message_at < $2
+		 ORDER BY c.last_message_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get conversations: %w", err)
+	}
+	return s.scanConversations(ctx, rows)
+}
+
+// scanConversations reads conversation rows and then loads their members.
+func (s *conversationStore) scanConversations(ctx context.Context, rows pgx.Rows) ([]models.Conversation, error) {
+	defer rows.Close()
+
+	conversations := []models.Conversation{}
+	index := map[string]int{}
+	var ids []string
+	for rows.Next() {
+		var c models.Conversation
+		var msgID, content, senderID, username, displayName, bio *string
+		var msgCreatedAt, senderCreatedAt *time.Time
+		if err := rows.Scan(
+			&c.ID, &c.CreatedAt, &c.LastMessageAt,
+			&msgID, &content, &msgCreatedAt,
+			&senderID, &username, &displayName, &bio, &senderCreatedAt,
+			&c.Unread,
+		); err != nil {
+			return nil, fmt.Errorf("scan conversation: %w", err)
+		}
+		if msgID != nil {
+			c.LastMessage = &models.Message{
+				ID:             *msgID,
+				ConversationID: c.ID,
+				Sender: models.User{
+					ID:          *senderID,
+					Username:    *username,
+					DisplayName: *displayName,
+					Bio:         *bio,
+					CreatedAt:   *senderCreatedAt,
+				},
+				Content:   *content,
+				CreatedAt: *msgCreatedAt,
+			}
+		}
+		index[c.ID] = len(conversations)
+		ids = append(ids, c.ID)
+		conversations = append(conversations, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate conversations: %w", err)
+	}
+	rows.Close()
+
+	if len(ids) == 0 {
+		return conversations, nil
+	}
+
+	memberRows, err := s.pool.Query(ctx,
+		`SELECT cm.conversation_id, u.id, u.username, u.display_name, u.bio, u.created_at
+		 FROM conversation_members cm
+		 JOIN users u ON u.id = cm.user_id
+		 WHERE cm.conversation_id = ANY($1::uuid[])
+		 ORDER BY u.username`, ids,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get conversation members: %w", err)
+	}
+	defer memberRows.Close()
+
+	for memberRows.Next() {
+		var conversationID string
+		var u models.User
+		if err := memberRows.Scan(&conversationID, &u.ID, &u.Username, &u.DisplayName, &u.Bio, &u.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan conversation member: %w", err)
+		}
+		c := &conversations[index[conversationID]]
+		c.Members = append(c.Members, u)
+	}
+	if err := memberRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate conversation members: %w", err)
+	}
+	return conversations, nil
+}
+
+// CreateMessage adds a message to conversationID, bumps the conversation's
+// activity time, and marks it read for the sender.
+func (s *conversationStore) CreateMessage(ctx context.Context, conversationID, senderID, content string) (*models.Message, error) {
+	msg := &models.Message{ConversationID: conversationID, Content: content}
+	err := s.pool.QueryRow(ctx,
+		`WITH inserted AS (
+		     INSERT INTO messages (conversation_id, sender_id, content)
+		     VALUES ($1, $2, $3)
+		     RETURNING id, created_at
+		 ), bumped AS (
+		     UPDATE conversations SET last_message_at = (SELECT created_at FROM inserted)
+		     WHERE id = $1
+		 ), seen AS (
+		     UPDATE conversation_members SET last_read_at = (SELECT created_at FROM inserted)
+		     WHERE conversation_id = $1 AND user_id = $2
+		 )
+		 SELECT i.id, i.created_at, u.id, u.username, u.display_name, u.bio, u.created_at
+		 FROM inserted i, users u
+		 WHERE u.id = $2`, conversationID, senderID, content,
+	).Scan(&msg.ID, &msg.CreatedAt,
+		&msg.Sender.ID, &msg.Sender.Username, &msg.Sender.DisplayName, &msg.Sender.Bio, &msg.Sender.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+			if pgErr.ConstraintName == "messages_content_max_length" {
+				return nil, &models.APIError{Code: models.ErrCodeContentLong, Message: "message exceeds 500 characters"}
+			}
+			return nil, &models.APIError{Code: models.ErrCodeValidation, Message: "message cannot be empty", Field: "content"}
+		}
+		return nil, fmt.Errorf("create message: %w", err)
+	}
+	return msg, nil
+}
+
+// GetMessages returns the messages in conversationID, newest first.
+func (s *conversationStore) GetMessages(ctx context.Context, conversationID string, cursor time.Time, limit int) ([]models.Message, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT m.id, m.content, m.created_at,
+		        u.id, u.username, u.display_name, u.bio, u.created_at
+		 FROM messages m
+		 JOIN users u ON u.id = m.sender_id
+		 WHERE m.conversation_id = $1 AND m.created_at < $2
+		 ORDER BY m.created_at DESC
+		 LIMIT $3`, conversationID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []models.Message{}
+	for rows.Next() {
+		msg := models.Message{ConversationID: conversationID}
+		if err := rows.Scan(&msg.ID, &msg.Content, &msg.CreatedAt,
+			&msg.Sender.ID, &msg.Sender.Username, &msg.Sender.DisplayName, &msg.Sender.Bio, &msg.Sender.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate messages: %w", err)
+	}
+	return messages, nil
+}
+
+// MarkRead marks conversationID read for userID.
+func (s *conversationStore) MarkRead(ctx context.Context, conversationID, userID string) error {
+	_, err := s.pool.Exec(ctx,
+		`UPDATE conversation_members SET last_read_at = NOW()
+		 WHERE conversation_id = $1 AND user_id = $2`,
+		conversationID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("mark conversation read: %w", err)
+	}
+	return nil
+}
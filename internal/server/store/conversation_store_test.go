@@ -0,0 +1,97 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestConversations(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	cs := store.NewConversationStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	omar := createTestUser(t, us, "omar", "omar@example.com")
+
+	direct, err := cs.CreateConversation(ctx, []string{akram, sara})
+	if err != nil {
+		t.Fatalf("CreateConversation: %v", err)
+	}
+	if _, err := cs.CreateConversation(ctx, []string{akram, sara, omar}); err != nil {
+		t.Fatalf("CreateConversation group: %v", err)
+	}
+
+	// The group conversation is not mistaken for the direct one
+	found, err := cs.FindDirectConversation(ctx, sara, akram)
+	if err != nil || found != direct {
+		t.Fatalf("FindDirectConversation = %q, %v; want %q", found, err, direct)
+	}
+	if _, err := cs.FindDirectConversation(ctx, akram, omar); err == nil {
+		t.Error("expected no direct conversation between akram and omar")
+	}
+
+	if _, err := cs.CreateMessage(ctx, direct, sara, "psst, akram"); err != nil {
+		t.Fatalf("CreateMessage: %v", err)
+	}
+
+	conversations, err := cs.GetConversations(ctx, akram, time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetConversations: %v", err)
+	}
+	if len(conversations) != 2 || conversations[0].ID != direct {
+		t.Fatalf("conversations = %+v, want the direct one first", conversations)
+	}
+	c := conversations[0]
+	if !c.Unread || c.LastMessage == nil || c.LastMessage.Sender.Username != "sara" || len(c.Members) != 2 {
+		t.Errorf("direct conversation = %+v, want unread with sara's message", c)
+	}
+
+	// Sending marks the conversation read for the sender only
+	sarasView, err := cs.GetConversation(ctx, sara, direct)
+	if err != nil {
+		t.Fatalf("GetConversation: %v", err)
+	}
+	if sarasView.Unread {
+		t.Error("sender's own message should not be unread")
+	}
+	if err := cs.MarkRead(ctx, direct, akram); err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+	if c, _ := cs.GetConversation(ctx, akram, direct); c == nil || c.Unread {
+		t.Errorf("conversation = %+v, want read", c)
+	}
+
+	if _, err := cs.GetConversation(ctx, omar, direct); err == nil {
+		t.Error("non-member should not see the conversation")
+	}
+	if member, _ := cs.IsMember(ctx, direct, omar); member {
+		t.Error("omar should not be a member of the direct conversation")
+	}
+
+	// Messages never show up as posts
+	timeline, err := ps.GetTimeline(ctx, akram, time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	userPosts, err := ps.GetUserPosts(ctx, akram, sara, time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetUserPosts: %v", err)
+	}
+	if len(timeline) != 0 || len(userPosts) != 0 {
+		t.Errorf("messages leaked into posts: timeline %d, user posts %d", len(timeline), len(userPosts))
+	}
+
+	messages, err := cs.GetMessages(ctx, direct, time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Content != "psst, akram" {
+		t.Errorf("messages = %+v", messages)
+	}
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type emailVerificationStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewEmailVerificationStore(pool *pgxpool.Pool) EmailVerificationStore {
+	return &emailVerificationStore{pool: pool}
+}
+
+// CreateVerification stores a verification code for userID. Earlier codes
+// of theirs are deleted, so only the most recently sent one works.
+func (s *emailVerificationStore) CreateVerification(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	_, err := s.pool.Exec(ctx,
+		`WITH replaced AS (
+		     DELETE FROM email_verifications WHERE user_id = $1
+		 )
+		 INSERT INTO email_verifications (user_id, token_hash, expires_at)
+		 VALUES ($1, $2, $3)`,
+		userID, tokenHash, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create email verification: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail deletes an unexpired verification code, marks its user's
+// email verified and returns the user's ID.
+func (s *emailVerificationStore) VerifyEmail(ctx context.Context, tokenHash string) (string, error) {
+	var userID string
+	err := s.pool.QueryRow(ctx,
+		`WITH used AS (
+		     DELETE FROM email_verifications
+		     WHERE token_hash = $1 AND expires_at > NOW()
+		     RETURNING user_id
+		 )
+		 UPDATE users SET email_verified_at = COALESCE(email_verified_at, NOW())
+		 FROM used WHERE users.id = used.user_id
+		 RETURNING users.id`, tokenHash,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", &models.APIError{Code: models.ErrCodeUnauthorized, Field: "token", Message: "invalid or expired verification code"}
+		}
+		return "", fmt.Errorf("verify email: %w", err)
+	}
+	return userID, nil
+}
+
+func (s *emailVerificationStore) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM email_verifications WHERE expires_at < NOW()`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired email verifications: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
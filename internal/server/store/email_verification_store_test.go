@@ -0,0 +1,70 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestEmailVerification(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	vs := store.NewEmailVerificationStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	var apiErr *models.APIError
+
+	email, verified, err := us.GetEmail(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetEmail: %v", err)
+	}
+	if email != "akram@example.com" || verified {
+		t.Errorf("GetEmail = %q, %v, want akram@example.com, false", email, verified)
+	}
+
+	if err := vs.CreateVerification(ctx, userID, "codehash1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreateVerification: %v", err)
+	}
+	// A newer code replaces the first
+	if err := vs.CreateVerification(ctx, userID, "codehash2", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreateVerification again: %v", err)
+	}
+	if _, err := vs.VerifyEmail(ctx, "codehash1"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("replaced code error = %v, want unauthorized", err)
+	}
+
+	got, err := vs.VerifyEmail(ctx, "codehash2")
+	if err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if got != userID {
+		t.Errorf("user = %q, want %q", got, userID)
+	}
+	if _, verified, _ := us.GetEmail(ctx, userID); !verified {
+		t.Error("expected the email to be verified")
+	}
+	if _, err := vs.VerifyEmail(ctx, "codehash2"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("used code error = %v, want unauthorized", err)
+	}
+
+	_ = vs.CreateVerification(ctx, userID, "expiredhash", time.Now().Add(-time.Minute))
+	if _, err := vs.VerifyEmail(ctx, "expiredhash"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("expired code error = %v, want unauthorized", err)
+	}
+	deleted, err := vs.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+
+	if _, _, err := us.GetEmail(ctx, "00000000-0000-0000-0000-000000000000"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("unknown user error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type exportStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewExportStore(pool *pgxpool.Pool) ExportStore {
+	return &exportStore{pool: pool}
+}
+
+// CreateExport records a new pending export for userID. Any earlier export
+// of theirs still pending was abandoned, for example by a server restart,
+// and is marked failed.
+func (s *exportStore) CreateExport(ctx context.Context, userID string) (*models.Export, error) {
+	var e models.Export
+	err := s.pool.QueryRow(ctx,
+		`WITH abandoned AS (
+		     UPDATE data_exports SET status = 'failed', completed_at = NOW()
+		     WHERE user_id = $1 AND status = 'pending'
+		 )
+		 INSERT INTO data_exports (user_id)
+		 VALUES ($1)
+		 RETURNING id, status, created_at, completed_at`, userID,
+	).Scan(&e.ID, &e.Status, &e.CreatedAt, &e.CompletedAt)
+	if err != nil {
+		return nil, fmt.Errorf("create export: %w", err)
+	}
+	return &e, nil
+}
+
+// GetExport returns one of userID's exports. Other users' exports are
+// reported as not found.
+func (s *exportStore) GetExport(ctx context.Context, userID, id string) (*models.Export, error) {
+	var e models.Export
+	err := s.pool.QueryRow(ctx,
+		`SELECT id, status, created_at, completed_at
+		 FROM data_exports
+		 WHERE id = $1 AND user_id = $2`, id, userID,
+	).Scan(&e.ID, &e.Status, &e.CreatedAt, &e.CompletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "export not found"}
+		}
+		return nil, fmt.Errorf("get export: %w", err)
+	}
+	return &e, nil
+}
+
+// GetArchive returns the archive of one of userID's finished exports.
+func (s *exportStore) GetArchive(ctx context.Context, userID, id string) ([]byte, error) {
+	var archive []byte
+	err := s.pool.QueryRow(ctx,
+		`SELECT archive::text
+		 FROM data_exports
+		 WHERE id = $1 AND user_id = $2 AND status = 'ready'`, id, userID,
+	).Scan(&archive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "export not found or not ready"}
+		}
+		return nil, fmt.Errorf("get archive: %w", err)
+	}
+	return archive, nil
+}
+
+// CompleteExport stores the archive of a pending export and marks it ready.
+func (s *exportStore) CompleteExport(ctx context.Context, id string, archive []byte) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE data_exports
+		 SET status = 'ready', archive = $2, completed_at = NOW()
+		 WHERE id = $1 AND status = 'pending'`, id, string(archive),
+	)
+	if err != nil {
+		return fmt.Errorf("complete export: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "export not found"}
+	}
+	return nil
+}
+
+// FailExport marks a pending export failed.
+func (s *exportStore) FailExport(ctx context.Context, id string) error {
+	_, err := s.pool.Exec(ctx,
+		`UPDATE data_exports
+		 SET status = 'failed', completed_at = NOW()
+		 WHERE id = $1 AND status = 'pending'`, id,
+	)
+	if err != nil {
+		return fmt.Errorf("fail export: %w", err)
+	}
+	return nil
+}
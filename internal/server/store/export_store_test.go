@@ -0,0 +1,87 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestExports(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	es := store.NewExportStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	export, err := es.CreateExport(ctx, akram)
+	if err != nil {
+		t.Fatalf("CreateExport: %v", err)
+	}
+	if export.Status != models.ExportPending || export.CompletedAt != nil {
+		t.Errorf("new export = %+v, want pending", export)
+	}
+
+	if _, err := es.GetExport(ctx, sara, export.ID); err == nil {
+		t.Error("expected error reading another user's export")
+	}
+	if _, err := es.GetArchive(ctx, akram, export.ID); err == nil {
+		t.Error("expected error reading the archive of a pending export")
+	}
+
+	if err := es.CompleteExport(ctx, export.ID, []byte(`{"posts": []}`)); err != nil {
+		t.Fatalf("CompleteExport: %v", err)
+	}
+	got, err := es.GetExport(ctx, akram, export.ID)
+	if err != nil {
+		t.Fatalf("GetExport: %v", err)
+	}
+	if got.Status != models.ExportReady || got.CompletedAt == nil {
+		t.Errorf("completed export = %+v, want ready", got)
+	}
+
+	archive, err := es.GetArchive(ctx, akram, export.ID)
+	if err != nil {
+		t.Fatalf("GetArchive: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(archive, &doc); err != nil || doc["posts"] == nil {
+		t.Errorf("archive = %s, want the stored document", archive)
+	}
+	if _, err := es.GetArchive(ctx, sara, export.ID); err == nil {
+		t.Error("expected error reading another user's archive")
+	}
+}
+
+func TestCreateExportFailsAbandonedExports(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	es := store.NewExportStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+
+	abandoned, _ := es.CreateExport(ctx, akram)
+	if _, err := es.CreateExport(ctx, akram); err != nil {
+		t.Fatalf("CreateExport: %v", err)
+	}
+
+	got, err := es.GetExport(ctx, akram, abandoned.ID)
+	if err != nil {
+		t.Fatalf("GetExport: %v", err)
+	}
+	if got.Status != models.ExportFailed {
+		t.Errorf("abandoned export status = %q, want failed", got.Status)
+	}
+
+	if err := es.FailExport(ctx, abandoned.ID); err != nil {
+		t.Fatalf("FailExport: %v", err)
+	}
+	if err := es.CompleteExport(ctx, abandoned.ID, []byte(`{}`)); err == nil {
+		t.Error("expected error completing a failed export")
+	}
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type filterStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewFilterStore(pool *pgxpool.Pool) FilterStore {
+	return &filterStore{pool: pool}
+}
+
+func (s *filterStore) CreateFilter(ctx context.Context, userID, phrase, match, action string, expiresAt *time.Time) (*models.Filter, error) {
+	var f models.Filter
+	err := s.pool.QueryRow(ctx,
+		`INSERT INTO filters (user_id, phrase, match_type, action, expires_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, phrase, match_type, action, expires_at, created_at`,
+		userID, phrase, match, action, expiresAt,
+	).Scan(&f.ID, &f.Phrase, &f.Match, &f.Action, &f.ExpiresAt, &f.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+			return nil, &models.APIError{Code: models.ErrCodeValidation, Message: "invalid filter"}
+		}
+		return nil, fmt.Errorf("create filter: %w", err)
+	}
+	return &f, nil
+}
+
+// GetFilters returns userID's filters that have not expired, newest first.
+func (s *filterStore) GetFilters(ctx context.Context, userID string) ([]models.Filter, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, phrase, match_type, action, expires_at, created_at
+		 FROM filters
+		 WHERE user_id = $1
+		   AND (expires_at IS NULL OR expires_at > NOW())
+		 ORDER BY created_at DESC`, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get filters: %w", err)
+	}
+	defer rows.Close()
+
+	filters := []models.Filter{}
+	for rows.Next() {
+		var f models.Filter
+		if err := rows.Scan(&f.ID, &f.Phrase, &f.Match, &f.Action, &f.ExpiresAt, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan filter: %w", err)
+		}
+		filters = append(filters, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate filters: %w", err)
+	}
+	return filters, nil
+}
+
+// DeleteFilter removes one of userID's filters. Other users' filters are
+// reported as not found.
+func (s *filterStore) DeleteFilter(ctx context.Context, userID, id string) error {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM filters WHERE id = $1 AND user_id = $2`, id, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete filter: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "filter not found"}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestFilters(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	fs := store.NewFilterStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	kept, err := fs.CreateFilter(ctx, akram, "spoiler", models.FilterMatchWord, models.FilterActionFlag, nil)
+	if err != nil {
+		t.Fatalf("CreateFilter: %v", err)
+	}
+	expired := time.Now().Add(-time.Minute)
+	if _, err := fs.CreateFilter(ctx, akram, "old news", models.FilterMatchPhrase, models.FilterActionHide, &expired); err != nil {
+		t.Fatalf("CreateFilter expired: %v", err)
+	}
+	if _, err := fs.CreateFilter(ctx, akram, "x", "glob", models.FilterActionHide, nil); err == nil {
+		t.Error("expected error for unknown match type")
+	}
+
+	filters, err := fs.GetFilters(ctx, akram)
+	if err != nil {
+		t.Fatalf("GetFilters: %v", err)
+	}
+	if len(filters) != 1 || filters[0].ID != kept.ID || filters[0].Match != models.FilterMatchWord {
+		t.Errorf("filters = %+v, want only the unexpired word filter", filters)
+	}
+
+	if err := fs.DeleteFilter(ctx, sara, kept.ID); err == nil {
+		t.Error("expected error deleting another user's filter")
+	}
+	if err := fs.DeleteFilter(ctx, akram, kept.ID); err != nil {
+		t.Fatalf("DeleteFilter: %v", err)
+	}
+	if filters, _ := fs.GetFilters(ctx, akram); len(filters) != 0 {
+		t.Errorf("filters after delete = %+v, want none", filters)
+	}
+}
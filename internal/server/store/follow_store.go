@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type followStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewFollowStore(pool *pgxpool.Pool) FollowStore {
+	return &followStore{pool: pool}
+}
+
+func (s *followStore) Follow(ctx context.Context, followerID, followeeID string) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO follows (follower_id, followee_id)
+		 VALUES ($1, $2)
+		 ON CONFLICT (follower_id, followee_id) DO NOTHING`,
+		followerID, followeeID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503":
+				return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+			case "23514":
+				return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot follow yourself"}
+			}
+		}
+		return fmt.Errorf("follow: %w", err)
+	}
+	return nil
+}
+
+func (s *followStore) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`,
+		followerID, followeeID,
+	)
+	if err != nil {
+		return fmt.Errorf("unfollow: %w", err)
+	}
+	return nil
+}
+
+func (s *followStore) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2
+		 )`, followerID, followeeID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("is following: %w", err)
+	}
+	return exists, nil
+}
+
+func (s *followStore) GetFollowers(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT u.id, u.username, u.display_name, u.bio, u.created_at, f.created_at
+		 FROM follows f
+		 JOIN users u ON f.follower_id = u.id
+		 WHERE f.followee_id = $1
+		   AND f.created_at < $2
+		 ORDER BY f.created_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get followers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFollowEntries(rows)
+}
+
+func (s *followStore) GetFollowing(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT u.id, u.username, u.display_name, u.bio, u.created_at, f.created_at
+		 FROM follows f
+		 JOIN users u ON f.followee_id = u.id
+		 WHERE f.follower_id = $1
+		   AND f.created_at < $2
+		 ORDER BY f.created_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get following: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFollowEntries(rows)
+}
+
+// GetExportFollowers returns a page of userID's followers for a data
+// export. Like GetExportPosts it pages on (created_at, id), where the ID is
+// the follower's, so follows sharing a timestamp are not skipped.
+func (s *followStore) GetExportFollowers(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT u.id, u.username, u.display_name, u.bio, u.created_at, f.created_at
+		 FROM follows f
+		 JOIN users u ON f.follower_id = u.id
+		 WHERE f.followee_id = $1
+		   AND ($3::uuid IS NULL OR (f.created_at, f.follower_id) < ($2, $3::uuid))
+		 ORDER BY f.created_at DESC, f.follower_id DESC
+		 LIMIT $4`, userID, cursor, cursorIDParam(cursorID), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get export followers: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFollowEntries(rows)
+}
+
+// GetExportFollowing returns a page of the users userID follows for a data
+// export, paging on (created_at, id) where the ID is the followed user's.
+func (s *followStore) GetExportFollowing(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT u.id, u.username, u.display_name, u.bio, u.created_at, f.created_at
+		 FROM follows f
+		 JOIN users u ON f.followee_id = u.id
+		 WHERE f.follower_id = $1
+		   AND ($3::uuid IS NULL OR (f.created_at, f.followee_id) < ($2, $3::uuid))
+		 ORDER BY f.created_at DESC, f.followee_id DESC
+		 LIMIT $4`, userID, cursor, cursorIDParam(cursorID), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get export following: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFollowEntries(rows)
+}
+
+func scanFollowEntries(rows pgx.Rows) ([]models.FollowEntry, error) {
+	var entries []models.FollowEntry
+	for rows.Next() {
+		var e models.FollowEntry
+		err := rows.Scan(
+			&e.User.ID, &e.User.Username, &e.User.DisplayName, &e.User.Bio, &e.User.CreatedAt,
+			&e.FollowedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan follow entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate follow entries: %w", err)
+	}
+	return entries, nil
+}
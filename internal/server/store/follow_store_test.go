@@ -0,0 +1,178 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestFollowAndIsFollowing(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	fs := store.NewFollowStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	if err := fs.Follow(ctx, akram, sara); err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+	// Following twice is a no-op
+	if err := fs.Follow(ctx, akram, sara); err != nil {
+		t.Fatalf("second Follow: %v", err)
+	}
+
+	following, err := fs.IsFollowing(ctx, akram, sara)
+	if err != nil {
+		t.Fatalf("IsFollowing: %v", err)
+	}
+	if !following {
+		t.Error("expected akram to follow sara")
+	}
+
+	reverse, err := fs.IsFollowing(ctx, sara, akram)
+	if err != nil {
+		t.Fatalf("IsFollowing reverse: %v", err)
+	}
+	if reverse {
+		t.Error("follows should not be mutual")
+	}
+}
+
+func TestFollowSelf(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	fs := store.NewFollowStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+
+	if err := fs.Follow(ctx, akram, akram); err == nil {
+		t.Fatal("expected error for self-follow")
+	}
+}
+
+func TestFollowNonexistentUser(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	fs := store.NewFollowStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+
+	if err := fs.Follow(ctx, akram, "00000000-0000-0000-0000-000000000000"); err == nil {
+		t.Fatal("expected error for nonexistent followee")
+	}
+}
+
+func TestUnfollow(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	fs := store.NewFollowStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	_ = fs.Follow(ctx, akram, sara)
+	if err := fs.Unfollow(ctx, akram, sara); err != nil {
+		t.Fatalf("Unfollow: %v", err)
+	}
+
+	following, err := fs.IsFollowing(ctx, akram, sara)
+	if err != nil {
+		t.Fatalf("IsFollowing: %v", err)
+	}
+	if following {
+		t.Error("expected follow to be removed")
+	}
+}
+
+func TestGetFollowersAndFollowing(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	fs := store.NewFollowStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	omar := createTestUser(t, us, "omar", "omar@example.com")
+
+	_ = fs.Follow(ctx, sara, akram)
+	time.Sleep(50 * time.Millisecond)
+	_ = fs.Follow(ctx, omar, akram)
+	_ = fs.Follow(ctx, akram, omar)
+
+	followers, err := fs.GetFollowers(ctx, akram, time.Now(), 50)
+	if err != nil {
+		t.Fatalf("GetFollowers: %v", err)
+	}
+	if len(followers) != 2 {
+		t.Fatalf("got %d followers, want 2", len(followers))
+	}
+	// Most recent follower first
+	if followers[0].User.Username != "omar" {
+		t.Errorf("first follower = %q, want %q", followers[0].User.Username, "omar")
+	}
+
+	following, err := fs.GetFollowing(ctx, akram, time.Now(), 50)
+	if err != nil {
+		t.Fatalf("GetFollowing: %v", err)
+	}
+	if len(following) != 1 || following[0].User.ID != omar {
+		t.Errorf("following = %+v, want only omar", following)
+	}
+
+	// Cursor excludes the newer follower
+	page2, err := fs.GetFollowers(ctx, akram, followers[0].FollowedAt, 50)
+	if err != nil {
+		t.Fatalf("GetFollowers page 2: %v", err)
+	}
+	if len(page2) != 1 || page2[0].User.ID != sara {
+		t.Errorf("page 2 = %+v, want only sara", page2)
+	}
+}
+
+func TestGetExportFollowsSharingATimestamp(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	fs := store.NewFollowStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	for _, name := range []string{"sara", "omar", "lina"} {
+		id := createTestUser(t, us, name, name+"@example.com")
+		_ = fs.Follow(ctx, akram, id)
+		_ = fs.Follow(ctx, id, akram)
+	}
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	if _, err := pool.Exec(ctx, `UPDATE follows SET created_at = $1`, at); err != nil {
+		t.Fatalf("set follow times: %v", err)
+	}
+
+	for name, fetch := range map[string]func(time.Time, string) ([]models.FollowEntry, error){
+		"following": func(cursor time.Time, cursorID string) ([]models.FollowEntry, error) {
+			return fs.GetExportFollowing(ctx, akram, cursor, cursorID, 2)
+		},
+		"followers": func(cursor time.Time, cursorID string) ([]models.FollowEntry, error) {
+			return fs.GetExportFollowers(ctx, akram, cursor, cursorID, 2)
+		},
+	} {
+		first, err := fetch(time.Time{}, "")
+		if err != nil || len(first) != 2 {
+			t.Fatalf("%s first page = %+v, %v, want 2 entries", name, first, err)
+		}
+		last := first[len(first)-1]
+		rest, err := fetch(last.FollowedAt, last.User.ID)
+		if err != nil {
+			t.Fatalf("%s second page: %v", name, err)
+		}
+		if len(rest) != 1 || rest[0].User.ID == first[0].User.ID || rest[0].User.ID == first[1].User.ID {
+			t.Errorf("%s second page = %+v, want the one remaining entry", name, rest)
+		}
+	}
+}
@@ -13,19 +13,174 @@ type UserStore interface {
 	GetUserByID(ctx context.Context, id string) (*models.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	UpdateUser(ctx context.Context, id string, updates *models.UserUpdate) (*models.User, error)
+	GetPasswordHash(ctx context.Context, id string) (string, error)
+	SetPasswordHash(ctx context.Context, id, hash string) error
+	GetEmail(ctx context.Context, id string) (string, bool, error) // returns email + whether it is verified
+	GetStatus(ctx context.Context, id string) (string, error)
+	SetStatus(ctx context.Context, id, from, to string) error
+	ListInactive(ctx context.Context) (map[string]string, error)
+	DeletePendingUsers(ctx context.Context, before time.Time) (int64, error)
+	GetRole(ctx context.Context, id string) (string, error)
+	SetRole(ctx context.Context, id, role string) error
+	ListUsers(ctx context.Context, cursor time.Time, limit int) ([]models.AdminUserEntry, error)
 }
 
 type PostStore interface {
 	CreatePost(ctx context.Context, authorID, content string) (*models.Post, error)
-	GetPostByID(ctx context.Context, id string) (*models.Post, error)
-	GetTimeline(ctx context.Context, cursor time.Time, limit int) ([]models.Post, error)
-	GetUserPosts(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error)
+	ImportPosts(ctx context.Context, authorID string, posts []models.Post) (int, error)
+	GetPostByID(ctx context.Context, viewerID, id string) (*models.Post, error)
+	GetTimeline(ctx context.Context, viewerID string, cursor time.Time, limit int) ([]models.Post, error)
+	GetUserPosts(ctx context.Context, viewerID, userID string, cursor time.Time, limit int) ([]models.Post, error)
+	GetExportPosts(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.Post, error)
+	GetHomeTimeline(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error)
+	CreateReply(ctx context.Context, authorID, parentID, content string) (*models.Post, error)
+	GetAncestors(ctx context.Context, viewerID, postID string) ([]models.Post, error)
+	GetDescendants(ctx context.Context, viewerID, postID string, limit int) ([]models.Post, error)
+	CreateRepost(ctx context.Context, authorID, originalID string) (*models.Post, error)
+	DeleteRepost(ctx context.Context, authorID, originalID string) error
+	CountReposts(ctx context.Context, postID string) (int, error)
+	CreateQuote(ctx context.Context, authorID, originalID, content string) (*models.Post, error)
+	EditPost(ctx context.Context, id, content string) error
+	DeletePost(ctx context.Context, id string) error
+	GetEdits(ctx context.Context, postID string) ([]models.PostEdit, error)
+}
+
+type MentionStore interface {
+	SetMentions(ctx context.Context, postID string, usernames []string) error
+	GetMentions(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error)
+}
+
+type TagStore interface {
+	SetTags(ctx context.Context, postID string, tags []string) error
+	GetTagPosts(ctx context.Context, viewerID, tag string, cursor time.Time, limit int) ([]models.Post, error)
+	GetTrending(ctx context.Context, since time.Time, limit int) ([]models.TrendingTag, error)
+}
+
+type SearchStore interface {
+	SearchPosts(ctx context.Context, viewerID, query string, cursor time.Time, limit int) ([]models.Post, error)
+	SearchUsers(ctx context.Context, viewerID, query string, cursor time.Time, limit int) ([]models.User, error)
+}
+
+type NotificationStore interface {
+	NotifyUser(ctx context.Context, userID, actorID, kind string, postID *string) error
+	NotifyPostAuthor(ctx context.Context, actorID, kind, authorOfID, postID string) error
+	NotifyMentioned(ctx context.Context, postID string) error
+	GetNotifications(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Notification, error)
+	CountUnread(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID string, upTo time.Time) error
+}
+
+type ConversationStore interface {
+	CreateConversation(ctx context.Context, memberIDs []string) (string, error)
+	FindDirectConversation(ctx context.Context, userID, otherID string) (string, error)
+	IsMember(ctx context.Context, conversationID, userID string) (bool, error)
+	GetConversation(ctx context.Context, userID, conversationID string) (*models.Conversation, error)
+	GetConversations(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Conversation, error)
+	CreateMessage(ctx context.Context, conversationID, senderID, content string) (*models.Message, error)
+	GetMessages(ctx context.Context, conversationID string, cursor time.Time, limit int) ([]models.Message, error)
+	MarkRead(ctx context.Context, conversationID, userID string) error
+}
+
+type LikeStore interface {
+	Like(ctx context.Context, userID, postID string) error
+	Unlike(ctx context.Context, userID, postID string) error
+	CountLikes(ctx context.Context, postID string) (int, error)
+	GetLikes(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.LikeEntry, error)
+	GetExportLikes(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.LikeEntry, error)
+}
+
+type FollowStore interface {
+	Follow(ctx context.Context, followerID, followeeID string) error
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+	GetFollowers(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error)
+	GetFollowing(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.FollowEntry, error)
+	GetExportFollowers(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error)
+	GetExportFollowing(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.FollowEntry, error)
+}
+
+type BlockStore interface {
+	Block(ctx context.Context, blockerID, blockedID string) error
+	Unblock(ctx context.Context, blockerID, blockedID string) error
+	IsBlocking(ctx context.Context, blockerID, blockedID string) (bool, error)
+	IsBlockedBetween(ctx context.Context, userID, otherID string) (bool, error)
+	GetBlocked(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error)
+}
+
+type MuteStore interface {
+	Mute(ctx context.Context, muterID, mutedID string) error
+	Unmute(ctx context.Context, muterID, mutedID string) error
+	IsMuting(ctx context.Context, muterID, mutedID string) (bool, error)
+	GetMuted(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error)
+}
+
+type FilterStore interface {
+	CreateFilter(ctx context.Context, userID, phrase, match, action string, expiresAt *time.Time) (*models.Filter, error)
+	GetFilters(ctx context.Context, userID string) ([]models.Filter, error)
+	DeleteFilter(ctx context.Context, userID, id string) error
+}
+
+type ReportStore interface {
+	CreatePostReport(ctx context.Context, reporterID, postID, reason, details string) (*models.Report, error)
+	CreateUserReport(ctx context.Context, reporterID, userID, reason, details string) (*models.Report, error)
+	GetReports(ctx context.Context, status string, cursor time.Time, limit int) ([]models.Report, error)
+}
+
+type ModerationStore interface {
+	GetPostAuthor(ctx context.Context, postID string) (string, error)
+	HidePost(ctx context.Context, moderatorID, postID, reason string) error
+	SuspendUser(ctx context.Context, moderatorID, userID, reason string) error
+	UnsuspendUser(ctx context.Context, moderatorID, userID, reason string) error
+	DismissReport(ctx context.Context, moderatorID, reportID, reason string) error
+	GetLog(ctx context.Context, cursor time.Time, limit int) ([]models.ModerationAction, error)
+}
+
+type ExportStore interface {
+	CreateExport(ctx context.Context, userID string) (*models.Export, error)
+	GetExport(ctx context.Context, userID, id string) (*models.Export, error)
+	GetArchive(ctx context.Context, userID, id string) ([]byte, error)
+	CompleteExport(ctx context.Context, id string, archive []byte) error
+	FailExport(ctx context.Context, id string) error
 }
 
 type RefreshTokenStore interface {
-	StoreToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
-	GetByHash(ctx context.Context, tokenHash string) (id, userID string, expiresAt time.Time, err error)
+	StoreToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time, device models.Device) (string, error)
+	RotateToken(ctx context.Context, id, newHash string, expiresAt time.Time, device models.Device) error
+	DeleteFamily(ctx context.Context, familyID string) error
+	ListSessions(ctx context.Context, userID string) ([]models.Session, error)
+	DeleteSession(ctx context.Context, userID, id string) error
+	GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
 	DeleteByHash(ctx context.Context, tokenHash string) error
 	DeleteAllForUser(ctx context.Context, userID string) error
+	DeleteOtherSessions(ctx context.Context, userID, keepID string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type PasswordResetStore interface {
+	CreateReset(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+	ConsumeReset(ctx context.Context, tokenHash string) (string, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type EmailVerificationStore interface {
+	CreateVerification(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error
+	VerifyEmail(ctx context.Context, tokenHash string) (string, error)
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type TwoFactorStore interface {
+	SetPendingSecret(ctx context.Context, userID, secret string) error
+	GetSecret(ctx context.Context, userID string) (string, bool, error) // returns secret + whether it is enabled
+	Enable(ctx context.Context, userID string, step int64, recoveryHashes []string) error
+	Disable(ctx context.Context, userID string) error
+	UseStep(ctx context.Context, userID string, step int64) error
+	UseRecoveryCode(ctx context.Context, userID, codeHash string) error
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+}
+
+type LoginChallengeStore interface {
+	CreateChallenge(ctx context.Context, userID, tokenHash string, reactivate bool, expiresAt time.Time) error
+	AttemptChallenge(ctx context.Context, tokenHash string, maxAttempts int) (string, bool, error) // returns user ID + reactivate
+	DeleteChallenge(ctx context.Context, tokenHash string) error
 	DeleteExpired(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type likeStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewLikeStore(pool *pgxpool.Pool) LikeStore {
+	return &likeStore{pool: pool}
+}
+
+func (s *likeStore) Like(ctx context.Context, userID, postID string) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO likes (user_id, post_id)
+		 VALUES ($1, $2)
+		 ON CONFLICT (user_id, post_id) DO NOTHING`,
+		userID, postID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+		}
+		return fmt.Errorf("like: %w", err)
+	}
+	return nil
+}
+
+func (s *likeStore) Unlike(ctx context.Context, userID, postID string) error {
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM likes WHERE user_id = $1 AND post_id = $2`,
+		userID, postID,
+	)
+	if err != nil {
+		return fmt.Errorf("unlike: %w", err)
+	}
+	return nil
+}
+
+func (s *likeStore) CountLikes(ctx context.Context, postID string) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM likes WHERE post_id = $1`, postID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count likes: %w", err)
+	}
+	return count, nil
+}
+
+// GetLikes returns the posts userID liked, most recently liked first.
+// Deleted posts are left out.
+func (s *likeStore) GetLikes(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.LikeEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT p.id, p.author_id, p.kind, p.content, p.edited_at, p.created_at,
+		        u.id, u.username, u.display_name, u.bio, u.created_at, l.created_at
+		 FROM likes l
+		 JOIN posts p ON p.id = l.post_id
+		 JOIN users u ON u.id = p.author_id
+		 WHERE l.user_id = $1
+		   AND l.created_at < $2
+		   AND p.deleted_at IS NULL
+		 ORDER BY l.created_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get likes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLikeEntries(rows)
+}
+
+// GetExportLikes returns a page of the posts userID liked for a data
+// export. Like GetExportPosts it pages on (created_at, id), where the ID is
+// the liked post's, so likes sharing a timestamp are not skipped.
+func (s *likeStore) GetExportLikes(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.LikeEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT p.id, p.author_id, p.kind, p.content, p.edited_at, p.created_at,
+		        u.id, u.username, u.display_name, u.bio, u.created_at, l.created_at
+		 FROM likes l
+		 JOIN posts p ON p.id = l.post_id
+		 JOIN users u ON u.id = p.author_id
+		 WHERE l.user_id = $1
+		   AND ($3::uuid IS NULL OR (l.created_at, l.post_id) < ($2, $3::uuid))
+		   AND p.deleted_at IS NULL
+		 ORDER BY l.created_at DESC, l.post_id DESC
+		 LIMIT $4`, userID, cursor, cursorIDParam(cursorID), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get export likes: %w", err)
+	}
+	defer rows.Close()
+
+	return scanLikeEntries(rows)
+}
+
+func scanLikeEntries(rows pgx.Rows) ([]models.LikeEntry, error) {
+	var entries []models.LikeEntry
+	for rows.Next() {
+		var e models.LikeEntry
+		var author models.User
+		if err := rows.Scan(&e.Post.ID, &e.Post.AuthorID, &e.Post.Kind, &e.Post.Content, &e.Post.EditedAt, &e.Post.CreatedAt,
+			&author.ID, &author.Username, &author.DisplayName, &author.Bio, &author.CreatedAt, &e.LikedAt); err != nil {
+			return nil, fmt.Errorf("scan like: %w", err)
+		}
+		e.Post.Author = &author
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate likes: %w", err)
+	}
+	return entries, nil
+}
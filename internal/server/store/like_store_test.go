@@ -0,0 +1,172 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestLikeAndCount(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ls := store.NewLikeStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	post, _ := ps.CreatePost(ctx, akram, "Like me")
+
+	if err := ls.Like(ctx, sara, post.ID); err != nil {
+		t.Fatalf("Like: %v", err)
+	}
+	// Liking twice is idempotent
+	if err := ls.Like(ctx, sara, post.ID); err != nil {
+		t.Fatalf("Like again: %v", err)
+	}
+	if err := ls.Like(ctx, akram, post.ID); err != nil {
+		t.Fatalf("Like by author: %v", err)
+	}
+
+	count, err := ls.CountLikes(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("CountLikes: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("count = %d, want 2", count)
+	}
+
+	if err := ls.Unlike(ctx, akram, post.ID); err != nil {
+		t.Fatalf("Unlike: %v", err)
+	}
+	count, _ = ls.CountLikes(ctx, post.ID)
+	if count != 1 {
+		t.Errorf("count after unlike = %d, want 1", count)
+	}
+}
+
+func TestLikeNonexistentPost(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ls := store.NewLikeStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+
+	if err := ls.Like(ctx, userID, "00000000-0000-0000-0000-000000000000"); err == nil {
+		t.Fatal("expected error liking a nonexistent post")
+	}
+}
+
+func TestPostsCarryLikeState(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ls := store.NewLikeStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	post, _ := ps.CreatePost(ctx, akram, "Like me")
+	_ = ls.Like(ctx, sara, post.ID)
+
+	got, err := ps.GetPostByID(ctx, sara, post.ID)
+	if err != nil {
+		t.Fatalf("GetPostByID: %v", err)
+	}
+	if got.LikeCount != 1 || !got.LikedByMe {
+		t.Errorf("sara sees like_count=%d liked_by_me=%v, want 1 true", got.LikeCount, got.LikedByMe)
+	}
+
+	timeline, err := ps.GetTimeline(ctx, akram, time.Now(), 10)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	if len(timeline) != 1 || timeline[0].LikeCount != 1 || timeline[0].LikedByMe {
+		t.Errorf("akram sees %+v, want like_count=1 liked_by_me=false", timeline)
+	}
+
+	anon, err := ps.GetPostByID(ctx, "", post.ID)
+	if err != nil {
+		t.Fatalf("GetPostByID anonymous: %v", err)
+	}
+	if anon.LikedByMe {
+		t.Error("anonymous viewer should not see liked_by_me")
+	}
+}
+
+func TestGetLikes(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ls := store.NewLikeStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	first, _ := ps.CreatePost(ctx, akram, "First")
+	second, _ := ps.CreatePost(ctx, akram, "Second")
+	gone, _ := ps.CreatePost(ctx, akram, "Gone")
+
+	for _, id := range []string{first.ID, second.ID, gone.ID} {
+		if err := ls.Like(ctx, sara, id); err != nil {
+			t.Fatalf("Like: %v", err)
+		}
+	}
+	if err := ps.DeletePost(ctx, gone.ID); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+
+	likes, err := ls.GetLikes(ctx, sara, time.Now().Add(time.Second), 10)
+	if err != nil {
+		t.Fatalf("GetLikes: %v", err)
+	}
+	if len(likes) != 2 || likes[0].Post.ID != second.ID || likes[1].Post.ID != first.ID {
+		t.Fatalf("likes = %+v, want second then first", likes)
+	}
+	if likes[0].Post.Content != "Second" || likes[0].Post.Author == nil || likes[0].Post.Author.Username != "akram" {
+		t.Errorf("liked post = %+v, want content and author", likes[0].Post)
+	}
+
+	page, err := ls.GetLikes(ctx, sara, likes[0].LikedAt, 10)
+	if err != nil {
+		t.Fatalf("GetLikes page 2: %v", err)
+	}
+	if len(page) != 1 || page[0].Post.ID != first.ID {
+		t.Errorf("second page = %+v, want only first", page)
+	}
+}
+
+func TestGetExportLikesSharingATimestamp(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ls := store.NewLikeStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	for _, content := range []string{"one", "two", "three"} {
+		post, _ := ps.CreatePost(ctx, akram, content)
+		_ = ls.Like(ctx, sara, post.ID)
+	}
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	if _, err := pool.Exec(ctx, `UPDATE likes SET created_at = $1`, at); err != nil {
+		t.Fatalf("set like times: %v", err)
+	}
+
+	first, err := ls.GetExportLikes(ctx, sara, time.Time{}, "", 2)
+	if err != nil || len(first) != 2 {
+		t.Fatalf("first page = %+v, %v, want 2 likes", first, err)
+	}
+	last := first[len(first)-1]
+	rest, err := ls.GetExportLikes(ctx, sara, last.LikedAt, last.Post.ID, 2)
+	if err != nil {
+		t.Fatalf("second page: %v", err)
+	}
+	if len(rest) != 1 || rest[0].Post.ID == first[0].Post.ID || rest[0].Post.ID == first[1].Post.ID {
+		t.Errorf("second page = %+v, want the one remaining like", rest)
+	}
+}
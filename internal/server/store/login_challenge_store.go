@@ -0,0 +1,78 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type loginChallengeStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewLoginChallengeStore(pool *pgxpool.Pool) LoginChallengeStore {
+	return &loginChallengeStore{pool: pool}
+}
+
+func (s *loginChallengeStore) CreateChallenge(ctx context.Context, userID, tokenHash string, reactivate bool, expiresAt time.Time) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO login_challenges (user_id, token_hash, reactivate, expires_at)
+		 VALUES ($1, $2, $3, $4)`,
+		userID, tokenHash, reactivate, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create login challenge: %w", err)
+	}
+	return nil
+}
+
+// AttemptChallenge counts an attempt at an unexpired challenge and returns
+// its user's ID and whether it reactivates their account. A challenge
+// stops working after maxAttempts.
+func (s *loginChallengeStore) AttemptChallenge(ctx context.Context, tokenHash string, maxAttempts int) (string, bool, error) {
+	var userID string
+	var reactivate bool
+	err := s.pool.QueryRow(ctx,
+		`UPDATE login_challenges SET attempts = attempts + 1
+		 WHERE token_hash = $1 AND expires_at > NOW() AND attempts < $2
+		 RETURNING user_id, reactivate`,
+		tokenHash, maxAttempts,
+	).Scan(&userID, &reactivate)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, &models.APIError{Code: models.ErrCodeUnauthorized, Field: "challenge_token", Message: "login challenge is invalid or expired; sign in again"}
+		}
+		return "", false, fmt.Errorf("attempt login challenge: %w", err)
+	}
+	return userID, reactivate, nil
+}
+
+// DeleteChallenge ends a challenge once it is passed. Only one request can
+// delete it, so a challenge completes one sign-in.
+func (s *loginChallengeStore) DeleteChallenge(ctx context.Context, tokenHash string) error {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM login_challenges WHERE token_hash = $1`, tokenHash,
+	)
+	if err != nil {
+		return fmt.Errorf("delete login challenge: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "challenge_token", Message: "login challenge is invalid or expired; sign in again"}
+	}
+	return nil
+}
+
+func (s *loginChallengeStore) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM login_challenges WHERE expires_at < NOW()`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired login challenges: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
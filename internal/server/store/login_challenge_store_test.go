@@ -0,0 +1,119 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestLoginChallenges(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	cs := store.NewLoginChallengeStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	var apiErr *models.APIError
+
+	if err := cs.CreateChallenge(ctx, userID, "challenge1", true, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+	for i := range 2 {
+		got, reactivate, err := cs.AttemptChallenge(ctx, "challenge1", 2)
+		if err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+		if got != userID || !reactivate {
+			t.Errorf("attempt %d = %q, %v, want %q, true", i+1, got, reactivate, userID)
+		}
+	}
+	if _, _, err := cs.AttemptChallenge(ctx, "challenge1", 2); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("attempt past the limit error = %v, want unauthorized", err)
+	}
+
+	_ = cs.CreateChallenge(ctx, userID, "challenge2", false, time.Now().Add(time.Minute))
+	if err := cs.DeleteChallenge(ctx, "challenge2"); err != nil {
+		t.Fatalf("DeleteChallenge: %v", err)
+	}
+	if err := cs.DeleteChallenge(ctx, "challenge2"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("delete twice error = %v, want unauthorized", err)
+	}
+
+	_ = cs.CreateChallenge(ctx, userID, "expired", false, time.Now().Add(-time.Minute))
+	if _, _, err := cs.AttemptChallenge(ctx, "expired", 5); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("expired challenge error = %v, want unauthorized", err)
+	}
+	deleted, err := cs.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+}
+
+func TestConsumedLoginChallenge(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	cs := store.NewLoginChallengeStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	var apiErr *models.APIError
+
+	if err := cs.CreateChallenge(ctx, userID, "challengehash1", true, time.Now().Add(5*time.Minute)); err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+	got, reactivate, err := cs.AttemptChallenge(ctx, "challengehash1", 5)
+	if err != nil {
+		t.Fatalf("AttemptChallenge: %v", err)
+	}
+	if got != userID || !reactivate {
+		t.Errorf("challenge = %q, %v, want %q reactivating", got, reactivate, userID)
+	}
+
+	if err := cs.DeleteChallenge(ctx, "challengehash1"); err != nil {
+		t.Fatalf("DeleteChallenge: %v", err)
+	}
+	// A challenge completes one sign-in
+	if err := cs.DeleteChallenge(ctx, "challengehash1"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("second delete error = %v, want unauthorized", err)
+	}
+	if _, _, err := cs.AttemptChallenge(ctx, "challengehash1", 5); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("deleted challenge error = %v, want unauthorized", err)
+	}
+	if _, _, err := cs.AttemptChallenge(ctx, "unknownhash", 5); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("unknown challenge error = %v, want unauthorized", err)
+	}
+}
+
+func TestDeleteExpiredKeepsLiveChallenges(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	cs := store.NewLoginChallengeStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	var apiErr *models.APIError
+
+	_ = cs.CreateChallenge(ctx, userID, "expiredhash", false, time.Now().Add(-time.Minute))
+	_ = cs.CreateChallenge(ctx, userID, "livehash", false, time.Now().Add(5*time.Minute))
+	if _, _, err := cs.AttemptChallenge(ctx, "expiredhash", 5); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("expired challenge error = %v, want unauthorized", err)
+	}
+
+	deleted, err := cs.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+	if _, _, err := cs.AttemptChallenge(ctx, "livehash", 5); err != nil {
+		t.Errorf("unexpired challenge after cleanup: %v", err)
+	}
+}
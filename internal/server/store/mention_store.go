@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type mentionStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewMentionStore(pool *pgxpool.Pool) MentionStore {
+	return &mentionStore{pool: pool}
+}
+
+// SetMentions makes postID mention exactly the existing users in
+// usernames, dropping anyone it no longer mentions. Unknown usernames, the
+// post's own author and users blocked either way by the author are skipped.
+func (s *mentionStore) SetMentions(ctx context.Context, postID string, usernames []string) error {
+	if usernames == nil {
+		// A nil slice is sent as NULL, which would match no mention to drop
+		usernames = []string{}
+	}
+	_, err := s.pool.Exec(ctx,
+		`WITH dropped AS (
+		     DELETE FROM mentions m
+		     USING users u
+		     WHERE m.post_id = $1 AND u.id = m.user_id
+		       AND NOT u.username = ANY($2)
+		 )
+		 INSERT INTO mentions (post_id, user_id)
+		 SELECT p.id, u.id
+		 FROM posts p
+		 JOIN users u ON u.username = ANY($2) AND u.id <> p.author_id
+		 WHERE p.id = $1
+		   AND NOT `+blockedBetween(`u.id`, `p.author_id`)+`
+		 ON CONFLICT (post_id, user_id) DO NOTHING`,
+		postID, usernames,
+	)
+	if err != nil {
+		return fmt.Errorf("set mentions: %w", err)
+	}
+	return nil
+}
+
+// GetMentions returns posts that mention userID, newest first.
+func (s *mentionStore) GetMentions(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 JOIN mentions m ON m.post_id = p.id AND m.user_id = $1
+		 WHERE p.created_at < $2
+		   AND p.deleted_at IS NULL
+		   AND `+notHidden+`
+		 ORDER BY p.created_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get mentions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
@@ -0,0 +1,58 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestMentionsFeed(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ms := store.NewMentionStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	first, _ := ps.CreatePost(ctx, akram, "Hi @sara")
+	if err := ms.SetMentions(ctx, first.ID, []string{"sara", "nobody", "akram"}); err != nil {
+		t.Fatalf("SetMentions: %v", err)
+	}
+	second, _ := ps.CreatePost(ctx, akram, "@sara again")
+	if err := ms.SetMentions(ctx, second.ID, []string{"sara"}); err != nil {
+		t.Fatalf("SetMentions: %v", err)
+	}
+	if _, err := ps.CreatePost(ctx, akram, "No mentions"); err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+
+	posts, err := ms.GetMentions(ctx, sara, time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetMentions: %v", err)
+	}
+	if len(posts) != 2 || posts[0].ID != second.ID || posts[1].ID != first.ID {
+		t.Fatalf("mentions feed = %+v, want both mentions newest first", posts)
+	}
+
+	// Unknown users and the author's self-mention are skipped
+	if len(posts[1].Mentions) != 1 || posts[1].Mentions[0].UserID != sara {
+		t.Errorf("mentions = %+v, want only sara", posts[1].Mentions)
+	}
+
+	self, _ := ms.GetMentions(ctx, akram, time.Now().Add(time.Second), 50)
+	if len(self) != 0 {
+		t.Errorf("akram has %d mentions, want 0", len(self))
+	}
+
+	// Setting mentions again drops the users no longer mentioned
+	if err := ms.SetMentions(ctx, first.ID, nil); err != nil {
+		t.Fatalf("SetMentions: %v", err)
+	}
+	if posts, _ := ms.GetMentions(ctx, sara, time.Now().Add(time.Second), 50); len(posts) != 1 || posts[0].ID != second.ID {
+		t.Errorf("mentions feed after edit = %+v, want only the second post", posts)
+	}
+}
@@ -0,0 +1,178 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// moderationStore applies moderators' actions. Each action and its entry in
+// the moderation log are written by a single statement, so the log cannot
+// miss an action or record one that did not happen.
+type moderationStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewModerationStore(pool *pgxpool.Pool) ModerationStore {
+	return &moderationStore{pool: pool}
+}
+
+// GetPostAuthor returns the ID of the author of postID, which must not
+// already be deleted or hidden.
+func (s *moderationStore) GetPostAuthor(ctx context.Context, postID string) (string, error) {
+	var authorID string
+	err := s.pool.QueryRow(ctx,
+		`SELECT author_id FROM posts WHERE id = $1 AND deleted_at IS NULL`, postID,
+	).Scan(&authorID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	if err != nil {
+		return "", fmt.Errorf("get post author: %w", err)
+	}
+	return authorID, nil
+}
+
+// HidePost removes postID the way its author deleting it would, and
+// resolves the open reports about it.
+func (s *moderationStore) HidePost(ctx context.Context, moderatorID, postID, reason string) error {
+	tag, err := s.pool.Exec(ctx,
+		`WITH hidden AS (
+		     UPDATE posts SET deleted_at = NOW()
+		     WHERE id = $2 AND deleted_at IS NULL
+		     RETURNING id, author_id
+		 ), resolved AS (
+		     UPDATE reports SET status = 'resolved', resolved_by = $1, resolved_at = NOW()
+		     WHERE post_id IN (SELECT id FROM hidden) AND status = 'open'
+		 )
+		 INSERT INTO moderation_log (moderator_id, action, user_id, post_id, reason)
+		 SELECT $1, 'hide_post', author_id, id, $3 FROM hidden`,
+		moderatorID, postID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("hide post: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	return nil
+}
+
+// SuspendUser suspends userID and resolves the open reports about them and
+// their posts. Only active accounts can be suspended, so unsuspending never
+// cancels a scheduled deletion or reactivates a deactivated account.
+func (s *moderationStore) SuspendUser(ctx context.Context, moderatorID, userID, reason string) error {
+	tag, err := s.pool.Exec(ctx,
+		`WITH suspended AS (
+		     UPDATE users SET status = 'suspended', status_changed_at = NOW()
+		     WHERE id = $2 AND status = 'active'
+		     RETURNING id
+		 ), resolved AS (
+		     UPDATE reports SET status = 'resolved', resolved_by = $1, resolved_at = NOW()
+		     WHERE user_id IN (SELECT id FROM suspended) AND status = 'open'
+		 )
+		 INSERT INTO moderation_log (moderator_id, action, user_id, reason)
+		 SELECT $1, 'suspend_user', id, $3 FROM suspended`,
+		moderatorID, userID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("suspend user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return s.suspensionUnchanged(ctx, userID, models.AccountActive)
+	}
+	return nil
+}
+
+func (s *moderationStore) UnsuspendUser(ctx context.Context, moderatorID, userID, reason string) error {
+	tag, err := s.pool.Exec(ctx,
+		`WITH restored AS (
+		     UPDATE users SET status = 'active', status_changed_at = NOW()
+		     WHERE id = $2 AND status = 'suspended'
+		     RETURNING id
+		 )
+		 INSERT INTO moderation_log (moderator_id, action, user_id, reason)
+		 SELECT $1, 'unsuspend_user', id, $3 FROM restored`,
+		moderatorID, userID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("unsuspend user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return s.suspensionUnchanged(ctx, userID, models.AccountSuspended)
+	}
+	return nil
+}
+
+// suspensionUnchanged explains why a suspension change matched no user:
+// either the user does not exist or their account is not in status from.
+func (s *moderationStore) suspensionUnchanged(ctx context.Context, userID, from string) error {
+	var status string
+	if err := s.pool.QueryRow(ctx,
+		`SELECT status FROM users WHERE id = $1`, userID,
+	).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		}
+		return fmt.Errorf("check user: %w", err)
+	}
+	return &models.APIError{
+		Code:    models.ErrCodeConflict,
+		Message: fmt.Sprintf("account is %s, not %s", strings.ReplaceAll(status, "_", " "), from),
+	}
+}
+
+// DismissReport closes an open report without acting on it.
+func (s *moderationStore) DismissReport(ctx context.Context, moderatorID, reportID, reason string) error {
+	tag, err := s.pool.Exec(ctx,
+		`WITH dismissed AS (
+		     UPDATE reports SET status = 'dismissed', resolved_by = $1, resolved_at = NOW()
+		     WHERE id = $2 AND status = 'open'
+		     RETURNING id, user_id, post_id
+		 )
+		 INSERT INTO moderation_log (moderator_id, action, user_id, post_id, report_id, reason)
+		 SELECT $1, 'dismiss_report', user_id, post_id, id, $3 FROM dismissed`,
+		moderatorID, reportID, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("dismiss report: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "open report not found"}
+	}
+	return nil
+}
+
+// GetLog returns the moderation log, newest first.
+func (s *moderationStore) GetLog(ctx context.Context, cursor time.Time, limit int) ([]models.ModerationAction, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, moderator_id, action, user_id, post_id, report_id, reason, created_at
+		 FROM moderation_log
+		 WHERE created_at < $1
+		 ORDER BY created_at DESC
+		 LIMIT $2`, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get moderation log: %w", err)
+	}
+	defer rows.Close()
+
+	actions := []models.ModerationAction{}
+	for rows.Next() {
+		var a models.ModerationAction
+		if err := rows.Scan(&a.ID, &a.ModeratorID, &a.Action, &a.UserID, &a.PostID, &a.ReportID, &a.Reason, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan moderation action: %w", err)
+		}
+		actions = append(actions, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate moderation log: %w", err)
+	}
+	return actions, nil
+}
@@ -0,0 +1,116 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestReportsAndModerationLog(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	rs := store.NewReportStore(pool)
+	ms := store.NewModerationStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	mod := createTestUser(t, us, "mod", "mod@example.com")
+
+	post, _ := ps.CreatePost(ctx, sara, "Buy followers now")
+	report, err := rs.CreatePostReport(ctx, akram, post.ID, models.ReportReasonSpam, "")
+	if err != nil {
+		t.Fatalf("CreatePostReport: %v", err)
+	}
+	if report.UserID != sara {
+		t.Errorf("report user = %q, want the post's author", report.UserID)
+	}
+	if _, err := rs.CreatePostReport(ctx, akram, post.ID, models.ReportReasonSpam, ""); err == nil {
+		t.Error("expected error for duplicate open report")
+	}
+	if _, err := rs.CreatePostReport(ctx, sara, post.ID, models.ReportReasonSpam, ""); err == nil {
+		t.Error("expected error for reporting own post")
+	}
+	userReport, err := rs.CreateUserReport(ctx, akram, sara, models.ReportReasonHarassment, "keeps replying")
+	if err != nil {
+		t.Fatalf("CreateUserReport: %v", err)
+	}
+
+	cursor := time.Now().Add(time.Second)
+	queue, err := rs.GetReports(ctx, models.ReportStatusOpen, cursor, 50)
+	if err != nil {
+		t.Fatalf("GetReports: %v", err)
+	}
+	if len(queue) != 2 || queue[1].Post == nil || queue[1].Post.Content != "Buy followers now" || queue[0].Reporter.Username != "akram" {
+		t.Errorf("queue = %+v, want both reports with the post's content", queue)
+	}
+
+	if author, err := ms.GetPostAuthor(ctx, post.ID); err != nil || author != sara {
+		t.Errorf("GetPostAuthor = %q, %v, want sara", author, err)
+	}
+	if err := ms.HidePost(ctx, mod, post.ID, "spam"); err != nil {
+		t.Fatalf("HidePost: %v", err)
+	}
+	if err := ms.HidePost(ctx, mod, post.ID, "spam"); err == nil {
+		t.Error("expected error hiding an already hidden post")
+	}
+	if _, err := ms.GetPostAuthor(ctx, post.ID); err == nil {
+		t.Error("expected error for the author of a hidden post")
+	}
+	if got, _ := ps.GetPostByID(ctx, akram, post.ID); got == nil || !got.Deleted {
+		t.Errorf("hidden post = %+v, want it removed", got)
+	}
+
+	if err := ms.DismissReport(ctx, mod, userReport.ID, ""); err != nil {
+		t.Fatalf("DismissReport: %v", err)
+	}
+	if open, _ := rs.GetReports(ctx, models.ReportStatusOpen, cursor, 50); len(open) != 0 {
+		t.Errorf("open reports = %+v, want none left", open)
+	}
+
+	if err := ms.SuspendUser(ctx, mod, sara, "spam"); err != nil {
+		t.Fatalf("SuspendUser: %v", err)
+	}
+	if status, _ := us.GetStatus(ctx, sara); status != models.AccountSuspended {
+		t.Errorf("status = %q, want %q", status, models.AccountSuspended)
+	}
+	if inactive, _ := us.ListInactive(ctx); inactive[sara] != models.AccountSuspended {
+		t.Errorf("inactive = %v, want sara suspended", inactive)
+	}
+	if err := ms.SuspendUser(ctx, mod, sara, ""); err == nil {
+		t.Error("expected conflict suspending twice")
+	}
+	if err := ms.UnsuspendUser(ctx, mod, sara, ""); err != nil {
+		t.Fatalf("UnsuspendUser: %v", err)
+	}
+
+	// A deactivated account stays deactivated rather than being suspended
+	// and later unsuspended into active
+	if err := us.SetStatus(ctx, akram, models.AccountActive, models.AccountDeactivated); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if err := ms.SuspendUser(ctx, mod, akram, ""); err == nil {
+		t.Error("expected conflict suspending a deactivated account")
+	}
+	if status, _ := us.GetStatus(ctx, akram); status != models.AccountDeactivated {
+		t.Errorf("status = %q, want %q", status, models.AccountDeactivated)
+	}
+
+	log, err := ms.GetLog(ctx, cursor, 50)
+	if err != nil {
+		t.Fatalf("GetLog: %v", err)
+	}
+	want := []string{models.ModerationUnsuspendUser, models.ModerationSuspendUser, models.ModerationDismissReport, models.ModerationHidePost}
+	if len(log) != len(want) {
+		t.Fatalf("log = %+v, want %v", log, want)
+	}
+	for i, action := range want {
+		if log[i].Action != action {
+			t.Errorf("log[%d] = %q, want %q", i, log[i].Action, action)
+		}
+	}
+}
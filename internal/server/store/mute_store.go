@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type muteStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewMuteStore(pool *pgxpool.Pool) MuteStore {
+	return &muteStore{pool: pool}
+}
+
+// Mute hides mutedID's posts and notifications from muterID without
+// telling mutedID or touching follows.
+func (s *muteStore) Mute(ctx context.Context, muterID, mutedID string) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO mutes (muter_id, muted_id)
+		 VALUES ($1, $2)
+		 ON CONFLICT (muter_id, muted_id) DO NOTHING`,
+		muterID, mutedID,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503":
+				return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+			case "23514":
+				return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot mute yourself"}
+			}
+		}
+		return fmt.Errorf("mute: %w", err)
+	}
+	return nil
+}
+
+func (s *muteStore) Unmute(ctx context.Context, muterID, mutedID string) error {
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM mutes WHERE muter_id = $1 AND muted_id = $2`,
+		muterID, mutedID,
+	)
+	if err != nil {
+		return fmt.Errorf("unmute: %w", err)
+	}
+	return nil
+}
+
+func (s *muteStore) IsMuting(ctx context.Context, muterID, mutedID string) (bool, error) {
+	var exists bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM mutes WHERE muter_id = $1 AND muted_id = $2
+		 )`, muterID, mutedID,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("is muting: %w", err)
+	}
+	return exists, nil
+}
+
+// GetMuted returns the users userID has muted, most recent first.
+func (s *muteStore) GetMuted(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.RelationEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT u.id, u.username, u.display_name, u.bio, u.created_at, m.created_at
+		 FROM mutes m
+		 JOIN users u ON m.muted_id = u.id
+		 WHERE m.muter_id = $1
+		   AND m.created_at < $2
+		 ORDER BY m.created_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get muted: %w", err)
+	}
+	defer rows.Close()
+
+	return scanRelationEntries(rows)
+}
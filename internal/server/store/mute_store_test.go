@@ -0,0 +1,128 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestMuteAndUnmute(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	mutes := store.NewMuteStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	omar := createTestUser(t, us, "omar", "omar@example.com")
+
+	if err := mutes.Mute(ctx, akram, sara); err != nil {
+		t.Fatalf("Mute: %v", err)
+	}
+	// Muting again is a no-op
+	if err := mutes.Mute(ctx, akram, sara); err != nil {
+		t.Errorf("second Mute: %v", err)
+	}
+	if err := mutes.Mute(ctx, akram, omar); err != nil {
+		t.Fatalf("Mute omar: %v", err)
+	}
+	if err := mutes.Mute(ctx, akram, akram); err == nil {
+		t.Error("expected error for self-mute")
+	}
+	if err := mutes.Mute(ctx, akram, "00000000-0000-0000-0000-000000000000"); err == nil {
+		t.Error("expected error for muting a missing user")
+	}
+
+	cursor := time.Now().Add(time.Second)
+	muted, err := mutes.GetMuted(ctx, akram, cursor, 50)
+	if err != nil {
+		t.Fatalf("GetMuted: %v", err)
+	}
+	if len(muted) != 2 || muted[0].User.ID != omar || muted[1].User.ID != sara {
+		t.Errorf("muted = %+v, want omar then sara", muted)
+	}
+	if page, _ := mutes.GetMuted(ctx, akram, cursor, 1); len(page) != 1 {
+		t.Errorf("limited page has %d entries, want 1", len(page))
+	}
+	if muting, _ := mutes.IsMuting(ctx, sara, akram); muting {
+		t.Error("muting should be one-way")
+	}
+
+	if err := mutes.Unmute(ctx, akram, sara); err != nil {
+		t.Fatalf("Unmute: %v", err)
+	}
+	// Unmuting someone who isn't muted is a no-op
+	if err := mutes.Unmute(ctx, akram, sara); err != nil {
+		t.Errorf("second Unmute: %v", err)
+	}
+	if muting, _ := mutes.IsMuting(ctx, akram, sara); muting {
+		t.Error("IsMuting after Unmute = true, want false")
+	}
+	muted, _ = mutes.GetMuted(ctx, akram, cursor, 50)
+	if len(muted) != 1 || muted[0].User.ID != omar {
+		t.Errorf("muted after unmute = %+v, want omar", muted)
+	}
+}
+
+func TestMuteHidesPostsAndNotifications(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ms := store.NewMentionStore(pool)
+	ns := store.NewNotificationStore(pool)
+	ss := store.NewSearchStore(pool)
+	mutes := store.NewMuteStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	if err := mutes.Mute(ctx, akram, sara); err != nil {
+		t.Fatalf("Mute: %v", err)
+	}
+
+	post, _ := ps.CreatePost(ctx, sara, "Hello @akram")
+	if err := ms.SetMentions(ctx, post.ID, []string{"akram"}); err != nil {
+		t.Fatalf("SetMentions: %v", err)
+	}
+	if err := ns.NotifyMentioned(ctx, post.ID); err != nil {
+		t.Fatalf("NotifyMentioned: %v", err)
+	}
+	if err := ns.NotifyUser(ctx, akram, sara, models.NotificationKindFollow, nil); err != nil {
+		t.Fatalf("NotifyUser: %v", err)
+	}
+
+	cursor := time.Now().Add(time.Second)
+	if notes, _ := ns.GetNotifications(ctx, akram, cursor, 50); len(notes) != 0 {
+		t.Errorf("notifications = %+v, want none from a muted user", notes)
+	}
+	if posts, _ := ps.GetTimeline(ctx, akram, cursor, 50); len(posts) != 0 {
+		t.Errorf("timeline = %+v, want muted posts hidden", posts)
+	}
+	if posts, _ := ms.GetMentions(ctx, akram, cursor, 50); len(posts) != 0 {
+		t.Errorf("mentions = %+v, want muted mentions hidden", posts)
+	}
+	if posts, _ := ss.SearchPosts(ctx, akram, "hello", cursor, 50); len(posts) != 0 {
+		t.Errorf("search = %+v, want muted posts hidden", posts)
+	}
+
+	// Muting is one-way: sara still sees akram's posts
+	_, _ = ps.CreatePost(ctx, akram, "Hi sara")
+	if posts, _ := ps.GetTimeline(ctx, sara, time.Now().Add(time.Second), 50); len(posts) != 2 {
+		t.Errorf("sara's timeline has %d posts, want 2", len(posts))
+	}
+
+	muting, err := mutes.IsMuting(ctx, akram, sara)
+	if err != nil || !muting {
+		t.Errorf("IsMuting = %v, %v; want true", muting, err)
+	}
+	if err := mutes.Unmute(ctx, akram, sara); err != nil {
+		t.Fatalf("Unmute: %v", err)
+	}
+	if posts, _ := ps.GetTimeline(ctx, akram, time.Now().Add(time.Second), 50); len(posts) != 2 {
+		t.Errorf("after unmute timeline has %d posts, want 2", len(posts))
+	}
+}
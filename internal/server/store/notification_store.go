@@ -0,0 +1,152 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// notIgnoring holds unless recipient has muted actor or either has blocked
+// the other. Both arguments are SQL expressions.
+func notIgnoring(recipient, actor string) string {
+	return `NOT EXISTS (SELECT 1 FROM mutes WHERE muter_id = ` + recipient + ` AND muted_id = ` + actor + `)
+		   AND NOT ` + blockedBetween(recipient, actor)
+}
+
+type notificationStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewNotificationStore(pool *pgxpool.Pool) NotificationStore {
+	return &notificationStore{pool: pool}
+}
+
+// NotifyUser notifies userID that actorID did kind, optionally about postID.
+// Users are never notified about their own actions or by actors they
+// ignore, and an event that was already notified is not notified again.
+func (s *notificationStore) NotifyUser(ctx context.Context, userID, actorID, kind string, postID *string) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO notifications (user_id, actor_id, kind, post_id)
+		 SELECT $1::uuid, $2::uuid, $3, $4::uuid
+		 WHERE $1::uuid <> $2::uuid
+		   AND `+notIgnoring(`$1::uuid`, `$2::uuid`)+`
+		 ON CONFLICT DO NOTHING`,
+		userID, actorID, kind, postID,
+	)
+	if err != nil {
+		return fmt.Errorf("notify user: %w", err)
+	}
+	return nil
+}
+
+// NotifyPostAuthor notifies the author of authorOfID that actorID did kind,
+// pointing at postID. For a like both IDs are the liked post; for a reply
+// authorOfID is the parent and postID the reply.
+func (s *notificationStore) NotifyPostAuthor(ctx context.Context, actorID, kind, authorOfID, postID string) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO notifications (user_id, actor_id, kind, post_id)
+		 SELECT p.author_id, $1::uuid, $2, $4::uuid
+		 FROM posts p
+		 WHERE p.id = $3 AND p.author_id <> $1::uuid
+		   AND `+notIgnoring(`p.author_id`, `$1::uuid`)+`
+		 ON CONFLICT DO NOTHING`,
+		actorID, kind, authorOfID, postID,
+	)
+	if err != nil {
+		return fmt.Errorf("notify post author: %w", err)
+	}
+	return nil
+}
+
+// NotifyMentioned notifies every user mentioned in postID who does not
+// ignore its author.
+func (s *notificationStore) NotifyMentioned(ctx context.Context, postID string) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO notifications (user_id, actor_id, kind, post_id)
+		 SELECT m.user_id, p.author_id, 'mention', p.id
+		 FROM mentions m
+		 JOIN posts p ON p.id = m.post_id
+		 WHERE m.post_id = $1 AND m.user_id <> p.author_id
+		   AND `+notIgnoring(`m.user_id`, `p.author_id`)+`
+		 ON CONFLICT DO NOTHING`,
+		postID,
+	)
+	if err != nil {
+		return fmt.Errorf("notify mentioned: %w", err)
+	}
+	return nil
+}
+
+// GetNotifications returns userID's notifications, newest first, leaving
+// out those from actors userID has since muted or blocked.
+func (s *notificationStore) GetNotifications(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Notification, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT n.id, n.kind, n.read_at IS NOT NULL, n.created_at,
+		        a.id, a.username, a.display_name, a.bio, a.created_at,
+		        p.id, CASE WHEN p.deleted_at IS NULL THEN p.content ELSE '' END, p.deleted_at IS NOT NULL
+		 FROM notifications n
+		 JOIN users a ON a.id = n.actor_id
+		 LEFT JOIN posts p ON p.id = n.post_id
+		 WHERE n.user_id = $1 AND n.created_at < $2
+		   AND `+notIgnoring(`n.user_id`, `n.actor_id`)+`
+		 ORDER BY n.created_at DESC
+		 LIMIT $3`, userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []models.Notification
+	for rows.Next() {
+		var n models.Notification
+		var postID, content *string
+		var deleted *bool
+		if err := rows.Scan(
+			&n.ID, &n.Kind, &n.Read, &n.CreatedAt,
+			&n.Actor.ID, &n.Actor.Username, &n.Actor.DisplayName, &n.Actor.Bio, &n.Actor.CreatedAt,
+			&postID, &content, &deleted,
+		); err != nil {
+			return nil, fmt.Errorf("scan notification: %w", err)
+		}
+		if postID != nil {
+			n.Post = &models.Post{ID: *postID, Content: *content, Deleted: *deleted}
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// CountUnread returns how many of userID's notifications are unread.
+func (s *notificationStore) CountUnread(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM notifications n
+		 WHERE n.user_id = $1 AND n.read_at IS NULL
+		   AND `+notIgnoring(`n.user_id`, `n.actor_id`),
+		userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks userID's notifications created at or before upTo as read.
+func (s *notificationStore) MarkRead(ctx context.Context, userID string, upTo time.Time) error {
+	_, err := s.pool.Exec(ctx,
+		`UPDATE notifications SET read_at = NOW()
+		 WHERE user_id = $1 AND read_at IS NULL AND created_at <= $2`,
+		userID, upTo,
+	)
+	if err != nil {
+		return fmt.Errorf("mark notifications read: %w", err)
+	}
+	return nil
+}
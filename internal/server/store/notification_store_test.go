@@ -0,0 +1,74 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestNotifications(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ms := store.NewMentionStore(pool)
+	ns := store.NewNotificationStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	post, _ := ps.CreatePost(ctx, akram, "Hello @sara")
+	if err := ms.SetMentions(ctx, post.ID, []string{"sara"}); err != nil {
+		t.Fatalf("SetMentions: %v", err)
+	}
+	if err := ns.NotifyMentioned(ctx, post.ID); err != nil {
+		t.Fatalf("NotifyMentioned: %v", err)
+	}
+
+	// Liking twice, and liking your own post, notify once and not at all
+	for i := 0; i < 2; i++ {
+		if err := ns.NotifyPostAuthor(ctx, sara, models.NotificationKindLike, post.ID, post.ID); err != nil {
+			t.Fatalf("NotifyPostAuthor: %v", err)
+		}
+	}
+	if err := ns.NotifyPostAuthor(ctx, akram, models.NotificationKindLike, post.ID, post.ID); err != nil {
+		t.Fatalf("NotifyPostAuthor self: %v", err)
+	}
+	if err := ns.NotifyUser(ctx, akram, sara, models.NotificationKindFollow, nil); err != nil {
+		t.Fatalf("NotifyUser: %v", err)
+	}
+
+	notifications, err := ns.GetNotifications(ctx, akram, time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetNotifications: %v", err)
+	}
+	if len(notifications) != 2 {
+		t.Fatalf("akram has %d notifications, want 2: %+v", len(notifications), notifications)
+	}
+	follow, like := notifications[0], notifications[1]
+	if follow.Kind != models.NotificationKindFollow || follow.Actor.ID != sara || follow.Post != nil {
+		t.Errorf("newest = %+v, want a follow from sara without a post", follow)
+	}
+	if like.Kind != models.NotificationKindLike || like.Post == nil || like.Post.ID != post.ID || like.Read {
+		t.Errorf("oldest = %+v, want an unread like of the post", like)
+	}
+
+	saraNotes, _ := ns.GetNotifications(ctx, sara, time.Now().Add(time.Second), 50)
+	if len(saraNotes) != 1 || saraNotes[0].Kind != models.NotificationKindMention || saraNotes[0].Actor.ID != akram {
+		t.Errorf("sara's notifications = %+v, want a mention from akram", saraNotes)
+	}
+
+	if err := ns.MarkRead(ctx, akram, like.CreatedAt); err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+	unread, err := ns.CountUnread(ctx, akram)
+	if err != nil {
+		t.Fatalf("CountUnread: %v", err)
+	}
+	if unread != 1 {
+		t.Errorf("unread = %d, want 1 after marking the like read", unread)
+	}
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type passwordResetStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPasswordResetStore(pool *pgxpool.Pool) PasswordResetStore {
+	return &passwordResetStore{pool: pool}
+}
+
+// CreateReset stores a reset token for userID. Earlier tokens of theirs
+// are deleted, so only the most recently sent one works.
+func (s *passwordResetStore) CreateReset(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+	_, err := s.pool.Exec(ctx,
+		`WITH replaced AS (
+		     DELETE FROM password_resets WHERE user_id = $1
+		 )
+		 INSERT INTO password_resets (user_id, token_hash, expires_at)
+		 VALUES ($1, $2, $3)`,
+		userID, tokenHash, expiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("create password reset: %w", err)
+	}
+	return nil
+}
+
+// ConsumeReset deletes an unexpired reset token and returns the user it
+// belongs to.
+func (s *passwordResetStore) ConsumeReset(ctx context.Context, tokenHash string) (string, error) {
+	var userID string
+	err := s.pool.QueryRow(ctx,
+		`DELETE FROM password_resets
+		 WHERE token_hash = $1 AND expires_at > NOW()
+		 RETURNING user_id`, tokenHash,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", &models.APIError{Code: models.ErrCodeUnauthorized, Field: "token", Message: "invalid or expired reset token"}
+		}
+		return "", fmt.Errorf("consume password reset: %w", err)
+	}
+	return userID, nil
+}
+
+func (s *passwordResetStore) DeleteExpired(ctx context.Context) (int64, error) {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM password_resets WHERE expires_at < NOW()`,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired password resets: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
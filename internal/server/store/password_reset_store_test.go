@@ -0,0 +1,78 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestPasswordResets(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPasswordResetStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	var apiErr *models.APIError
+
+	if err := ps.CreateReset(ctx, userID, "resethash1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreateReset: %v", err)
+	}
+	// A newer reset replaces the first
+	if err := ps.CreateReset(ctx, userID, "resethash2", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreateReset again: %v", err)
+	}
+	if _, err := ps.ConsumeReset(ctx, "resethash1"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("replaced token error = %v, want unauthorized", err)
+	}
+
+	got, err := ps.ConsumeReset(ctx, "resethash2")
+	if err != nil {
+		t.Fatalf("ConsumeReset: %v", err)
+	}
+	if got != userID {
+		t.Errorf("user = %q, want %q", got, userID)
+	}
+	if _, err := ps.ConsumeReset(ctx, "resethash2"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("consumed token error = %v, want unauthorized", err)
+	}
+
+	_ = ps.CreateReset(ctx, userID, "expiredhash", time.Now().Add(-time.Minute))
+	if _, err := ps.ConsumeReset(ctx, "expiredhash"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("expired token error = %v, want unauthorized", err)
+	}
+	deleted, err := ps.DeleteExpired(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpired: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+}
+
+func TestSetPasswordHash(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	var apiErr *models.APIError
+
+	if err := us.SetPasswordHash(ctx, userID, "newhash"); err != nil {
+		t.Fatalf("SetPasswordHash: %v", err)
+	}
+	hash, err := us.GetPasswordHash(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetPasswordHash: %v", err)
+	}
+	if hash != "newhash" {
+		t.Errorf("hash = %q, want %q", hash, "newhash")
+	}
+	if err := us.SetPasswordHash(ctx, "00000000-0000-0000-0000-000000000000", "x"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("unknown user error = %v, want not found", err)
+	}
+}
@@ -12,6 +12,78 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// postColumns selects a post joined with its author and, for reposts and
+// quotes, the original post and its author, in the order scanPost expects.
+// Deleted posts keep their place but have their content blanked. Queries
+// using it must select FROM postFrom and bind the viewer ID (see
+// viewerParam) as $1 so liked_by_me and reposted_by_me reflect the caller.
+const postColumns = `p.id, p.author_id, p.kind, p.parent_id, p.root_id, p.original_id,
+		        CASE WHEN p.deleted_at IS NULL THEN p.content ELSE '' END,
+		        p.edited_at, p.deleted_at IS NOT NULL, p.created_at,
+		        (SELECT COALESCE(json_agg(json_build_object('user_id', mu.id, 'username', mu.username) ORDER BY mu.username), '[]')
+		         FROM mentions m JOIN users mu ON mu.id = m.user_id WHERE m.post_id = p.id AND p.deleted_at IS NULL),
+		        ARRAY(SELECT t.name FROM post_tags pt JOIN tags t ON t.id = pt.tag_id
+		              WHERE pt.post_id = p.id AND p.deleted_at IS NULL ORDER BY pt.position),
+		        (SELECT COUNT(*) FROM likes l WHERE l.post_id = p.id),
+		        EXISTS (SELECT 1 FROM likes l WHERE l.post_id = p.id AND l.user_id = $1),
+		        (SELECT COUNT(*) FROM posts r WHERE r.original_id = p.id AND r.kind = 'repost'),
+		        EXISTS (SELECT 1 FROM posts r WHERE r.original_id = p.id AND r.kind = 'repost' AND r.author_id = $1),
+		        u.id, u.username, u.display_name, u.bio, u.created_at,
+		        o.id, o.author_id, o.kind, o.parent_id, o.root_id,
+		        CASE WHEN o.deleted_at IS NULL THEN o.content ELSE '' END,
+		        o.edited_at, o.deleted_at IS NOT NULL, o.created_at,
+		        (SELECT COALESCE(json_agg(json_build_object('user_id', mu.id, 'username', mu.username) ORDER BY mu.username), '[]')
+		         FROM mentions m JOIN users mu ON mu.id = m.user_id WHERE m.post_id = o.id AND o.deleted_at IS NULL),
+		        ARRAY(SELECT t.name FROM post_tags pt JOIN tags t ON t.id = pt.tag_id
+		              WHERE pt.post_id = o.id AND o.deleted_at IS NULL ORDER BY pt.position),
+		        (SELECT COUNT(*) FROM likes l WHERE l.post_id = o.id),
+		        EXISTS (SELECT 1 FROM likes l WHERE l.post_id = o.id AND l.user_id = $1),
+		        (SELECT COUNT(*) FROM posts r WHERE r.original_id = o.id AND r.kind = 'repost'),
+		        EXISTS (SELECT 1 FROM posts r WHERE r.original_id = o.id AND r.kind = 'repost' AND r.author_id = $1),
+		        ou.id, ou.username, ou.display_name, ou.bio, ou.created_at`
+
+// postFrom joins posts with the tables postColumns reads from.
+const postFrom = `posts p
+		 JOIN users u ON p.author_id = u.id
+		 LEFT JOIN posts o ON o.id = p.original_id
+		 LEFT JOIN users ou ON o.author_id = ou.id`
+
+// postReturning lists the columns scanned by scanInserted.
+const postReturning = `id, author_id, kind, parent_id, root_id, original_id, content, edited_at, created_at`
+
+// feedVisible excludes deleted posts, posts by accounts that are not
+// active, and reposts of such originals, from feeds. Threads still show
+// deleted posts as placeholders.
+const feedVisible = `p.deleted_at IS NULL
+		   AND u.status = 'active'
+		   AND NOT (p.kind = 'repost' AND (o.id IS NULL OR o.deleted_at IS NOT NULL OR ou.status <> 'active'))`
+
+// hiddenAuthors selects the users the viewer ($1) does not see: those they
+// muted, and those they blocked or were blocked by. An anonymous viewer
+// hides no one.
+const hiddenAuthors = `(SELECT muted_id FROM mutes WHERE muter_id = $1
+		       UNION ALL SELECT blocked_id FROM blocks WHERE blocker_id = $1
+		       UNION ALL SELECT blocker_id FROM blocks WHERE blocked_id = $1)`
+
+// notHidden excludes posts by hiddenAuthors, and reposts and quotes of
+// their posts.
+const notHidden = `p.author_id NOT IN ` + hiddenAuthors + `
+		   AND (o.author_id IS NULL OR o.author_id NOT IN ` + hiddenAuthors + `)`
+
+// latestShareOnly keeps a single feed entry per original post: a post, or a
+// repost of it, is skipped when a newer repost matching visible exists.
+func latestShareOnly(visible string) string {
+	return `NOT EXISTS (
+		       SELECT 1 FROM posts newer
+		       WHERE newer.kind = 'repost'
+		         AND newer.deleted_at IS NULL
+		         AND newer.author_id IN (SELECT id FROM users WHERE status = 'active')
+		         AND newer.original_id = CASE WHEN p.kind = 'repost' THEN p.original_id ELSE p.id END
+		         AND newer.created_at > p.created_at
+		         AND ` + visible + `
+		   )`
+}
+
 type postStore struct {
 	pool *pgxpool.Pool
 }
@@ -21,41 +93,288 @@ func NewPostStore(pool *pgxpool.Pool) PostStore {
 }
 
 func (s *postStore) CreatePost(ctx context.Context, authorID, content string) (*models.Post, error) {
-	var post models.Post
-	err := s.pool.QueryRow(ctx,
+	post, err := scanInserted(s.pool.QueryRow(ctx,
 		`INSERT INTO posts (author_id, content)
 		 VALUES ($1, $2)
-		 RETURNING id, author_id, content, created_at`,
+		 RETURNING `+postReturning,
 		authorID, content,
-	).Scan(&post.ID, &post.AuthorID, &post.Content, &post.CreatedAt)
+	))
+
+	if err != nil {
+		if apiErr := postContentError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("create post: %w", err)
+	}
+
+	return post, nil
+}
+
+// ImportPosts inserts authorID's posts with their original creation times
+// and attaches each post's Tags, all in one statement, and returns how many
+// posts it inserted. A post by authorID with the same content and creation
+// time is taken to be an earlier import of the same item and is left out,
+// as are repeats within posts.
+func (s *postStore) ImportPosts(ctx context.Context, authorID string, posts []models.Post) (int, error) {
+	var contents []string
+	var times []time.Time
+	var tagItems, tagPositions []int
+	var tagNames []string
+	seen := make(map[string]bool, len(posts))
+	for _, post := range posts {
+		// Postgres keeps microseconds, so finer times would not match
+		createdAt := post.CreatedAt.Truncate(time.Microsecond)
+		key := fmt.Sprintf("%d/%s", createdAt.UnixMicro(), post.Content)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		contents = append(contents, post.Content)
+		times = append(times, createdAt)
+		for i, tag := range post.Tags {
+			tagItems = append(tagItems, len(contents))
+			tagNames = append(tagNames, tag)
+			tagPositions = append(tagPositions, i+1)
+		}
+	}
+	if len(contents) == 0 {
+		return 0, nil
+	}
+
+	var imported int
+	err := s.pool.QueryRow(ctx,
+		`WITH items AS (
+		     SELECT * FROM unnest($2::text[], $3::timestamptz[]) WITH ORDINALITY AS i(content, created_at, n)
+		 ), inserted AS (
+		     INSERT INTO posts (author_id, content, created_at)
+		     SELECT $1, items.content, items.created_at FROM items
+		     WHERE NOT EXISTS (
+		         SELECT 1 FROM posts
+		         WHERE author_id = $1 AND content = items.content AND created_at = items.created_at
+		     )
+		     RETURNING id, content, created_at
+		 ), item_posts AS (
+		     SELECT items.n, inserted.id
+		     FROM items JOIN inserted ON inserted.content = items.content AND inserted.created_at = items.created_at
+		 ), names AS (
+		     SELECT item_posts.id AS post_id, t.name, t.position
+		     FROM unnest($4::int[], $5::text[], $6::int[]) AS t(n, name, position)
+		     JOIN item_posts ON item_posts.n = t.n
+		 ), upserted AS (
+		     INSERT INTO tags (name)
+		     SELECT DISTINCT name FROM names
+		     ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		     RETURNING id, name
+		 ), tagged AS (
+		     INSERT INTO post_tags (post_id, tag_id, position)
+		     SELECT names.post_id, upserted.id, names.position
+		     FROM names JOIN upserted ON upserted.name = names.name
+		     ON CONFLICT (post_id, tag_id) DO NOTHING
+		 )
+		 SELECT COUNT(*) FROM inserted`,
+		authorID, contents, times, tagItems, tagNames, tagPositions,
+	).Scan(&imported)
+	if err != nil {
+		if apiErr := postContentError(err); apiErr != nil {
+			return 0, apiErr
+		}
+		return 0, fmt.Errorf("import posts: %w", err)
+	}
+	return imported, nil
+}
+
+// CreateReply inserts a reply to parentID. The root is inherited from the
+// parent, or is the parent itself when replying to a top-level post.
+func (s *postStore) CreateReply(ctx context.Context, authorID, parentID, content string) (*models.Post, error) {
+	post, err := scanInserted(s.pool.QueryRow(ctx,
+		`INSERT INTO posts (author_id, content, parent_id, root_id)
+		 SELECT $1, $2, parent.id, COALESCE(parent.root_id, parent.id)
+		 FROM posts parent
+		 WHERE parent.id = $3 AND parent.deleted_at IS NULL
+		 RETURNING `+postReturning,
+		authorID, content, parentID,
+	))
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+		}
+		if apiErr := postContentError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("create reply: %w", err)
+	}
+
+	return post, nil
+}
+
+// CreateRepost records authorID's repost of originalID. Reposting the same
+// post twice is a conflict.
+func (s *postStore) CreateRepost(ctx context.Context, authorID, originalID string) (*models.Post, error) {
+	post, err := scanInserted(s.pool.QueryRow(ctx,
+		`INSERT INTO posts (author_id, kind, original_id, content)
+		 VALUES ($1, 'repost', $2, '')
+		 RETURNING `+postReturning,
+		authorID, originalID,
+	))
 
 	if err != nil {
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23514" {
-			if pgErr.ConstraintName == "posts_content_max_length" {
-				return nil, &models.APIError{Code: models.ErrCodeContentLong, Message: "post content exceeds 140 characters"}
-			}
-			if pgErr.ConstraintName == "posts_content_not_empty" {
-				return nil, &models.APIError{Code: models.ErrCodeValidation, Message: "post content cannot be empty", Field: "content"}
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case "23503":
+				return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+			case "23505":
+				return nil, &models.APIError{Code: models.ErrCodeConflict, Message: "you already reposted this post"}
 			}
 		}
-		return nil, fmt.Errorf("create post: %w", err)
+		return nil, fmt.Errorf("create repost: %w", err)
 	}
 
-	return &post, nil
+	return post, nil
 }
 
-func (s *postStore) GetPostByID(ctx context.Context, id string) (*models.Post, error) {
-	var post models.Post
-	var author models.User
+func (s *postStore) DeleteRepost(ctx context.Context, authorID, originalID string) error {
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM posts
+		 WHERE author_id = $1 AND original_id = $2 AND kind = 'repost'`,
+		authorID, originalID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete repost: %w", err)
+	}
+	return nil
+}
+
+func (s *postStore) CountReposts(ctx context.Context, postID string) (int, error) {
+	var count int
 	err := s.pool.QueryRow(ctx,
-		`SELECT p.id, p.author_id, p.content, p.created_at,
-		        u.id, u.username, u.display_name, u.bio, u.created_at
-		 FROM posts p
-		 JOIN users u ON p.author_id = u.id
-		 WHERE p.id = $1`, id,
-	).Scan(&post.ID, &post.AuthorID, &post.Content, &post.CreatedAt,
-		&author.ID, &author.Username, &author.DisplayName, &author.Bio, &author.CreatedAt)
+		`SELECT COUNT(*) FROM posts WHERE original_id = $1 AND kind = 'repost'`, postID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count reposts: %w", err)
+	}
+	return count, nil
+}
+
+// CreateQuote inserts a post by authorID that embeds originalID.
+func (s *postStore) CreateQuote(ctx context.Context, authorID, originalID, content string) (*models.Post, error) {
+	post, err := scanInserted(s.pool.QueryRow(ctx,
+		`INSERT INTO posts (author_id, kind, original_id, content)
+		 VALUES ($1, 'quote', $2, $3)
+		 RETURNING `+postReturning,
+		authorID, originalID, content,
+	))
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+		}
+		if apiErr := postContentError(err); apiErr != nil {
+			return nil, apiErr
+		}
+		return nil, fmt.Errorf("create quote: %w", err)
+	}
+
+	return post, nil
+}
+
+// EditPost replaces a post's content, appending the previous content to its
+// edit history in the same statement.
+func (s *postStore) EditPost(ctx context.Context, id, content string) error {
+	tag, err := s.pool.Exec(ctx,
+		`WITH prev AS (
+		     SELECT id, content FROM posts
+		     WHERE id = $1 AND deleted_at IS NULL
+		     FOR UPDATE
+		 ), logged AS (
+		     INSERT INTO post_edits (post_id, content)
+		     SELECT id, content FROM prev
+		 )
+		 UPDATE posts p
+		 SET content = $2, edited_at = NOW()
+		 FROM prev
+		 WHERE p.id = prev.id`,
+		id, content,
+	)
+	if err != nil {
+		if apiErr := postContentError(err); apiErr != nil {
+			return apiErr
+		}
+		return fmt.Errorf("edit post: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	return nil
+}
+
+// DeletePost soft-deletes a post so replies beneath it keep their place in
+// the thread.
+func (s *postStore) DeletePost(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE posts SET deleted_at = NOW()
+		 WHERE id = $1 AND deleted_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("delete post: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	return nil
+}
+
+// GetEdits returns the edit history of a post, newest first.
+func (s *postStore) GetEdits(ctx context.Context, postID string) ([]models.PostEdit, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT content, edited_at
+		 FROM post_edits
+		 WHERE post_id = $1
+		 ORDER BY edited_at DESC`, postID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get edits: %w", err)
+	}
+	defer rows.Close()
+
+	edits := []models.PostEdit{}
+	for rows.Next() {
+		var edit models.PostEdit
+		if err := rows.Scan(&edit.Content, &edit.EditedAt); err != nil {
+			return nil, fmt.Errorf("scan edit: %w", err)
+		}
+		edits = append(edits, edit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate edits: %w", err)
+	}
+	return edits, nil
+}
+
+// postContentError maps content CHECK constraint violations to API errors,
+// returning nil for any other error.
+func postContentError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+		if pgErr.ConstraintName == "posts_content_max_length" {
+			return &models.APIError{Code: models.ErrCodeContentLong, Message: "post content exceeds 140 characters"}
+		}
+		if pgErr.ConstraintName == "posts_content_not_empty" {
+			return &models.APIError{Code: models.ErrCodeValidation, Message: "post content cannot be empty", Field: "content"}
+		}
+	}
+	return nil
+}
+
+func (s *postStore) GetPostByID(ctx context.Context, viewerID, id string) (*models.Post, error) {
+	post, err := scanPost(s.pool.QueryRow(ctx,
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 WHERE p.id = $2`, viewerParam(viewerID), id,
+	))
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -64,19 +383,19 @@ func (s *postStore) GetPostByID(ctx context.Context, id string) (*models.Post, e
 		return nil, fmt.Errorf("get post by id: %w", err)
 	}
 
-	post.Author = &author
-	return &post, nil
+	return post, nil
 }
 
-func (s *postStore) GetTimeline(ctx context.Context, cursor time.Time, limit int) ([]models.Post, error) {
+func (s *postStore) GetTimeline(ctx context.Context, viewerID string, cursor time.Time, limit int) ([]models.Post, error) {
 	rows, err := s.pool.Query(ctx,
-		`SELECT p.id, p.author_id, p.content, p.created_at,
-		        u.id, u.username, u.display_name, u.bio, u.created_at
-		 FROM posts p
-		 JOIN users u ON p.author_id = u.id
-		 WHERE p.created_at < $1
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 WHERE p.created_at < $2
+		   AND `+feedVisible+`
+		   AND `+notHidden+`
+		   AND `+latestShareOnly(`newer.author_id NOT IN `+hiddenAuthors)+`
 		 ORDER BY p.created_at DESC
-		 LIMIT $2`, cursor, limit,
+		 LIMIT $3`, viewerParam(viewerID), cursor, limit,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("get timeline: %w", err)
@@ -86,39 +405,208 @@ func (s *postStore) GetTimeline(ctx context.Context, cursor time.Time, limit int
 	return scanPosts(rows)
 }
 
-func (s *postStore) GetUserPosts(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+func (s *postStore) GetUserPosts(ctx context.Context, viewerID, userID string, cursor time.Time, limit int) ([]models.Post, error) {
 	rows, err := s.pool.Query(ctx,
-		`SELECT p.id, p.author_id, p.content, p.created_at,
-		        u.id, u.username, u.display_name, u.bio, u.created_at
-		 FROM posts p
-		 JOIN users u ON p.author_id = u.id
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 WHERE p.author_id = $2
+		   AND p.created_at < $3
+		   AND `+feedVisible+`
+		   AND `+notHidden+`
+		   AND `+latestShareOnly(`newer.author_id = $2`)+`
+		 ORDER BY p.created_at DESC
+		 LIMIT $4`, viewerParam(viewerID), userID, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get user posts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+// GetExportPosts returns a page of userID's posts for a data export, newest
+// first. Unlike GetUserPosts it applies no feed filtering, so it works for
+// accounts in any status. It pages on (created_at, id) so posts sharing a
+// timestamp are not skipped: the page starts after the post cursorID
+// created at cursor, or at the newest post when cursorID is empty.
+func (s *postStore) GetExportPosts(ctx context.Context, userID string, cursor time.Time, cursorID string, limit int) ([]models.Post, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
 		 WHERE p.author_id = $1
-		   AND p.created_at < $2
+		   AND p.deleted_at IS NULL
+		   AND ($3::uuid IS NULL OR (p.created_at, p.id) < ($2, $3::uuid))
+		 ORDER BY p.created_at DESC, p.id DESC
+		 LIMIT $4`, userID, cursor, cursorIDParam(cursorID), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get export posts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+func (s *postStore) GetHomeTimeline(ctx context.Context, userID string, cursor time.Time, limit int) ([]models.Post, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 JOIN follows f ON f.followee_id = p.author_id AND f.follower_id = $1
+		 WHERE p.created_at < $2
+		   AND `+feedVisible+`
+		   AND `+notHidden+`
+		   AND `+latestShareOnly(`newer.author_id IN (SELECT followee_id FROM follows WHERE follower_id = $1)
+		         AND newer.author_id NOT IN `+hiddenAuthors)+`
 		 ORDER BY p.created_at DESC
 		 LIMIT $3`, userID, cursor, limit,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("get user posts: %w", err)
+		return nil, fmt.Errorf("get home timeline: %w", err)
 	}
 	defer rows.Close()
 
 	return scanPosts(rows)
 }
 
+// GetAncestors returns the chain of parents above postID, root first.
+func (s *postStore) GetAncestors(ctx context.Context, viewerID, postID string) ([]models.Post, error) {
+	rows, err := s.pool.Query(ctx,
+		`WITH RECURSIVE chain AS (
+		     SELECT id, parent_id, 0 AS depth FROM posts WHERE id = $2
+		     UNION ALL
+		     SELECT parent.id, parent.parent_id, chain.depth + 1
+		     FROM posts parent
+		     JOIN chain ON parent.id = chain.parent_id
+		 )
+		 SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 JOIN chain ON chain.id = p.id
+		 WHERE chain.depth > 0
+		 ORDER BY chain.depth DESC`, viewerParam(viewerID), postID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get ancestors: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+// GetDescendants returns up to limit replies beneath postID at any depth,
+// oldest first.
+func (s *postStore) GetDescendants(ctx context.Context, viewerID, postID string, limit int) ([]models.Post, error) {
+	rows, err := s.pool.Query(ctx,
+		`WITH RECURSIVE tree AS (
+		     SELECT id FROM posts WHERE parent_id = $2
+		     UNION ALL
+		     SELECT child.id
+		     FROM posts child
+		     JOIN tree ON child.parent_id = tree.id
+		 )
+		 SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 JOIN tree ON tree.id = p.id
+		 ORDER BY p.created_at ASC
+		 LIMIT $3`, viewerParam(viewerID), postID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get descendants: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+// viewerParam binds an optional viewer ID; anonymous callers match no likes
+// or reposts.
+func viewerParam(viewerID string) any {
+	if viewerID == "" {
+		return nil
+	}
+	return viewerID
+}
+
+// cursorIDParam turns the ID half of an export cursor into a query
+// parameter. An empty ID becomes NULL, which starts at the newest row.
+func cursorIDParam(cursorID string) any {
+	if cursorID == "" {
+		return nil
+	}
+	return cursorID
+}
+
+func scanInserted(row pgx.Row) (*models.Post, error) {
+	var post models.Post
+	err := row.Scan(&post.ID, &post.AuthorID, &post.Kind, &post.ParentID, &post.RootID,
+		&post.OriginalID, &post.Content, &post.EditedAt, &post.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func scanPost(row pgx.Row) (*models.Post, error) {
+	var post models.Post
+	var author models.User
+	// The original's columns are NULL unless the post embeds one.
+	var (
+		origID, origAuthorID, origKind, origContent *string
+		origParentID, origRootID                    *string
+		origEditedAt, origCreatedAt                 *time.Time
+		origDeleted                                 *bool
+		origMentions                                []models.Mention
+		origTags                                    []string
+		origLikes, origReposts                      int
+		origLiked, origReposted                     bool
+		ouID, ouUsername, ouDisplayName, ouBio      *string
+		ouCreatedAt                                 *time.Time
+	)
+	err := row.Scan(
+		&post.ID, &post.AuthorID, &post.Kind, &post.ParentID, &post.RootID, &post.OriginalID,
+		&post.Content, &post.EditedAt, &post.Deleted, &post.CreatedAt, &post.Mentions, &post.Tags,
+		&post.LikeCount, &post.LikedByMe, &post.RepostCount, &post.RepostedByMe,
+		&author.ID, &author.Username, &author.DisplayName, &author.Bio, &author.CreatedAt,
+		&origID, &origAuthorID, &origKind, &origParentID, &origRootID,
+		&origContent, &origEditedAt, &origDeleted, &origCreatedAt, &origMentions, &origTags,
+		&origLikes, &origLiked, &origReposts, &origReposted,
+		&ouID, &ouUsername, &ouDisplayName, &ouBio, &ouCreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	post.Author = &author
+	if origID != nil {
+		post.Original = &models.Post{
+			ID:           *origID,
+			AuthorID:     *origAuthorID,
+			Author:       &models.User{ID: *ouID, Username: *ouUsername, DisplayName: *ouDisplayName, Bio: *ouBio, CreatedAt: *ouCreatedAt},
+			Kind:         *origKind,
+			ParentID:     origParentID,
+			RootID:       origRootID,
+			Content:      *origContent,
+			Mentions:     origMentions,
+			Tags:         origTags,
+			Deleted:      *origDeleted,
+			EditedAt:     origEditedAt,
+			LikeCount:    origLikes,
+			LikedByMe:    origLiked,
+			RepostCount:  origReposts,
+			RepostedByMe: origReposted,
+			CreatedAt:    *origCreatedAt,
+		}
+	}
+	return &post, nil
+}
+
 func scanPosts(rows pgx.Rows) ([]models.Post, error) {
 	var posts []models.Post
 	for rows.Next() {
-		var post models.Post
-		var author models.User
-		err := rows.Scan(
-			&post.ID, &post.AuthorID, &post.Content, &post.CreatedAt,
-			&author.ID, &author.Username, &author.DisplayName, &author.Bio, &author.CreatedAt,
-		)
+		post, err := scanPost(rows)
 		if err != nil {
 			return nil, fmt.Errorf("scan post: %w", err)
 		}
-		post.Author = &author
-		posts = append(posts, post)
+		posts = append(posts, *post)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate posts: %w", err)
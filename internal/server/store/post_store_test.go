@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/server/store"
 )
 
@@ -82,7 +83,7 @@ func TestGetPostByID(t *testing.T) {
 		t.Fatalf("create: %v", err)
 	}
 
-	post, err := ps.GetPostByID(ctx, created.ID)
+	post, err := ps.GetPostByID(ctx, "", created.ID)
 	if err != nil {
 		t.Fatalf("GetPostByID: %v", err)
 	}
@@ -102,7 +103,7 @@ func TestGetPostByIDNotFound(t *testing.T) {
 	ps := store.NewPostStore(pool)
 	ctx := context.Background()
 
-	_, err := ps.GetPostByID(ctx, "00000000-0000-0000-0000-000000000000")
+	_, err := ps.GetPostByID(ctx, "", "00000000-0000-0000-0000-000000000000")
 	if err == nil {
 		t.Fatal("expected error for nonexistent post")
 	}
@@ -122,7 +123,7 @@ func TestGetTimeline(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 	_, _ = ps.CreatePost(ctx, userID, "Third post")
 
-	posts, err := ps.GetTimeline(ctx, time.Now(), 50)
+	posts, err := ps.GetTimeline(ctx, "", time.Now(), 50)
 	if err != nil {
 		t.Fatalf("GetTimeline: %v", err)
 	}
@@ -138,6 +139,32 @@ func TestGetTimeline(t *testing.T) {
 	}
 }
 
+func TestGetTimelineHidesSuspendedAuthors(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	_, _ = ps.CreatePost(ctx, akram, "Still here")
+	spam, _ := ps.CreatePost(ctx, sara, "Buy followers now")
+	_, _ = ps.CreateRepost(ctx, akram, spam.ID)
+
+	if err := us.SetStatus(ctx, sara, models.AccountActive, models.AccountSuspended); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	posts, err := ps.GetTimeline(ctx, "", time.Now(), 50)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	if len(posts) != 1 || posts[0].Content != "Still here" {
+		t.Errorf("timeline = %+v, want only akram's own post", posts)
+	}
+}
+
 func TestGetTimelineCursorPagination(t *testing.T) {
 	pool := setupTestDB(t)
 	us := store.NewUserStore(pool)
@@ -152,7 +179,7 @@ func TestGetTimelineCursorPagination(t *testing.T) {
 	}
 
 	// Fetch first page
-	page1, err := ps.GetTimeline(ctx, time.Now(), 2)
+	page1, err := ps.GetTimeline(ctx, "", time.Now(), 2)
 	if err != nil {
 		t.Fatalf("page 1: %v", err)
 	}
@@ -162,7 +189,7 @@ func TestGetTimelineCursorPagination(t *testing.T) {
 
 	// Use the last post's created_at as cursor for next page
 	cursor := page1[len(page1)-1].CreatedAt
-	page2, err := ps.GetTimeline(ctx, cursor, 2)
+	page2, err := ps.GetTimeline(ctx, "", cursor, 2)
 	if err != nil {
 		t.Fatalf("page 2: %v", err)
 	}
@@ -184,7 +211,7 @@ func TestGetUserPostsEmpty(t *testing.T) {
 
 	userID := createTestUser(t, us, "testuser", "test@example.com")
 
-	result, err := ps.GetUserPosts(ctx, userID, time.Now(), 10)
+	result, err := ps.GetUserPosts(ctx, "", userID, time.Now(), 10)
 	if err != nil {
 		t.Fatalf("GetUserPosts: %v", err)
 	}
@@ -198,7 +225,7 @@ func TestGetTimelineEmpty(t *testing.T) {
 	ps := store.NewPostStore(pool)
 	ctx := context.Background()
 
-	result, err := ps.GetTimeline(ctx, time.Now(), 10)
+	result, err := ps.GetTimeline(ctx, "", time.Now(), 10)
 	if err != nil {
 		t.Fatalf("GetTimeline: %v", err)
 	}
@@ -220,7 +247,7 @@ func TestGetUserPosts(t *testing.T) {
 	_, _ = ps.CreatePost(ctx, user1ID, "Akram's post 2")
 	_, _ = ps.CreatePost(ctx, user2ID, "Sara's post")
 
-	posts, err := ps.GetUserPosts(ctx, user1ID, time.Now(), 50)
+	posts, err := ps.GetUserPosts(ctx, "", user1ID, time.Now(), 50)
 	if err != nil {
 		t.Fatalf("GetUserPosts: %v", err)
 	}
@@ -233,3 +260,234 @@ func TestGetUserPosts(t *testing.T) {
 		}
 	}
 }
+
+func TestGetHomeTimeline(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	fs := store.NewFollowStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	omar := createTestUser(t, us, "omar", "omar@example.com")
+
+	_ = fs.Follow(ctx, akram, sara)
+
+	_, _ = ps.CreatePost(ctx, sara, "Sara's post")
+	_, _ = ps.CreatePost(ctx, omar, "Omar's post")
+	_, _ = ps.CreatePost(ctx, akram, "Akram's post")
+
+	posts, err := ps.GetHomeTimeline(ctx, akram, time.Now(), 50)
+	if err != nil {
+		t.Fatalf("GetHomeTimeline: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("got %d posts, want 1", len(posts))
+	}
+	if posts[0].AuthorID != sara {
+		t.Errorf("author_id = %q, want %q", posts[0].AuthorID, sara)
+	}
+}
+
+func TestCreateReplyAndThread(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+
+	root, _ := ps.CreatePost(ctx, akram, "root")
+	reply, err := ps.CreateReply(ctx, sara, root.ID, "reply")
+	if err != nil {
+		t.Fatalf("CreateReply: %v", err)
+	}
+	if reply.ParentID == nil || *reply.ParentID != root.ID {
+		t.Errorf("reply parent = %v, want %q", reply.ParentID, root.ID)
+	}
+	nested, err := ps.CreateReply(ctx, akram, reply.ID, "nested")
+	if err != nil {
+		t.Fatalf("CreateReply nested: %v", err)
+	}
+	if nested.RootID == nil || *nested.RootID != root.ID {
+		t.Errorf("nested root = %v, want %q", nested.RootID, root.ID)
+	}
+
+	ancestors, err := ps.GetAncestors(ctx, "", nested.ID)
+	if err != nil {
+		t.Fatalf("GetAncestors: %v", err)
+	}
+	if len(ancestors) != 2 || ancestors[0].ID != root.ID || ancestors[1].ID != reply.ID {
+		t.Errorf("ancestors = %+v, want [root, reply]", ancestors)
+	}
+
+	descendants, err := ps.GetDescendants(ctx, "", root.ID, 50)
+	if err != nil {
+		t.Fatalf("GetDescendants: %v", err)
+	}
+	if len(descendants) != 2 || descendants[0].ID != reply.ID || descendants[1].ID != nested.ID {
+		t.Errorf("descendants = %+v, want [reply, nested]", descendants)
+	}
+}
+
+func TestCreateReplyParentNotFound(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+
+	_, err := ps.CreateReply(ctx, userID, "00000000-0000-0000-0000-000000000000", "orphan")
+	if err == nil {
+		t.Fatal("expected error for missing parent")
+	}
+}
+
+func TestEditPostKeepsHistory(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	post, _ := ps.CreatePost(ctx, userID, "Version one")
+
+	if err := ps.EditPost(ctx, post.ID, "Version two"); err != nil {
+		t.Fatalf("EditPost: %v", err)
+	}
+	if err := ps.EditPost(ctx, post.ID, "Version three"); err != nil {
+		t.Fatalf("EditPost again: %v", err)
+	}
+
+	got, _ := ps.GetPostByID(ctx, "", post.ID)
+	if got.Content != "Version three" || got.EditedAt == nil {
+		t.Errorf("post = %+v, want latest content with edited_at", got)
+	}
+
+	edits, err := ps.GetEdits(ctx, post.ID)
+	if err != nil {
+		t.Fatalf("GetEdits: %v", err)
+	}
+	if len(edits) != 2 || edits[0].Content != "Version two" || edits[1].Content != "Version one" {
+		t.Errorf("edits = %+v, want versions two and one, newest first", edits)
+	}
+
+	if _, err := pool.Exec(ctx, `UPDATE post_edits SET content = 'rewritten'`); err == nil {
+		t.Error("expected post_edits to reject updates")
+	}
+}
+
+func TestDeletePostIsSoft(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	post, _ := ps.CreatePost(ctx, userID, "Soon gone")
+	reply, _ := ps.CreateReply(ctx, userID, post.ID, "Still here")
+
+	if err := ps.DeletePost(ctx, post.ID); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+	if err := ps.DeletePost(ctx, post.ID); err == nil {
+		t.Error("expected error deleting twice")
+	}
+
+	posts, _ := ps.GetTimeline(ctx, "", time.Now().Add(time.Second), 50)
+	if len(posts) != 1 || posts[0].ID != reply.ID {
+		t.Errorf("timeline = %+v, want only the reply", posts)
+	}
+
+	ancestors, err := ps.GetAncestors(ctx, "", reply.ID)
+	if err != nil {
+		t.Fatalf("GetAncestors: %v", err)
+	}
+	if len(ancestors) != 1 || !ancestors[0].Deleted || ancestors[0].Content != "" {
+		t.Errorf("ancestors = %+v, want a deleted placeholder", ancestors)
+	}
+
+	if _, err := ps.CreateReply(ctx, userID, post.ID, "Too late"); err == nil {
+		t.Error("expected error replying to a deleted post")
+	}
+	if err := ps.EditPost(ctx, post.ID, "Revived"); err == nil {
+		t.Error("expected error editing a deleted post")
+	}
+}
+
+func TestGetExportPosts(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	imports := []models.Post{
+		{Content: "one", CreatedAt: createdAt},
+		{Content: "two", CreatedAt: createdAt},
+		{Content: "three", CreatedAt: createdAt},
+	}
+	if _, err := ps.ImportPosts(ctx, userID, imports); err != nil {
+		t.Fatalf("ImportPosts: %v", err)
+	}
+	// Exports work for accounts that feeds leave out
+	if err := us.SetStatus(ctx, userID, models.AccountActive, models.AccountDeactivated); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	first, err := ps.GetExportPosts(ctx, userID, time.Time{}, "", 2)
+	if err != nil {
+		t.Fatalf("GetExportPosts: %v", err)
+	}
+	if len(first) != 2 {
+		t.Fatalf("first page has %d posts, want 2", len(first))
+	}
+	last := first[len(first)-1]
+	rest, err := ps.GetExportPosts(ctx, userID, last.CreatedAt, last.ID, 2)
+	if err != nil {
+		t.Fatalf("GetExportPosts: %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID == first[0].ID || rest[0].ID == first[1].ID {
+		t.Errorf("second page = %+v, want the one post sharing the timestamp", rest)
+	}
+}
+
+func TestImportPosts(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ts := store.NewTagStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	posts := []models.Post{
+		{Content: "From the old instance #go", CreatedAt: createdAt, Tags: []string{"go"}},
+		{Content: "From the old instance #go", CreatedAt: createdAt, Tags: []string{"go"}},
+		{Content: "Earlier", CreatedAt: createdAt.Add(-time.Hour)},
+	}
+
+	imported, err := ps.ImportPosts(ctx, userID, posts)
+	if err != nil {
+		t.Fatalf("ImportPosts: %v", err)
+	}
+	if imported != 2 {
+		t.Errorf("imported = %d, want 2 with the repeat left out", imported)
+	}
+	tagged, _ := ts.GetTagPosts(ctx, userID, "go", time.Now(), 50)
+	if len(tagged) != 1 || !tagged[0].CreatedAt.Equal(createdAt) {
+		t.Errorf("#go posts = %+v, want the imported post with its original time", tagged)
+	}
+
+	imported, err = ps.ImportPosts(ctx, userID, posts)
+	if err != nil {
+		t.Fatalf("second ImportPosts: %v", err)
+	}
+	if imported != 0 {
+		t.Errorf("second import inserted %d posts, want 0", imported)
+	}
+}
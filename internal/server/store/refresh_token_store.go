@@ -19,38 +19,127 @@ func NewRefreshTokenStore(pool *pgxpool.Pool) RefreshTokenStore {
 	return &refreshTokenStore{pool: pool}
 }
 
-func (s *refreshTokenStore) StoreToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time) error {
+// StoreToken starts a session for userID on device and returns its ID,
+// which is the ID of the token's family.
+func (s *refreshTokenStore) StoreToken(ctx context.Context, userID, tokenHash string, expiresAt time.Time, device models.Device) (string, error) {
+	var familyID string
+	err := s.pool.QueryRow(ctx,
+		`INSERT INTO refresh_tokens (user_id, token_hash, expires_at, device_name, user_agent, ip)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING family_id`,
+		userID, tokenHash, expiresAt, device.DeviceName, device.UserAgent, device.IP,
+	).Scan(&familyID)
+	if err != nil {
+		return "", fmt.Errorf("store refresh token: %w", err)
+	}
+	return familyID, nil
+}
+
+// RotateToken marks token id rotated and stores newHash as the next token
+// of its family, recording the device's latest details. A device name is
+// only replaced when the client sends one. Rotating a token that was
+// already rotated fails as an invalid refresh token.
+func (s *refreshTokenStore) RotateToken(ctx context.Context, id, newHash string, expiresAt time.Time, device models.Device) error {
+	tag, err := s.pool.Exec(ctx,
+		`WITH old AS (
+		     UPDATE refresh_tokens SET rotated_at = NOW()
+		     WHERE id = $1 AND rotated_at IS NULL
+		     RETURNING user_id, family_id, device_name, created_at
+		 )
+		 INSERT INTO refresh_tokens (user_id, family_id, token_hash, expires_at, device_name, user_agent, ip, created_at)
+		 SELECT user_id, family_id, $2, $3, COALESCE(NULLIF($4, ''), device_name), $5, $6, created_at
+		 FROM old`,
+		id, newHash, expiresAt, device.DeviceName, device.UserAgent, device.IP,
+	)
+	if err != nil {
+		return fmt.Errorf("rotate refresh token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid refresh token"}
+	}
+	return nil
+}
+
+// DeleteFamily revokes every token of a family, ending its session.
+func (s *refreshTokenStore) DeleteFamily(ctx context.Context, familyID string) error {
 	_, err := s.pool.Exec(ctx,
-		`INSERT INTO refresh_tokens (user_id, token_hash, expires_at)
-		 VALUES ($1, $2, $3)`,
-		userID, tokenHash, expiresAt,
+		`DELETE FROM refresh_tokens WHERE family_id = $1`, familyID,
 	)
 	if err != nil {
-		return fmt.Errorf("store refresh token: %w", err)
+		return fmt.Errorf("delete refresh token family: %w", err)
 	}
 	return nil
 }
 
-func (s *refreshTokenStore) GetByHash(ctx context.Context, tokenHash string) (id, userID string, expiresAt time.Time, err error) {
-	err = s.pool.QueryRow(ctx,
-		`SELECT id, user_id, expires_at
+// ListSessions returns userID's unexpired sessions, most recently used
+// first. A session is shown by the latest token of its family.
+func (s *refreshTokenStore) ListSessions(ctx context.Context, userID string) ([]models.Session, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT family_id, device_name, user_agent, ip, created_at, last_used_at
+		 FROM refresh_tokens
+		 WHERE user_id = $1 AND rotated_at IS NULL AND expires_at > NOW()
+		 ORDER BY last_used_at DESC`, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		var sess models.Session
+		if err := rows.Scan(&sess.ID, &sess.DeviceName, &sess.UserAgent, &sess.IP, &sess.CreatedAt, &sess.LastUsedAt); err != nil {
+			return nil, fmt.Errorf("scan session: %w", err)
+		}
+		sessions = append(sessions, sess)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// DeleteSession ends one of userID's sessions. Other users' sessions are
+// reported as not found.
+func (s *refreshTokenStore) DeleteSession(ctx context.Context, userID, id string) error {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM refresh_tokens WHERE family_id = $1 AND user_id = $2`, id, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "session not found"}
+	}
+	return nil
+}
+
+// GetByHash returns the token with tokenHash, including tokens that were
+// already rotated.
+func (s *refreshTokenStore) GetByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var t models.RefreshToken
+	err := s.pool.QueryRow(ctx,
+		`SELECT id, user_id, family_id, expires_at, rotated_at
 		 FROM refresh_tokens
 		 WHERE token_hash = $1`, tokenHash,
-	).Scan(&id, &userID, &expiresAt)
+	).Scan(&t.ID, &t.UserID, &t.FamilyID, &t.ExpiresAt, &t.RotatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", "", time.Time{}, &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid refresh token"}
+			return nil, &models.APIError{Code: models.ErrCodeUnauthorized, Message: "invalid refresh token"}
 		}
-		return "", "", time.Time{}, fmt.Errorf("get refresh token by hash: %w", err)
+		return nil, fmt.Errorf("get refresh token by hash: %w", err)
 	}
 
-	return id, userID, expiresAt, nil
+	return &t, nil
 }
 
+// DeleteByHash ends the session tokenHash belongs to, revoking its whole
+// family.
 func (s *refreshTokenStore) DeleteByHash(ctx context.Context, tokenHash string) error {
 	_, err := s.pool.Exec(ctx,
-		`DELETE FROM refresh_tokens WHERE token_hash = $1`, tokenHash,
+		`DELETE FROM refresh_tokens
+		 WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)`, tokenHash,
 	)
 	if err != nil {
 		return fmt.Errorf("delete refresh token by hash: %w", err)
@@ -68,6 +157,20 @@ func (s *refreshTokenStore) DeleteAllForUser(ctx context.Context, userID string)
 	return nil
 }
 
+// DeleteOtherSessions ends every session of userID except keepID. An empty
+// keepID ends them all.
+func (s *refreshTokenStore) DeleteOtherSessions(ctx context.Context, userID, keepID string) error {
+	_, err := s.pool.Exec(ctx,
+		`DELETE FROM refresh_tokens
+		 WHERE user_id = $1 AND family_id IS DISTINCT FROM NULLIF($2, '')::uuid`,
+		userID, keepID,
+	)
+	if err != nil {
+		return fmt.Errorf("delete other sessions: %w", err)
+	}
+	return nil
+}
+
 func (s *refreshTokenStore) DeleteExpired(ctx context.Context) (int64, error) {
 	tag, err := s.pool.Exec(ctx,
 		`DELETE FROM refresh_tokens WHERE expires_at < NOW()`,
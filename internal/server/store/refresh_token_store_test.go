@@ -2,9 +2,11 @@ package store_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/server/store"
 )
 
@@ -17,24 +19,27 @@ func TestStoreAndGetToken(t *testing.T) {
 	userID := createTestUser(t, us, "akram", "akram@example.com")
 
 	expiresAt := time.Now().Add(24 * time.Hour)
-	err := rs.StoreToken(ctx, userID, "abc123hash", expiresAt)
+	_, err := rs.StoreToken(ctx, userID, "abc123hash", expiresAt, models.Device{})
 	if err != nil {
 		t.Fatalf("StoreToken: %v", err)
 	}
 
-	id, gotUserID, gotExpires, err := rs.GetByHash(ctx, "abc123hash")
+	token, err := rs.GetByHash(ctx, "abc123hash")
 	if err != nil {
 		t.Fatalf("GetByHash: %v", err)
 	}
-	if id == "" {
-		t.Error("expected non-empty token ID")
+	if token.ID == "" || token.FamilyID == "" {
+		t.Error("expected non-empty token and family IDs")
 	}
-	if gotUserID != userID {
-		t.Errorf("user_id = %q, want %q", gotUserID, userID)
+	if token.UserID != userID {
+		t.Errorf("user_id = %q, want %q", token.UserID, userID)
 	}
-	if gotExpires.Before(time.Now()) {
+	if token.ExpiresAt.Before(time.Now()) {
 		t.Error("expected expires_at in the future")
 	}
+	if token.RotatedAt != nil {
+		t.Error("expected a new token not to be rotated")
+	}
 }
 
 func TestDeleteByHash(t *testing.T) {
@@ -45,14 +50,14 @@ func TestDeleteByHash(t *testing.T) {
 
 	userID := createTestUser(t, us, "akram", "akram@example.com")
 
-	_ = rs.StoreToken(ctx, userID, "tokenhash1", time.Now().Add(24*time.Hour))
+	_, _ = rs.StoreToken(ctx, userID, "tokenhash1", time.Now().Add(24*time.Hour), models.Device{})
 
 	err := rs.DeleteByHash(ctx, "tokenhash1")
 	if err != nil {
 		t.Fatalf("DeleteByHash: %v", err)
 	}
 
-	_, _, _, err = rs.GetByHash(ctx, "tokenhash1")
+	_, err = rs.GetByHash(ctx, "tokenhash1")
 	if err == nil {
 		t.Fatal("expected error after deleting token")
 	}
@@ -66,31 +71,61 @@ func TestDeleteAllForUser(t *testing.T) {
 
 	userID := createTestUser(t, us, "akram", "akram@example.com")
 
-	_ = rs.StoreToken(ctx, userID, "hash1", time.Now().Add(24*time.Hour))
-	_ = rs.StoreToken(ctx, userID, "hash2", time.Now().Add(24*time.Hour))
-	_ = rs.StoreToken(ctx, userID, "hash3", time.Now().Add(24*time.Hour))
+	_, _ = rs.StoreToken(ctx, userID, "hash1", time.Now().Add(24*time.Hour), models.Device{})
+	_, _ = rs.StoreToken(ctx, userID, "hash2", time.Now().Add(24*time.Hour), models.Device{})
+	_, _ = rs.StoreToken(ctx, userID, "hash3", time.Now().Add(24*time.Hour), models.Device{})
 
 	err := rs.DeleteAllForUser(ctx, userID)
 	if err != nil {
 		t.Fatalf("DeleteAllForUser: %v", err)
 	}
 
-	_, _, _, err = rs.GetByHash(ctx, "hash1")
+	_, err = rs.GetByHash(ctx, "hash1")
 	if err == nil {
 		t.Fatal("expected error for deleted token hash1")
 	}
-	_, _, _, err = rs.GetByHash(ctx, "hash2")
+	_, err = rs.GetByHash(ctx, "hash2")
 	if err == nil {
 		t.Fatal("expected error for deleted token hash2")
 	}
 }
 
+func TestDeleteOtherSessions(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	rs := store.NewRefreshTokenStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+
+	keep, _ := rs.StoreToken(ctx, userID, "hash1", time.Now().Add(24*time.Hour), models.Device{})
+	_, _ = rs.StoreToken(ctx, userID, "hash2", time.Now().Add(24*time.Hour), models.Device{})
+
+	if err := rs.DeleteOtherSessions(ctx, userID, keep); err != nil {
+		t.Fatalf("DeleteOtherSessions: %v", err)
+	}
+	if _, err := rs.GetByHash(ctx, "hash1"); err != nil {
+		t.Errorf("kept session: %v", err)
+	}
+	if _, err := rs.GetByHash(ctx, "hash2"); err == nil {
+		t.Error("expected error for deleted token hash2")
+	}
+
+	// Without a session to keep, all of them end
+	if err := rs.DeleteOtherSessions(ctx, userID, ""); err != nil {
+		t.Fatalf("DeleteOtherSessions without keep: %v", err)
+	}
+	if _, err := rs.GetByHash(ctx, "hash1"); err == nil {
+		t.Error("expected error for deleted token hash1")
+	}
+}
+
 func TestGetByHashNotFound(t *testing.T) {
 	pool := setupTestDB(t)
 	s := store.NewRefreshTokenStore(pool)
 	ctx := context.Background()
 
-	_, _, _, err := s.GetByHash(ctx, "nonexistent-hash")
+	_, err := s.GetByHash(ctx, "nonexistent-hash")
 	if err == nil {
 		t.Fatal("expected error for nonexistent token hash")
 	}
@@ -119,9 +154,9 @@ func TestDeleteExpired(t *testing.T) {
 	userID := createTestUser(t, us, "akram", "akram@example.com")
 
 	// Store 2 expired tokens and 1 valid
-	_ = rs.StoreToken(ctx, userID, "expired1", time.Now().Add(-1*time.Hour))
-	_ = rs.StoreToken(ctx, userID, "expired2", time.Now().Add(-2*time.Hour))
-	_ = rs.StoreToken(ctx, userID, "valid1", time.Now().Add(24*time.Hour))
+	_, _ = rs.StoreToken(ctx, userID, "expired1", time.Now().Add(-1*time.Hour), models.Device{})
+	_, _ = rs.StoreToken(ctx, userID, "expired2", time.Now().Add(-2*time.Hour), models.Device{})
+	_, _ = rs.StoreToken(ctx, userID, "valid1", time.Now().Add(24*time.Hour), models.Device{})
 
 	count, err := rs.DeleteExpired(ctx)
 	if err != nil {
@@ -132,8 +167,105 @@ func TestDeleteExpired(t *testing.T) {
 	}
 
 	// Valid token should still exist
-	_, _, _, err = rs.GetByHash(ctx, "valid1")
+	_, err = rs.GetByHash(ctx, "valid1")
 	if err != nil {
 		t.Fatalf("valid token should still exist: %v", err)
 	}
 }
+
+func TestRotateToken(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	rs := store.NewRefreshTokenStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	laptop := models.Device{DeviceName: "laptop", UserAgent: "niotebook-tui/1.0", IP: "10.0.0.1"}
+	familyID, err := rs.StoreToken(ctx, userID, "old", time.Now().Add(time.Hour), laptop)
+	if err != nil {
+		t.Fatalf("StoreToken: %v", err)
+	}
+	old, err := rs.GetByHash(ctx, "old")
+	if err != nil {
+		t.Fatalf("GetByHash(old): %v", err)
+	}
+
+	if err := rs.RotateToken(ctx, old.ID, "new", time.Now().Add(2*time.Hour), models.Device{UserAgent: "niotebook-tui/1.1", IP: "10.0.0.2"}); err != nil {
+		t.Fatalf("RotateToken: %v", err)
+	}
+	next, err := rs.GetByHash(ctx, "new")
+	if err != nil || next.FamilyID != familyID {
+		t.Fatalf("GetByHash(new) = %+v, %v, want the same family", next, err)
+	}
+	if old, _ = rs.GetByHash(ctx, "old"); old == nil || old.RotatedAt == nil {
+		t.Errorf("old token = %+v, want it kept and marked rotated", old)
+	}
+	if err := rs.RotateToken(ctx, old.ID, "newer", time.Now().Add(2*time.Hour), laptop); err == nil {
+		t.Error("expected rotating an already rotated token to fail")
+	}
+
+	sessions, err := rs.ListSessions(ctx, userID)
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("sessions = %+v, want 1", sessions)
+	}
+	if s := sessions[0]; s.ID != familyID || s.DeviceName != "laptop" || s.UserAgent != "niotebook-tui/1.1" || s.IP != "10.0.0.2" {
+		t.Errorf("session = %+v, want the laptop with updated agent and IP", s)
+	}
+}
+
+func TestDeleteFamily(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	rs := store.NewRefreshTokenStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	familyID, _ := rs.StoreToken(ctx, userID, "first", time.Now().Add(time.Hour), models.Device{})
+	_, _ = rs.StoreToken(ctx, userID, "other-device", time.Now().Add(time.Hour), models.Device{})
+	first, _ := rs.GetByHash(ctx, "first")
+	if err := rs.RotateToken(ctx, first.ID, "second", time.Now().Add(time.Hour), models.Device{}); err != nil {
+		t.Fatalf("RotateToken: %v", err)
+	}
+
+	if err := rs.DeleteFamily(ctx, familyID); err != nil {
+		t.Fatalf("DeleteFamily: %v", err)
+	}
+	for _, hash := range []string{"first", "second"} {
+		if _, err := rs.GetByHash(ctx, hash); err == nil {
+			t.Errorf("expected token %q of the family to be revoked", hash)
+		}
+	}
+	if _, err := rs.GetByHash(ctx, "other-device"); err != nil {
+		t.Errorf("other session should survive: %v", err)
+	}
+}
+
+func TestDeleteSession(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	rs := store.NewRefreshTokenStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	id, _ := rs.StoreToken(ctx, akram, "akram-token", time.Now().Add(time.Hour), models.Device{})
+	_, _ = rs.StoreToken(ctx, akram, "expired", time.Now().Add(-time.Hour), models.Device{})
+
+	var apiErr *models.APIError
+	if err := rs.DeleteSession(ctx, sara, id); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("deleting another user's session error = %v, want not_found", err)
+	}
+	if err := rs.DeleteSession(ctx, akram, id); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	sessions, err := rs.ListSessions(ctx, akram)
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Errorf("sessions = %+v, want none left unexpired", sessions)
+	}
+}
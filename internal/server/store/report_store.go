@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reportReturning lists the columns scanned by scanReport.
+const reportReturning = `id, user_id, post_id, reason, details, status, created_at, resolved_at`
+
+type reportStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewReportStore(pool *pgxpool.Pool) ReportStore {
+	return &reportStore{pool: pool}
+}
+
+// CreatePostReport reports postID, and with it the post's author.
+func (s *reportStore) CreatePostReport(ctx context.Context, reporterID, postID, reason, details string) (*models.Report, error) {
+	report, err := scanReport(s.pool.QueryRow(ctx,
+		`INSERT INTO reports (reporter_id, user_id, post_id, reason, details)
+		 SELECT $1, p.author_id, p.id, $3, $4
+		 FROM posts p WHERE p.id = $2 AND p.deleted_at IS NULL
+		 RETURNING `+reportReturning,
+		reporterID, postID, reason, details,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, &models.APIError{Code: models.ErrCodeNotFound, Message: "post not found"}
+	}
+	if err != nil {
+		return nil, reportError(err, "post")
+	}
+	return report, nil
+}
+
+func (s *reportStore) CreateUserReport(ctx context.Context, reporterID, userID, reason, details string) (*models.Report, error) {
+	report, err := scanReport(s.pool.QueryRow(ctx,
+		`INSERT INTO reports (reporter_id, user_id, reason, details)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING `+reportReturning,
+		reporterID, userID, reason, details,
+	))
+	if err != nil {
+		return nil, reportError(err, "user")
+	}
+	return report, nil
+}
+
+// reportError maps constraint violations from filing a report about a post
+// or user to API errors.
+func reportError(err error, target string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return &models.APIError{Code: models.ErrCodeConflict, Message: "you have already reported this " + target}
+		case "23503":
+			return &models.APIError{Code: models.ErrCodeNotFound, Message: target + " not found"}
+		case "23514":
+			if pgErr.ConstraintName == "reports_no_self_report" {
+				return &models.APIError{Code: models.ErrCodeValidation, Message: "you cannot report yourself"}
+			}
+			return &models.APIError{Code: models.ErrCodeValidation, Message: "invalid report"}
+		}
+	}
+	return fmt.Errorf("create report: %w", err)
+}
+
+// GetReports returns reports with the given status, newest first, with the
+// reporter, the reported account and any reported post filled in.
+func (s *reportStore) GetReports(ctx context.Context, status string, cursor time.Time, limit int) ([]models.Report, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT r.id, r.user_id, r.post_id, r.reason, r.details, r.status, r.created_at, r.resolved_at,
+		        ru.id, ru.username, ru.display_name, ru.bio, ru.created_at,
+		        u.id, u.username, u.display_name, u.bio, u.created_at,
+		        p.id, p.author_id, p.kind, p.content, p.deleted_at IS NOT NULL, p.created_at
+		 FROM reports r
+		 JOIN users ru ON ru.id = r.reporter_id
+		 JOIN users u ON u.id = r.user_id
+		 LEFT JOIN posts p ON p.id = r.post_id
+		 WHERE r.status = $1 AND r.created_at < $2
+		 ORDER BY r.created_at DESC
+		 LIMIT $3`, status, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []models.Report{}
+	for rows.Next() {
+		var r models.Report
+		var reporter, user models.User
+		// The post's columns are NULL for reports about an account.
+		var (
+			postID, postAuthorID, postKind, postContent *string
+			postDeleted                                 *bool
+			postCreatedAt                               *time.Time
+		)
+		if err := rows.Scan(
+			&r.ID, &r.UserID, &r.PostID, &r.Reason, &r.Details, &r.Status, &r.CreatedAt, &r.ResolvedAt,
+			&reporter.ID, &reporter.Username, &reporter.DisplayName, &reporter.Bio, &reporter.CreatedAt,
+			&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.CreatedAt,
+			&postID, &postAuthorID, &postKind, &postContent, &postDeleted, &postCreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan report: %w", err)
+		}
+		r.Reporter = &reporter
+		r.User = &user
+		if postID != nil {
+			r.Post = &models.Post{
+				ID:        *postID,
+				AuthorID:  *postAuthorID,
+				Author:    &user,
+				Kind:      *postKind,
+				Content:   *postContent,
+				Deleted:   *postDeleted,
+				CreatedAt: *postCreatedAt,
+			}
+		}
+		reports = append(reports, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reports: %w", err)
+	}
+	return reports, nil
+}
+
+func scanReport(row pgx.Row) (*models.Report, error) {
+	var r models.Report
+	if err := row.Scan(&r.ID, &r.UserID, &r.PostID, &r.Reason, &r.Details, &r.Status, &r.CreatedAt, &r.ResolvedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,122 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestCreateReports(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	rs := store.NewReportStore(pool)
+	ctx := context.Background()
+
+	var apiErr *models.APIError
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	post, _ := ps.CreatePost(ctx, sara, "Buy followers now")
+
+	report, err := rs.CreatePostReport(ctx, akram, post.ID, models.ReportReasonSpam, "links everywhere")
+	if err != nil {
+		t.Fatalf("CreatePostReport: %v", err)
+	}
+	if report.UserID != sara || report.PostID == nil || *report.PostID != post.ID || report.Status != models.ReportStatusOpen {
+		t.Errorf("report = %+v, want an open report on sara's post", report)
+	}
+	if _, err := rs.CreatePostReport(ctx, akram, post.ID, models.ReportReasonHate, ""); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeConflict {
+		t.Errorf("duplicate post report error = %v, want conflict", err)
+	}
+	if _, err := rs.CreatePostReport(ctx, akram, "00000000-0000-0000-0000-000000000000", models.ReportReasonSpam, ""); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("missing post report error = %v, want not found", err)
+	}
+
+	userReport, err := rs.CreateUserReport(ctx, akram, sara, models.ReportReasonHarassment, "")
+	if err != nil {
+		t.Fatalf("CreateUserReport: %v", err)
+	}
+	if userReport.PostID != nil {
+		t.Errorf("user report post = %v, want none", *userReport.PostID)
+	}
+	// A report about the account is separate from reports about its posts
+	if _, err := rs.CreateUserReport(ctx, akram, sara, models.ReportReasonSpam, ""); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeConflict {
+		t.Errorf("duplicate user report error = %v, want conflict", err)
+	}
+	if _, err := rs.CreateUserReport(ctx, akram, akram, models.ReportReasonSpam, ""); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeValidation {
+		t.Errorf("self report error = %v, want validation error", err)
+	}
+	if _, err := rs.CreateUserReport(ctx, akram, "00000000-0000-0000-0000-000000000000", models.ReportReasonSpam, ""); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("missing user report error = %v, want not found", err)
+	}
+}
+
+func TestGetReportsByStatus(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	rs := store.NewReportStore(pool)
+	ms := store.NewModerationStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	mod := createTestUser(t, us, "mod", "mod@example.com")
+	post, _ := ps.CreatePost(ctx, sara, "Buy followers now")
+
+	postReport, err := rs.CreatePostReport(ctx, akram, post.ID, models.ReportReasonSpam, "")
+	if err != nil {
+		t.Fatalf("CreatePostReport: %v", err)
+	}
+	userReport, err := rs.CreateUserReport(ctx, akram, sara, models.ReportReasonHarassment, "")
+	if err != nil {
+		t.Fatalf("CreateUserReport: %v", err)
+	}
+
+	cursor := time.Now().Add(time.Second)
+	open, err := rs.GetReports(ctx, models.ReportStatusOpen, cursor, 50)
+	if err != nil {
+		t.Fatalf("GetReports: %v", err)
+	}
+	if len(open) != 2 || open[0].ID != userReport.ID || open[1].ID != postReport.ID {
+		t.Fatalf("open = %+v, want the user report then the post report", open)
+	}
+	if open[0].Post != nil || open[1].Post == nil || open[1].Post.Content != "Buy followers now" {
+		t.Errorf("open = %+v, want only the post report to carry the post", open)
+	}
+	if open[1].Reporter.Username != "akram" || open[1].User.Username != "sara" {
+		t.Errorf("post report = %+v, want akram reporting sara", open[1])
+	}
+	if page, _ := rs.GetReports(ctx, models.ReportStatusOpen, open[0].CreatedAt, 50); len(page) != 1 || page[0].ID != postReport.ID {
+		t.Errorf("next page = %+v, want the post report", page)
+	}
+
+	// Hiding the post resolves its report; dismissing closes the other
+	if err := ms.HidePost(ctx, mod, post.ID, ""); err != nil {
+		t.Fatalf("HidePost: %v", err)
+	}
+	if err := ms.DismissReport(ctx, mod, userReport.ID, ""); err != nil {
+		t.Fatalf("DismissReport: %v", err)
+	}
+
+	if open, _ := rs.GetReports(ctx, models.ReportStatusOpen, cursor, 50); len(open) != 0 {
+		t.Errorf("open = %+v, want none", open)
+	}
+	resolved, _ := rs.GetReports(ctx, models.ReportStatusResolved, cursor, 50)
+	if len(resolved) != 1 || resolved[0].ID != postReport.ID || resolved[0].ResolvedAt == nil {
+		t.Errorf("resolved = %+v, want the post report", resolved)
+	}
+	dismissed, _ := rs.GetReports(ctx, models.ReportStatusDismissed, cursor, 50)
+	if len(dismissed) != 1 || dismissed[0].ID != userReport.ID {
+		t.Errorf("dismissed = %+v, want the user report", dismissed)
+	}
+
+	// Once a report is closed the reporter can report the account again
+	if _, err := rs.CreateUserReport(ctx, akram, sara, models.ReportReasonHarassment, "still at it"); err != nil {
+		t.Errorf("report after dismissal: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestRepostAndCount(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	post, _ := ps.CreatePost(ctx, akram, "Share me")
+
+	repost, err := ps.CreateRepost(ctx, sara, post.ID)
+	if err != nil {
+		t.Fatalf("CreateRepost: %v", err)
+	}
+	if repost.Kind != models.PostKindRepost || repost.OriginalID == nil || *repost.OriginalID != post.ID {
+		t.Errorf("repost = %+v", repost)
+	}
+
+	_, err = ps.CreateRepost(ctx, sara, post.ID)
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeConflict {
+		t.Errorf("duplicate repost error = %v, want conflict", err)
+	}
+
+	got, err := ps.GetPostByID(ctx, sara, repost.ID)
+	if err != nil {
+		t.Fatalf("GetPostByID: %v", err)
+	}
+	if got.Original == nil || got.Original.ID != post.ID || got.Original.Author.Username != "akram" {
+		t.Fatalf("repost original = %+v", got.Original)
+	}
+	if got.Original.RepostCount != 1 || !got.Original.RepostedByMe {
+		t.Errorf("original repost state = %d/%v, want 1/true", got.Original.RepostCount, got.Original.RepostedByMe)
+	}
+
+	if err := ps.DeleteRepost(ctx, sara, post.ID); err != nil {
+		t.Fatalf("DeleteRepost: %v", err)
+	}
+	count, _ := ps.CountReposts(ctx, post.ID)
+	if count != 0 {
+		t.Errorf("count after delete = %d, want 0", count)
+	}
+}
+
+func TestRepostNonexistentPost(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+
+	if _, err := ps.CreateRepost(ctx, userID, "00000000-0000-0000-0000-000000000000"); err == nil {
+		t.Fatal("expected error reposting a nonexistent post")
+	}
+}
+
+func TestTimelineShowsLatestShareOnly(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	omar := createTestUser(t, us, "omar", "omar@example.com")
+
+	original, _ := ps.CreatePost(ctx, akram, "Original")
+	other, _ := ps.CreatePost(ctx, akram, "Other")
+	if _, err := ps.CreateRepost(ctx, sara, original.ID); err != nil {
+		t.Fatalf("CreateRepost: %v", err)
+	}
+	latest, err := ps.CreateRepost(ctx, omar, original.ID)
+	if err != nil {
+		t.Fatalf("CreateRepost: %v", err)
+	}
+
+	posts, err := ps.GetTimeline(ctx, "", time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetTimeline: %v", err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("got %d posts, want 2", len(posts))
+	}
+	if posts[0].ID != latest.ID || posts[0].Original == nil || posts[0].Original.ID != original.ID {
+		t.Errorf("first entry = %+v, want omar's repost of the original", posts[0])
+	}
+	if posts[1].ID != other.ID {
+		t.Errorf("second entry = %q, want %q", posts[1].ID, other.ID)
+	}
+}
+
+func TestCreateQuote(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	post, _ := ps.CreatePost(ctx, akram, "Quote me")
+
+	quote, err := ps.CreateQuote(ctx, sara, post.ID, "Well said")
+	if err != nil {
+		t.Fatalf("CreateQuote: %v", err)
+	}
+
+	got, _ := ps.GetPostByID(ctx, "", quote.ID)
+	if got.Kind != models.PostKindQuote || got.Content != "Well said" {
+		t.Errorf("quote = %+v", got)
+	}
+	if got.Original == nil || got.Original.Content != "Quote me" {
+		t.Errorf("quote original = %+v", got.Original)
+	}
+
+	if _, err := ps.CreateQuote(ctx, sara, post.ID, "   "); err == nil {
+		t.Error("expected error for empty quote")
+	}
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type searchStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewSearchStore(pool *pgxpool.Pool) SearchStore {
+	return &searchStore{pool: pool}
+}
+
+// SearchPosts returns visible posts whose content matches query, newest
+// first. query uses web search syntax: quoted phrases, "or" and "-word".
+func (s *searchStore) SearchPosts(ctx context.Context, viewerID, query string, cursor time.Time, limit int) ([]models.Post, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 WHERE p.search_vector @@ websearch_to_tsquery('english', $2)
+		   AND p.created_at < $3
+		   AND `+feedVisible+`
+		   AND `+notHidden+`
+		 ORDER BY p.created_at DESC
+		 LIMIT $4`, viewerParam(viewerID), query, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search posts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+// SearchUsers returns users whose username or display name contains or
+// closely resembles query, newest first. Only active accounts are returned,
+// and users blocked either way by viewerID are left out.
+func (s *searchStore) SearchUsers(ctx context.Context, viewerID, query string, cursor time.Time, limit int) ([]models.User, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, username, display_name, bio, created_at
+		 FROM users
+		 WHERE (username ILIKE $2 OR display_name ILIKE $2
+		        OR username % $1 OR display_name % $1)
+		   AND status = 'active'
+		   AND created_at < $3
+		   AND NOT `+blockedBetween(`users.id`, `$5::uuid`)+`
+		 ORDER BY created_at DESC
+		 LIMIT $4`, query, "%"+escapeLike(query)+"%", cursor, limit, viewerParam(viewerID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.ID, &u.Username, &u.DisplayName, &u.Bio, &u.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate users: %w", err)
+	}
+	return users, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,89 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestSearchPosts(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ss := store.NewSearchStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+
+	first, _ := ps.CreatePost(ctx, akram, "Writing terminal apps in Go")
+	second, _ := ps.CreatePost(ctx, akram, "Another terminal session")
+	if _, err := ps.CreatePost(ctx, akram, "Nothing relevant"); err != nil {
+		t.Fatalf("CreatePost: %v", err)
+	}
+	deleted, _ := ps.CreatePost(ctx, akram, "Deleted terminal post")
+	if err := ps.DeletePost(ctx, deleted.ID); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+
+	posts, err := ss.SearchPosts(ctx, akram, "terminal", time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if len(posts) != 2 || posts[0].ID != second.ID || posts[1].ID != first.ID {
+		t.Fatalf("results = %+v, want both terminal posts newest first", posts)
+	}
+
+	// Stemming matches other forms of a word
+	stemmed, _ := ss.SearchPosts(ctx, akram, "write", time.Now().Add(time.Second), 50)
+	if len(stemmed) != 1 || stemmed[0].ID != first.ID {
+		t.Errorf("results for write = %+v, want the writing post", stemmed)
+	}
+
+	page, _ := ss.SearchPosts(ctx, akram, "terminal", posts[0].CreatedAt, 50)
+	if len(page) != 1 || page[0].ID != first.ID {
+		t.Errorf("second page = %+v, want the older post", page)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ss := store.NewSearchStore(pool)
+	ctx := context.Background()
+
+	sara := createTestUser(t, us, "sara_k", "sara@example.com")
+	createTestUser(t, us, "omar", "omar@example.com")
+
+	users, err := ss.SearchUsers(ctx, "", "sar", time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("SearchUsers: %v", err)
+	}
+	if len(users) != 1 || users[0].ID != sara {
+		t.Errorf("results = %+v, want sara_k", users)
+	}
+
+	// Underscores match literally rather than as a LIKE wildcard
+	none, _ := ss.SearchUsers(ctx, "", "a_k_x", time.Now().Add(time.Second), 50)
+	if len(none) != 0 {
+		t.Errorf("results = %+v, want none", none)
+	}
+
+	// Suspended and deactivated accounts are left out
+	sarah := createTestUser(t, us, "sarah", "sarah@example.com")
+	if err := us.SetStatus(ctx, sara, models.AccountActive, models.AccountSuspended); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if err := us.SetStatus(ctx, sarah, models.AccountActive, models.AccountDeactivated); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	users, err = ss.SearchUsers(ctx, "", "sar", time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("SearchUsers: %v", err)
+	}
+	if len(users) != 0 {
+		t.Errorf("results = %+v, want no inactive users", users)
+	}
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type tagStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewTagStore(pool *pgxpool.Pool) TagStore {
+	return &tagStore{pool: pool}
+}
+
+// SetTags makes postID carry exactly tags, creating tags that do not exist
+// yet and dropping the ones the post no longer carries. The order of tags
+// is kept as the tags' positions in the post.
+func (s *tagStore) SetTags(ctx context.Context, postID string, tags []string) error {
+	if tags == nil {
+		// A nil slice is sent as NULL, which would match no tag to drop
+		tags = []string{}
+	}
+	_, err := s.pool.Exec(ctx,
+		`WITH dropped AS (
+		     DELETE FROM post_tags pt
+		     USING tags t
+		     WHERE pt.post_id = $1 AND t.id = pt.tag_id
+		       AND NOT t.name = ANY($2)
+		 ), names AS (
+		     SELECT name, position FROM unnest($2::text[]) WITH ORDINALITY AS n(name, position)
+		 ), upserted AS (
+		     INSERT INTO tags (name)
+		     SELECT name FROM names
+		     ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		     RETURNING id, name
+		 )
+		 INSERT INTO post_tags (post_id, tag_id, position)
+		 SELECT $1, upserted.id, names.position
+		 FROM upserted JOIN names ON names.name = upserted.name
+		 ON CONFLICT (post_id, tag_id) DO UPDATE SET position = EXCLUDED.position`,
+		postID, tags,
+	)
+	if err != nil {
+		return fmt.Errorf("set tags: %w", err)
+	}
+	return nil
+}
+
+// GetTagPosts returns posts carrying tag, newest first.
+func (s *tagStore) GetTagPosts(ctx context.Context, viewerID, tag string, cursor time.Time, limit int) ([]models.Post, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT `+postColumns+`
+		 FROM `+postFrom+`
+		 JOIN post_tags pt ON pt.post_id = p.id
+		 JOIN tags t ON t.id = pt.tag_id AND t.name = $2
+		 WHERE p.created_at < $3
+		   AND `+feedVisible+`
+		   AND `+notHidden+`
+		 ORDER BY p.created_at DESC
+		 LIMIT $4`, viewerParam(viewerID), tag, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get tag posts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+// GetTrending returns the tags used by the most visible posts created after
+// since, most used first. Ties go to the tag used most recently.
+func (s *tagStore) GetTrending(ctx context.Context, since time.Time, limit int) ([]models.TrendingTag, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT t.name, COUNT(*) AS uses
+		 FROM post_tags pt
+		 JOIN tags t ON t.id = pt.tag_id
+		 JOIN posts p ON p.id = pt.post_id
+		 WHERE pt.created_at >= $1
+		   AND p.deleted_at IS NULL
+		 GROUP BY t.name
+		 ORDER BY uses DESC, MAX(pt.created_at) DESC
+		 LIMIT $2`, since, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get trending tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []models.TrendingTag
+	for rows.Next() {
+		var tag models.TrendingTag
+		if err := rows.Scan(&tag.Tag, &tag.PostCount); err != nil {
+			return nil, fmt.Errorf("scan trending tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate trending tags: %w", err)
+	}
+	return tags, nil
+}
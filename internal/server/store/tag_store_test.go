@@ -0,0 +1,78 @@
+package store_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestTagPostsAndTrending(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ts := store.NewTagStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+
+	first, _ := ps.CreatePost(ctx, akram, "#tui with #go")
+	if err := ts.SetTags(ctx, first.ID, []string{"tui", "go"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	second, _ := ps.CreatePost(ctx, akram, "more #go")
+	if err := ts.SetTags(ctx, second.ID, []string{"go"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	deleted, _ := ps.CreatePost(ctx, akram, "#tui again")
+	if err := ts.SetTags(ctx, deleted.ID, []string{"tui"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	if err := ps.DeletePost(ctx, deleted.ID); err != nil {
+		t.Fatalf("DeletePost: %v", err)
+	}
+
+	posts, err := ts.GetTagPosts(ctx, akram, "go", time.Now().Add(time.Second), 50)
+	if err != nil {
+		t.Fatalf("GetTagPosts: %v", err)
+	}
+	if len(posts) != 2 || posts[0].ID != second.ID || posts[1].ID != first.ID {
+		t.Fatalf("tag posts = %+v, want both #go posts newest first", posts)
+	}
+	if !reflect.DeepEqual(posts[1].Tags, []string{"tui", "go"}) {
+		t.Errorf("tags = %v, want [tui go] in order of appearance", posts[1].Tags)
+	}
+
+	tui, _ := ts.GetTagPosts(ctx, akram, "tui", time.Now().Add(time.Second), 50)
+	if len(tui) != 1 || tui[0].ID != first.ID {
+		t.Errorf("#tui posts = %+v, want only the undeleted post", tui)
+	}
+
+	trending, err := ts.GetTrending(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("GetTrending: %v", err)
+	}
+	if len(trending) != 2 || trending[0].Tag != "go" || trending[0].PostCount != 2 ||
+		trending[1].Tag != "tui" || trending[1].PostCount != 1 {
+		t.Errorf("trending = %+v, want go (2) then tui (1)", trending)
+	}
+
+	old, _ := ts.GetTrending(ctx, time.Now().Add(time.Hour), 10)
+	if len(old) != 0 {
+		t.Errorf("trending outside window = %+v, want none", old)
+	}
+
+	// Setting tags again replaces them
+	if err := ts.SetTags(ctx, first.ID, []string{"go", "rust"}); err != nil {
+		t.Fatalf("SetTags: %v", err)
+	}
+	if tui, _ := ts.GetTagPosts(ctx, akram, "tui", time.Now().Add(time.Second), 50); len(tui) != 0 {
+		t.Errorf("#tui posts after retagging = %+v, want none", tui)
+	}
+	rust, _ := ts.GetTagPosts(ctx, akram, "rust", time.Now().Add(time.Second), 50)
+	if len(rust) != 1 || !reflect.DeepEqual(rust[0].Tags, []string{"go", "rust"}) {
+		t.Errorf("#rust posts = %+v, want the retagged post with [go rust]", rust)
+	}
+}
@@ -39,7 +39,7 @@ func setupTestDB(t *testing.T) *pgxpool.Pool {
 
 	t.Cleanup(func() {
 		_, _ = pool.Exec(context.Background(),
-			"TRUNCATE users, posts, refresh_tokens CASCADE")
+			"TRUNCATE users, posts, tags, conversations, refresh_tokens CASCADE")
 		pool.Close()
 	})
 
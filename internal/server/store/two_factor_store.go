@@ -0,0 +1,142 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type twoFactorStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewTwoFactorStore(pool *pgxpool.Pool) TwoFactorStore {
+	return &twoFactorStore{pool: pool}
+}
+
+// SetPendingSecret stores a new secret for userID that isn't enabled yet,
+// replacing an earlier pending one. It fails with a conflict once
+// two-factor authentication is enabled.
+func (s *twoFactorStore) SetPendingSecret(ctx context.Context, userID, secret string) error {
+	tag, err := s.pool.Exec(ctx,
+		`INSERT INTO user_totp (user_id, secret)
+		 VALUES ($1, $2)
+		 ON CONFLICT (user_id) DO UPDATE
+		 SET secret = EXCLUDED.secret, last_step = 0, created_at = NOW()
+		 WHERE user_totp.enabled_at IS NULL`,
+		userID, secret,
+	)
+	if err != nil {
+		return fmt.Errorf("set totp secret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeConflict, Message: "two-factor authentication is already enabled"}
+	}
+	return nil
+}
+
+func (s *twoFactorStore) GetSecret(ctx context.Context, userID string) (string, bool, error) {
+	var secret string
+	var enabled bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT secret, enabled_at IS NOT NULL FROM user_totp WHERE user_id = $1`, userID,
+	).Scan(&secret, &enabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, &models.APIError{Code: models.ErrCodeNotFound, Message: "two-factor authentication is not set up"}
+		}
+		return "", false, fmt.Errorf("get totp secret: %w", err)
+	}
+	return secret, enabled, nil
+}
+
+// Enable turns on userID's pending secret, recording step as used, and
+// replaces their recovery codes with recoveryHashes.
+func (s *twoFactorStore) Enable(ctx context.Context, userID string, step int64, recoveryHashes []string) error {
+	var enabled bool
+	err := s.pool.QueryRow(ctx,
+		`WITH enabled AS (
+		     UPDATE user_totp SET enabled_at = NOW(), last_step = $2
+		     WHERE user_id = $1 AND enabled_at IS NULL
+		     RETURNING user_id
+		 ), cleared AS (
+		     DELETE FROM totp_recovery_codes WHERE user_id IN (SELECT user_id FROM enabled)
+		 ), inserted AS (
+		     INSERT INTO totp_recovery_codes (user_id, code_hash)
+		     SELECT enabled.user_id, hash FROM enabled, unnest($3::text[]) AS hash
+		 )
+		 SELECT EXISTS (SELECT 1 FROM enabled)`,
+		userID, step, recoveryHashes,
+	).Scan(&enabled)
+	if err != nil {
+		return fmt.Errorf("enable totp: %w", err)
+	}
+	if !enabled {
+		return &models.APIError{Code: models.ErrCodeConflict, Message: "two-factor authentication is already enabled"}
+	}
+	return nil
+}
+
+// Disable removes userID's secret, recovery codes and pending login
+// challenges.
+func (s *twoFactorStore) Disable(ctx context.Context, userID string) error {
+	_, err := s.pool.Exec(ctx,
+		`WITH codes AS (
+		     DELETE FROM totp_recovery_codes WHERE user_id = $1
+		 ), challenges AS (
+		     DELETE FROM login_challenges WHERE user_id = $1
+		 )
+		 DELETE FROM user_totp WHERE user_id = $1`, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("disable totp: %w", err)
+	}
+	return nil
+}
+
+// UseStep records that the code for step was used. It fails if a code
+// from that step or a later one was already used.
+func (s *twoFactorStore) UseStep(ctx context.Context, userID string, step int64) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE user_totp SET last_step = $2
+		 WHERE user_id = $1 AND enabled_at IS NOT NULL AND last_step < $2`,
+		userID, step,
+	)
+	if err != nil {
+		return fmt.Errorf("use totp step: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "code", Message: "code was already used"}
+	}
+	return nil
+}
+
+// UseRecoveryCode deletes one of userID's recovery codes.
+func (s *twoFactorStore) UseRecoveryCode(ctx context.Context, userID, codeHash string) error {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM totp_recovery_codes WHERE user_id = $1 AND code_hash = $2`,
+		userID, codeHash,
+	)
+	if err != nil {
+		return fmt.Errorf("use recovery code: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeUnauthorized, Field: "code", Message: "invalid two-factor code"}
+	}
+	return nil
+}
+
+func (s *twoFactorStore) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM totp_recovery_codes WHERE user_id = $1`, userID,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count recovery codes: %w", err)
+	}
+	return count, nil
+}
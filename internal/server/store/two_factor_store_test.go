@@ -0,0 +1,83 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/server/store"
+)
+
+func TestTwoFactorSecrets(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ts := store.NewTwoFactorStore(pool)
+	ctx := context.Background()
+
+	userID := createTestUser(t, us, "akram", "akram@example.com")
+	var apiErr *models.APIError
+
+	if _, _, err := ts.GetSecret(ctx, userID); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("secret before setup error = %v, want not found", err)
+	}
+
+	// A pending secret can be replaced
+	if err := ts.SetPendingSecret(ctx, userID, "SECRETONE"); err != nil {
+		t.Fatalf("SetPendingSecret: %v", err)
+	}
+	if err := ts.SetPendingSecret(ctx, userID, "SECRETTWO"); err != nil {
+		t.Fatalf("SetPendingSecret again: %v", err)
+	}
+	secret, enabled, err := ts.GetSecret(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetSecret: %v", err)
+	}
+	if secret != "SECRETTWO" || enabled {
+		t.Errorf("GetSecret = %q, %v, want SECRETTWO, false", secret, enabled)
+	}
+	if err := ts.UseStep(ctx, userID, 100); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("use step while pending error = %v, want unauthorized", err)
+	}
+
+	if err := ts.Enable(ctx, userID, 100, []string{"hash1", "hash2"}); err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	if _, enabled, _ := ts.GetSecret(ctx, userID); !enabled {
+		t.Error("expected two-factor authentication to be enabled")
+	}
+	if err := ts.Enable(ctx, userID, 101, []string{"hash3"}); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeConflict {
+		t.Errorf("enable twice error = %v, want conflict", err)
+	}
+	if err := ts.SetPendingSecret(ctx, userID, "SECRETTHREE"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeConflict {
+		t.Errorf("new secret while enabled error = %v, want conflict", err)
+	}
+
+	// Steps only move forward
+	if err := ts.UseStep(ctx, userID, 100); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("enabling step reused error = %v, want unauthorized", err)
+	}
+	if err := ts.UseStep(ctx, userID, 101); err != nil {
+		t.Errorf("UseStep: %v", err)
+	}
+
+	if err := ts.UseRecoveryCode(ctx, userID, "hash1"); err != nil {
+		t.Errorf("UseRecoveryCode: %v", err)
+	}
+	if err := ts.UseRecoveryCode(ctx, userID, "hash1"); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeUnauthorized {
+		t.Errorf("used recovery code error = %v, want unauthorized", err)
+	}
+	if count, err := ts.CountRecoveryCodes(ctx, userID); err != nil || count != 1 {
+		t.Errorf("CountRecoveryCodes = %d, %v, want 1", count, err)
+	}
+
+	if err := ts.Disable(ctx, userID); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if _, _, err := ts.GetSecret(ctx, userID); !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("secret after disable error = %v, want not found", err)
+	}
+	if count, _ := ts.CountRecoveryCodes(ctx, userID); count != 0 {
+		t.Errorf("recovery codes after disable = %d, want 0", count)
+	}
+}
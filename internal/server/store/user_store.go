@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/jackc/pgx/v5"
@@ -25,9 +26,9 @@ func (s *userStore) CreateUser(ctx context.Context, username, email, passwordHas
 	err := s.pool.QueryRow(ctx,
 		`INSERT INTO users (username, email, password, display_name)
 		 VALUES ($1, $2, $3, $4)
-		 RETURNING id, username, display_name, bio, created_at`,
+		 RETURNING id, username, display_name, bio, role, created_at`,
 		strings.ToLower(username), strings.ToLower(email), passwordHash, displayName,
-	).Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.CreatedAt)
+	).Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.Role, &user.CreatedAt)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -50,9 +51,9 @@ func (s *userStore) GetUserByEmail(ctx context.Context, email string) (*models.U
 	var passwordHash string
 	var ignoredEmail string
 	err := s.pool.QueryRow(ctx,
-		`SELECT id, username, email, password, display_name, bio, created_at
+		`SELECT id, username, email, password, display_name, bio, role, created_at
 		 FROM users WHERE LOWER(email) = LOWER($1)`, email,
-	).Scan(&user.ID, &user.Username, &ignoredEmail, &passwordHash, &user.DisplayName, &user.Bio, &user.CreatedAt)
+	).Scan(&user.ID, &user.Username, &ignoredEmail, &passwordHash, &user.DisplayName, &user.Bio, &user.Role, &user.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -67,9 +68,9 @@ func (s *userStore) GetUserByEmail(ctx context.Context, email string) (*models.U
 func (s *userStore) GetUserByID(ctx context.Context, id string) (*models.User, error) {
 	var user models.User
 	err := s.pool.QueryRow(ctx,
-		`SELECT id, username, display_name, bio, created_at
+		`SELECT id, username, display_name, bio, role, created_at
 		 FROM users WHERE id = $1`, id,
-	).Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.CreatedAt)
+	).Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.Role, &user.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -84,9 +85,9 @@ func (s *userStore) GetUserByID(ctx context.Context, id string) (*models.User, e
 func (s *userStore) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
 	var user models.User
 	err := s.pool.QueryRow(ctx,
-		`SELECT id, username, display_name, bio, created_at
+		`SELECT id, username, display_name, bio, role, created_at
 		 FROM users WHERE LOWER(username) = LOWER($1)`, username,
-	).Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.CreatedAt)
+	).Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.Role, &user.CreatedAt)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -123,16 +124,211 @@ func (s *userStore) UpdateUser(ctx context.Context, id string, updates *models.U
 
 	query := fmt.Sprintf(
 		`UPDATE users SET %s WHERE id = $%d
-		 RETURNING id, username, display_name, bio, created_at`,
+		 RETURNING id, username, display_name, bio, role, created_at`,
 		strings.Join(setClauses, ", "), argIdx,
 	)
 
 	var user models.User
 	err := s.pool.QueryRow(ctx, query, args...).
-		Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.CreatedAt)
+		Scan(&user.ID, &user.Username, &user.DisplayName, &user.Bio, &user.Role, &user.CreatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("update user: %w", err)
 	}
 
 	return &user, nil
 }
+
+// GetPasswordHash returns user id's password hash, for re-checking the
+// password of a signed-in user.
+func (s *userStore) GetPasswordHash(ctx context.Context, id string) (string, error) {
+	var hash string
+	err := s.pool.QueryRow(ctx,
+		`SELECT password FROM users WHERE id = $1`, id,
+	).Scan(&hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		}
+		return "", fmt.Errorf("get password hash: %w", err)
+	}
+	return hash, nil
+}
+
+// SetPasswordHash replaces user id's password hash.
+func (s *userStore) SetPasswordHash(ctx context.Context, id, hash string) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE users SET password = $2, updated_at = NOW() WHERE id = $1`, id, hash,
+	)
+	if err != nil {
+		return fmt.Errorf("set password hash: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	return nil
+}
+
+// GetEmail returns user id's email address and whether they have verified
+// it.
+func (s *userStore) GetEmail(ctx context.Context, id string) (string, bool, error) {
+	var email string
+	var verified bool
+	err := s.pool.QueryRow(ctx,
+		`SELECT email, email_verified_at IS NOT NULL FROM users WHERE id = $1`, id,
+	).Scan(&email, &verified)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		}
+		return "", false, fmt.Errorf("get email: %w", err)
+	}
+	return email, verified, nil
+}
+
+// GetStatus returns user id's account status.
+func (s *userStore) GetStatus(ctx context.Context, id string) (string, error) {
+	var status string
+	err := s.pool.QueryRow(ctx,
+		`SELECT status FROM users WHERE id = $1`, id,
+	).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		}
+		return "", fmt.Errorf("get status: %w", err)
+	}
+	return status, nil
+}
+
+// SetStatus moves user id from status from to status to. It returns a
+// conflict error, and changes nothing, if the account is not in from.
+func (s *userStore) SetStatus(ctx context.Context, id, from, to string) error {
+	var current *string
+	var changed bool
+	err := s.pool.QueryRow(ctx,
+		`WITH target AS (
+		     SELECT status FROM users WHERE id = $1
+		 ), changed AS (
+		     UPDATE users SET status = $3, status_changed_at = NOW(), updated_at = NOW()
+		     WHERE id = $1 AND status = $2
+		     RETURNING id
+		 )
+		 SELECT (SELECT status FROM target), EXISTS (SELECT 1 FROM changed)`,
+		id, from, to,
+	).Scan(&current, &changed)
+	if err != nil {
+		return fmt.Errorf("set status: %w", err)
+	}
+	if current == nil {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	if !changed {
+		return &models.APIError{
+			Code:    models.ErrCodeConflict,
+			Message: fmt.Sprintf("account is %s, not %s", strings.ReplaceAll(*current, "_", " "), strings.ReplaceAll(from, "_", " ")),
+		}
+	}
+	return nil
+}
+
+// DeletePendingUsers permanently deletes the accounts that entered
+// pending_deletion before before. Everything they own goes with them
+// through ON DELETE CASCADE.
+func (s *userStore) DeletePendingUsers(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := s.pool.Exec(ctx,
+		`DELETE FROM users WHERE status = 'pending_deletion' AND status_changed_at < $1`, before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete pending users: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ListInactive returns the status of every account that is not active,
+// keyed by user ID.
+func (s *userStore) ListInactive(ctx context.Context) (map[string]string, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, status FROM users WHERE status <> 'active'`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list inactive users: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]string)
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, fmt.Errorf("scan inactive user: %w", err)
+		}
+		statuses[id] = status
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate inactive users: %w", err)
+	}
+	return statuses, nil
+}
+
+// GetRole returns user id's role.
+func (s *userStore) GetRole(ctx context.Context, id string) (string, error) {
+	var role string
+	err := s.pool.QueryRow(ctx,
+		`SELECT role FROM users WHERE id = $1`, id,
+	).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+		}
+		return "", fmt.Errorf("get role: %w", err)
+	}
+	return role, nil
+}
+
+// SetRole changes user id's role.
+func (s *userStore) SetRole(ctx context.Context, id, role string) error {
+	tag, err := s.pool.Exec(ctx,
+		`UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1`, id, role,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+			return &models.APIError{Code: models.ErrCodeValidation, Field: "role", Message: "invalid role"}
+		}
+		return fmt.Errorf("set role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return &models.APIError{Code: models.ErrCodeNotFound, Message: "user not found"}
+	}
+	return nil
+}
+
+// ListUsers returns every account, newest first, for the admin API.
+func (s *userStore) ListUsers(ctx context.Context, cursor time.Time, limit int) ([]models.AdminUserEntry, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT id, username, display_name, bio, role, created_at, email, status, status_changed_at
+		 FROM users
+		 WHERE created_at < $1
+		 ORDER BY created_at DESC
+		 LIMIT $2`, cursor, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AdminUserEntry{}
+	for rows.Next() {
+		var e models.AdminUserEntry
+		if err := rows.Scan(
+			&e.User.ID, &e.User.Username, &e.User.DisplayName, &e.User.Bio, &e.User.Role, &e.User.CreatedAt,
+			&e.Email, &e.Status, &e.StatusChangedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate users: %w", err)
+	}
+	return entries, nil
+}
@@ -2,7 +2,9 @@ package store_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/server/store"
@@ -203,3 +205,123 @@ func TestUpdateUser(t *testing.T) {
 		t.Errorf("bio = %q, want %q", updated.Bio, "Building Niotebook.")
 	}
 }
+
+func TestSetRole(t *testing.T) {
+	pool := setupTestDB(t)
+	s := store.NewUserStore(pool)
+	ctx := context.Background()
+
+	created, _ := s.CreateUser(ctx, "akram", "akram@example.com", "$2a$12$hash", "akram")
+	if created.Role != models.RoleUser {
+		t.Errorf("new user role = %q, want %q", created.Role, models.RoleUser)
+	}
+
+	if err := s.SetRole(ctx, created.ID, models.RoleAdmin); err != nil {
+		t.Fatalf("SetRole: %v", err)
+	}
+	user, _ := s.GetUserByID(ctx, created.ID)
+	if user.Role != models.RoleAdmin {
+		t.Errorf("role = %q, want %q", user.Role, models.RoleAdmin)
+	}
+
+	err := s.SetRole(ctx, created.ID, "owner")
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeValidation {
+		t.Errorf("unknown role error = %v, want validation error", err)
+	}
+	err = s.SetRole(ctx, "00000000-0000-0000-0000-000000000000", models.RoleUser)
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("missing user error = %v, want not_found", err)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	pool := setupTestDB(t)
+	s := store.NewUserStore(pool)
+	ctx := context.Background()
+
+	_, _ = s.CreateUser(ctx, "akram", "akram@example.com", "$2a$12$hash", "akram")
+	_, _ = s.CreateUser(ctx, "sara", "sara@example.com", "$2a$12$hash", "sara")
+
+	users, err := s.ListUsers(ctx, time.Now().Add(time.Minute), 10)
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("len = %d, want 2", len(users))
+	}
+	if users[0].User.Username != "sara" || users[0].Email != "sara@example.com" {
+		t.Errorf("first = %+v, want sara with email", users[0])
+	}
+	if users[0].Status != models.AccountActive {
+		t.Errorf("status = %q, want %q", users[0].Status, models.AccountActive)
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	pool := setupTestDB(t)
+	s := store.NewUserStore(pool)
+	ctx := context.Background()
+
+	created, _ := s.CreateUser(ctx, "akram", "akram@example.com", "$2a$12$hash", "akram")
+
+	if err := s.SetStatus(ctx, created.ID, models.AccountActive, models.AccountDeactivated); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if status, _ := s.GetStatus(ctx, created.ID); status != models.AccountDeactivated {
+		t.Errorf("status = %q, want %q", status, models.AccountDeactivated)
+	}
+
+	err := s.SetStatus(ctx, created.ID, models.AccountActive, models.AccountDeactivated)
+	var apiErr *models.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeConflict {
+		t.Errorf("wrong from status error = %v, want conflict", err)
+	}
+	err = s.SetStatus(ctx, "00000000-0000-0000-0000-000000000000", models.AccountActive, models.AccountDeactivated)
+	if !errors.As(err, &apiErr) || apiErr.Code != models.ErrCodeNotFound {
+		t.Errorf("missing user error = %v, want not_found", err)
+	}
+}
+
+func TestDeletePendingUsers(t *testing.T) {
+	pool := setupTestDB(t)
+	us := store.NewUserStore(pool)
+	ps := store.NewPostStore(pool)
+	ctx := context.Background()
+
+	akram := createTestUser(t, us, "akram", "akram@example.com")
+	sara := createTestUser(t, us, "sara", "sara@example.com")
+	_, _ = ps.CreatePost(ctx, sara, "Goodbye")
+
+	if err := us.SetStatus(ctx, sara, models.AccountActive, models.AccountPendingDeletion); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	// Still inside the grace window
+	deleted, err := us.DeletePendingUsers(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("DeletePendingUsers: %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("deleted = %d inside the grace window, want 0", deleted)
+	}
+
+	deleted, err = us.DeletePendingUsers(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("DeletePendingUsers: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+	if _, err := us.GetUserByID(ctx, sara); err == nil {
+		t.Error("expected sara to be gone")
+	}
+	if _, err := us.GetUserByID(ctx, akram); err != nil {
+		t.Errorf("akram should be untouched: %v", err)
+	}
+	var posts int
+	_ = pool.QueryRow(ctx, `SELECT COUNT(*) FROM posts WHERE author_id = $1`, sara).Scan(&posts)
+	if posts != 0 {
+		t.Errorf("posts = %d, want them deleted with the account", posts)
+	}
+}
@@ -0,0 +1,80 @@
+package totp_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/server/totp"
+)
+
+// rfcSecret is the SHA-1 key from RFC 6238 appendix B, "12345678901234567890",
+// in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCodeRFC6238Vectors(t *testing.T) {
+	// The RFC lists eight-digit codes; six-digit codes are their last six
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := totp.Code(rfcSecret, totp.Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %q, want %q", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totp.NewSecret()
+	if err != nil {
+		t.Fatalf("NewSecret: %v", err)
+	}
+	now := time.Unix(1_800_000_000, 0)
+	current := totp.Step(now)
+
+	for _, offset := range []int64{-1, 0, 1} {
+		code, _ := totp.Code(secret, current+offset)
+		step, ok := totp.Validate(secret, code, now)
+		if !ok || step != current+offset {
+			t.Errorf("code from step %+d: Validate = %d, %v, want %d, true", offset, step, ok, current+offset)
+		}
+	}
+	for _, offset := range []int64{-2, 2} {
+		code, _ := totp.Code(secret, current+offset)
+		if _, ok := totp.Validate(secret, code, now); ok {
+			t.Errorf("code from step %+d should be outside the allowed drift", offset)
+		}
+	}
+	for _, code := range []string{"", "12345", "1234567", "abcdef"} {
+		if _, ok := totp.Validate(secret, code, now); ok {
+			t.Errorf("Validate(%q) = true, want false", code)
+		}
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("niotebook", "akram", rfcSecret)
+	u, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("parse %q: %v", uri, err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/niotebook:akram" {
+		t.Errorf("uri = %q, want otpauth://totp/niotebook:akram", uri)
+	}
+	q := u.Query()
+	if q.Get("secret") != rfcSecret || q.Get("issuer") != "niotebook" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Errorf("query = %v", q)
+	}
+}
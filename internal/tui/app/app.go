@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
@@ -18,6 +20,16 @@ const (
 	ViewRegister
 	ViewTimeline
 	ViewProfile
+	ViewThread
+	ViewTag
+	ViewTrending
+	ViewSearch
+	ViewNotifications
+	ViewInbox
+	ViewConversation
+	ViewBlocked
+	ViewSessions
+	ViewTwoFactor
 )
 
 // ViewModel is the interface that all view sub-models must implement.
@@ -55,6 +67,72 @@ type TimelineViewModel interface {
 	FetchLatest() tea.Cmd
 }
 
+// ThreadViewModel is the interface for the conversation thread view.
+type ThreadViewModel interface {
+	ViewModel
+	Dismissed() bool
+	SelectedPost() *models.Post
+}
+
+// TagViewModel is the interface for a hashtag's timeline.
+type TagViewModel interface {
+	ViewModel
+	Dismissed() bool
+}
+
+// TrendingViewModel is the interface for the trending tags view.
+type TrendingViewModel interface {
+	ViewModel
+	Dismissed() bool
+}
+
+// SearchViewModel is the interface for the search view.
+type SearchViewModel interface {
+	ViewModel
+	Dismissed() bool
+	IsTextInputFocused() bool
+}
+
+// NotificationsViewModel is the interface for the notifications view.
+type NotificationsViewModel interface {
+	ViewModel
+	Dismissed() bool
+}
+
+// InboxViewModel is the interface for the direct message inbox.
+type InboxViewModel interface {
+	ViewModel
+	Dismissed() bool
+	IsTextInputFocused() bool
+}
+
+// ConversationViewModel is the interface for a direct message conversation.
+type ConversationViewModel interface {
+	ViewModel
+	Dismissed() bool
+	IsTextInputFocused() bool
+}
+
+// BlockedViewModel is the interface for the blocked and muted accounts list.
+type BlockedViewModel interface {
+	ViewModel
+	Dismissed() bool
+}
+
+// SessionsViewModel is the interface for the list of signed-in devices.
+type SessionsViewModel interface {
+	ViewModel
+	Dismissed() bool
+}
+
+// TwoFactorViewModel is the interface for the two-factor authentication
+// settings.
+type TwoFactorViewModel interface {
+	ViewModel
+	Dismissed() bool
+	IsTextInputFocused() bool
+}
+
 // ViewFactory creates view sub-models. This breaks the import cycle between
 // the app and views packages.
 type ViewFactory interface {
@@ -63,16 +141,42 @@ type ViewFactory interface {
 	NewTimeline(c *client.Client) TimelineViewModel
 	NewProfile(c *client.Client, userID string, isOwn bool) ProfileViewModel
 	NewCompose(c *client.Client) ComposeViewModel
+	NewReplyCompose(c *client.Client, parent models.Post) ComposeViewModel
+	NewQuoteCompose(c *client.Client, original models.Post) ComposeViewModel
+	NewThread(c *client.Client, postID string) ThreadViewModel
+	NewTag(c *client.Client, tag string) TagViewModel
+	NewTrending(c *client.Client) TrendingViewModel
+	NewSearch(c *client.Client) SearchViewModel
+	NewNotifications(c *client.Client) NotificationsViewModel
+	NewInbox(c *client.Client, userID string) InboxViewModel
+	NewConversation(c *client.Client, conversation models.Conversation, userID string) ConversationViewModel
+	NewBlocked(c *client.Client) BlockedViewModel
+	NewSessions(c *client.Client) SessionsViewModel
+	NewTwoFactor(c *client.Client) TwoFactorViewModel
 	NewHelp(viewName string) HelpViewModel
 }
 
 // Help view name constants.
 const (
-	HelpViewTimeline = "timeline"
-	HelpViewProfile  = "profile"
-	HelpViewCompose  = "compose"
+	HelpViewTimeline      = "timeline"
+	HelpViewProfile       = "profile"
+	HelpViewCompose       = "compose"
+	HelpViewThread        = "thread"
+	HelpViewTag           = "tag"
+	HelpViewTrending      = "trending"
+	HelpViewSearch        = "search"
+	HelpViewNotifications = "notifications"
+	HelpViewInbox         = "inbox"
+	HelpViewConversation  = "conversation"
+	HelpViewBlocked       = "blocked"
+	HelpViewSessions      = "sessions"
+	HelpViewTwoFactor     = "two-factor"
 )
 
+// unreadPollInterval is how often the unread notification count in the
+// header is refreshed.
+const unreadPollInterval = time.Minute
+
 // AppModel is the root Bubble Tea model that manages all sub-models,
 // shared state, and view routing.
 type AppModel struct {
@@ -92,6 +196,28 @@ type AppModel struct {
 	register ViewModel
 	timeline TimelineViewModel
 	profile  ProfileViewModel
+	thread   ThreadViewModel
+	tag      TagViewModel
+	trending TrendingViewModel
+	search   SearchViewModel
+
+	notifications NotificationsViewModel
+	inbox         InboxViewModel
+	conversation  ConversationViewModel
+	blocked       BlockedViewModel
+	sessions      SessionsViewModel
+	twoFactor     TwoFactorViewModel
+
+	// threadReturn, tagReturn, searchReturn and conversationReturn are the
+	// views to restore when the thread, tag, search or conversation view is
+	// dismissed.
+	threadReturn       View
+	tagReturn          View
+	searchReturn       View
+	conversationReturn View
+
+	// unread is the number of unread notifications shown in the header.
+	unread int
 
 	// Overlays
 	compose ComposeViewModel
@@ -150,6 +276,22 @@ func (m AppModel) isTextInputFocused() bool {
 		if m.profile != nil {
 			return m.profile.Editing()
 		}
+	case ViewSearch:
+		if m.search != nil {
+			return m.search.IsTextInputFocused()
+		}
+	case ViewInbox:
+		if m.inbox != nil {
+			return m.inbox.IsTextInputFocused()
+		}
+	case ViewConversation:
+		if m.conversation != nil {
+			return m.conversation.IsTextInputFocused()
+		}
+	case ViewTwoFactor:
+		if m.twoFactor != nil {
+			return m.twoFactor.IsTextInputFocused()
+		}
 	}
 	return false
 }
@@ -197,9 +339,14 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.isTextInputFocused() {
 			switch {
 			case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'n':
-				if m.currentView == ViewTimeline || m.currentView == ViewProfile {
+				if m.currentView == ViewTimeline || m.currentView == ViewProfile || m.currentView == ViewTag {
 					return m.openCompose()
 				}
+				if m.currentView == ViewThread && m.thread != nil {
+					if parent := m.thread.SelectedPost(); parent != nil {
+						return m.openReply(*parent.Subject())
+					}
+				}
 			case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '?':
 				return m.openHelp()
 			case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
@@ -211,6 +358,18 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.currentView == ViewTimeline && m.user != nil {
 					return m.openProfile(m.user.ID, true)
 				}
+			case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '/':
+				if m.currentView != ViewSearch {
+					return m.openSearch()
+				}
+			case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'N':
+				if m.currentView != ViewNotifications {
+					return m.openNotifications()
+				}
+			case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'D':
+				if m.currentView != ViewInbox {
+					return m.openInbox()
+				}
 			}
 		}
 
@@ -234,6 +393,36 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmd := m.statusBar.SetError("Session expired. Please log in again.")
 		return m, cmd
 
+	case MsgLoggedOut:
+		m.user = nil
+		m.tokens = nil
+		m.currentView = ViewLogin
+		if m.factory == nil {
+			return m, nil
+		}
+		m.login = m.factory.NewLogin(m.client)
+		return m, m.login.Init()
+
+	// Scheduling deletion ends every session, so return to the login
+	// screen, which has no status bar, and say when the purge happens there
+	case MsgAccountDeletionScheduled:
+		m.user = nil
+		m.tokens = nil
+		m.currentView = ViewLogin
+		if m.factory == nil {
+			return m, nil
+		}
+		m.login = m.factory.NewLogin(m.client)
+		var cmd tea.Cmd
+		m.login, cmd = m.login.Update(MsgAuthError{
+			Message: "Your account will be deleted on " + msg.DeleteAfter.Format("Jan 2, 2006") + ".",
+		})
+		return m, tea.Batch(m.login.Init(), cmd)
+
+	case MsgExportSaved:
+		cmd := m.statusBar.SetSuccess("Data export saved to " + msg.Path)
+		return m, cmd
+
 	case MsgTimelineLoaded, MsgTimelineRefreshed:
 		if m.timeline != nil {
 			var updated ViewModel
@@ -248,12 +437,26 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case MsgPostPublished:
 		m.compose = nil
-		cmd := m.statusBar.SetSuccess("Post published!")
+		status := "Post published!"
+		if msg.Post.ParentID != nil {
+			status = "Reply published!"
+		} else if msg.Post.Kind == models.PostKindQuote {
+			status = "Quote published!"
+		}
+		cmd := m.statusBar.SetSuccess(status)
 		var fetchCmd tea.Cmd
 		if m.timeline != nil {
 			fetchCmd = m.timeline.FetchLatest()
 		}
-		return m, tea.Batch(cmd, fetchCmd)
+		var threadCmd tea.Cmd
+		if m.currentView == ViewThread && m.thread != nil {
+			var updated ViewModel
+			updated, threadCmd = m.thread.Update(msg)
+			if tv, ok := updated.(ThreadViewModel); ok {
+				m.thread = tv
+			}
+		}
+		return m, tea.Batch(cmd, fetchCmd, threadCmd)
 
 	case MsgProfileLoaded:
 		if m.profile != nil {
@@ -282,6 +485,233 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case MsgFollowToggled:
+		var status string
+		if msg.Following {
+			status = "Following @" + msg.Username
+		} else {
+			status = "Unfollowed @" + msg.Username
+		}
+		cmd := m.statusBar.SetSuccess(status)
+		if m.profile != nil {
+			var updated ViewModel
+			var profileCmd tea.Cmd
+			updated, profileCmd = m.profile.Update(msg)
+			if pv, ok := updated.(ProfileViewModel); ok {
+				m.profile = pv
+			}
+			return m, tea.Batch(cmd, profileCmd)
+		}
+		return m, cmd
+
+	case MsgLikeToggled:
+		var cmds []tea.Cmd
+		m, cmds = m.syncPostViews(msg)
+		return m, tea.Batch(cmds...)
+
+	case MsgRepostToggled:
+		status := "Reposted"
+		if !msg.Reposted {
+			status = "Repost removed"
+		}
+		var cmds []tea.Cmd
+		m, cmds = m.syncPostViews(msg)
+		cmds = append(cmds, m.statusBar.SetSuccess(status))
+		if m.timeline != nil {
+			// Reposts show up as timeline entries of their own.
+			cmds = append(cmds, m.timeline.FetchLatest())
+		}
+		return m, tea.Batch(cmds...)
+
+	case MsgOpenQuote:
+		return m.openQuote(msg.Post)
+
+	case MsgOpenProfile:
+		isOwn := m.user != nil && msg.UserID == m.user.ID
+		return m.openProfile(msg.UserID, isOwn)
+
+	case MsgOpenThread:
+		return m.openThread(msg.PostID)
+
+	case MsgOpenTag:
+		return m.openTag(msg.Tag)
+
+	case MsgOpenTrending:
+		return m.openTrending()
+
+	case MsgOpenSearch:
+		return m.openSearch()
+
+	case MsgSearchResults:
+		if m.search != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.search.Update(msg)
+			if sv, ok := updated.(SearchViewModel); ok {
+				m.search = sv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgTagLoaded:
+		if m.tag != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.tag.Update(msg)
+			if tv, ok := updated.(TagViewModel); ok {
+				m.tag = tv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgTrendingLoaded:
+		if m.trending != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.trending.Update(msg)
+			if tv, ok := updated.(TrendingViewModel); ok {
+				m.trending = tv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgOpenNotifications:
+		return m.openNotifications()
+
+	case MsgNotificationsLoaded:
+		m.unread = msg.UnreadCount
+		if m.notifications != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.notifications.Update(msg)
+			if nv, ok := updated.(NotificationsViewModel); ok {
+				m.notifications = nv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgOpenInbox:
+		return m.openInbox()
+
+	case MsgOpenConversation:
+		return m.openConversation(msg.Conversation)
+
+	case MsgConversationsLoaded:
+		if m.inbox != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.inbox.Update(msg)
+			if iv, ok := updated.(InboxViewModel); ok {
+				m.inbox = iv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgMessagesLoaded, MsgMessageSent:
+		if m.conversation != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.conversation.Update(msg)
+			if cv, ok := updated.(ConversationViewModel); ok {
+				m.conversation = cv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgBlockToggled:
+		status := "Blocked @" + msg.Username
+		if !msg.Blocking {
+			status = "Unblocked @" + msg.Username
+		}
+		return m.syncRelationViews(msg, status)
+
+	case MsgMuteToggled:
+		status := "Muted @" + msg.Username
+		if !msg.Muting {
+			status = "Unmuted @" + msg.Username
+		}
+		return m.syncRelationViews(msg, status)
+
+	case MsgOpenBlocked:
+		return m.openBlocked()
+
+	case MsgRelationsLoaded:
+		if m.blocked != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.blocked.Update(msg)
+			if bv, ok := updated.(BlockedViewModel); ok {
+				m.blocked = bv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgOpenSessions:
+		return m.openSessions()
+
+	case MsgSessionsLoaded:
+		if m.sessions != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.sessions.Update(msg)
+			if sv, ok := updated.(SessionsViewModel); ok {
+				m.sessions = sv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgSessionDeleted:
+		cmd := m.statusBar.SetSuccess("Device signed out")
+		if m.sessions != nil {
+			var updated ViewModel
+			var sessCmd tea.Cmd
+			updated, sessCmd = m.sessions.Update(msg)
+			if sv, ok := updated.(SessionsViewModel); ok {
+				m.sessions = sv
+			}
+			cmd = tea.Batch(cmd, sessCmd)
+		}
+		return m, cmd
+
+	case MsgOpenTwoFactor:
+		return m.openTwoFactor()
+
+	case MsgTwoFactorStatusLoaded, MsgTwoFactorSetup:
+		if m.twoFactor != nil {
+			var updated ViewModel
+			var cmd tea.Cmd
+			updated, cmd = m.twoFactor.Update(msg)
+			if tv, ok := updated.(TwoFactorViewModel); ok {
+				m.twoFactor = tv
+			}
+			return m, cmd
+		}
+		return m, nil
+
+	case MsgTwoFactorEnabled:
+		return m.updateTwoFactor(msg, "Two-factor authentication turned on")
+
+	case MsgTwoFactorDisabled:
+		return m.updateTwoFactor(msg, "Two-factor authentication turned off")
+
+	case MsgUnreadCount:
+		m.unread = msg.Count
+		return m, nil
+
+	case MsgUnreadTick:
+		if m.user == nil {
+			return m, nil
+		}
+		return m, tea.Batch(fetchUnreadCount(m.client), pollUnreadCount())
+
 	case MsgAPIError:
 		cmd := m.statusBar.SetError(msg.Message)
 		return m, cmd
@@ -321,7 +751,7 @@ func (m AppModel) View() string {
 		username = m.user.Username
 	}
 	viewName := m.viewName()
-	header := components.RenderHeader("niotebook", username, viewName, m.width)
+	header := components.RenderHeader("niotebook", username, viewName, m.unread, m.width)
 
 	// Content area: total height minus header (1 line) and status bar (1 line)
 	contentHeight := m.height - 2
@@ -358,12 +788,34 @@ func (m AppModel) handleAuthSuccess(msg MsgAuthSuccess) (AppModel, tea.Cmd) {
 		m.client.SetRefreshToken(msg.Tokens.RefreshToken)
 	}
 
-	// Fetch timeline
+	// Fetch timeline and start polling the unread notification count
+	cmds := []tea.Cmd{fetchUnreadCount(m.client), pollUnreadCount()}
 	if m.timeline != nil {
-		cmd := m.timeline.FetchLatest()
-		return m, cmd
+		cmds = append(cmds, m.timeline.FetchLatest())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// fetchUnreadCount returns a command that loads the unread notification
+// count. Failures are ignored; the next poll will try again.
+func fetchUnreadCount(c *client.Client) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return nil
+		}
+		count, err := c.UnreadNotificationCount()
+		if err != nil {
+			return nil
+		}
+		return MsgUnreadCount{Count: count}
 	}
-	return m, nil
+}
+
+// pollUnreadCount schedules the next unread count refresh.
+func pollUnreadCount() tea.Cmd {
+	return tea.Tick(unreadPollInterval, func(time.Time) tea.Msg {
+		return MsgUnreadTick{}
+	})
 }
 
 // openCompose creates a new compose overlay.
@@ -376,6 +828,92 @@ func (m AppModel) openCompose() (AppModel, tea.Cmd) {
 	return m, nil
 }
 
+// openReply creates a compose overlay that replies to parent.
+func (m AppModel) openReply(parent models.Post) (AppModel, tea.Cmd) {
+	if m.factory != nil {
+		m.compose = m.factory.NewReplyCompose(m.client, parent)
+		cmd := m.compose.Init()
+		return m, cmd
+	}
+	return m, nil
+}
+
+// openQuote creates a compose overlay that quotes original.
+func (m AppModel) openQuote(original models.Post) (AppModel, tea.Cmd) {
+	if m.factory != nil {
+		m.compose = m.factory.NewQuoteCompose(m.client, original)
+		cmd := m.compose.Init()
+		return m, cmd
+	}
+	return m, nil
+}
+
+// syncPostViews forwards a post state change to every open view, since the
+// same post can appear in several of them.
+func (m AppModel) syncPostViews(msg tea.Msg) (AppModel, []tea.Cmd) {
+	var cmds []tea.Cmd
+	if m.timeline != nil {
+		updated, cmd := m.timeline.Update(msg)
+		if tl, ok := updated.(TimelineViewModel); ok {
+			m.timeline = tl
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.profile != nil {
+		updated, cmd := m.profile.Update(msg)
+		if pv, ok := updated.(ProfileViewModel); ok {
+			m.profile = pv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.thread != nil {
+		updated, cmd := m.thread.Update(msg)
+		if tv, ok := updated.(ThreadViewModel); ok {
+			m.thread = tv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.tag != nil {
+		updated, cmd := m.tag.Update(msg)
+		if tv, ok := updated.(TagViewModel); ok {
+			m.tag = tv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.search != nil {
+		updated, cmd := m.search.Update(msg)
+		if sv, ok := updated.(SearchViewModel); ok {
+			m.search = sv
+		}
+		cmds = append(cmds, cmd)
+	}
+	return m, cmds
+}
+
+// syncRelationViews forwards a block or mute change to the profile and
+// blocked views and reloads the timeline, whose contents it changes.
+func (m AppModel) syncRelationViews(msg tea.Msg, status string) (AppModel, tea.Cmd) {
+	cmds := []tea.Cmd{m.statusBar.SetSuccess(status)}
+	if m.profile != nil {
+		updated, cmd := m.profile.Update(msg)
+		if pv, ok := updated.(ProfileViewModel); ok {
+			m.profile = pv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.blocked != nil {
+		updated, cmd := m.blocked.Update(msg)
+		if bv, ok := updated.(BlockedViewModel); ok {
+			m.blocked = bv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.timeline != nil {
+		cmds = append(cmds, m.timeline.FetchLatest())
+	}
+	return m, tea.Batch(cmds...)
+}
+
 // openHelp creates a new help overlay for the current context.
 func (m AppModel) openHelp() (AppModel, tea.Cmd) {
 	if m.factory == nil {
@@ -390,6 +928,26 @@ func (m AppModel) openHelp() (AppModel, tea.Cmd) {
 			viewName = HelpViewTimeline
 		case ViewProfile:
 			viewName = HelpViewProfile
+		case ViewThread:
+			viewName = HelpViewThread
+		case ViewTag:
+			viewName = HelpViewTag
+		case ViewTrending:
+			viewName = HelpViewTrending
+		case ViewSearch:
+			viewName = HelpViewSearch
+		case ViewNotifications:
+			viewName = HelpViewNotifications
+		case ViewInbox:
+			viewName = HelpViewInbox
+		case ViewConversation:
+			viewName = HelpViewConversation
+		case ViewBlocked:
+			viewName = HelpViewBlocked
+		case ViewSessions:
+			viewName = HelpViewSessions
+		case ViewTwoFactor:
+			viewName = HelpViewTwoFactor
 		default:
 			viewName = HelpViewTimeline
 		}
@@ -413,6 +971,189 @@ func (m AppModel) openProfile(userID string, isOwn bool) (AppModel, tea.Cmd) {
 	return m, nil
 }
 
+// openThread navigates to the conversation view for postID. Dismissing the
+// thread returns to the view it was opened from.
+func (m AppModel) openThread(postID string) (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	if m.currentView != ViewThread {
+		m.threadReturn = m.currentView
+	}
+	m.thread = m.factory.NewThread(m.client, postID)
+	updated, _ := m.thread.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if tv, ok := updated.(ThreadViewModel); ok {
+		m.thread = tv
+	}
+	m.currentView = ViewThread
+	return m, m.thread.Init()
+}
+
+// openTag navigates to the timeline of tag. Like the thread view, dismissing
+// it returns to the view it was opened from.
+func (m AppModel) openTag(tag string) (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	if m.currentView != ViewTag {
+		m.tagReturn = m.currentView
+	}
+	m.tag = m.factory.NewTag(m.client, tag)
+	updated, _ := m.tag.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if tv, ok := updated.(TagViewModel); ok {
+		m.tag = tv
+	}
+	m.currentView = ViewTag
+	return m, m.tag.Init()
+}
+
+// openTrending navigates to the trending tags view.
+func (m AppModel) openTrending() (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	m.trending = m.factory.NewTrending(m.client)
+	updated, _ := m.trending.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if tv, ok := updated.(TrendingViewModel); ok {
+		m.trending = tv
+	}
+	m.currentView = ViewTrending
+	return m, m.trending.Init()
+}
+
+// openSearch navigates to a new search view. Dismissing it returns to the
+// view it was opened from.
+func (m AppModel) openSearch() (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	m.searchReturn = m.currentView
+	m.search = m.factory.NewSearch(m.client)
+	updated, _ := m.search.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if sv, ok := updated.(SearchViewModel); ok {
+		m.search = sv
+	}
+	m.currentView = ViewSearch
+	return m, m.search.Init()
+}
+
+// openNotifications navigates to the notifications view. Dismissing it
+// returns to the timeline.
+func (m AppModel) openNotifications() (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	m.notifications = m.factory.NewNotifications(m.client)
+	updated, _ := m.notifications.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if nv, ok := updated.(NotificationsViewModel); ok {
+		m.notifications = nv
+	}
+	m.currentView = ViewNotifications
+	return m, m.notifications.Init()
+}
+
+// openInbox navigates to the direct message inbox. Dismissing it returns to
+// the timeline.
+func (m AppModel) openInbox() (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	m.inbox = m.factory.NewInbox(m.client, m.userID())
+	updated, _ := m.inbox.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if iv, ok := updated.(InboxViewModel); ok {
+		m.inbox = iv
+	}
+	m.currentView = ViewInbox
+	return m, m.inbox.Init()
+}
+
+// openConversation navigates to conversation. Dismissing it returns to the
+// view it was opened from, usually the inbox or a profile.
+func (m AppModel) openConversation(conversation models.Conversation) (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	if m.currentView != ViewConversation {
+		m.conversationReturn = m.currentView
+	}
+	m.conversation = m.factory.NewConversation(m.client, conversation, m.userID())
+	updated, _ := m.conversation.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if cv, ok := updated.(ConversationViewModel); ok {
+		m.conversation = cv
+	}
+	m.currentView = ViewConversation
+	return m, m.conversation.Init()
+}
+
+// openBlocked navigates to the list of blocked and muted accounts. It is
+// opened from the user's own profile, which dismissing it returns to.
+func (m AppModel) openBlocked() (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	m.blocked = m.factory.NewBlocked(m.client)
+	updated, _ := m.blocked.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if bv, ok := updated.(BlockedViewModel); ok {
+		m.blocked = bv
+	}
+	m.currentView = ViewBlocked
+	return m, m.blocked.Init()
+}
+
+// openSessions navigates to the list of signed-in devices. Like the blocked
+// list, it is opened from the user's own profile.
+func (m AppModel) openSessions() (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	m.sessions = m.factory.NewSessions(m.client)
+	updated, _ := m.sessions.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if sv, ok := updated.(SessionsViewModel); ok {
+		m.sessions = sv
+	}
+	m.currentView = ViewSessions
+	return m, m.sessions.Init()
+}
+
+// openTwoFactor navigates to the two-factor authentication settings, opened
+// from the user's own profile.
+func (m AppModel) openTwoFactor() (AppModel, tea.Cmd) {
+	if m.factory == nil {
+		return m, nil
+	}
+	m.twoFactor = m.factory.NewTwoFactor(m.client)
+	updated, _ := m.twoFactor.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	if tv, ok := updated.(TwoFactorViewModel); ok {
+		m.twoFactor = tv
+	}
+	m.currentView = ViewTwoFactor
+	return m, m.twoFactor.Init()
+}
+
+// updateTwoFactor reports a change to two-factor authentication in the
+// status bar and passes it on to the two-factor view, if it is open.
+func (m AppModel) updateTwoFactor(msg tea.Msg, text string) (AppModel, tea.Cmd) {
+	cmd := m.statusBar.SetSuccess(text)
+	if m.twoFactor != nil {
+		var updated ViewModel
+		var tfCmd tea.Cmd
+		updated, tfCmd = m.twoFactor.Update(msg)
+		if tv, ok := updated.(TwoFactorViewModel); ok {
+			m.twoFactor = tv
+		}
+		cmd = tea.Batch(cmd, tfCmd)
+	}
+	return m, cmd
+}
+
+// userID returns the logged-in user's ID, or "" before login.
+func (m AppModel) userID() string {
+	if m.user == nil {
+		return ""
+	}
+	return m.user.ID
+}
+
 // updateCompose routes messages to the compose overlay.
 func (m AppModel) updateCompose(msg tea.Msg) (AppModel, tea.Cmd) {
 	var updated ViewModel
@@ -483,6 +1224,149 @@ func (m AppModel) updateCurrentView(msg tea.Msg) (AppModel, tea.Cmd) {
 				return m, nil
 			}
 		}
+	case ViewThread:
+		if m.thread != nil {
+			var updated ViewModel
+			updated, cmd = m.thread.Update(msg)
+			if tv, ok := updated.(ThreadViewModel); ok {
+				m.thread = tv
+			}
+			if m.thread.Dismissed() {
+				m.thread = nil
+				m.currentView = m.threadReturn
+				return m, nil
+			}
+		}
+	case ViewTag:
+		if m.tag != nil {
+			var updated ViewModel
+			updated, cmd = m.tag.Update(msg)
+			if tv, ok := updated.(TagViewModel); ok {
+				m.tag = tv
+			}
+			if m.tag.Dismissed() {
+				m.tag = nil
+				m.currentView = m.tagReturn
+				return m, nil
+			}
+		}
+	case ViewTrending:
+		if m.trending != nil {
+			var updated ViewModel
+			updated, cmd = m.trending.Update(msg)
+			if tv, ok := updated.(TrendingViewModel); ok {
+				m.trending = tv
+			}
+			if m.trending.Dismissed() {
+				m.trending = nil
+				m.currentView = ViewTimeline
+				return m, nil
+			}
+		}
+	case ViewSearch:
+		if m.search != nil {
+			var updated ViewModel
+			updated, cmd = m.search.Update(msg)
+			if sv, ok := updated.(SearchViewModel); ok {
+				m.search = sv
+			}
+			if m.search.Dismissed() {
+				m.search = nil
+				m.currentView = m.searchReturn
+				return m, nil
+			}
+		}
+	case ViewNotifications:
+		if m.notifications != nil {
+			var updated ViewModel
+			updated, cmd = m.notifications.Update(msg)
+			if nv, ok := updated.(NotificationsViewModel); ok {
+				m.notifications = nv
+			}
+			if m.notifications.Dismissed() {
+				m.notifications = nil
+				m.currentView = ViewTimeline
+				return m, nil
+			}
+		}
+	case ViewInbox:
+		if m.inbox != nil {
+			var updated ViewModel
+			updated, cmd = m.inbox.Update(msg)
+			if iv, ok := updated.(InboxViewModel); ok {
+				m.inbox = iv
+			}
+			if m.inbox.Dismissed() {
+				m.inbox = nil
+				m.currentView = ViewTimeline
+				return m, nil
+			}
+		}
+	case ViewConversation:
+		if m.conversation != nil {
+			var updated ViewModel
+			updated, cmd = m.conversation.Update(msg)
+			if cv, ok := updated.(ConversationViewModel); ok {
+				m.conversation = cv
+			}
+			if m.conversation.Dismissed() {
+				m.conversation = nil
+				m.currentView = m.conversationReturn
+				// Reload the inbox so it shows the latest message
+				if m.currentView == ViewInbox && m.inbox != nil {
+					return m, m.inbox.Init()
+				}
+				return m, nil
+			}
+		}
+	case ViewBlocked:
+		if m.blocked != nil {
+			var updated ViewModel
+			updated, cmd = m.blocked.Update(msg)
+			if bv, ok := updated.(BlockedViewModel); ok {
+				m.blocked = bv
+			}
+			if m.blocked.Dismissed() {
+				m.blocked = nil
+				m.currentView = ViewTimeline
+				if m.profile != nil {
+					m.currentView = ViewProfile
+				}
+				return m, nil
+			}
+		}
+	case ViewSessions:
+		if m.sessions != nil {
+			var updated ViewModel
+			updated, cmd = m.sessions.Update(msg)
+			if sv, ok := updated.(SessionsViewModel); ok {
+				m.sessions = sv
+			}
+			if m.sessions.Dismissed() {
+				m.sessions = nil
+				m.currentView = ViewTimeline
+				if m.profile != nil {
+					m.currentView = ViewProfile
+				}
+				return m, nil
+			}
+		}
+	case ViewTwoFactor:
+		if m.twoFactor != nil {
+			var updated ViewModel
+			updated, cmd = m.twoFactor.Update(msg)
+			if tv, ok := updated.(TwoFactorViewModel); ok {
+				m.twoFactor = tv
+			}
+			if m.twoFactor.Dismissed() {
+				m.twoFactor = nil
+				m.currentView = ViewTimeline
+				if m.profile != nil {
+					m.currentView = ViewProfile
+				}
+				return m, nil
+			}
+		}
 	}
 	return m, cmd
 }
@@ -519,6 +1403,96 @@ func (m AppModel) propagateWindowSize(msg tea.WindowSizeMsg) (AppModel, tea.Cmd)
 		}
 		cmds = append(cmds, cmd)
 	}
+	if m.thread != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.thread.Update(msg)
+		if tv, ok := updated.(ThreadViewModel); ok {
+			m.thread = tv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.tag != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.tag.Update(msg)
+		if tv, ok := updated.(TagViewModel); ok {
+			m.tag = tv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.trending != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.trending.Update(msg)
+		if tv, ok := updated.(TrendingViewModel); ok {
+			m.trending = tv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.search != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.search.Update(msg)
+		if sv, ok := updated.(SearchViewModel); ok {
+			m.search = sv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.notifications != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.notifications.Update(msg)
+		if nv, ok := updated.(NotificationsViewModel); ok {
+			m.notifications = nv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.inbox != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.inbox.Update(msg)
+		if iv, ok := updated.(InboxViewModel); ok {
+			m.inbox = iv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.conversation != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.conversation.Update(msg)
+		if cv, ok := updated.(ConversationViewModel); ok {
+			m.conversation = cv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.blocked != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.blocked.Update(msg)
+		if bv, ok := updated.(BlockedViewModel); ok {
+			m.blocked = bv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.sessions != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.sessions.Update(msg)
+		if sv, ok := updated.(SessionsViewModel); ok {
+			m.sessions = sv
+		}
+		cmds = append(cmds, cmd)
+	}
+	if m.twoFactor != nil {
+		var updated ViewModel
+		var cmd tea.Cmd
+		updated, cmd = m.twoFactor.Update(msg)
+		if tv, ok := updated.(TwoFactorViewModel); ok {
+			m.twoFactor = tv
+		}
+		cmds = append(cmds, cmd)
+	}
 	if m.compose != nil {
 		var updated ViewModel
 		var cmd tea.Cmd
@@ -560,6 +1534,46 @@ func (m AppModel) viewCurrentContent() string {
 		if m.profile != nil {
 			return m.profile.View()
 		}
+	case ViewThread:
+		if m.thread != nil {
+			return m.thread.View()
+		}
+	case ViewTag:
+		if m.tag != nil {
+			return m.tag.View()
+		}
+	case ViewTrending:
+		if m.trending != nil {
+			return m.trending.View()
+		}
+	case ViewSearch:
+		if m.search != nil {
+			return m.search.View()
+		}
+	case ViewNotifications:
+		if m.notifications != nil {
+			return m.notifications.View()
+		}
+	case ViewInbox:
+		if m.inbox != nil {
+			return m.inbox.View()
+		}
+	case ViewConversation:
+		if m.conversation != nil {
+			return m.conversation.View()
+		}
+	case ViewBlocked:
+		if m.blocked != nil {
+			return m.blocked.View()
+		}
+	case ViewSessions:
+		if m.sessions != nil {
+			return m.sessions.View()
+		}
+	case ViewTwoFactor:
+		if m.twoFactor != nil {
+			return m.twoFactor.View()
+		}
 	}
 	return ""
 }
@@ -577,6 +1591,26 @@ func (m AppModel) viewName() string {
 		return "Timeline"
 	case ViewProfile:
 		return "Profile"
+	case ViewThread:
+		return "Thread"
+	case ViewTag:
+		return "Tag"
+	case ViewTrending:
+		return "Trending"
+	case ViewSearch:
+		return "Search"
+	case ViewNotifications:
+		return "Notifications"
+	case ViewInbox:
+		return "Messages"
+	case ViewConversation:
+		return "Conversation"
+	case ViewBlocked:
+		return "Blocked & Muted"
+	case ViewSessions:
+		return "Sessions"
+	case ViewTwoFactor:
+		return "Two-factor"
 	default:
 		return ""
 	}
@@ -607,6 +1641,46 @@ func (m AppModel) currentHelpText() string {
 		if m.profile != nil {
 			return m.profile.HelpText()
 		}
+	case ViewThread:
+		if m.thread != nil {
+			return m.thread.HelpText()
+		}
+	case ViewTag:
+		if m.tag != nil {
+			return m.tag.HelpText()
+		}
+	case ViewTrending:
+		if m.trending != nil {
+			return m.trending.HelpText()
+		}
+	case ViewSearch:
+		if m.search != nil {
+			return m.search.HelpText()
+		}
+	case ViewNotifications:
+		if m.notifications != nil {
+			return m.notifications.HelpText()
+		}
+	case ViewInbox:
+		if m.inbox != nil {
+			return m.inbox.HelpText()
+		}
+	case ViewConversation:
+		if m.conversation != nil {
+			return m.conversation.HelpText()
+		}
+	case ViewBlocked:
+		if m.blocked != nil {
+			return m.blocked.HelpText()
+		}
+	case ViewSessions:
+		if m.sessions != nil {
+			return m.sessions.HelpText()
+		}
+	case ViewTwoFactor:
+		if m.twoFactor != nil {
+			return m.twoFactor.HelpText()
+		}
 	}
 	return ""
 }
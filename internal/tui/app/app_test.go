@@ -1,7 +1,9 @@
 package app_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -40,6 +42,50 @@ type stubHelp struct{ stubViewModel }
 
 func (s *stubHelp) Dismissed() bool { return false }
 
+// stubThread dismisses itself on Esc and always has a selected post.
+type stubThread struct {
+	stubViewModel
+	dismissed bool
+}
+
+func (s *stubThread) Dismissed() bool            { return s.dismissed }
+func (s *stubThread) SelectedPost() *models.Post { return &models.Post{ID: "p1"} }
+func (s *stubThread) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyEsc {
+		s.dismissed = true
+	}
+	return s, nil
+}
+
+// stubEscView dismisses itself on Esc, like the tag and trending views.
+type stubEscView struct {
+	stubViewModel
+	dismissed bool
+}
+
+func (s *stubEscView) Dismissed() bool { return s.dismissed }
+func (s *stubEscView) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyEsc {
+		s.dismissed = true
+	}
+	return s, nil
+}
+
+// stubSearch keeps its query box focused until Enter, then dismisses on Esc.
+type stubSearch struct {
+	stubEscView
+	submitted bool
+}
+
+func (s *stubSearch) IsTextInputFocused() bool { return !s.submitted }
+func (s *stubSearch) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyEnter {
+		s.submitted = true
+	}
+	s.stubEscView.Update(msg)
+	return s, nil
+}
+
 type stubFactory struct{}
 
 func (f *stubFactory) NewLogin(_ *client.Client) app.ViewModel            { return &stubViewModel{} }
@@ -49,7 +95,33 @@ func (f *stubFactory) NewProfile(_ *client.Client, _ string, _ bool) app.Profile
 	return &stubProfile{}
 }
 func (f *stubFactory) NewCompose(_ *client.Client) app.ComposeViewModel { return &stubCompose{} }
-func (f *stubFactory) NewHelp(_ string) app.HelpViewModel               { return &stubHelp{} }
+func (f *stubFactory) NewReplyCompose(_ *client.Client, _ models.Post) app.ComposeViewModel {
+	return &stubCompose{}
+}
+func (f *stubFactory) NewQuoteCompose(_ *client.Client, _ models.Post) app.ComposeViewModel {
+	return &stubCompose{}
+}
+func (f *stubFactory) NewThread(_ *client.Client, _ string) app.ThreadViewModel { return &stubThread{} }
+func (f *stubFactory) NewHelp(_ string) app.HelpViewModel                       { return &stubHelp{} }
+func (f *stubFactory) NewTag(_ *client.Client, _ string) app.TagViewModel       { return &stubEscView{} }
+func (f *stubFactory) NewTrending(_ *client.Client) app.TrendingViewModel       { return &stubEscView{} }
+func (f *stubFactory) NewSearch(_ *client.Client) app.SearchViewModel           { return &stubSearch{} }
+func (f *stubFactory) NewNotifications(_ *client.Client) app.NotificationsViewModel {
+	return &stubEscView{}
+}
+func (f *stubFactory) NewInbox(_ *client.Client, _ string) app.InboxViewModel {
+	return &stubSearch{submitted: true}
+}
+func (f *stubFactory) NewConversation(_ *client.Client, _ models.Conversation, _ string) app.ConversationViewModel {
+	return &stubSearch{}
+}
+func (f *stubFactory) NewBlocked(_ *client.Client) app.BlockedViewModel { return &stubEscView{} }
+func (f *stubFactory) NewSessions(_ *client.Client) app.SessionsViewModel {
+	return &stubEscView{}
+}
+func (f *stubFactory) NewTwoFactor(_ *client.Client) app.TwoFactorViewModel {
+	return &stubSearch{submitted: true}
+}
 
 func update(m app.AppModel, msg tea.Msg) app.AppModel {
 	result, _ := m.Update(msg)
@@ -163,6 +235,30 @@ func TestAppModelAuthExpiredReturnsToLogin(t *testing.T) {
 	}
 }
 
+func TestAppModelAccountDeletionReturnsToLogin(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgAccountDeletionScheduled{DeleteAfter: time.Date(2026, 11, 15, 0, 0, 0, 0, time.UTC)})
+	if m.CurrentView() != app.ViewLogin {
+		t.Errorf("view = %v, want ViewLogin after scheduling deletion", m.CurrentView())
+	}
+}
+
+func TestAppModelLogoutReturnsToLogin(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgLoggedOut{})
+	if m.CurrentView() != app.ViewLogin {
+		t.Errorf("view = %v, want ViewLogin after logging out", m.CurrentView())
+	}
+}
+
 func TestAppModelWindowResize(t *testing.T) {
 	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
 	m = update(m, app.MsgAuthSuccess{
@@ -196,6 +292,169 @@ func TestAppModelPOpensOwnProfile(t *testing.T) {
 	}
 }
 
+func TestAppModelOpenProfileMessage(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgOpenProfile{UserID: "u2"})
+	if m.CurrentView() != app.ViewProfile {
+		t.Errorf("view = %v, want ViewProfile after MsgOpenProfile", m.CurrentView())
+	}
+}
+
+func TestAppModelOpenThreadAndReply(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgOpenThread{PostID: "p1"})
+	if m.CurrentView() != app.ViewThread {
+		t.Fatalf("view = %v, want ViewThread after MsgOpenThread", m.CurrentView())
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !m.IsComposeOpen() {
+		t.Error("expected n to open reply compose in thread view")
+	}
+}
+
+func TestAppModelThreadDismissReturnsToOrigin(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = update(m, app.MsgOpenThread{PostID: "p1"})
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewProfile {
+		t.Errorf("view = %v, want ViewProfile after dismissing thread", m.CurrentView())
+	}
+}
+
+func TestAppModelTagAndTrendingNavigation(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgOpenTrending{})
+	if m.CurrentView() != app.ViewTrending {
+		t.Fatalf("view = %v, want ViewTrending after MsgOpenTrending", m.CurrentView())
+	}
+	m = update(m, app.MsgOpenTag{Tag: "golang"})
+	if m.CurrentView() != app.ViewTag {
+		t.Fatalf("view = %v, want ViewTag after MsgOpenTag", m.CurrentView())
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !m.IsComposeOpen() {
+		t.Error("expected n to open compose in tag view")
+	}
+	m = update(m, app.MsgPostPublished{Post: models.Post{ID: "p2"}})
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewTrending {
+		t.Errorf("view = %v, want ViewTrending after dismissing tag", m.CurrentView())
+	}
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewTimeline {
+		t.Errorf("view = %v, want ViewTimeline after dismissing trending", m.CurrentView())
+	}
+}
+
+func TestAppModelSearch(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgOpenThread{PostID: "p1"})
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if m.CurrentView() != app.ViewSearch {
+		t.Fatalf("view = %v, want ViewSearch after /", m.CurrentView())
+	}
+
+	// q types into the query box instead of quitting
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("q quit while the search box was focused")
+		}
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewThread {
+		t.Errorf("view = %v, want ViewThread after dismissing search", m.CurrentView())
+	}
+}
+
+func TestAppModelNotifications(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgUnreadCount{Count: 3})
+	if !strings.Contains(m.View(), "● 3") {
+		t.Error("header should show the unread count")
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if m.CurrentView() != app.ViewNotifications {
+		t.Fatalf("view = %v, want ViewNotifications after N", m.CurrentView())
+	}
+	m = update(m, app.MsgNotificationsLoaded{UnreadCount: 0})
+	if strings.Contains(m.View(), "●") {
+		t.Error("unread count should clear once notifications are read")
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewTimeline {
+		t.Errorf("view = %v, want ViewTimeline after dismissing notifications", m.CurrentView())
+	}
+}
+
+func TestAppModelDirectMessages(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if m.CurrentView() != app.ViewInbox {
+		t.Fatalf("view = %v, want ViewInbox after D", m.CurrentView())
+	}
+
+	m = update(m, app.MsgOpenConversation{Conversation: models.Conversation{ID: "c1"}})
+	if m.CurrentView() != app.ViewConversation {
+		t.Fatalf("view = %v, want ViewConversation", m.CurrentView())
+	}
+	// The message box has focus, so q and n are typed rather than handled
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}); cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("q quit while writing a message")
+		}
+	}
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if m.IsComposeOpen() {
+		t.Error("n opened compose while writing a message")
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewInbox {
+		t.Errorf("view = %v, want ViewInbox after leaving the conversation", m.CurrentView())
+	}
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewTimeline {
+		t.Errorf("view = %v, want ViewTimeline after leaving the inbox", m.CurrentView())
+	}
+}
+
 func TestAppModelPostPublished(t *testing.T) {
 	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
 	m = update(m, app.MsgAuthSuccess{
@@ -576,16 +835,133 @@ func (f *stubTrackFactory) NewCompose(_ *client.Client) app.ComposeViewModel {
 	return &stubCompose{}
 }
 
-// stubTrackTimeline tracks whether Update was called with MsgTimelineLoaded
+// stubTrackTimeline tracks whether Update was called with MsgTimelineLoaded,
+// MsgLikeToggled, or MsgRepostToggled
 type stubTrackTimeline struct {
 	stubViewModel
-	updated bool
+	updated  bool
+	liked    bool
+	reposted bool
 }
 
 func (s *stubTrackTimeline) FetchLatest() tea.Cmd { return nil }
 func (s *stubTrackTimeline) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
-	if _, ok := msg.(app.MsgTimelineLoaded); ok {
+	switch msg.(type) {
+	case app.MsgTimelineLoaded:
 		s.updated = true
+	case app.MsgLikeToggled:
+		s.liked = true
+	case app.MsgRepostToggled:
+		s.reposted = true
 	}
 	return s, nil
 }
+
+func TestAppModelLikeToggledReachesTimeline(t *testing.T) {
+	f := &stubTrackFactory{}
+	m := app.NewAppModelWithFactory(nil, nil, f)
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgOpenThread{PostID: "p1"})
+	_ = update(m, app.MsgLikeToggled{PostID: "p1", Liked: true, LikeCount: 1})
+	if f.lastTimeline == nil || !f.lastTimeline.liked {
+		t.Error("expected timeline to receive MsgLikeToggled while thread is open")
+	}
+}
+
+func TestAppModelRepostToggledReachesTimeline(t *testing.T) {
+	f := &stubTrackFactory{}
+	m := app.NewAppModelWithFactory(nil, nil, f)
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgOpenThread{PostID: "p1"})
+	_ = update(m, app.MsgRepostToggled{PostID: "p1", Reposted: true, RepostCount: 1})
+	if f.lastTimeline == nil || !f.lastTimeline.reposted {
+		t.Error("expected timeline to receive MsgRepostToggled while thread is open")
+	}
+}
+
+func TestAppModelOpenQuoteOpensCompose(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, app.MsgOpenQuote{Post: models.Post{ID: "p1"}})
+	if !m.IsComposeOpen() {
+		t.Error("expected MsgOpenQuote to open the quote composer")
+	}
+}
+
+func TestAppModelBlockedList(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = update(m, app.MsgOpenBlocked{})
+	if m.CurrentView() != app.ViewBlocked {
+		t.Fatalf("view = %v, want ViewBlocked", m.CurrentView())
+	}
+
+	m = update(m, app.MsgBlockToggled{UserID: "u2", Username: "sara", Blocking: false})
+	if !strings.Contains(m.View(), "Unblocked @sara") {
+		t.Error("status bar should confirm the unblock")
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewProfile {
+		t.Errorf("view = %v, want ViewProfile after dismissing the blocked list", m.CurrentView())
+	}
+}
+
+func TestAppModelSessionsList(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = update(m, app.MsgOpenSessions{})
+	if m.CurrentView() != app.ViewSessions {
+		t.Fatalf("view = %v, want ViewSessions", m.CurrentView())
+	}
+
+	m = update(m, app.MsgSessionDeleted{ID: "s2"})
+	if !strings.Contains(m.View(), "Device signed out") {
+		t.Error("status bar should confirm the signed out device")
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewProfile {
+		t.Errorf("view = %v, want ViewProfile after dismissing the sessions list", m.CurrentView())
+	}
+}
+
+func TestAppModelTwoFactor(t *testing.T) {
+	m := app.NewAppModelWithFactory(nil, nil, &stubFactory{})
+	m = update(m, app.MsgAuthSuccess{
+		User:   &models.User{ID: "u1", Username: "akram"},
+		Tokens: &models.TokenPair{AccessToken: "tok"},
+	})
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = update(m, app.MsgOpenTwoFactor{})
+	if m.CurrentView() != app.ViewTwoFactor {
+		t.Fatalf("view = %v, want ViewTwoFactor", m.CurrentView())
+	}
+
+	m = update(m, app.MsgTwoFactorEnabled{RecoveryCodes: []string{"aaaaa-bbbbb"}})
+	if !strings.Contains(m.View(), "Two-factor authentication turned on") {
+		t.Error("status bar should confirm two-factor authentication is on")
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.CurrentView() != app.ViewProfile {
+		t.Errorf("view = %v, want ViewProfile after dismissing two-factor settings", m.CurrentView())
+	}
+}
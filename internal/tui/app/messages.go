@@ -1,6 +1,10 @@
 package app
 
-import "github.com/Akram012388/niotebook-tui/internal/models"
+import (
+	"time"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+)
 
 // Auth messages
 type MsgAuthSuccess struct {
@@ -13,6 +17,21 @@ type MsgAuthError struct {
 	Field   string
 }
 
+// MsgRegistered is sent when an account was created but its email address
+// still has to be verified.
+type MsgRegistered struct {
+	User   *models.User
+	Tokens *models.TokenPair
+}
+type MsgEmailVerified struct{}
+type MsgVerificationSent struct{}
+
+// MsgTwoFactorRequired is sent when the password was right but the account
+// needs a two-factor code to finish signing in.
+type MsgTwoFactorRequired struct {
+	ChallengeToken string
+}
+
 // Timeline messages
 type MsgTimelineLoaded struct {
 	Posts      []models.Post
@@ -28,16 +47,132 @@ type MsgTimelineRefreshed struct {
 // Post messages
 type MsgPostPublished struct{ Post models.Post }
 
+type MsgLikeToggled struct {
+	PostID    string
+	Liked     bool
+	LikeCount int
+}
+
+type MsgRepostToggled struct {
+	PostID      string
+	Reposted    bool
+	RepostCount int
+}
+
+// Thread messages
+type MsgThreadLoaded struct{ Thread *models.ThreadResponse }
+
+// Tag messages
+type MsgTagLoaded struct {
+	Tag        string
+	Posts      []models.Post
+	NextCursor string
+	HasMore    bool
+}
+type MsgTrendingLoaded struct{ Tags []models.TrendingTag }
+
+// Search messages
+type MsgSearchResults struct {
+	Query      string
+	Type       string
+	Posts      []models.Post
+	Users      []models.User
+	NextCursor string
+	HasMore    bool
+	// Append is set for a follow-up page of an existing search.
+	Append bool
+}
+
+// Notification messages
+type MsgNotificationsLoaded struct {
+	Notifications []models.Notification
+	NextCursor    string
+	HasMore       bool
+	UnreadCount   int
+	// Append is set for a follow-up page.
+	Append bool
+}
+type MsgUnreadCount struct{ Count int }
+type MsgUnreadTick struct{}
+
+// Direct message messages
+type MsgConversationsLoaded struct {
+	Conversations []models.Conversation
+	NextCursor    string
+	HasMore       bool
+	Append        bool
+}
+type MsgMessagesLoaded struct {
+	ConversationID string
+	// Messages are newest first, as returned by the server.
+	Messages   []models.Message
+	NextCursor string
+	HasMore    bool
+	Append     bool
+}
+type MsgMessageSent struct{ Message models.Message }
+
 // Profile messages
 type MsgProfileLoaded struct {
-	User  *models.User
-	Posts []models.Post
+	User      *models.User
+	Posts     []models.Post
+	Following bool
+	Blocking  bool
+	Muting    bool
 }
 type MsgProfileUpdated struct{ User *models.User }
+type MsgAccountDeletionScheduled struct{ DeleteAfter time.Time }
+type MsgExportSaved struct{ Path string }
+type MsgLoggedOut struct{}
+
+// Follow messages
+type MsgFollowToggled struct {
+	UserID    string
+	Username  string
+	Following bool
+}
+
+// Block and mute messages
+type MsgBlockToggled struct {
+	UserID   string
+	Username string
+	Blocking bool
+}
+type MsgMuteToggled struct {
+	UserID   string
+	Username string
+	Muting   bool
+}
+type MsgRelationsLoaded struct {
+	Blocked []models.RelationEntry
+	Muted   []models.RelationEntry
+}
+
+// Session messages
+type MsgSessionsLoaded struct{ Sessions []models.Session }
+type MsgSessionDeleted struct{ ID string }
+
+// Two-factor authentication messages
+type MsgTwoFactorStatusLoaded struct{ Status models.TwoFactorStatus }
+type MsgTwoFactorSetup struct{ Setup models.TwoFactorSetup }
+type MsgTwoFactorEnabled struct{ RecoveryCodes []string }
+type MsgTwoFactorDisabled struct{}
 
 // Navigation messages
 type MsgSwitchToRegister struct{}
 type MsgSwitchToLogin struct{}
+type MsgOpenProfile struct{ UserID string }
+type MsgOpenThread struct{ PostID string }
+type MsgOpenQuote struct{ Post models.Post }
+type MsgOpenTag struct{ Tag string }
+type MsgOpenTrending struct{}
+type MsgOpenSearch struct{}
+type MsgOpenNotifications struct{}
+type MsgOpenInbox struct{}
+type MsgOpenConversation struct{ Conversation models.Conversation }
+type MsgOpenBlocked struct{}
+type MsgOpenSessions struct{}
+type MsgOpenTwoFactor struct{}
 
 // Generic messages
 type MsgAPIError struct{ Message string }
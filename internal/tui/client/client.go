@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Akram012388/niotebook-tui/internal/build"
 	"github.com/Akram012388/niotebook-tui/internal/models"
 )
 
@@ -22,7 +23,10 @@ type Client struct {
 	accessToken  string
 	refreshToken string
 	mu           sync.Mutex
+	refreshMu    sync.Mutex // held while refreshing, so a token is never sent twice
 	onRefresh    func(accessToken, refreshToken string)
+	onLogout     func()
+	deviceName   string
 }
 
 // New creates a new API client pointing at the given base URL.
@@ -56,9 +60,34 @@ func (c *Client) OnTokenRefresh(fn func(accessToken, refreshToken string)) {
 	c.onRefresh = fn
 }
 
-// Login authenticates with email and password.
+// OnLogout registers a callback invoked when the client logs out, so
+// stored tokens can be removed.
+func (c *Client) OnLogout(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onLogout = fn
+}
+
+// SetDeviceName sets the name the server shows for sessions this client
+// starts, such as the machine's hostname.
+func (c *Client) SetDeviceName(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deviceName = name
+}
+
+// device describes this client to the server when starting a session.
+func (c *Client) device() models.Device {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return models.Device{DeviceName: c.deviceName}
+}
+
+// Login authenticates with email and password. If the account has
+// two-factor authentication on, the response carries only a challenge token
+// to pass to CompleteLogin with a code.
 func (c *Client) Login(email, password string) (*models.AuthResponse, error) {
-	body := models.LoginRequest{Email: email, Password: password}
+	body := models.LoginRequest{Email: email, Password: password, Device: c.device()}
 	var resp models.AuthResponse
 	if err := c.doJSON("POST", "/api/v1/auth/login", body, &resp, false); err != nil {
 		return nil, err
@@ -70,9 +99,24 @@ func (c *Client) Login(email, password string) (*models.AuthResponse, error) {
 	return &resp, nil
 }
 
+// CompleteLogin finishes signing in with the challenge token Login returned
+// and a code from the user's authenticator app or a recovery code.
+func (c *Client) CompleteLogin(challengeToken, code string) (*models.AuthResponse, error) {
+	body := models.TwoFactorLoginRequest{ChallengeToken: challengeToken, Code: code, Device: c.device()}
+	var resp models.AuthResponse
+	if err := c.doJSON("POST", "/api/v1/auth/login/2fa", body, &resp, false); err != nil {
+		return nil, err
+	}
+	if resp.Tokens != nil {
+		c.SetToken(resp.Tokens.AccessToken)
+		c.SetRefreshToken(resp.Tokens.RefreshToken)
+	}
+	return &resp, nil
+}
+
 // Register creates a new account.
 func (c *Client) Register(username, email, password string) (*models.AuthResponse, error) {
-	body := models.RegisterRequest{Username: username, Email: email, Password: password}
+	body := models.RegisterRequest{Username: username, Email: email, Password: password, Device: c.device()}
 	var resp models.AuthResponse
 	if err := c.doJSON("POST", "/api/v1/auth/register", body, &resp, false); err != nil {
 		return nil, err
@@ -86,6 +130,31 @@ func (c *Client) Register(username, email, password string) (*models.AuthRespons
 
 // Refresh exchanges a refresh token for new tokens.
 func (c *Client) Refresh() (*models.TokenPair, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	return c.refresh()
+}
+
+// refreshSince refreshes the tokens after a request sent with accessToken
+// was rejected, unless a concurrent request already refreshed them. The
+// server revokes the session if a refresh token is presented twice.
+func (c *Client) refreshSince(accessToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	c.mu.Lock()
+	current := c.accessToken
+	c.mu.Unlock()
+	if current != accessToken {
+		return nil
+	}
+	_, err := c.refresh()
+	return err
+}
+
+// refresh exchanges the refresh token for new tokens. The caller must hold
+// refreshMu.
+func (c *Client) refresh() (*models.TokenPair, error) {
 	c.mu.Lock()
 	rt := c.refreshToken
 	c.mu.Unlock()
@@ -110,27 +179,299 @@ func (c *Client) Refresh() (*models.TokenPair, error) {
 	return &wrapper.Tokens, nil
 }
 
+// Logout ends this client's session on the server. The tokens are
+// forgotten even if the server cannot be reached.
+func (c *Client) Logout() error {
+	c.mu.Lock()
+	rt := c.refreshToken
+	c.mu.Unlock()
+
+	body := models.RefreshRequest{RefreshToken: rt}
+	err := c.doJSON("POST", "/api/v1/auth/logout", body, nil, false)
+	c.clearTokens()
+	return err
+}
+
+// LogoutAll ends every session of the authenticated user, on all devices,
+// then forgets this client's tokens.
+func (c *Client) LogoutAll() error {
+	if err := c.doJSON("POST", "/api/v1/auth/logout-all", nil, nil, true); err != nil {
+		return err
+	}
+	c.clearTokens()
+	return nil
+}
+
+// GetSessions lists the devices signed in to the authenticated account.
+func (c *Client) GetSessions() ([]models.Session, error) {
+	var wrapper struct {
+		Sessions []models.Session `json:"sessions"`
+	}
+	if err := c.doJSON("GET", "/api/v1/auth/sessions", nil, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return wrapper.Sessions, nil
+}
+
+// DeleteSession signs out the device with the given session ID.
+func (c *Client) DeleteSession(id string) error {
+	return c.doJSON("DELETE", "/api/v1/auth/sessions/"+id, nil, nil, true)
+}
+
+// ChangePassword replaces the authenticated user's password. The server
+// signs out their other devices; this one stays signed in.
+func (c *Client) ChangePassword(current, newPassword string) error {
+	body := models.ChangePasswordRequest{Password: current, NewPassword: newPassword}
+	return c.doJSON("POST", "/api/v1/auth/password", body, nil, true)
+}
+
+// RequestPasswordReset asks the server to email a reset code to email. It
+// succeeds whether or not the address has an account.
+func (c *Client) RequestPasswordReset(email string) error {
+	body := models.PasswordResetRequest{Email: email}
+	return c.doJSON("POST", "/api/v1/auth/password-reset/request", body, nil, false)
+}
+
+// ConfirmPasswordReset sets a new password with an emailed reset code. Every
+// session of the account ends, so the user signs in again afterwards.
+func (c *Client) ConfirmPasswordReset(code, newPassword string) error {
+	body := models.ConfirmPasswordResetRequest{Token: code, NewPassword: newPassword}
+	return c.doJSON("POST", "/api/v1/auth/password-reset/confirm", body, nil, false)
+}
+
+// VerifyEmail confirms the user's email address with the code emailed to
+// them.
+func (c *Client) VerifyEmail(code string) error {
+	body := models.VerifyEmailRequest{Token: code}
+	return c.doJSON("POST", "/api/v1/auth/verify-email", body, nil, false)
+}
+
+// ResendVerification asks the server to email the user a new verification
+// code. Earlier codes stop working.
+func (c *Client) ResendVerification() error {
+	return c.doJSON("POST", "/api/v1/auth/verify-email/resend", nil, nil, true)
+}
+
+// TwoFactorStatus reports whether the user has two-factor authentication on.
+func (c *Client) TwoFactorStatus() (*models.TwoFactorStatus, error) {
+	var resp models.TwoFactorStatus
+	if err := c.doJSON("GET", "/api/v1/auth/2fa", nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetupTwoFactor creates a new secret for the user's authenticator app.
+// It takes effect once EnableTwoFactor confirms a code.
+func (c *Client) SetupTwoFactor() (*models.TwoFactorSetup, error) {
+	var resp models.TwoFactorSetup
+	if err := c.doJSON("POST", "/api/v1/auth/2fa/setup", nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// EnableTwoFactor turns on two-factor authentication with a code from the
+// app and returns the recovery codes, which the server can't show again.
+func (c *Client) EnableTwoFactor(code string) ([]string, error) {
+	body := models.TwoFactorCodeRequest{Code: code}
+	var wrapper struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	if err := c.doJSON("POST", "/api/v1/auth/2fa/enable", body, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return wrapper.RecoveryCodes, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication.
+func (c *Client) DisableTwoFactor(password, code string) error {
+	body := models.DisableTwoFactorRequest{Password: password, Code: code}
+	return c.doJSON("POST", "/api/v1/auth/2fa/disable", body, nil, true)
+}
+
+// clearTokens forgets the tokens and runs the logout callback.
+func (c *Client) clearTokens() {
+	c.mu.Lock()
+	c.accessToken = ""
+	c.refreshToken = ""
+	cb := c.onLogout
+	c.mu.Unlock()
+
+	if cb != nil {
+		cb()
+	}
+}
+
 // GetTimeline fetches the global timeline with cursor-based pagination.
 func (c *Client) GetTimeline(cursor string, limit int) (*models.TimelineResponse, error) {
-	q := url.Values{}
+	var resp models.TimelineResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/timeline", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetHomeTimeline fetches posts from accounts the authenticated user follows.
+func (c *Client) GetHomeTimeline(cursor string, limit int) (*models.TimelineResponse, error) {
+	var resp models.TimelineResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/timeline/home", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMentions fetches posts that mention the authenticated user.
+func (c *Client) GetMentions(cursor string, limit int) (*models.TimelineResponse, error) {
+	var resp models.TimelineResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/users/me/mentions", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTagPosts fetches posts tagged with tag.
+func (c *Client) GetTagPosts(tag, cursor string, limit int) (*models.TimelineResponse, error) {
+	var resp models.TimelineResponse
+	path := pagedPath("/api/v1/tags/"+url.PathEscape(tag)+"/posts", cursor, limit)
+	if err := c.doJSON("GET", path, nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetTrendingTags fetches the most used recent hashtags.
+func (c *Client) GetTrendingTags(limit int) ([]models.TrendingTag, error) {
+	var wrapper struct {
+		Tags []models.TrendingTag `json:"tags"`
+	}
+	if err := c.doJSON("GET", pagedPath("/api/v1/tags/trending", "", limit), nil, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return wrapper.Tags, nil
+}
+
+// SearchPosts fetches posts matching query.
+func (c *Client) SearchPosts(query, cursor string, limit int) (*models.TimelineResponse, error) {
+	var resp models.TimelineResponse
+	if err := c.doJSON("GET", searchPath(query, models.SearchTypePosts, cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SearchUsers fetches users whose username or display name matches query.
+func (c *Client) SearchUsers(query, cursor string, limit int) (*models.UserSearchResponse, error) {
+	var resp models.UserSearchResponse
+	if err := c.doJSON("GET", searchPath(query, models.SearchTypeUsers, cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func searchPath(query, searchType, cursor string, limit int) string {
+	q := url.Values{"q": {query}, "type": {searchType}}
 	if cursor != "" {
 		q.Set("cursor", cursor)
 	}
 	if limit > 0 {
 		q.Set("limit", strconv.Itoa(limit))
 	}
-	path := "/api/v1/timeline"
-	if encoded := q.Encode(); encoded != "" {
-		path += "?" + encoded
+	return "/api/v1/search?" + q.Encode()
+}
+
+// GetNotifications fetches the authenticated user's notifications.
+func (c *Client) GetNotifications(cursor string, limit int) (*models.NotificationListResponse, error) {
+	var resp models.NotificationListResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/notifications", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
 	}
+	return &resp, nil
+}
 
-	var resp models.TimelineResponse
+// UnreadNotificationCount returns how many notifications are unread.
+func (c *Client) UnreadNotificationCount() (int, error) {
+	var wrapper struct {
+		UnreadCount int `json:"unread_count"`
+	}
+	if err := c.doJSON("GET", "/api/v1/notifications/unread_count", nil, &wrapper, true); err != nil {
+		return 0, err
+	}
+	return wrapper.UnreadCount, nil
+}
+
+// MarkNotificationsRead marks notifications up to and including upTo as read
+// and returns how many remain unread.
+func (c *Client) MarkNotificationsRead(upTo time.Time) (int, error) {
+	body := struct {
+		Cursor time.Time `json:"cursor"`
+	}{Cursor: upTo}
+
+	var wrapper struct {
+		UnreadCount int `json:"unread_count"`
+	}
+	if err := c.doJSON("POST", "/api/v1/notifications/read", body, &wrapper, true); err != nil {
+		return 0, err
+	}
+	return wrapper.UnreadCount, nil
+}
+
+// GetConversations fetches the authenticated user's direct message
+// conversations, most recently active first.
+func (c *Client) GetConversations(cursor string, limit int) (*models.ConversationListResponse, error) {
+	var resp models.ConversationListResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/conversations", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StartConversation opens a conversation with the given users, reusing an
+// existing one-to-one conversation.
+func (c *Client) StartConversation(usernames []string) (*models.Conversation, error) {
+	body := struct {
+		Usernames []string `json:"usernames"`
+	}{Usernames: usernames}
+
+	var wrapper struct {
+		Conversation models.Conversation `json:"conversation"`
+	}
+	if err := c.doJSON("POST", "/api/v1/conversations", body, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return &wrapper.Conversation, nil
+}
+
+// GetMessages fetches the messages in a conversation, newest first.
+func (c *Client) GetMessages(conversationID, cursor string, limit int) (*models.MessageListResponse, error) {
+	var resp models.MessageListResponse
+	path := pagedPath("/api/v1/conversations/"+url.PathEscape(conversationID)+"/messages", cursor, limit)
 	if err := c.doJSON("GET", path, nil, &resp, true); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
+// SendMessage sends a direct message to a conversation.
+func (c *Client) SendMessage(conversationID, content string) (*models.Message, error) {
+	body := struct {
+		Content string `json:"content"`
+	}{Content: content}
+
+	var wrapper struct {
+		Message models.Message `json:"message"`
+	}
+	if err := c.doJSON("POST", "/api/v1/conversations/"+url.PathEscape(conversationID)+"/messages", body, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return &wrapper.Message, nil
+}
+
+// MarkConversationRead marks a conversation as read.
+func (c *Client) MarkConversationRead(conversationID string) error {
+	return c.doJSON("POST", "/api/v1/conversations/"+url.PathEscape(conversationID)+"/read", nil, nil, true)
+}
+
 // CreatePost publishes a new post with the given content.
 func (c *Client) CreatePost(content string) (*models.Post, error) {
 	body := struct {
@@ -157,6 +498,85 @@ func (c *Client) GetPost(id string) (*models.Post, error) {
 	return &wrapper.Post, nil
 }
 
+// CreateReply publishes a reply to the given post.
+func (c *Client) CreateReply(parentID, content string) (*models.Post, error) {
+	body := struct {
+		Content string `json:"content"`
+	}{Content: content}
+
+	var wrapper struct {
+		Post models.Post `json:"post"`
+	}
+	if err := c.doJSON("POST", "/api/v1/posts/"+parentID+"/replies", body, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return &wrapper.Post, nil
+}
+
+// GetThread retrieves a post with its ancestors and replies.
+func (c *Client) GetThread(postID string) (*models.ThreadResponse, error) {
+	var resp models.ThreadResponse
+	if err := c.doJSON("GET", "/api/v1/posts/"+postID+"/thread", nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Like likes a post and returns its updated like count.
+func (c *Client) Like(postID string) (int, error) {
+	return c.setLike("POST", postID)
+}
+
+// Unlike removes the authenticated user's like and returns the updated count.
+func (c *Client) Unlike(postID string) (int, error) {
+	return c.setLike("DELETE", postID)
+}
+
+func (c *Client) setLike(method, postID string) (int, error) {
+	var wrapper struct {
+		LikeCount int `json:"like_count"`
+	}
+	if err := c.doJSON(method, "/api/v1/posts/"+postID+"/like", nil, &wrapper, true); err != nil {
+		return 0, err
+	}
+	return wrapper.LikeCount, nil
+}
+
+// Repost shares a post and returns the original's updated repost count.
+func (c *Client) Repost(postID string) (int, error) {
+	return c.setRepost("POST", postID)
+}
+
+// Unrepost removes the authenticated user's repost and returns the updated count.
+func (c *Client) Unrepost(postID string) (int, error) {
+	return c.setRepost("DELETE", postID)
+}
+
+func (c *Client) setRepost(method, postID string) (int, error) {
+	var wrapper struct {
+		RepostCount int `json:"repost_count"`
+	}
+	if err := c.doJSON(method, "/api/v1/posts/"+postID+"/repost", nil, &wrapper, true); err != nil {
+		return 0, err
+	}
+	return wrapper.RepostCount, nil
+}
+
+// QuotePost publishes a post that embeds the given post.
+func (c *Client) QuotePost(postID, content string) (*models.Post, error) {
+	body := struct {
+		Content string `json:"content"`
+	}{Content: content}
+
+	var wrapper struct {
+		Post models.Post `json:"post"`
+	}
+	if err := c.doJSON("POST", "/api/v1/posts/"+postID+"/quote", body, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return &wrapper.Post, nil
+}
+
 // GetUser retrieves a user by ID. Use "me" for the authenticated user.
 func (c *Client) GetUser(id string) (*models.User, error) {
 	var wrapper struct {
@@ -170,20 +590,8 @@ func (c *Client) GetUser(id string) (*models.User, error) {
 
 // GetUserPosts retrieves posts by a specific user with cursor-based pagination.
 func (c *Client) GetUserPosts(userID, cursor string, limit int) (*models.TimelineResponse, error) {
-	q := url.Values{}
-	if cursor != "" {
-		q.Set("cursor", cursor)
-	}
-	if limit > 0 {
-		q.Set("limit", strconv.Itoa(limit))
-	}
-	path := "/api/v1/users/" + userID + "/posts"
-	if encoded := q.Encode(); encoded != "" {
-		path += "?" + encoded
-	}
-
 	var resp models.TimelineResponse
-	if err := c.doJSON("GET", path, nil, &resp, true); err != nil {
+	if err := c.doJSON("GET", pagedPath("/api/v1/users/"+userID+"/posts", cursor, limit), nil, &resp, true); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -200,9 +608,211 @@ func (c *Client) UpdateUser(updates *models.UserUpdate) (*models.User, error) {
 	return &wrapper.User, nil
 }
 
+// DeleteAccount schedules the authenticated user's account for deletion,
+// confirming with their password, and returns when it will be purged.
+func (c *Client) DeleteAccount(password string) (time.Time, error) {
+	body := map[string]string{"password": password}
+	var resp struct {
+		DeleteAfter time.Time `json:"delete_after"`
+	}
+	if err := c.doJSON("DELETE", "/api/v1/users/me", body, &resp, true); err != nil {
+		return time.Time{}, err
+	}
+	return resp.DeleteAfter, nil
+}
+
+// CancelDeletion restores an account that is pending deletion.
+func (c *Client) CancelDeletion(email, password string) error {
+	body := map[string]string{"email": email, "password": password}
+	return c.doJSON("POST", "/api/v1/auth/cancel-deletion", body, nil, false)
+}
+
+// RequestExport starts building an archive of the authenticated user's
+// data. The returned export is pending; poll GetExport until it is ready.
+func (c *Client) RequestExport() (*models.Export, error) {
+	var wrapper struct {
+		Export models.Export `json:"export"`
+	}
+	if err := c.doJSON("POST", "/api/v1/users/me/export", nil, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return &wrapper.Export, nil
+}
+
+// GetExport fetches the status of one of the authenticated user's exports.
+func (c *Client) GetExport(id string) (*models.Export, error) {
+	var wrapper struct {
+		Export models.Export `json:"export"`
+	}
+	if err := c.doJSON("GET", "/api/v1/users/me/export/"+url.PathEscape(id), nil, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return &wrapper.Export, nil
+}
+
+// DownloadExport fetches the JSON archive of a ready export.
+func (c *Client) DownloadExport(id string) ([]byte, error) {
+	var archive json.RawMessage
+	if err := c.doJSON("GET", "/api/v1/users/me/export/"+url.PathEscape(id)+"/archive", nil, &archive, true); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// Follow starts following the given user.
+func (c *Client) Follow(userID string) error {
+	return c.doJSON("POST", "/api/v1/users/"+userID+"/follow", nil, nil, true)
+}
+
+// Unfollow stops following the given user.
+func (c *Client) Unfollow(userID string) error {
+	return c.doJSON("DELETE", "/api/v1/users/"+userID+"/follow", nil, nil, true)
+}
+
+// IsFollowing reports whether the authenticated user follows the given user.
+func (c *Client) IsFollowing(userID string) (bool, error) {
+	var wrapper struct {
+		Following bool `json:"following"`
+	}
+	if err := c.doJSON("GET", "/api/v1/users/"+userID+"/follow", nil, &wrapper, true); err != nil {
+		return false, err
+	}
+	return wrapper.Following, nil
+}
+
+// GetFollowers lists accounts following the given user, newest first.
+func (c *Client) GetFollowers(userID, cursor string, limit int) (*models.FollowListResponse, error) {
+	var resp models.FollowListResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/users/"+userID+"/followers", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetFollowing lists accounts the given user follows, newest first.
+func (c *Client) GetFollowing(userID, cursor string, limit int) (*models.FollowListResponse, error) {
+	var resp models.FollowListResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/users/"+userID+"/following", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Block blocks the given user, removing any follows between you.
+func (c *Client) Block(userID string) error {
+	return c.doJSON("POST", "/api/v1/users/"+userID+"/block", nil, nil, true)
+}
+
+// Unblock removes a block on the given user.
+func (c *Client) Unblock(userID string) error {
+	return c.doJSON("DELETE", "/api/v1/users/"+userID+"/block", nil, nil, true)
+}
+
+// IsBlocking reports whether the authenticated user blocks the given user.
+func (c *Client) IsBlocking(userID string) (bool, error) {
+	var wrapper struct {
+		Blocking bool `json:"blocking"`
+	}
+	if err := c.doJSON("GET", "/api/v1/users/"+userID+"/block", nil, &wrapper, true); err != nil {
+		return false, err
+	}
+	return wrapper.Blocking, nil
+}
+
+// Mute hides the given user's posts and notifications.
+func (c *Client) Mute(userID string) error {
+	return c.doJSON("POST", "/api/v1/users/"+userID+"/mute", nil, nil, true)
+}
+
+// Unmute removes a mute on the given user.
+func (c *Client) Unmute(userID string) error {
+	return c.doJSON("DELETE", "/api/v1/users/"+userID+"/mute", nil, nil, true)
+}
+
+// IsMuting reports whether the authenticated user mutes the given user.
+func (c *Client) IsMuting(userID string) (bool, error) {
+	var wrapper struct {
+		Muting bool `json:"muting"`
+	}
+	if err := c.doJSON("GET", "/api/v1/users/"+userID+"/mute", nil, &wrapper, true); err != nil {
+		return false, err
+	}
+	return wrapper.Muting, nil
+}
+
+// GetBlocked lists the accounts the authenticated user blocks, newest first.
+func (c *Client) GetBlocked(cursor string, limit int) (*models.RelationListResponse, error) {
+	var resp models.RelationListResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/users/me/blocks", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMuted lists the accounts the authenticated user mutes, newest first.
+func (c *Client) GetMuted(cursor string, limit int) (*models.RelationListResponse, error) {
+	var resp models.RelationListResponse
+	if err := c.doJSON("GET", pagedPath("/api/v1/users/me/mutes", cursor, limit), nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetFilters lists the authenticated user's active keyword filters.
+func (c *Client) GetFilters() ([]models.Filter, error) {
+	var resp models.FilterListResponse
+	if err := c.doJSON("GET", "/api/v1/filters", nil, &resp, true); err != nil {
+		return nil, err
+	}
+	return resp.Filters, nil
+}
+
+// CreateFilter adds a keyword filter. An empty match or action uses the
+// server's default, and an expiresIn of zero never expires.
+func (c *Client) CreateFilter(phrase, match, action string, expiresIn time.Duration) (*models.Filter, error) {
+	body := struct {
+		Phrase    string `json:"phrase"`
+		Match     string `json:"match,omitempty"`
+		Action    string `json:"action,omitempty"`
+		ExpiresIn int64  `json:"expires_in,omitempty"`
+	}{Phrase: phrase, Match: match, Action: action, ExpiresIn: int64(expiresIn / time.Second)}
+
+	var wrapper struct {
+		Filter models.Filter `json:"filter"`
+	}
+	if err := c.doJSON("POST", "/api/v1/filters", body, &wrapper, true); err != nil {
+		return nil, err
+	}
+	return &wrapper.Filter, nil
+}
+
+// DeleteFilter removes one of the authenticated user's filters.
+func (c *Client) DeleteFilter(id string) error {
+	return c.doJSON("DELETE", "/api/v1/filters/"+url.PathEscape(id), nil, nil, true)
+}
+
+// pagedPath appends the cursor and limit query parameters to path.
+func pagedPath(path, cursor string, limit int) string {
+	q := url.Values{}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if encoded := q.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	return path
+}
+
 // doJSON performs an HTTP request, optionally with auth, and decodes the JSON response.
 // If withAuth is true and a 401 with token_expired is received, it attempts a refresh and retries.
 func (c *Client) doJSON(method, path string, body any, dst any, withAuth bool) error {
+	c.mu.Lock()
+	sentToken := c.accessToken
+	c.mu.Unlock()
+
 	resp, err := c.do(method, path, body, withAuth)
 	if err != nil {
 		return err
@@ -211,7 +821,7 @@ func (c *Client) doJSON(method, path string, body any, dst any, withAuth bool) e
 	// Handle 401 with transparent refresh
 	if resp.StatusCode == http.StatusUnauthorized && withAuth {
 		_ = resp.Body.Close()
-		if _, err := c.Refresh(); err != nil {
+		if err := c.refreshSince(sentToken); err != nil {
 			return fmt.Errorf("token refresh failed: %w", err)
 		}
 		resp, err = c.do(method, path, body, withAuth)
@@ -290,6 +900,7 @@ func (c *Client) doOnce(method, path string, body any, withAuth bool) (*http.Res
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	req.Header.Set("User-Agent", "niotebook-tui/"+build.Version)
 
 	if withAuth {
 		c.mu.Lock()
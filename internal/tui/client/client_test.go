@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/tui/client"
@@ -112,6 +116,58 @@ func TestAuthRefreshOn401(t *testing.T) {
 	}
 }
 
+func TestConcurrent401sRefreshOnce(t *testing.T) {
+	var refreshes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/refresh" {
+			var body models.RefreshRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			refreshes.Add(1)
+			// The server revokes the session when a token is reused
+			if body.RefreshToken != "valid-refresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"tokens": models.TokenPair{AccessToken: "new-access", RefreshToken: "new-refresh"},
+			})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer new-access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(models.TimelineResponse{})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("expired-token")
+	c.SetRefreshToken("valid-refresh")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.GetTimeline("", 50)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetTimeline: %v", err)
+		}
+	}
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("refreshed %d times, want 1", n)
+	}
+}
+
 func TestLogin(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "POST" || r.URL.Path != "/api/v1/auth/login" {
@@ -307,3 +363,899 @@ func TestAPIErrorResponse(t *testing.T) {
 		t.Fatal("expected error for 400 response")
 	}
 }
+
+func TestGetHomeTimeline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/timeline/home" {
+			t.Errorf("path = %q, want /api/v1/timeline/home", r.URL.Path)
+		}
+		if r.URL.Query().Get("cursor") != "2026-02-15T23:00:00Z" {
+			t.Errorf("cursor = %q, want 2026-02-15T23:00:00Z", r.URL.Query().Get("cursor"))
+		}
+		_ = json.NewEncoder(w).Encode(models.TimelineResponse{
+			Posts: []models.Post{{ID: "1", Content: "From a friend"}},
+		})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	resp, err := c.GetHomeTimeline("2026-02-15T23:00:00Z", 20)
+	if err != nil {
+		t.Fatalf("GetHomeTimeline: %v", err)
+	}
+	if len(resp.Posts) != 1 {
+		t.Errorf("got %d posts, want 1", len(resp.Posts))
+	}
+}
+
+func TestFollowAndUnfollow(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users/u2/follow" {
+			t.Errorf("path = %q, want /api/v1/users/u2/follow", r.URL.Path)
+		}
+		methods = append(methods, r.Method)
+		_ = json.NewEncoder(w).Encode(map[string]any{"following": r.Method == "POST"})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	if err := c.Follow("u2"); err != nil {
+		t.Fatalf("Follow: %v", err)
+	}
+	if err := c.Unfollow("u2"); err != nil {
+		t.Fatalf("Unfollow: %v", err)
+	}
+	if len(methods) != 2 || methods[0] != "POST" || methods[1] != "DELETE" {
+		t.Errorf("methods = %v, want [POST DELETE]", methods)
+	}
+}
+
+func TestIsFollowing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/users/u2/follow" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"following": true})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	following, err := c.IsFollowing("u2")
+	if err != nil {
+		t.Fatalf("IsFollowing: %v", err)
+	}
+	if !following {
+		t.Error("expected following = true")
+	}
+}
+
+func TestGetFollowers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users/u1/followers" {
+			t.Errorf("path = %q, want /api/v1/users/u1/followers", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(models.FollowListResponse{
+			Users: []models.FollowEntry{{User: models.User{ID: "u2", Username: "sara"}}},
+		})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	resp, err := c.GetFollowers("u1", "", 20)
+	if err != nil {
+		t.Fatalf("GetFollowers: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].User.Username != "sara" {
+		t.Errorf("users = %+v, want only sara", resp.Users)
+	}
+}
+
+func TestCreateReply(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/posts/post-1/replies" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		parent := "post-1"
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"post": models.Post{ID: "reply-1", ParentID: &parent, Content: "A reply"},
+		})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	post, err := c.CreateReply("post-1", "A reply")
+	if err != nil {
+		t.Fatalf("CreateReply: %v", err)
+	}
+	if post.ParentID == nil || *post.ParentID != "post-1" {
+		t.Errorf("parent_id = %v, want %q", post.ParentID, "post-1")
+	}
+}
+
+func TestGetThread(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/api/v1/posts/post-2/thread" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(models.ThreadResponse{
+			Ancestors: []models.Post{{ID: "post-1"}},
+			Post:      models.Post{ID: "post-2"},
+			Replies:   []models.Post{{ID: "post-3"}, {ID: "post-4"}},
+		})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	thread, err := c.GetThread("post-2")
+	if err != nil {
+		t.Fatalf("GetThread: %v", err)
+	}
+	if len(thread.Ancestors) != 1 || thread.Post.ID != "post-2" || len(thread.Replies) != 2 {
+		t.Errorf("thread = %+v, want 1 ancestor, post-2, 2 replies", thread)
+	}
+}
+
+func TestLikeAndUnlike(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		count := 1
+		if r.Method == "DELETE" {
+			count = 0
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"liked": r.Method == "POST", "like_count": count})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	count, err := c.Like("post-1")
+	if err != nil {
+		t.Fatalf("Like: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+	count, err = c.Unlike("post-1")
+	if err != nil {
+		t.Fatalf("Unlike: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count = %d, want 0", count)
+	}
+
+	want := []string{"POST /api/v1/posts/post-1/like", "DELETE /api/v1/posts/post-1/like"}
+	if len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestRepostAndQuote(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/quote") {
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"post": models.Post{ID: "post-2", Kind: models.PostKindQuote, Content: "Agreed"},
+			})
+			return
+		}
+		count := 1
+		if r.Method == "DELETE" {
+			count = 0
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"reposted": r.Method == "POST", "repost_count": count})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	count, err := c.Repost("post-1")
+	if err != nil {
+		t.Fatalf("Repost: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("count = %d, want 1", count)
+	}
+	count, err = c.Unrepost("post-1")
+	if err != nil {
+		t.Fatalf("Unrepost: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("count = %d, want 0", count)
+	}
+	post, err := c.QuotePost("post-1", "Agreed")
+	if err != nil {
+		t.Fatalf("QuotePost: %v", err)
+	}
+	if post.Kind != models.PostKindQuote {
+		t.Errorf("kind = %q, want quote", post.Kind)
+	}
+
+	want := []string{
+		"POST /api/v1/posts/post-1/repost",
+		"DELETE /api/v1/posts/post-1/repost",
+		"POST /api/v1/posts/post-1/quote",
+	}
+	if len(calls) != len(want) || calls[0] != want[0] || calls[1] != want[1] || calls[2] != want[2] {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestGetMentions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/users/me/mentions" {
+			t.Errorf("path = %q, want /api/v1/users/me/mentions", r.URL.Path)
+		}
+		if r.URL.Query().Get("limit") != "20" {
+			t.Errorf("limit = %q, want 20", r.URL.Query().Get("limit"))
+		}
+		_ = json.NewEncoder(w).Encode(models.TimelineResponse{
+			Posts: []models.Post{{ID: "post-1", Content: "hi @akram"}},
+		})
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	resp, err := c.GetMentions("", 20)
+	if err != nil {
+		t.Fatalf("GetMentions: %v", err)
+	}
+	if len(resp.Posts) != 1 || resp.Posts[0].ID != "post-1" {
+		t.Errorf("posts = %+v, want post-1", resp.Posts)
+	}
+}
+
+func TestGetTagPostsAndTrending(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/tags/golang/posts":
+			_ = json.NewEncoder(w).Encode(models.TimelineResponse{
+				Posts: []models.Post{{ID: "post-1", Content: "#golang", Tags: []string{"golang"}}},
+			})
+		case "/api/v1/tags/trending":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"tags": []models.TrendingTag{{Tag: "golang", PostCount: 3}},
+			})
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	resp, err := c.GetTagPosts("golang", "", 20)
+	if err != nil {
+		t.Fatalf("GetTagPosts: %v", err)
+	}
+	if len(resp.Posts) != 1 || resp.Posts[0].ID != "post-1" {
+		t.Errorf("posts = %+v, want post-1", resp.Posts)
+	}
+
+	tags, err := c.GetTrendingTags(10)
+	if err != nil {
+		t.Fatalf("GetTrendingTags: %v", err)
+	}
+	if len(tags) != 1 || tags[0].Tag != "golang" || tags[0].PostCount != 3 {
+		t.Errorf("tags = %+v, want golang (3)", tags)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/search" {
+			t.Errorf("path = %q, want /api/v1/search", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("q") != "go tui" {
+			t.Errorf("q = %q, want %q", q.Get("q"), "go tui")
+		}
+		switch q.Get("type") {
+		case models.SearchTypePosts:
+			if q.Get("cursor") != "2026-01-01T00:00:00Z" {
+				t.Errorf("cursor = %q, want 2026-01-01T00:00:00Z", q.Get("cursor"))
+			}
+			_ = json.NewEncoder(w).Encode(models.TimelineResponse{
+				Posts: []models.Post{{ID: "post-1"}},
+			})
+		case models.SearchTypeUsers:
+			_ = json.NewEncoder(w).Encode(models.UserSearchResponse{
+				Users: []models.User{{ID: "user-1", Username: "sara"}},
+			})
+		default:
+			t.Errorf("type = %q, want posts or users", q.Get("type"))
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	posts, err := c.SearchPosts("go tui", "2026-01-01T00:00:00Z", 20)
+	if err != nil {
+		t.Fatalf("SearchPosts: %v", err)
+	}
+	if len(posts.Posts) != 1 || posts.Posts[0].ID != "post-1" {
+		t.Errorf("posts = %+v, want post-1", posts.Posts)
+	}
+
+	users, err := c.SearchUsers("go tui", "", 20)
+	if err != nil {
+		t.Fatalf("SearchUsers: %v", err)
+	}
+	if len(users.Users) != 1 || users.Users[0].Username != "sara" {
+		t.Errorf("users = %+v, want sara", users.Users)
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	readUpTo := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/notifications":
+			_ = json.NewEncoder(w).Encode(models.NotificationListResponse{
+				Notifications: []models.Notification{{ID: "n1", Kind: models.NotificationKindFollow}},
+				UnreadCount:   1,
+			})
+		case "/api/v1/notifications/unread_count":
+			_ = json.NewEncoder(w).Encode(map[string]int{"unread_count": 4})
+		case "/api/v1/notifications/read":
+			var body struct {
+				Cursor time.Time `json:"cursor"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if !body.Cursor.Equal(readUpTo) {
+				t.Errorf("cursor = %v, want %v", body.Cursor, readUpTo)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]int{"unread_count": 0})
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	resp, err := c.GetNotifications("", 20)
+	if err != nil {
+		t.Fatalf("GetNotifications: %v", err)
+	}
+	if len(resp.Notifications) != 1 || resp.UnreadCount != 1 {
+		t.Errorf("resp = %+v, want one unread notification", resp)
+	}
+
+	if count, err := c.UnreadNotificationCount(); err != nil || count != 4 {
+		t.Errorf("UnreadNotificationCount = %d, %v; want 4", count, err)
+	}
+	if count, err := c.MarkNotificationsRead(readUpTo); err != nil || count != 0 {
+		t.Errorf("MarkNotificationsRead = %d, %v; want 0", count, err)
+	}
+}
+
+func TestConversations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v1/conversations":
+			_ = json.NewEncoder(w).Encode(models.ConversationListResponse{
+				Conversations: []models.Conversation{{ID: "c1", Unread: true}},
+			})
+		case "POST /api/v1/conversations":
+			var body struct {
+				Usernames []string `json:"usernames"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if len(body.Usernames) != 2 || body.Usernames[0] != "sara" {
+				t.Errorf("usernames = %v, want [sara omar]", body.Usernames)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"conversation": models.Conversation{ID: "c2"}})
+		case "GET /api/v1/conversations/c1/messages":
+			_ = json.NewEncoder(w).Encode(models.MessageListResponse{
+				Messages: []models.Message{{ID: "m1", Content: "hi"}},
+			})
+		case "POST /api/v1/conversations/c1/messages":
+			var body struct {
+				Content string `json:"content"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"message": models.Message{ID: "m2", Content: body.Content}})
+		case "POST /api/v1/conversations/c1/read":
+			_ = json.NewEncoder(w).Encode(map[string]bool{"read": true})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	inbox, err := c.GetConversations("", 20)
+	if err != nil || len(inbox.Conversations) != 1 || !inbox.Conversations[0].Unread {
+		t.Fatalf("GetConversations = %+v, %v", inbox, err)
+	}
+	conv, err := c.StartConversation([]string{"sara", "omar"})
+	if err != nil || conv.ID != "c2" {
+		t.Errorf("StartConversation = %+v, %v; want c2", conv, err)
+	}
+	messages, err := c.GetMessages("c1", "", 20)
+	if err != nil || len(messages.Messages) != 1 {
+		t.Errorf("GetMessages = %+v, %v", messages, err)
+	}
+	msg, err := c.SendMessage("c1", "hello")
+	if err != nil || msg.Content != "hello" {
+		t.Errorf("SendMessage = %+v, %v", msg, err)
+	}
+	if err := c.MarkConversationRead("c1"); err != nil {
+		t.Errorf("MarkConversationRead: %v", err)
+	}
+}
+
+func TestBlocksAndMutes(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/users/u2/block":
+			_ = json.NewEncoder(w).Encode(map[string]any{"blocking": r.Method != "DELETE"})
+		case "/api/v1/users/u2/mute":
+			_ = json.NewEncoder(w).Encode(map[string]any{"muting": r.Method != "DELETE"})
+		case "/api/v1/users/me/blocks", "/api/v1/users/me/mutes":
+			_ = json.NewEncoder(w).Encode(models.RelationListResponse{
+				Users: []models.RelationEntry{{User: models.User{ID: "u2", Username: "sara"}}},
+			})
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	if err := c.Block("u2"); err != nil {
+		t.Fatalf("Block: %v", err)
+	}
+	if blocking, err := c.IsBlocking("u2"); err != nil || !blocking {
+		t.Errorf("IsBlocking = %v, %v; want true", blocking, err)
+	}
+	if err := c.Unblock("u2"); err != nil {
+		t.Fatalf("Unblock: %v", err)
+	}
+	if err := c.Mute("u2"); err != nil {
+		t.Fatalf("Mute: %v", err)
+	}
+	if muting, err := c.IsMuting("u2"); err != nil || !muting {
+		t.Errorf("IsMuting = %v, %v; want true", muting, err)
+	}
+	if err := c.Unmute("u2"); err != nil {
+		t.Fatalf("Unmute: %v", err)
+	}
+
+	blocked, err := c.GetBlocked("", 0)
+	if err != nil {
+		t.Fatalf("GetBlocked: %v", err)
+	}
+	muted, err := c.GetMuted("", 0)
+	if err != nil {
+		t.Fatalf("GetMuted: %v", err)
+	}
+	if len(blocked.Users) != 1 || len(muted.Users) != 1 {
+		t.Errorf("blocked = %+v, muted = %+v", blocked.Users, muted.Users)
+	}
+
+	want := []string{
+		"POST /api/v1/users/u2/block", "GET /api/v1/users/u2/block", "DELETE /api/v1/users/u2/block",
+		"POST /api/v1/users/u2/mute", "GET /api/v1/users/u2/mute", "DELETE /api/v1/users/u2/mute",
+		"GET /api/v1/users/me/blocks", "GET /api/v1/users/me/mutes",
+	}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestFilters(t *testing.T) {
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/filters":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"filter": models.Filter{ID: "f1", Phrase: "spoiler"}})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/filters":
+			_ = json.NewEncoder(w).Encode(models.FilterListResponse{Filters: []models.Filter{{ID: "f1", Phrase: "spoiler"}}})
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/filters/f1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"deleted": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	f, err := c.CreateFilter("spoiler", models.FilterMatchWord, models.FilterActionFlag, time.Hour)
+	if err != nil {
+		t.Fatalf("CreateFilter: %v", err)
+	}
+	if f.ID != "f1" {
+		t.Errorf("filter id = %q, want %q", f.ID, "f1")
+	}
+	if created["match"] != "word" || created["action"] != "flag" || created["expires_in"] != float64(3600) {
+		t.Errorf("request body = %v", created)
+	}
+
+	filters, err := c.GetFilters()
+	if err != nil || len(filters) != 1 {
+		t.Fatalf("GetFilters = %+v, %v", filters, err)
+	}
+	if err := c.DeleteFilter("f1"); err != nil {
+		t.Fatalf("DeleteFilter: %v", err)
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	deleteAfter := time.Date(2026, 11, 15, 12, 0, 0, 0, time.UTC)
+	var body map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE" && r.URL.Path == "/api/v1/users/me":
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"delete_after": deleteAfter})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/cancel-deletion":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("cancel-deletion should not send a token")
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"cancelled": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	got, err := c.DeleteAccount("securepass123")
+	if err != nil {
+		t.Fatalf("DeleteAccount: %v", err)
+	}
+	if !got.Equal(deleteAfter) {
+		t.Errorf("delete_after = %v, want %v", got, deleteAfter)
+	}
+	if body["password"] != "securepass123" {
+		t.Errorf("request body = %v", body)
+	}
+	if err := c.CancelDeletion("akram@example.com", "securepass123"); err != nil {
+		t.Fatalf("CancelDeletion: %v", err)
+	}
+}
+
+func TestDataExport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/users/me/export":
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"export": models.Export{ID: "exp-1", Status: models.ExportPending},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/me/export/exp-1":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"export": models.Export{ID: "exp-1", Status: models.ExportReady},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/users/me/export/exp-1/archive":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"user":{"username":"akram"},"posts":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	export, err := c.RequestExport()
+	if err != nil {
+		t.Fatalf("RequestExport: %v", err)
+	}
+	if export.ID != "exp-1" || export.Status != models.ExportPending {
+		t.Errorf("export = %+v, want pending exp-1", export)
+	}
+	export, err = c.GetExport("exp-1")
+	if err != nil {
+		t.Fatalf("GetExport: %v", err)
+	}
+	if export.Status != models.ExportReady {
+		t.Errorf("status = %q, want ready", export.Status)
+	}
+	archive, err := c.DownloadExport("exp-1")
+	if err != nil {
+		t.Fatalf("DownloadExport: %v", err)
+	}
+	if string(archive) != `{"user":{"username":"akram"},"posts":[]}` {
+		t.Errorf("archive = %s", archive)
+	}
+}
+
+func TestLogout(t *testing.T) {
+	var body models.RefreshRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/logout":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("logout should not send an access token")
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_ = json.NewEncoder(w).Encode(map[string]any{"logged_out": true})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/logout-all":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Error("logout-all should send the access token")
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"logged_out": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	var loggedOut int
+	c.OnLogout(func() { loggedOut++ })
+
+	c.SetToken("test-token")
+	c.SetRefreshToken("refresh-456")
+	if err := c.Logout(); err != nil {
+		t.Fatalf("Logout: %v", err)
+	}
+	if body.RefreshToken != "refresh-456" {
+		t.Errorf("logout sent refresh token %q, want refresh-456", body.RefreshToken)
+	}
+
+	c.SetToken("test-token")
+	if err := c.LogoutAll(); err != nil {
+		t.Fatalf("LogoutAll: %v", err)
+	}
+	if loggedOut != 2 {
+		t.Errorf("OnLogout called %d times, want 2", loggedOut)
+	}
+}
+
+func TestSessions(t *testing.T) {
+	var login models.LoginRequest
+	var deleted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.UserAgent(), "niotebook-tui/") {
+			t.Errorf("User-Agent = %q, want niotebook-tui/<version>", r.UserAgent())
+		}
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/login":
+			_ = json.NewDecoder(r.Body).Decode(&login)
+			_ = json.NewEncoder(w).Encode(models.AuthResponse{
+				Tokens: &models.TokenPair{AccessToken: "at", RefreshToken: "rt"},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/auth/sessions":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"sessions": []models.Session{{ID: "s1", DeviceName: "laptop", Current: true}, {ID: "s2", DeviceName: "desktop"}},
+			})
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/api/v1/auth/sessions/"):
+			deleted = strings.TrimPrefix(r.URL.Path, "/api/v1/auth/sessions/")
+			_ = json.NewEncoder(w).Encode(map[string]any{"deleted": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetDeviceName("laptop")
+	if _, err := c.Login("akram@example.com", "password123"); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if login.DeviceName != "laptop" {
+		t.Errorf("login sent device name %q, want laptop", login.DeviceName)
+	}
+
+	sessions, err := c.GetSessions()
+	if err != nil {
+		t.Fatalf("GetSessions: %v", err)
+	}
+	if len(sessions) != 2 || !sessions[0].Current {
+		t.Errorf("sessions = %+v, want 2 with the first current", sessions)
+	}
+	if err := c.DeleteSession("s2"); err != nil {
+		t.Fatalf("DeleteSession: %v", err)
+	}
+	if deleted != "s2" {
+		t.Errorf("deleted session %q, want s2", deleted)
+	}
+}
+
+func TestPasswordChangeAndReset(t *testing.T) {
+	var change models.ChangePasswordRequest
+	var reset models.PasswordResetRequest
+	var confirm models.ConfirmPasswordResetRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/password":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Error("password change should send the access token")
+			}
+			_ = json.NewDecoder(r.Body).Decode(&change)
+			_ = json.NewEncoder(w).Encode(map[string]any{"changed": true})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/password-reset/request":
+			_ = json.NewDecoder(r.Body).Decode(&reset)
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"requested": true})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/password-reset/confirm":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("confirming a reset should not send a token")
+			}
+			_ = json.NewDecoder(r.Body).Decode(&confirm)
+			_ = json.NewEncoder(w).Encode(map[string]any{"reset": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	if err := c.ChangePassword("oldpass123", "newpass123"); err != nil {
+		t.Fatalf("ChangePassword: %v", err)
+	}
+	if change.Password != "oldpass123" || change.NewPassword != "newpass123" {
+		t.Errorf("change request = %+v", change)
+	}
+
+	c = client.New(srv.URL)
+	if err := c.RequestPasswordReset("akram@example.com"); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	if reset.Email != "akram@example.com" {
+		t.Errorf("reset request = %+v", reset)
+	}
+	if err := c.ConfirmPasswordReset("code123", "newpass123"); err != nil {
+		t.Fatalf("ConfirmPasswordReset: %v", err)
+	}
+	if confirm.Token != "code123" || confirm.NewPassword != "newpass123" {
+		t.Errorf("confirm request = %+v", confirm)
+	}
+}
+
+func TestEmailVerification(t *testing.T) {
+	var verify models.VerifyEmailRequest
+	resent := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/verify-email":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("verifying an email should not send a token")
+			}
+			_ = json.NewDecoder(r.Body).Decode(&verify)
+			_ = json.NewEncoder(w).Encode(map[string]any{"verified": true})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/verify-email/resend":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Error("resending a code should send the access token")
+			}
+			resent = true
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(map[string]any{"sent": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	c.SetToken("test-token")
+
+	if err := c.ResendVerification(); err != nil {
+		t.Fatalf("ResendVerification: %v", err)
+	}
+	if !resent {
+		t.Error("expected a resend request")
+	}
+
+	c = client.New(srv.URL)
+	if err := c.VerifyEmail("code123"); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+	if verify.Token != "code123" {
+		t.Errorf("verify request = %+v", verify)
+	}
+}
+
+func TestTwoFactor(t *testing.T) {
+	var complete models.TwoFactorLoginRequest
+	var enable models.TwoFactorCodeRequest
+	var disable models.DisableTwoFactorRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/login":
+			_ = json.NewEncoder(w).Encode(models.AuthResponse{ChallengeToken: "challenge"})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/login/2fa":
+			if r.Header.Get("Authorization") != "" {
+				t.Error("completing a login should not send a token")
+			}
+			_ = json.NewDecoder(r.Body).Decode(&complete)
+			_ = json.NewEncoder(w).Encode(models.AuthResponse{
+				User:   &models.User{ID: "u1", Username: "akram"},
+				Tokens: &models.TokenPair{AccessToken: "access", RefreshToken: "refresh"},
+			})
+		case r.Method == "GET" && r.URL.Path == "/api/v1/auth/2fa":
+			if r.Header.Get("Authorization") != "Bearer access" {
+				t.Errorf("status sent %q, want the token from completing the login", r.Header.Get("Authorization"))
+			}
+			_ = json.NewEncoder(w).Encode(models.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: 3})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/2fa/setup":
+			_ = json.NewEncoder(w).Encode(models.TwoFactorSetup{Secret: "ABC", URI: "otpauth://totp/x"})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/2fa/enable":
+			_ = json.NewDecoder(r.Body).Decode(&enable)
+			_ = json.NewEncoder(w).Encode(map[string]any{"recovery_codes": []string{"aaaaa-bbbbb"}})
+		case r.Method == "POST" && r.URL.Path == "/api/v1/auth/2fa/disable":
+			_ = json.NewDecoder(r.Body).Decode(&disable)
+			_ = json.NewEncoder(w).Encode(map[string]any{"disabled": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := client.New(srv.URL)
+	resp, err := c.Login("akram@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if resp.ChallengeToken != "challenge" || resp.Tokens != nil {
+		t.Fatalf("Login = %+v, want only a challenge", resp)
+	}
+	if _, err := c.CompleteLogin(resp.ChallengeToken, "123456"); err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+	if complete.ChallengeToken != "challenge" || complete.Code != "123456" {
+		t.Errorf("complete request = %+v", complete)
+	}
+
+	status, err := c.TwoFactorStatus()
+	if err != nil || !status.Enabled || status.RecoveryCodesLeft != 3 {
+		t.Errorf("TwoFactorStatus = %+v, %v", status, err)
+	}
+	setup, err := c.SetupTwoFactor()
+	if err != nil || setup.Secret != "ABC" || setup.URI != "otpauth://totp/x" {
+		t.Errorf("SetupTwoFactor = %+v, %v", setup, err)
+	}
+	codes, err := c.EnableTwoFactor("654321")
+	if err != nil || len(codes) != 1 || enable.Code != "654321" {
+		t.Errorf("EnableTwoFactor = %v, %v, request %+v", codes, err, enable)
+	}
+	if err := c.DisableTwoFactor("password123", "aaaaa-bbbbb"); err != nil {
+		t.Fatalf("DisableTwoFactor: %v", err)
+	}
+	if disable.Password != "password123" || disable.Code != "aaaaa-bbbbb" {
+		t.Errorf("disable request = %+v", disable)
+	}
+}
@@ -1,6 +1,8 @@
 package components
 
 import (
+	"strconv"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -15,13 +17,21 @@ var (
 	viewNameStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("8")).
 			Faint(true)
+
+	unreadStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("3")).
+			Bold(true)
 )
 
 // RenderHeader renders the app header bar with left-aligned app name + username
-// and right-aligned view name, spanning the given width.
-func RenderHeader(appName, username, viewName string, width int) string {
+// and right-aligned view name, spanning the given width. A positive unread
+// count of notifications is shown before the view name.
+func RenderHeader(appName, username, viewName string, unread, width int) string {
 	left := appNameStyle.Render(appName) + "  " + headerUsernameStyle.Render("@"+username)
 	right := viewNameStyle.Render(viewName)
+	if unread > 0 {
+		right = unreadStyle.Render("● "+strconv.Itoa(unread)) + "  " + right
+	}
 
 	leftWidth := lipgloss.Width(left)
 	rightWidth := lipgloss.Width(right)
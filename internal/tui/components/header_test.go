@@ -8,29 +8,41 @@ import (
 )
 
 func TestRenderHeaderShowsAppName(t *testing.T) {
-	result := components.RenderHeader("niotebook", "akram", "Timeline", 80)
+	result := components.RenderHeader("niotebook", "akram", "Timeline", 0, 80)
 	if !strings.Contains(result, "niotebook") {
 		t.Error("expected app name in header")
 	}
 }
 
 func TestRenderHeaderShowsUsername(t *testing.T) {
-	result := components.RenderHeader("niotebook", "akram", "Timeline", 80)
+	result := components.RenderHeader("niotebook", "akram", "Timeline", 0, 80)
 	if !strings.Contains(result, "@akram") {
 		t.Error("expected @akram in header")
 	}
 }
 
 func TestRenderHeaderEmptyUsername(t *testing.T) {
-	result := components.RenderHeader("niotebook", "", "Timeline", 80)
+	result := components.RenderHeader("niotebook", "", "Timeline", 0, 80)
 	if !strings.Contains(result, "niotebook") {
 		t.Error("expected app name in header even with empty username")
 	}
 }
 
 func TestRenderHeaderNarrowWidth(t *testing.T) {
-	result := components.RenderHeader("niotebook", "akram", "Timeline", 10)
+	result := components.RenderHeader("niotebook", "akram", "Timeline", 0, 10)
 	if result == "" {
 		t.Error("expected non-empty output for narrow width")
 	}
 }
+
+func TestRenderHeaderUnreadCount(t *testing.T) {
+	result := components.RenderHeader("niotebook", "akram", "Timeline", 3, 80)
+	if !strings.Contains(result, "● 3") {
+		t.Errorf("expected unread count in header, got %q", result)
+	}
+
+	result = components.RenderHeader("niotebook", "akram", "Timeline", 0, 80)
+	if strings.Contains(result, "●") {
+		t.Errorf("expected no unread badge with nothing unread, got %q", result)
+	}
+}
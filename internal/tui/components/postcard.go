@@ -1,6 +1,7 @@
 package components
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -29,10 +30,30 @@ var (
 	markerStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("5")).
 			Bold(true)
+
+	likedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("1"))
+
+	repostedStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("2"))
+
+	quoteGutterStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("8"))
+
+	mentionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("4")).
+			Bold(true)
+
+	tagStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("3"))
 )
 
 // RenderPostCard renders a single post card. If selected is true, the post
 // is highlighted with an accent marker. width is the total terminal width.
+// A repost renders as its original under a "reposted" line; a quote renders
+// its original indented beneath the quoting post's content. Deleted posts
+// and posts flagged by one of the viewer's filters render as a one-line
+// placeholder; views reveal a flagged post by clearing its Filtered field.
 func RenderPostCard(post models.Post, width int, selected bool, now time.Time) string {
 	var b strings.Builder
 
@@ -42,29 +63,40 @@ func RenderPostCard(post models.Post, width int, selected bool, now time.Time) s
 		marker = markerStyle.Render("▸") + " "
 	}
 
-	// Username
-	username := "@"
-	if post.Author != nil {
-		username += post.Author.Username
-	} else {
-		username += "unknown"
+	if post.Filtered != "" {
+		b.WriteString(marker)
+		b.WriteString(dimStyle.Render("Filtered: \"" + post.Filtered + "\" · " + authorHandle(post) + " · v to show"))
+		b.WriteString("\n")
+		b.WriteString(separatorStyle.Render(strings.Repeat("─", max(width, 1))))
+		return b.String()
 	}
-	var styledUsername string
-	if selected {
-		styledUsername = selectedUsernameStyle.Render(username)
-	} else {
-		styledUsername = usernameStyle.Render(username)
+
+	shown := post
+	if post.Kind == models.PostKindRepost && post.Original != nil {
+		b.WriteString(marker)
+		b.WriteString(dimStyle.Render("⟲ " + authorHandle(post) + " reposted · " + RelativeTimeFrom(post.CreatedAt, now)))
+		b.WriteString("\n")
+		marker = "  "
+		shown = *post.Original
 	}
 
-	// Separator dot and relative time
-	sep := dimStyle.Render(" · ")
-	relTime := dimStyle.Render(RelativeTimeFrom(post.CreatedAt, now))
+	// Separator line
+	if width < 1 {
+		width = 1
+	}
+	separator := separatorStyle.Render(strings.Repeat("─", width))
+
+	if shown.Deleted {
+		b.WriteString(marker)
+		b.WriteString(dimStyle.Render("This post was deleted."))
+		b.WriteString("\n")
+		b.WriteString(separator)
+		return b.String()
+	}
 
 	// Header line
 	b.WriteString(marker)
-	b.WriteString(styledUsername)
-	b.WriteString(sep)
-	b.WriteString(relTime)
+	b.WriteString(renderPostHeader(shown, selected, now))
 	b.WriteString("\n")
 
 	// Content, word-wrapped with 2-char left padding
@@ -72,18 +104,141 @@ func RenderPostCard(post models.Post, width int, selected bool, now time.Time) s
 	if contentWidth < 10 {
 		contentWidth = 10
 	}
-	wrapped := ansi.Wordwrap(post.Content, contentWidth, "")
+	wrapped := ansi.Wordwrap(shown.Content, contentWidth, "")
 	for _, line := range strings.Split(wrapped, "\n") {
 		b.WriteString("  ")
-		b.WriteString(line)
+		b.WriteString(highlightReferences(line, shown))
 		b.WriteString("\n")
 	}
 
-	// Separator line
-	if width < 1 {
-		width = 1
+	if shown.Kind == models.PostKindQuote {
+		renderQuoted(&b, shown.Original, contentWidth-2, now)
+	}
+
+	b.WriteString(separator)
+
+	return b.String()
+}
+
+// renderPostHeader renders the author, age, and engagement line of a post.
+func renderPostHeader(post models.Post, selected bool, now time.Time) string {
+	var b strings.Builder
+
+	username := authorHandle(post)
+	if selected {
+		b.WriteString(selectedUsernameStyle.Render(username))
+	} else {
+		b.WriteString(usernameStyle.Render(username))
+	}
+
+	// Separator dot and relative time
+	sep := dimStyle.Render(" · ")
+	b.WriteString(sep)
+	b.WriteString(dimStyle.Render(RelativeTimeFrom(post.CreatedAt, now)))
+	if post.EditedAt != nil {
+		b.WriteString(sep)
+		b.WriteString(dimStyle.Render("edited"))
+	}
+	if post.ParentID != nil {
+		b.WriteString(sep)
+		b.WriteString(dimStyle.Render("↩ reply"))
+	}
+	if post.LikeCount > 0 || post.LikedByMe {
+		b.WriteString(sep)
+		likes := fmt.Sprintf("♥ %d", post.LikeCount)
+		if post.LikedByMe {
+			b.WriteString(likedStyle.Render(likes))
+		} else {
+			b.WriteString(dimStyle.Render(likes))
+		}
+	}
+	if post.RepostCount > 0 || post.RepostedByMe {
+		b.WriteString(sep)
+		reposts := fmt.Sprintf("⟲ %d", post.RepostCount)
+		if post.RepostedByMe {
+			b.WriteString(repostedStyle.Render(reposts))
+		} else {
+			b.WriteString(dimStyle.Render(reposts))
+		}
 	}
-	b.WriteString(separatorStyle.Render(strings.Repeat("─", width)))
 
 	return b.String()
 }
+
+// renderQuoted writes a quoted original behind a "│" gutter. A nil or deleted
+// original was removed after being quoted.
+func renderQuoted(b *strings.Builder, original *models.Post, width int, now time.Time) {
+	gutter := "  " + quoteGutterStyle.Render("│") + " "
+	if original == nil || original.Deleted {
+		b.WriteString(gutter)
+		b.WriteString(dimStyle.Render("original post unavailable"))
+		b.WriteString("\n")
+		return
+	}
+
+	b.WriteString(gutter)
+	b.WriteString(usernameStyle.Render(authorHandle(*original)))
+	b.WriteString(dimStyle.Render(" · " + RelativeTimeFrom(original.CreatedAt, now)))
+	b.WriteString("\n")
+
+	if width < 10 {
+		width = 10
+	}
+	wrapped := ansi.Wordwrap(original.Content, width, "")
+	for _, line := range strings.Split(wrapped, "\n") {
+		b.WriteString(gutter)
+		b.WriteString(highlightReferences(line, *original))
+		b.WriteString("\n")
+	}
+}
+
+func authorHandle(post models.Post) string {
+	if post.Author != nil {
+		return "@" + post.Author.Username
+	}
+	return "@unknown"
+}
+
+// highlightReferences styles each @username and #tag in line that refers to
+// one of post's resolved mentions or tags. Anything else is left as plain
+// text.
+func highlightReferences(line string, post models.Post) string {
+	if len(post.Mentions) == 0 && len(post.Tags) == 0 {
+		return line
+	}
+	known := make(map[string]bool, len(post.Mentions)+len(post.Tags))
+	for _, m := range post.Mentions {
+		known["@"+strings.ToLower(m.Username)] = true
+	}
+	for _, t := range post.Tags {
+		known["#"+t] = true
+	}
+
+	var b strings.Builder
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if (runes[i] != '@' && runes[i] != '#') || (i > 0 && isHandleRune(runes[i-1])) {
+			b.WriteRune(runes[i])
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && isHandleRune(runes[end]) {
+			end++
+		}
+		ref := string(runes[i:end])
+		switch {
+		case !known[strings.ToLower(ref)]:
+			b.WriteString(ref)
+		case runes[i] == '#':
+			b.WriteString(tagStyle.Render(ref))
+		default:
+			b.WriteString(mentionStyle.Render(ref))
+		}
+		i = end - 1
+	}
+	return b.String()
+}
+
+func isHandleRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
@@ -53,3 +53,111 @@ func TestRenderPostCardNarrowWidth(t *testing.T) {
 		t.Error("expected non-empty output for narrow width")
 	}
 }
+
+func TestRenderPostCardReplyMarker(t *testing.T) {
+	parent := "post-1"
+	reply := models.Post{Content: "Agreed", ParentID: &parent, Author: &models.User{Username: "sara"}}
+	if !strings.Contains(components.RenderPostCard(reply, 80, false, time.Now()), "reply") {
+		t.Error("expected reply marker on a reply")
+	}
+
+	post := models.Post{Content: "Top level", Author: &models.User{Username: "sara"}}
+	if strings.Contains(components.RenderPostCard(post, 80, false, time.Now()), "reply") {
+		t.Error("top-level post should not have a reply marker")
+	}
+}
+
+func TestRenderPostCardLikeCount(t *testing.T) {
+	post := models.Post{Content: "Popular", LikeCount: 3, Author: &models.User{Username: "sara"}}
+	if !strings.Contains(components.RenderPostCard(post, 80, false, time.Now()), "♥ 3") {
+		t.Error("expected like count on card")
+	}
+
+	post.LikeCount = 0
+	if strings.Contains(components.RenderPostCard(post, 80, false, time.Now()), "♥") {
+		t.Error("card without likes should not show a count")
+	}
+}
+
+func TestRenderPostCardRepost(t *testing.T) {
+	original := models.Post{Content: "Original words", RepostCount: 2, Author: &models.User{Username: "akram"}}
+	repost := models.Post{Kind: models.PostKindRepost, Original: &original, Author: &models.User{Username: "sara"}}
+	result := components.RenderPostCard(repost, 80, false, time.Now())
+	for _, want := range []string{"@sara reposted", "@akram", "Original words", "⟲ 2"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in repost card:\n%s", want, result)
+		}
+	}
+}
+
+func TestRenderPostCardQuote(t *testing.T) {
+	original := models.Post{Content: "Quoted words", Author: &models.User{Username: "akram"}}
+	quote := models.Post{Kind: models.PostKindQuote, Content: "My take", Original: &original, Author: &models.User{Username: "sara"}}
+	result := components.RenderPostCard(quote, 80, false, time.Now())
+	for _, want := range []string{"@sara", "My take", "│ Quoted words"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in quote card:\n%s", want, result)
+		}
+	}
+
+	quote.Original = nil
+	if !strings.Contains(components.RenderPostCard(quote, 80, false, time.Now()), "unavailable") {
+		t.Error("expected placeholder for a removed original")
+	}
+}
+
+func TestRenderPostCardEditedMarker(t *testing.T) {
+	edited := time.Now()
+	post := models.Post{Content: "Fixed typo", EditedAt: &edited, Author: &models.User{Username: "sara"}}
+	if !strings.Contains(components.RenderPostCard(post, 80, false, time.Now()), "edited") {
+		t.Error("expected edited marker on card")
+	}
+	post.EditedAt = nil
+	if strings.Contains(components.RenderPostCard(post, 80, false, time.Now()), "edited") {
+		t.Error("unexpected edited marker on unedited card")
+	}
+}
+
+func TestRenderPostCardDeleted(t *testing.T) {
+	post := models.Post{Deleted: true, Author: &models.User{Username: "sara"}}
+	result := components.RenderPostCard(post, 80, false, time.Now())
+	if !strings.Contains(result, "deleted") || strings.Contains(result, "@sara") {
+		t.Errorf("expected anonymous deleted placeholder, got:\n%s", result)
+	}
+}
+
+func TestRenderPostCardFiltered(t *testing.T) {
+	post := models.Post{Content: "the ending was a twist", Filtered: "ending", Author: &models.User{Username: "sara"}}
+	result := components.RenderPostCard(post, 80, false, time.Now())
+	if !strings.Contains(result, `Filtered: "ending"`) || strings.Contains(result, "twist") {
+		t.Errorf("expected collapsed filtered card, got:\n%s", result)
+	}
+}
+
+func TestRenderPostCardMentions(t *testing.T) {
+	post := models.Post{
+		Content:  "hi @Sara, mail akram@example.com or ping @nobody",
+		Mentions: []models.Mention{{UserID: "u2", Username: "sara"}},
+		Author:   &models.User{Username: "akram"},
+	}
+	result := components.RenderPostCard(post, 80, false, time.Now())
+	for _, want := range []string{"@Sara", "akram@example.com", "@nobody"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in card:\n%s", want, result)
+		}
+	}
+}
+
+func TestRenderPostCardTags(t *testing.T) {
+	post := models.Post{
+		Content: "shipping #TUI work, see issue#4 and #unknown",
+		Tags:    []string{"tui"},
+		Author:  &models.User{Username: "akram"},
+	}
+	result := components.RenderPostCard(post, 80, false, time.Now())
+	for _, want := range []string{"#TUI", "issue#4", "#unknown"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in card:\n%s", want, result)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	"rsc.io/qr"
+)
+
+// qrQuietZone is the light border around a QR code, in modules. The spec
+// asks for four, but two is enough for phone cameras and keeps the code
+// small in a terminal.
+const qrQuietZone = 2
+
+// RenderQRCode renders text as a QR code for scanning off the terminal.
+// Each line packs two rows of modules into half blocks. Light modules are
+// drawn and dark ones left blank, so the code reads correctly on the dark
+// background most terminals have.
+func RenderQRCode(text string) (string, error) {
+	code, err := qr.Encode(text, qr.M)
+	if err != nil {
+		return "", fmt.Errorf("encode qr code: %w", err)
+	}
+
+	light := func(x, y int) bool {
+		if x < 0 || y < 0 || x >= code.Size || y >= code.Size {
+			return true
+		}
+		return !code.Black(x, y)
+	}
+
+	var b strings.Builder
+	for y := -qrQuietZone; y < code.Size+qrQuietZone; y += 2 {
+		for x := -qrQuietZone; x < code.Size+qrQuietZone; x++ {
+			top, bottom := light(x, y), light(x, y+1)
+			switch {
+			case top && bottom:
+				b.WriteRune('█')
+			case top:
+				b.WriteRune('▀')
+			case bottom:
+				b.WriteRune('▄')
+			default:
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
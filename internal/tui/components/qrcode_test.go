@@ -0,0 +1,32 @@
+package components_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+func TestRenderQRCode(t *testing.T) {
+	result, err := components.RenderQRCode("otpauth://totp/niotebook:akram?secret=ABC")
+	if err != nil {
+		t.Fatalf("RenderQRCode: %v", err)
+	}
+	lines := strings.Split(result, "\n")
+	width := utf8.RuneCountInString(lines[0])
+	for i, line := range lines {
+		if n := utf8.RuneCountInString(line); n != width {
+			t.Fatalf("line %d is %d wide, want %d", i, n, width)
+		}
+	}
+	// Two rows of modules per line, so the code is about half as tall as
+	// it is wide
+	if len(lines) != (width+1)/2 {
+		t.Errorf("got %d lines for a width of %d, want %d", len(lines), width, (width+1)/2)
+	}
+	// The quiet zone is light all the way round
+	if strings.Trim(lines[0], "█") != "" {
+		t.Errorf("first line %q should be all quiet zone", lines[0])
+	}
+}
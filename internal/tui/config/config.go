@@ -2,9 +2,11 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -49,6 +51,30 @@ func EnsureConfigDir() error {
 	return os.MkdirAll(ConfigDir(), 0755)
 }
 
+// DataDir is where the TUI keeps user data such as exports, following the
+// XDG base directory spec.
+func DataDir() string {
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, "niotebook")
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".local", "share", "niotebook")
+}
+
+// SaveExport writes a data export archive under the exports directory of
+// DataDir, named after when it was taken, and returns its path.
+func SaveExport(archive []byte, takenAt time.Time) (string, error) {
+	dir := filepath.Join(DataDir(), "exports")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, "niotebook-export-"+takenAt.Format("20060102-150405")+".json")
+	if err := os.WriteFile(path, archive, 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
 func SaveAuth(path string, auth *StoredAuth) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return err
@@ -60,6 +86,14 @@ func SaveAuth(path string, auth *StoredAuth) error {
 	return os.WriteFile(path, data, 0600)
 }
 
+// ClearAuth removes the stored auth file. A missing file is not an error.
+func ClearAuth(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
 func LoadAuth(path string) (*StoredAuth, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Akram012388/niotebook-tui/internal/tui/config"
 )
@@ -90,3 +91,41 @@ func TestConfigDirDefaultWithoutXDG(t *testing.T) {
 		t.Errorf("ConfigDir() = %q, want suffix /.config/niotebook", dir)
 	}
 }
+
+func TestSaveExportUsesXDGDataHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+
+	path, err := config.SaveExport([]byte(`{"posts":[]}`), time.Date(2026, 10, 16, 21, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("SaveExport: %v", err)
+	}
+	want := filepath.Join(dir, "niotebook", "exports", "niotebook-export-20261016-213000.json")
+	if path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != `{"posts":[]}` {
+		t.Errorf("saved archive = %q", data)
+	}
+}
+
+func TestClearAuth(t *testing.T) {
+	authPath := filepath.Join(t.TempDir(), "auth.json")
+	if err := config.SaveAuth(authPath, &config.StoredAuth{AccessToken: "access-123"}); err != nil {
+		t.Fatalf("SaveAuth: %v", err)
+	}
+
+	if err := config.ClearAuth(authPath); err != nil {
+		t.Fatalf("ClearAuth: %v", err)
+	}
+	if _, err := config.LoadAuth(authPath); err == nil {
+		t.Error("expected auth file removed")
+	}
+	if err := config.ClearAuth(authPath); err != nil {
+		t.Errorf("ClearAuth on missing file: %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package views
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+)
+
+// BlockedModel lists the accounts the user has blocked or muted, one list
+// at a time, and lets them lift a block or mute.
+type BlockedModel struct {
+	blocked   []models.RelationEntry
+	muted     []models.RelationEntry
+	showMuted bool
+	cursor    int
+	scrollTop int
+	loading   bool
+	dismissed bool
+	client    *client.Client
+	width     int
+	height    int
+}
+
+// NewBlockedModel creates a blocked and muted accounts view.
+func NewBlockedModel(c *client.Client) BlockedModel {
+	return BlockedModel{
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch both lists.
+func (m BlockedModel) Init() tea.Cmd {
+	return m.fetchRelations()
+}
+
+func (m BlockedModel) fetchRelations() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		blocked, err := c.GetBlocked("", 100)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		muted, err := c.GetMuted("", 100)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgRelationsLoaded{Blocked: blocked.Users, Muted: muted.Users}
+	}
+}
+
+// Dismissed returns whether the user left the view.
+func (m BlockedModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// entries returns the list currently shown.
+func (m BlockedModel) entries() []models.RelationEntry {
+	if m.showMuted {
+		return m.muted
+	}
+	return m.blocked
+}
+
+// Update handles messages for the blocked view.
+func (m BlockedModel) Update(msg tea.Msg) (BlockedModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgRelationsLoaded:
+		m.loading = false
+		m.blocked = msg.Blocked
+		m.muted = msg.Muted
+		m.cursor, m.scrollTop = 0, 0
+		return m, nil
+
+	case app.MsgBlockToggled:
+		if !msg.Blocking {
+			m.blocked = removeRelation(m.blocked, msg.UserID)
+			m.clampCursor()
+		}
+		return m, nil
+
+	case app.MsgMuteToggled:
+		if !msg.Muting {
+			m.muted = removeRelation(m.muted, msg.UserID)
+			m.clampCursor()
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m BlockedModel) handleKey(msg tea.KeyMsg) (BlockedModel, tea.Cmd) {
+	entries := m.entries()
+
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyTab:
+		m.showMuted = !m.showMuted
+		m.cursor, m.scrollTop = 0, 0
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+		m.loading = true
+		return m, m.fetchRelations()
+
+	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+		if m.cursor < len(entries)-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+		return m, nil
+	}
+
+	if m.cursor >= len(entries) {
+		return m, nil
+	}
+	user := entries[m.cursor].User
+
+	switch {
+	case msg.Type == tea.KeyEnter:
+		userID := user.ID
+		return m, func() tea.Msg { return app.MsgOpenProfile{UserID: userID} }
+
+	// x: lift the selected block or mute
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'x':
+		if m.showMuted {
+			return m, toggleMute(m.client, user, false)
+		}
+		return m, toggleBlock(m.client, user, false)
+	}
+
+	return m, nil
+}
+
+func removeRelation(entries []models.RelationEntry, userID string) []models.RelationEntry {
+	kept := entries[:0:0]
+	for _, e := range entries {
+		if e.User.ID != userID {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+func (m *BlockedModel) clampCursor() {
+	if n := len(m.entries()); m.cursor >= n && n > 0 {
+		m.cursor = n - 1
+	}
+	m.ensureCursorVisible()
+}
+
+func (m *BlockedModel) ensureCursorVisible() {
+	visibleCount := m.visibleCount()
+	if m.cursor < m.scrollTop {
+		m.scrollTop = m.cursor
+	}
+	if m.cursor >= m.scrollTop+visibleCount {
+		m.scrollTop = m.cursor - visibleCount + 1
+	}
+}
+
+func (m BlockedModel) visibleCount() int {
+	if m.height <= 0 {
+		return 5
+	}
+	// The tabs take 2 lines and each account 2 lines
+	count := (m.height - 2) / 2
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// View renders the blocked view.
+func (m BlockedModel) View() string {
+	if m.loading && len(m.blocked) == 0 && len(m.muted) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading accounts..."))
+	}
+
+	var b strings.Builder
+	b.WriteString(m.renderTabs())
+	b.WriteString("\n\n")
+
+	entries := m.entries()
+	if len(entries) == 0 {
+		empty := "You haven't blocked anyone."
+		if m.showMuted {
+			empty = "You haven't muted anyone."
+		}
+		b.WriteString(emptyStateStyle.Render("  " + empty))
+		return b.String()
+	}
+
+	end := m.scrollTop + m.visibleCount()
+	if end > len(entries) {
+		end = len(entries)
+	}
+	for i := m.scrollTop; i < end; i++ {
+		b.WriteString(renderUserResult(entries[i].User, i == m.cursor))
+	}
+	return b.String()
+}
+
+// renderTabs renders the Blocked/Muted switcher with the active list
+// highlighted.
+func (m BlockedModel) renderTabs() string {
+	blocked, muted := feedActiveStyle.Render("Blocked"), feedInactiveStyle.Render("Muted")
+	if m.showMuted {
+		blocked, muted = feedInactiveStyle.Render("Blocked"), feedActiveStyle.Render("Muted")
+	}
+	return "  " + blocked + "  " + muted
+}
+
+// HelpText returns the status bar help text for the blocked view.
+func (m BlockedModel) HelpText() string {
+	if m.showMuted {
+		return "j/k: navigate  Tab: blocked/muted  x: unmute  Enter: profile  r: refresh  Esc: back  ?: help"
+	}
+	return "j/k: navigate  Tab: blocked/muted  x: unblock  Enter: profile  r: refresh  Esc: back  ?: help"
+}
@@ -0,0 +1,51 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestBlockedViewListsAndLifts(t *testing.T) {
+	m := views.NewBlockedModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgRelationsLoaded{
+		Blocked: []models.RelationEntry{{User: models.User{ID: "u2", Username: "bob"}}},
+		Muted:   []models.RelationEntry{{User: models.User{ID: "u3", Username: "carol"}}},
+	})
+
+	if view := m.View(); !strings.Contains(view, "@bob") || strings.Contains(view, "@carol") {
+		t.Errorf("blocked tab should list only bob:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(app.MsgOpenProfile); !ok || msg.UserID != "u2" {
+		t.Errorf("Enter = %#v, want MsgOpenProfile for u2", msg)
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd == nil {
+		t.Error("x should unblock the selected account")
+	}
+
+	m, _ = m.Update(app.MsgBlockToggled{UserID: "u2", Username: "bob", Blocking: false})
+	if !strings.Contains(m.View(), "You haven't blocked anyone.") {
+		t.Error("unblocked account should leave the list")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !strings.Contains(m.View(), "@carol") {
+		t.Error("Tab should switch to the muted list")
+	}
+	if !strings.Contains(m.HelpText(), "unmute") {
+		t.Error("help text should offer unmute on the muted list")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("Esc should dismiss the view")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/tui/app"
 	"github.com/Akram012388/niotebook-tui/internal/tui/client"
 )
@@ -44,6 +45,8 @@ var (
 type ComposeModel struct {
 	textarea  textarea.Model
 	client    *client.Client
+	replyTo   *models.Post
+	quoting   *models.Post
 	submitted bool
 	cancelled bool
 	posting   bool
@@ -67,6 +70,32 @@ func NewComposeModel(c *client.Client) ComposeModel {
 	}
 }
 
+// NewReplyComposeModel creates a compose modal that publishes a reply to parent.
+func NewReplyComposeModel(c *client.Client, parent models.Post) ComposeModel {
+	m := NewComposeModel(c)
+	m.replyTo = &parent
+	m.textarea.Placeholder = "Write your reply"
+	return m
+}
+
+// NewQuoteComposeModel creates a compose modal that publishes a quote of original.
+func NewQuoteComposeModel(c *client.Client, original models.Post) ComposeModel {
+	m := NewComposeModel(c)
+	m.quoting = &original
+	m.textarea.Placeholder = "Add your comment"
+	return m
+}
+
+// Quoting returns the post being quoted, or nil.
+func (m ComposeModel) Quoting() *models.Post {
+	return m.quoting
+}
+
+// ReplyTo returns the post being replied to, or nil for a new post.
+func (m ComposeModel) ReplyTo() *models.Post {
+	return m.replyTo
+}
+
 // Submitted returns whether the post was published.
 func (m ComposeModel) Submitted() bool {
 	return m.submitted
@@ -146,11 +175,22 @@ func (m ComposeModel) handleKey(msg tea.KeyMsg) (ComposeModel, tea.Cmd) {
 
 func (m ComposeModel) publish(content string) tea.Cmd {
 	c := m.client
+	replyTo := m.replyTo
+	quoting := m.quoting
 	return func() tea.Msg {
 		if c == nil {
 			return app.MsgAPIError{Message: "no server connection"}
 		}
-		post, err := c.CreatePost(content)
+		var post *models.Post
+		var err error
+		switch {
+		case replyTo != nil:
+			post, err = c.CreateReply(replyTo.ID, content)
+		case quoting != nil:
+			post, err = c.QuotePost(quoting.ID, content)
+		default:
+			post, err = c.CreatePost(content)
+		}
 		if err != nil {
 			return app.MsgAPIError{Message: err.Error()}
 		}
@@ -179,10 +219,19 @@ func (m *ComposeModel) updateTextareaSize() {
 func (m ComposeModel) View() string {
 	var b strings.Builder
 
-	b.WriteString(composeTitleStyle.Render("New Post"))
-	b.WriteString("\n\n")
-
-	b.WriteString(composePromptStyle.Render("What's on your mind?"))
+	if m.replyTo != nil {
+		b.WriteString(composeTitleStyle.Render("Reply"))
+		b.WriteString("\n\n")
+		b.WriteString(composePromptStyle.Render("Replying to " + replyTargetName(m.replyTo)))
+	} else if m.quoting != nil {
+		b.WriteString(composeTitleStyle.Render("Quote"))
+		b.WriteString("\n\n")
+		b.WriteString(composePromptStyle.Render("Quoting " + replyTargetName(m.quoting) + ": " + quoteExcerpt(m.quoting.Content)))
+	} else {
+		b.WriteString(composeTitleStyle.Render("New Post"))
+		b.WriteString("\n\n")
+		b.WriteString(composePromptStyle.Render("What's on your mind?"))
+	}
 	b.WriteString("\n")
 
 	b.WriteString(m.textarea.View())
@@ -217,6 +266,24 @@ func (m ComposeModel) View() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, modalContent)
 }
 
+// replyTargetName returns "@username" for the post being replied to.
+func replyTargetName(p *models.Post) string {
+	if p.Author != nil {
+		return "@" + p.Author.Username
+	}
+	return "post"
+}
+
+// quoteExcerpt shortens quoted content to fit on the compose prompt line.
+func quoteExcerpt(content string) string {
+	const maxExcerpt = 40
+	runes := []rune(strings.Join(strings.Fields(content), " "))
+	if len(runes) <= maxExcerpt {
+		return string(runes)
+	}
+	return string(runes[:maxExcerpt-1]) + "…"
+}
+
 // HelpText returns the status bar help text for the compose modal.
 func (m ComposeModel) HelpText() string {
 	return "Ctrl+Enter: publish  Esc: cancel"
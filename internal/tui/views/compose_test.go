@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/tui/app"
 	"github.com/Akram012388/niotebook-tui/internal/tui/views"
 )
@@ -145,3 +146,29 @@ func TestComposeInitReturnsBlink(t *testing.T) {
 		t.Error("Init should return blink command")
 	}
 }
+
+func TestReplyComposeShowsTarget(t *testing.T) {
+	parent := models.Post{ID: "p1", Author: &models.User{Username: "sara"}}
+	m := views.NewReplyComposeModel(nil, parent)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	if m.ReplyTo() == nil || m.ReplyTo().ID != "p1" {
+		t.Errorf("ReplyTo = %+v, want p1", m.ReplyTo())
+	}
+	if !strings.Contains(m.View(), "Replying to @sara") {
+		t.Error("expected reply target in compose view")
+	}
+}
+
+func TestQuoteComposeShowsTarget(t *testing.T) {
+	original := models.Post{ID: "p1", Content: "Worth sharing", Author: &models.User{Username: "sara"}}
+	m := views.NewQuoteComposeModel(nil, original)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	if m.Quoting() == nil || m.Quoting().ID != "p1" {
+		t.Errorf("Quoting = %+v, want p1", m.Quoting())
+	}
+	if !strings.Contains(m.View(), "Quoting @sara") {
+		t.Error("expected quoted author in compose view")
+	}
+}
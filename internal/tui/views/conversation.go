@@ -0,0 +1,312 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+const maxMessageLength = 500
+
+var (
+	messageSenderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("6")).
+				Bold(true)
+
+	messageOwnSenderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("5")).
+				Bold(true)
+)
+
+// ConversationModel shows the messages in a conversation above a textarea
+// for writing the next one, like ComposeModel. The textarea always has
+// focus, so the message list is scrolled with PgUp/PgDn.
+type ConversationModel struct {
+	conversation models.Conversation
+	userID       string
+	// messages are newest first, as returned by the server.
+	messages   []models.Message
+	textarea   textarea.Model
+	offset     int
+	nextCursor string
+	hasMore    bool
+	loading    bool
+	dismissed  bool
+	client     *client.Client
+	width      int
+	height     int
+}
+
+// NewConversationModel creates a view of conv for userID.
+func NewConversationModel(c *client.Client, conv models.Conversation, userID string) ConversationModel {
+	ta := textarea.New()
+	ta.Placeholder = "Write a message"
+	ta.SetWidth(40)
+	ta.SetHeight(3)
+	ta.ShowLineNumbers = false
+	ta.Focus()
+	ta.CharLimit = 0 // Checked on send against maxMessageLength
+
+	return ConversationModel{
+		conversation: conv,
+		userID:       userID,
+		textarea:     ta,
+		client:       c,
+		loading:      true,
+	}
+}
+
+// Init fetches the latest messages.
+func (m ConversationModel) Init() tea.Cmd {
+	return tea.Batch(textarea.Blink, m.fetchMessages(""))
+}
+
+func (m ConversationModel) fetchMessages(cursor string) tea.Cmd {
+	c := m.client
+	id := m.conversation.ID
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		resp, err := c.GetMessages(id, cursor, 50)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		nextCursor := ""
+		if resp.NextCursor != nil {
+			nextCursor = *resp.NextCursor
+		}
+		return app.MsgMessagesLoaded{
+			ConversationID: id,
+			Messages:       resp.Messages,
+			NextCursor:     nextCursor,
+			HasMore:        resp.HasMore,
+			Append:         cursor != "",
+		}
+	}
+}
+
+func (m ConversationModel) markRead() tea.Cmd {
+	c := m.client
+	id := m.conversation.ID
+	return func() tea.Msg {
+		if c == nil {
+			return nil
+		}
+		if err := c.MarkConversationRead(id); err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return nil
+	}
+}
+
+func (m ConversationModel) send(content string) tea.Cmd {
+	c := m.client
+	id := m.conversation.ID
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		msg, err := c.SendMessage(id, content)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgMessageSent{Message: *msg}
+	}
+}
+
+// Dismissed returns whether the user left the conversation.
+func (m ConversationModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// IsTextInputFocused returns true since the textarea always has focus.
+func (m ConversationModel) IsTextInputFocused() bool {
+	return true
+}
+
+// Update handles messages for the conversation view.
+func (m ConversationModel) Update(msg tea.Msg) (ConversationModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		width := msg.Width - 4
+		if width < 20 {
+			width = 20
+		}
+		m.textarea.SetWidth(width)
+		return m, nil
+
+	case app.MsgMessagesLoaded:
+		if msg.ConversationID != m.conversation.ID {
+			return m, nil
+		}
+		m.loading = false
+		m.nextCursor = msg.NextCursor
+		m.hasMore = msg.HasMore
+		if msg.Append {
+			m.messages = append(m.messages, msg.Messages...)
+			return m, nil
+		}
+		m.messages = msg.Messages
+		m.offset = 0
+		return m, m.markRead()
+
+	case app.MsgMessageSent:
+		if msg.Message.ConversationID != m.conversation.ID {
+			return m, nil
+		}
+		m.messages = append([]models.Message{msg.Message}, m.messages...)
+		m.offset = 0
+		m.textarea.Reset()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+func (m ConversationModel) handleKey(msg tea.KeyMsg) (ConversationModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case tea.KeyCtrlJ: // Ctrl+Enter, as in ComposeModel
+		content := strings.TrimSpace(m.textarea.Value())
+		charCount := len([]rune(content))
+		if charCount == 0 || charCount > maxMessageLength {
+			return m, nil
+		}
+		return m, m.send(content)
+
+	case tea.KeyCtrlR:
+		m.loading = true
+		return m, m.fetchMessages("")
+
+	case tea.KeyPgUp:
+		page := m.pageSize()
+		if m.offset+page < len(m.messages) {
+			m.offset += page
+			return m, nil
+		}
+		if m.hasMore && !m.loading {
+			m.loading = true
+			return m, m.fetchMessages(m.nextCursor)
+		}
+		return m, nil
+
+	case tea.KeyPgDown:
+		m.offset -= m.pageSize()
+		if m.offset < 0 {
+			m.offset = 0
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+// pageSize is how many messages PgUp and PgDn scroll by.
+func (m ConversationModel) pageSize() int {
+	page := m.messageAreaHeight() / 3
+	if page < 1 {
+		page = 1
+	}
+	return page
+}
+
+// messageAreaHeight is the height left for messages after the title line,
+// the textarea and the counter below it.
+func (m ConversationModel) messageAreaHeight() int {
+	h := m.height - m.textarea.Height() - 4
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// View renders the conversation.
+func (m ConversationModel) View() string {
+	var b strings.Builder
+	b.WriteString("  " + inboxNamesStyle.Render(conversationTitle(m.conversation, m.userID)))
+	b.WriteString("\n")
+
+	areaHeight := m.messageAreaHeight()
+	var area string
+	switch {
+	case m.loading && len(m.messages) == 0:
+		area = lipgloss.Place(m.width, areaHeight, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading messages..."))
+	case len(m.messages) == 0:
+		area = lipgloss.Place(m.width, areaHeight, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("No messages yet. Say hello!"))
+	default:
+		area = m.renderMessages(areaHeight)
+	}
+	b.WriteString(lipgloss.NewStyle().Height(areaHeight).Render(area))
+	b.WriteString("\n")
+
+	b.WriteString(m.textarea.View())
+	b.WriteString("\n")
+	charCount := len([]rune(m.textarea.Value()))
+	counterText := fmt.Sprintf("%d/%d", charCount, maxMessageLength)
+	if charCount > maxMessageLength-20 {
+		b.WriteString(counterWarningStyle.Render(counterText))
+	} else {
+		b.WriteString(counterNormalStyle.Render(counterText))
+	}
+	return b.String()
+}
+
+// renderMessages fills height lines with messages, newest at the bottom,
+// skipping the newest offset messages.
+func (m ConversationModel) renderMessages(height int) string {
+	now := time.Now()
+	width := m.width - 4
+	if width < 20 {
+		width = 20
+	}
+	textStyle := lipgloss.NewStyle().Width(width)
+
+	var lines []string
+	for i := m.offset; i < len(m.messages) && len(lines) < height; i++ {
+		msg := m.messages[i]
+		senderStyle := messageSenderStyle
+		if msg.Sender.ID == m.userID {
+			senderStyle = messageOwnSenderStyle
+		}
+		block := []string{"  " + senderStyle.Render("@"+msg.Sender.Username) + " " +
+			emptyStateStyle.Render("· "+components.RelativeTimeFrom(msg.CreatedAt, now))}
+		for _, line := range strings.Split(textStyle.Render(msg.Content), "\n") {
+			block = append(block, "  "+line)
+		}
+		block = append(block, "")
+		lines = append(block, lines...)
+	}
+	if len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// HelpText returns the status bar help text for the conversation view.
+func (m ConversationModel) HelpText() string {
+	return "Ctrl+Enter: send  PgUp/PgDn: scroll  Ctrl+R: refresh  Esc: back"
+}
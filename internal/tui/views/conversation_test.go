@@ -0,0 +1,77 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestConversationShowsMessagesOldestFirst(t *testing.T) {
+	me := models.User{ID: "u1", Username: "akram"}
+	sara := models.User{ID: "u2", Username: "sara"}
+	conv := models.Conversation{ID: "c1", Members: []models.User{me, sara}}
+	now := time.Now()
+
+	m := views.NewConversationModel(nil, conv, me.ID)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, cmd := m.Update(app.MsgMessagesLoaded{
+		ConversationID: "c1",
+		Messages: []models.Message{
+			{ID: "m2", ConversationID: "c1", Sender: me, Content: "second", CreatedAt: now},
+			{ID: "m1", ConversationID: "c1", Sender: sara, Content: "first", CreatedAt: now.Add(-time.Minute)},
+		},
+	})
+	if cmd == nil {
+		t.Error("loading messages should mark the conversation read")
+	}
+
+	view := m.View()
+	first, second := strings.Index(view, "first"), strings.Index(view, "second")
+	if first < 0 || second < 0 || first > second {
+		t.Errorf("expected the older message above the newer one:\n%s", view)
+	}
+
+	// Messages for another conversation are ignored
+	m, _ = m.Update(app.MsgMessageSent{Message: models.Message{ConversationID: "c9", Content: "elsewhere"}})
+	m, _ = m.Update(app.MsgMessageSent{Message: models.Message{ConversationID: "c1", Sender: me, Content: "third", CreatedAt: now}})
+	view = m.View()
+	if strings.Contains(view, "elsewhere") || !strings.Contains(view, "third") {
+		t.Errorf("unexpected messages in view:\n%s", view)
+	}
+}
+
+func TestConversationSend(t *testing.T) {
+	conv := models.Conversation{ID: "c1"}
+	m := views.NewConversationModel(nil, conv, "u1")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	// An empty message is not sent
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlJ}); cmd != nil {
+		t.Error("expected no command for an empty message")
+	}
+
+	for _, r := range "hi" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if !m.IsTextInputFocused() {
+		t.Error("the textarea should always have focus")
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlJ})
+	if cmd == nil {
+		t.Fatal("expected a command to send the message")
+	}
+	if msg, ok := cmd().(app.MsgAPIError); !ok || msg.Message != "no server connection" {
+		t.Errorf("got %+v, want the nil-client error", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("expected Esc to dismiss the conversation")
+	}
+}
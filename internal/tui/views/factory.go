@@ -3,6 +3,7 @@ package views
 import (
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/tui/app"
 	"github.com/Akram012388/niotebook-tui/internal/tui/client"
 )
@@ -40,6 +41,66 @@ func (f *Factory) NewCompose(c *client.Client) app.ComposeViewModel {
 	return &composeAdapter{m}
 }
 
+func (f *Factory) NewReplyCompose(c *client.Client, parent models.Post) app.ComposeViewModel {
+	m := NewReplyComposeModel(c, parent)
+	return &composeAdapter{m}
+}
+
+func (f *Factory) NewQuoteCompose(c *client.Client, original models.Post) app.ComposeViewModel {
+	m := NewQuoteComposeModel(c, original)
+	return &composeAdapter{m}
+}
+
+func (f *Factory) NewThread(c *client.Client, postID string) app.ThreadViewModel {
+	m := NewThreadModel(c, postID)
+	return &threadAdapter{m}
+}
+
+func (f *Factory) NewTag(c *client.Client, tag string) app.TagViewModel {
+	m := NewTagModel(c, tag)
+	return &tagAdapter{m}
+}
+
+func (f *Factory) NewTrending(c *client.Client) app.TrendingViewModel {
+	m := NewTrendingModel(c)
+	return &trendingAdapter{m}
+}
+
+func (f *Factory) NewSearch(c *client.Client) app.SearchViewModel {
+	m := NewSearchModel(c)
+	return &searchAdapter{m}
+}
+
+func (f *Factory) NewNotifications(c *client.Client) app.NotificationsViewModel {
+	m := NewNotificationsModel(c)
+	return &notificationsAdapter{m}
+}
+
+func (f *Factory) NewInbox(c *client.Client, userID string) app.InboxViewModel {
+	m := NewInboxModel(c, userID)
+	return &inboxAdapter{m}
+}
+
+func (f *Factory) NewConversation(c *client.Client, conversation models.Conversation, userID string) app.ConversationViewModel {
+	m := NewConversationModel(c, conversation, userID)
+	return &conversationAdapter{m}
+}
+
+func (f *Factory) NewBlocked(c *client.Client) app.BlockedViewModel {
+	m := NewBlockedModel(c)
+	return &blockedAdapter{m}
+}
+
+func (f *Factory) NewSessions(c *client.Client) app.SessionsViewModel {
+	m := NewSessionsModel(c)
+	return &sessionsAdapter{m}
+}
+
+func (f *Factory) NewTwoFactor(c *client.Client) app.TwoFactorViewModel {
+	m := NewTwoFactorModel(c)
+	return &twoFactorAdapter{m}
+}
+
 func (f *Factory) NewHelp(viewName string) app.HelpViewModel {
 	m := NewHelpModel(viewName)
 	return &helpAdapter{m}
@@ -104,6 +165,117 @@ func (a *profileAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
 	return a, cmd
 }
 
+// threadAdapter wraps ThreadModel to implement app.ThreadViewModel.
+type threadAdapter struct {
+	model ThreadModel
+}
+
+func (a *threadAdapter) Init() tea.Cmd              { return a.model.Init() }
+func (a *threadAdapter) View() string               { return a.model.View() }
+func (a *threadAdapter) HelpText() string           { return a.model.HelpText() }
+func (a *threadAdapter) Dismissed() bool            { return a.model.Dismissed() }
+func (a *threadAdapter) SelectedPost() *models.Post { return a.model.SelectedPost() }
+func (a *threadAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// tagAdapter wraps TagModel to implement app.TagViewModel.
+type tagAdapter struct {
+	model TagModel
+}
+
+func (a *tagAdapter) Init() tea.Cmd    { return a.model.Init() }
+func (a *tagAdapter) View() string     { return a.model.View() }
+func (a *tagAdapter) HelpText() string { return a.model.HelpText() }
+func (a *tagAdapter) Dismissed() bool  { return a.model.Dismissed() }
+func (a *tagAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// trendingAdapter wraps TrendingModel to implement app.TrendingViewModel.
+type trendingAdapter struct {
+	model TrendingModel
+}
+
+func (a *trendingAdapter) Init() tea.Cmd     { return a.model.Init() }
+func (a *trendingAdapter) View() string      { return a.model.View() }
+func (a *trendingAdapter) HelpText() string  { return a.model.HelpText() }
+func (a *trendingAdapter) Dismissed() bool   { return a.model.Dismissed() }
+func (a *trendingAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// searchAdapter wraps SearchModel to implement app.SearchViewModel.
+type searchAdapter struct {
+	model SearchModel
+}
+
+func (a *searchAdapter) Init() tea.Cmd            { return a.model.Init() }
+func (a *searchAdapter) View() string             { return a.model.View() }
+func (a *searchAdapter) HelpText() string         { return a.model.HelpText() }
+func (a *searchAdapter) Dismissed() bool          { return a.model.Dismissed() }
+func (a *searchAdapter) IsTextInputFocused() bool { return a.model.IsTextInputFocused() }
+func (a *searchAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// notificationsAdapter wraps NotificationsModel to implement
+// app.NotificationsViewModel.
+type notificationsAdapter struct {
+	model NotificationsModel
+}
+
+func (a *notificationsAdapter) Init() tea.Cmd    { return a.model.Init() }
+func (a *notificationsAdapter) View() string     { return a.model.View() }
+func (a *notificationsAdapter) HelpText() string { return a.model.HelpText() }
+func (a *notificationsAdapter) Dismissed() bool  { return a.model.Dismissed() }
+func (a *notificationsAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// inboxAdapter wraps InboxModel to implement app.InboxViewModel.
+type inboxAdapter struct {
+	model InboxModel
+}
+
+func (a *inboxAdapter) Init() tea.Cmd            { return a.model.Init() }
+func (a *inboxAdapter) View() string             { return a.model.View() }
+func (a *inboxAdapter) HelpText() string         { return a.model.HelpText() }
+func (a *inboxAdapter) Dismissed() bool          { return a.model.Dismissed() }
+func (a *inboxAdapter) IsTextInputFocused() bool { return a.model.IsTextInputFocused() }
+func (a *inboxAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// conversationAdapter wraps ConversationModel to implement
+// app.ConversationViewModel.
+type conversationAdapter struct {
+	model ConversationModel
+}
+
+func (a *conversationAdapter) Init() tea.Cmd            { return a.model.Init() }
+func (a *conversationAdapter) View() string             { return a.model.View() }
+func (a *conversationAdapter) HelpText() string         { return a.model.HelpText() }
+func (a *conversationAdapter) Dismissed() bool          { return a.model.Dismissed() }
+func (a *conversationAdapter) IsTextInputFocused() bool { return a.model.IsTextInputFocused() }
+func (a *conversationAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
 // composeAdapter wraps ComposeModel to implement app.ComposeViewModel.
 type composeAdapter struct {
 	model ComposeModel
@@ -121,6 +293,52 @@ func (a *composeAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
 	return a, cmd
 }
 
+// blockedAdapter wraps BlockedModel to implement app.BlockedViewModel.
+type blockedAdapter struct {
+	model BlockedModel
+}
+
+func (a *blockedAdapter) Init() tea.Cmd    { return a.model.Init() }
+func (a *blockedAdapter) View() string     { return a.model.View() }
+func (a *blockedAdapter) HelpText() string { return a.model.HelpText() }
+func (a *blockedAdapter) Dismissed() bool  { return a.model.Dismissed() }
+func (a *blockedAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// sessionsAdapter wraps SessionsModel to implement app.SessionsViewModel.
+type sessionsAdapter struct {
+	model SessionsModel
+}
+
+func (a *sessionsAdapter) Init() tea.Cmd    { return a.model.Init() }
+func (a *sessionsAdapter) View() string     { return a.model.View() }
+func (a *sessionsAdapter) HelpText() string { return a.model.HelpText() }
+func (a *sessionsAdapter) Dismissed() bool  { return a.model.Dismissed() }
+func (a *sessionsAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
+// twoFactorAdapter wraps TwoFactorModel to implement app.TwoFactorViewModel.
+type twoFactorAdapter struct {
+	model TwoFactorModel
+}
+
+func (a *twoFactorAdapter) Init() tea.Cmd            { return a.model.Init() }
+func (a *twoFactorAdapter) View() string             { return a.model.View() }
+func (a *twoFactorAdapter) HelpText() string         { return a.model.HelpText() }
+func (a *twoFactorAdapter) Dismissed() bool          { return a.model.Dismissed() }
+func (a *twoFactorAdapter) IsTextInputFocused() bool { return a.model.IsTextInputFocused() }
+func (a *twoFactorAdapter) Update(msg tea.Msg) (app.ViewModel, tea.Cmd) {
+	m, cmd := a.model.Update(msg)
+	a.model = m
+	return a, cmd
+}
+
 // helpAdapter wraps HelpModel to implement app.HelpViewModel.
 type helpAdapter struct {
 	model HelpModel
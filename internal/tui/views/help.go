@@ -9,9 +9,19 @@ import (
 
 // View constants for help binding context.
 const (
-	HelpViewTimeline = "timeline"
-	HelpViewProfile  = "profile"
-	HelpViewCompose  = "compose"
+	HelpViewTimeline      = "timeline"
+	HelpViewProfile       = "profile"
+	HelpViewCompose       = "compose"
+	HelpViewThread        = "thread"
+	HelpViewTag           = "tag"
+	HelpViewTrending      = "trending"
+	HelpViewSearch        = "search"
+	HelpViewNotifications = "notifications"
+	HelpViewInbox         = "inbox"
+	HelpViewConversation  = "conversation"
+	HelpViewBlocked       = "blocked"
+	HelpViewSessions      = "sessions"
+	HelpViewTwoFactor     = "two-factor"
 )
 
 // HelpEntry represents a single key binding help entry.
@@ -25,7 +35,20 @@ var helpBindings = map[string][]HelpEntry{
 		{"j/k", "Scroll up/down"},
 		{"n", "New post"},
 		{"r", "Refresh"},
-		{"Enter", "View profile"},
+		{"Enter", "Open thread"},
+		{"l", "Like/unlike"},
+		{"R", "Repost/undo repost"},
+		{"Q", "Quote post"},
+		{"v", "Show filtered post"},
+		{"Tab", "Home/global feed"},
+		{"M", "Mentions feed"},
+		{"t", "Trending tags"},
+		{"/", "Search"},
+		{"N", "Notifications"},
+		{"D", "Direct messages"},
+		{"u", "View author profile"},
+		{"m", "View mentioned user's profile"},
+		{"#", "Open hashtag timeline"},
 		{"p", "Own profile"},
 		{"g/G", "Top/bottom"},
 		{"?", "Close help"},
@@ -34,10 +57,125 @@ var helpBindings = map[string][]HelpEntry{
 	HelpViewProfile: {
 		{"j/k", "Scroll up/down"},
 		{"e", "Edit bio (own profile)"},
+		{"f", "Follow/unfollow"},
+		{"d", "Send a direct message"},
+		{"b", "Block/unblock (own profile: blocked & muted list)"},
+		{"x", "Mute/unmute"},
+		{"S", "Signed-in devices (own profile)"},
+		{"T", "Two-factor authentication (own profile)"},
+		{"E", "Export your data (own profile)"},
+		{"L", "Log out (own profile)"},
+		{"X", "Delete account (own profile)"},
+		{"m", "View mentioned user's profile"},
+		{"#", "Open hashtag timeline"},
+		{"l", "Like/unlike"},
+		{"R", "Repost/undo repost"},
+		{"Q", "Quote post"},
+		{"v", "Show filtered post"},
+		{"Enter", "Open thread"},
+		{"Esc", "Back to timeline"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewThread: {
+		{"j/k", "Scroll up/down"},
+		{"n", "Reply to selected post"},
+		{"l", "Like/unlike"},
+		{"R", "Repost/undo repost"},
+		{"Q", "Quote post"},
+		{"Enter", "Open selected post's thread"},
+		{"u", "View author profile"},
+		{"m", "View mentioned user's profile"},
+		{"#", "Open hashtag timeline"},
+		{"r", "Refresh"},
+		{"Esc", "Back"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewTag: {
+		{"j/k", "Scroll up/down"},
+		{"n", "New post"},
+		{"Enter", "Open thread"},
+		{"l", "Like/unlike"},
+		{"R", "Repost/undo repost"},
+		{"Q", "Quote post"},
+		{"#", "Open the post's other hashtag"},
+		{"u", "View author profile"},
+		{"m", "View mentioned user's profile"},
+		{"r", "Refresh"},
+		{"Esc", "Back"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewTrending: {
+		{"j/k", "Scroll up/down"},
+		{"Enter", "Open hashtag timeline"},
+		{"r", "Refresh"},
 		{"Esc", "Back to timeline"},
 		{"?", "Close help"},
 		{"q", "Quit"},
 	},
+	HelpViewSearch: {
+		{"Enter", "Search / open result"},
+		{"Tab", "Posts/users"},
+		{"/", "Edit query"},
+		{"j/k", "Scroll up/down, loading more at the end"},
+		{"l", "Like/unlike"},
+		{"u", "View author profile"},
+		{"Esc", "Back"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewNotifications: {
+		{"j/k", "Scroll up/down, loading more at the end"},
+		{"Enter", "Open post or profile"},
+		{"u", "View actor profile"},
+		{"r", "Refresh"},
+		{"Esc", "Back to timeline"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewInbox: {
+		{"j/k", "Scroll up/down, loading more at the end"},
+		{"Enter", "Open conversation"},
+		{"n", "New conversation"},
+		{"r", "Refresh"},
+		{"Esc", "Back to timeline"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewBlocked: {
+		{"j/k", "Scroll up/down"},
+		{"Tab", "Switch between blocked and muted"},
+		{"x", "Unblock/unmute"},
+		{"Enter", "View profile"},
+		{"r", "Refresh"},
+		{"Esc", "Back to profile"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewSessions: {
+		{"j/k", "Scroll up/down"},
+		{"x", "Sign out device"},
+		{"r", "Refresh"},
+		{"Esc", "Back to profile"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewTwoFactor: {
+		{"s", "Set up with an authenticator app"},
+		{"d", "Turn off"},
+		{"r", "Refresh"},
+		{"Esc", "Back to profile"},
+		{"?", "Close help"},
+		{"q", "Quit"},
+	},
+	HelpViewConversation: {
+		{"Ctrl+Enter", "Send message"},
+		{"PgUp/PgDn", "Scroll older/newer messages"},
+		{"Ctrl+R", "Refresh"},
+		{"Esc", "Back to inbox"},
+	},
 	HelpViewCompose: {
 		{"Ctrl+Enter", "Publish post"},
 		{"Esc", "Cancel"},
@@ -0,0 +1,298 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+var inboxNamesStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("6")).
+	Bold(true)
+
+// InboxModel lists the user's direct message conversations, most recently
+// active first. Pressing n opens a prompt for the usernames to message.
+type InboxModel struct {
+	conversations []models.Conversation
+	input         textinput.Model
+	starting      bool
+	userID        string
+	cursor        int
+	scrollTop     int
+	nextCursor    string
+	hasMore       bool
+	loading       bool
+	dismissed     bool
+	client        *client.Client
+	width         int
+	height        int
+}
+
+// NewInboxModel creates an inbox for userID.
+func NewInboxModel(c *client.Client, userID string) InboxModel {
+	input := textinput.New()
+	input.Placeholder = "@sara @omar"
+	input.CharLimit = 200
+	input.Width = 40
+
+	return InboxModel{
+		input:   input,
+		userID:  userID,
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch conversations.
+func (m InboxModel) Init() tea.Cmd {
+	return m.fetchConversations("")
+}
+
+func (m InboxModel) fetchConversations(cursor string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		resp, err := c.GetConversations(cursor, 20)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		nextCursor := ""
+		if resp.NextCursor != nil {
+			nextCursor = *resp.NextCursor
+		}
+		return app.MsgConversationsLoaded{
+			Conversations: resp.Conversations,
+			NextCursor:    nextCursor,
+			HasMore:       resp.HasMore,
+			Append:        cursor != "",
+		}
+	}
+}
+
+// startConversation opens a conversation with usernames, reusing an
+// existing one-to-one conversation.
+func startConversation(c *client.Client, usernames []string) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		conv, err := c.StartConversation(usernames)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgOpenConversation{Conversation: *conv}
+	}
+}
+
+// Dismissed returns whether the user left the inbox.
+func (m InboxModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// IsTextInputFocused returns whether the new conversation prompt is open.
+func (m InboxModel) IsTextInputFocused() bool {
+	return m.starting
+}
+
+// Update handles messages for the inbox.
+func (m InboxModel) Update(msg tea.Msg) (InboxModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgConversationsLoaded:
+		m.loading = false
+		m.nextCursor = msg.NextCursor
+		m.hasMore = msg.HasMore
+		if msg.Append {
+			m.conversations = append(m.conversations, msg.Conversations...)
+			return m, nil
+		}
+		m.conversations = msg.Conversations
+		m.cursor, m.scrollTop = 0, 0
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.starting {
+			return m.handlePromptKey(msg)
+		}
+		return m.handleKey(msg)
+	}
+
+	if m.starting {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m InboxModel) handlePromptKey(msg tea.KeyMsg) (InboxModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.starting = false
+		m.input.Blur()
+		m.input.SetValue("")
+		return m, nil
+
+	case tea.KeyEnter:
+		usernames := strings.FieldsFunc(m.input.Value(), func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		if len(usernames) == 0 {
+			return m, nil
+		}
+		m.starting = false
+		m.input.Blur()
+		m.input.SetValue("")
+		return m, startConversation(m.client, usernames)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m InboxModel) handleKey(msg tea.KeyMsg) (InboxModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'n':
+		m.starting = true
+		return m, m.input.Focus()
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+		m.loading = true
+		return m, m.fetchConversations("")
+
+	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+		if m.cursor < len(m.conversations)-1 {
+			m.cursor++
+			if m.cursor >= m.scrollTop+m.visibleCount() {
+				m.scrollTop = m.cursor - m.visibleCount() + 1
+			}
+			return m, nil
+		}
+		if m.hasMore && !m.loading {
+			m.loading = true
+			return m, m.fetchConversations(m.nextCursor)
+		}
+
+	case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+		if m.cursor > 0 {
+			m.cursor--
+			if m.cursor < m.scrollTop {
+				m.scrollTop = m.cursor
+			}
+		}
+
+	case msg.Type == tea.KeyEnter:
+		if m.cursor < len(m.conversations) {
+			conv := m.conversations[m.cursor]
+			// Opening the conversation reads it
+			m.conversations[m.cursor].Unread = false
+			return m, func() tea.Msg { return app.MsgOpenConversation{Conversation: conv} }
+		}
+	}
+
+	return m, nil
+}
+
+func (m InboxModel) visibleCount() int {
+	// One line for the prompt, then 3 lines per conversation
+	count := (m.height - 1) / 3
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// View renders the inbox.
+func (m InboxModel) View() string {
+	var b strings.Builder
+	if m.starting {
+		b.WriteString("  Message: " + m.input.View())
+	} else {
+		b.WriteString(emptyStateStyle.Render("  n: new conversation"))
+	}
+	b.WriteString("\n")
+	bodyHeight := m.height - 1
+
+	if m.loading && len(m.conversations) == 0 {
+		b.WriteString(lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading messages...")))
+		return b.String()
+	}
+	if len(m.conversations) == 0 {
+		b.WriteString(lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("No conversations yet. Press n to message someone.")))
+		return b.String()
+	}
+
+	now := time.Now()
+	end := m.scrollTop + m.visibleCount()
+	if end > len(m.conversations) {
+		end = len(m.conversations)
+	}
+	for i := m.scrollTop; i < end; i++ {
+		b.WriteString(m.renderConversation(m.conversations[i], i == m.cursor, now))
+	}
+	return b.String()
+}
+
+func (m InboxModel) renderConversation(conv models.Conversation, selected bool, now time.Time) string {
+	marker := "  "
+	if selected {
+		marker = feedActiveStyle.Render("▸") + " "
+	}
+	unread := "  "
+	if conv.Unread {
+		unread = notificationUnreadStyle.Render("•") + " "
+	}
+
+	line := marker + unread + inboxNamesStyle.Render(conversationTitle(conv, m.userID)) +
+		" " + emptyStateStyle.Render("· "+components.RelativeTimeFrom(conv.LastMessageAt, now))
+
+	excerpt := "No messages yet."
+	if conv.LastMessage != nil {
+		excerpt = "@" + conv.LastMessage.Sender.Username + ": " + quoteExcerpt(conv.LastMessage.Content)
+	}
+	return line + "\n      " + emptyStateStyle.Render(excerpt) + "\n\n"
+}
+
+// conversationTitle names a conversation after its members other than
+// userID.
+func conversationTitle(conv models.Conversation, userID string) string {
+	var names []string
+	for _, u := range conv.Members {
+		if u.ID != userID {
+			names = append(names, "@"+u.Username)
+		}
+	}
+	if len(names) == 0 {
+		return "Just you"
+	}
+	return strings.Join(names, ", ")
+}
+
+// HelpText returns the status bar help text for the inbox.
+func (m InboxModel) HelpText() string {
+	if m.starting {
+		return "Enter: start conversation  Esc: cancel"
+	}
+	return "j/k: navigate  Enter: open  n: new conversation  r: refresh  Esc: back  ?: help"
+}
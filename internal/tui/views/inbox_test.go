@@ -0,0 +1,82 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestInboxListsConversations(t *testing.T) {
+	me := models.User{ID: "u1", Username: "akram"}
+	sara := models.User{ID: "u2", Username: "sara"}
+	omar := models.User{ID: "u3", Username: "omar"}
+
+	m := views.NewInboxModel(nil, me.ID)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgConversationsLoaded{Conversations: []models.Conversation{
+		{
+			ID:            "c1",
+			Members:       []models.User{me, sara},
+			LastMessage:   &models.Message{Sender: sara, Content: "see you tomorrow"},
+			Unread:        true,
+			LastMessageAt: time.Now(),
+		},
+		{ID: "c2", Members: []models.User{me, sara, omar}, LastMessageAt: time.Now()},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"@sara", "@sara: see you tomorrow", "@omar", "No messages yet"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "@akram") {
+		t.Error("conversation titles should leave out the current user")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(app.MsgOpenConversation); !ok || msg.Conversation.ID != "c1" {
+		t.Errorf("got %+v, want MsgOpenConversation{c1}", msg)
+	}
+}
+
+func TestInboxNewConversationPrompt(t *testing.T) {
+	m := views.NewInboxModel(nil, "u1")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgConversationsLoaded{})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !m.IsTextInputFocused() {
+		t.Fatal("n should open the username prompt")
+	}
+	for _, r := range "@sara omar" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsTextInputFocused() {
+		t.Error("prompt should close after Enter")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command to start the conversation")
+	}
+	if msg, ok := cmd().(app.MsgAPIError); !ok || msg.Message != "no server connection" {
+		t.Errorf("got %+v, want the nil-client error", msg)
+	}
+
+	// Esc closes the prompt before it leaves the inbox
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsTextInputFocused() || m.Dismissed() {
+		t.Error("Esc should only close the prompt")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("second Esc should dismiss the inbox")
+	}
+}
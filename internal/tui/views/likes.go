@@ -0,0 +1,43 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+)
+
+// toggleLike likes or unlikes post depending on its current state.
+func toggleLike(c *client.Client, post models.Post) tea.Cmd {
+	postID := post.ID
+	like := !post.LikedByMe
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		var count int
+		var err error
+		if like {
+			count, err = c.Like(postID)
+		} else {
+			count, err = c.Unlike(postID)
+		}
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgLikeToggled{PostID: postID, Liked: like, LikeCount: count}
+	}
+}
+
+// applyLike updates the like state of the matching post, or the original it
+// embeds, in place.
+func applyLike(post *models.Post, msg app.MsgLikeToggled) {
+	if post.Original != nil {
+		applyLike(post.Original, msg)
+	}
+	if post.ID == msg.PostID {
+		post.LikedByMe = msg.Liked
+		post.LikeCount = msg.LikeCount
+	}
+}
@@ -48,6 +48,11 @@ type LoginModel struct {
 	client        *client.Client
 	width         int
 	height        int
+
+	// When the account has two-factor authentication on, the password only
+	// earns a challenge token, and the user is asked for a code.
+	challenge string
+	codeInput textinput.Model
 }
 
 // NewLoginModel creates a new login form model.
@@ -65,13 +70,24 @@ func NewLoginModel(c *client.Client) LoginModel {
 	password.CharLimit = 128
 	password.Width = 30
 
+	code := textinput.New()
+	code.Placeholder = "123456 or a recovery code"
+	code.CharLimit = 32
+	code.Width = 30
+
 	return LoginModel{
 		emailInput:    email,
 		passwordInput: password,
+		codeInput:     code,
 		client:        c,
 	}
 }
 
+// EnteringCode reports whether the view is asking for a two-factor code.
+func (m LoginModel) EnteringCode() bool {
+	return m.challenge != ""
+}
+
 // FocusIndex returns the currently focused field index.
 func (m LoginModel) FocusIndex() int {
 	return m.focusIndex
@@ -94,12 +110,24 @@ func (m LoginModel) Update(msg tea.Msg) (LoginModel, tea.Cmd) {
 		m.submitting = false
 		m.err = msg.Message
 		m.errField = msg.Field
-		if msg.Field == "password" || msg.Field == "" {
+		if msg.Field == "code" {
+			m.codeInput.SetValue("")
+		} else if msg.Field == "password" || msg.Field == "" {
 			m.passwordInput.SetValue("")
 		}
 		return m, nil
 
+	case app.MsgTwoFactorRequired:
+		m.submitting = false
+		m.challenge = msg.ChallengeToken
+		m.passwordInput.Blur()
+		m.emailInput.Blur()
+		return m, m.codeInput.Focus()
+
 	case tea.KeyMsg:
+		if m.challenge != "" {
+			return m.updateCode(msg)
+		}
 		// Clear previous error on any keypress
 		if m.err != "" && msg.Type != tea.KeyEnter {
 			m.err = ""
@@ -139,6 +167,64 @@ func (m LoginModel) Update(msg tea.Msg) (LoginModel, tea.Cmd) {
 	return m, cmd
 }
 
+// updateCode handles keys on the two-factor code step.
+func (m LoginModel) updateCode(msg tea.KeyMsg) (LoginModel, tea.Cmd) {
+	if m.err != "" && msg.Type != tea.KeyEnter {
+		m.err = ""
+		m.errField = ""
+	}
+	if m.submitting {
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		return m, m.completeLogin()
+
+	case tea.KeyEsc:
+		// Back to the form; the challenge is abandoned and a new login
+		// starts over
+		m.challenge = ""
+		m.codeInput.SetValue("")
+		m.codeInput.Blur()
+		m.passwordInput.SetValue("")
+		m.focusIndex = 1
+		return m, m.passwordInput.Focus()
+	}
+
+	var cmd tea.Cmd
+	m.codeInput, cmd = m.codeInput.Update(msg)
+	return m, cmd
+}
+
+// completeLogin submits the two-factor code with the challenge token.
+func (m *LoginModel) completeLogin() tea.Cmd {
+	code := strings.TrimSpace(m.codeInput.Value())
+	if code == "" {
+		m.err = "code is required"
+		m.errField = "code"
+		return nil
+	}
+
+	m.submitting = true
+	challenge := m.challenge
+	c := m.client
+
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAuthError{Message: "no server connection", Field: "code"}
+		}
+		resp, err := c.CompleteLogin(challenge, code)
+		if err != nil {
+			return app.MsgAuthError{Message: err.Error(), Field: "code"}
+		}
+		return app.MsgAuthSuccess{
+			User:   resp.User,
+			Tokens: resp.Tokens,
+		}
+	}
+}
+
 // submit validates and submits the login form.
 func (m *LoginModel) submit() tea.Cmd {
 	email := strings.TrimSpace(m.emailInput.Value())
@@ -166,6 +252,9 @@ func (m *LoginModel) submit() tea.Cmd {
 		if err != nil {
 			return app.MsgAuthError{Message: err.Error()}
 		}
+		if resp.ChallengeToken != "" {
+			return app.MsgTwoFactorRequired{ChallengeToken: resp.ChallengeToken}
+		}
 		return app.MsgAuthSuccess{
 			User:   resp.User,
 			Tokens: resp.Tokens,
@@ -175,6 +264,10 @@ func (m *LoginModel) submit() tea.Cmd {
 
 // View renders the login form.
 func (m LoginModel) View() string {
+	if m.challenge != "" {
+		return m.viewCode()
+	}
+
 	var b strings.Builder
 
 	b.WriteString(formTitleStyle.Render("Login"))
@@ -224,7 +317,46 @@ func (m LoginModel) View() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form)
 }
 
+// viewCode renders the two-factor code step.
+func (m LoginModel) viewCode() string {
+	var b strings.Builder
+
+	b.WriteString(formTitleStyle.Render("Two-factor authentication"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hintStyle.Render("Enter the code from your authenticator app,"))
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("or one of your recovery codes."))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Code"))
+	b.WriteString("\n")
+	b.WriteString(m.codeInput.View())
+	b.WriteString("\n")
+	if m.err != "" {
+		b.WriteString(errMsgStyle.Render(m.err))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.submitting {
+		b.WriteString(hintStyle.Render("Checking..."))
+	} else {
+		b.WriteString(buttonStyle.Render("[Enter] Verify"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(hintStyle.Render("[Esc] Back"))
+
+	form := formBoxStyle.Render(b.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form)
+}
+
 // HelpText returns the status bar help text for the login view.
 func (m LoginModel) HelpText() string {
+	if m.challenge != "" {
+		return "Enter: verify  Esc: back"
+	}
 	return "Tab: switch to register  Enter: submit  q: quit"
 }
@@ -171,3 +171,70 @@ func TestLoginInit(t *testing.T) {
 		t.Error("Init should return a blink command")
 	}
 }
+
+func challengedModel(t *testing.T) views.LoginModel {
+	t.Helper()
+	m := views.NewLoginModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgTwoFactorRequired{ChallengeToken: "challenge"})
+	if !m.EnteringCode() {
+		t.Fatal("expected the code step after a challenge")
+	}
+	return m
+}
+
+func TestLoginCodeStepRenders(t *testing.T) {
+	m := challengedModel(t)
+	if !strings.Contains(m.View(), "Two-factor authentication") {
+		t.Error("expected the two-factor title")
+	}
+	if !strings.Contains(m.HelpText(), "Esc: back") {
+		t.Errorf("help text = %q, want the code step keys", m.HelpText())
+	}
+}
+
+func TestLoginCodeStepEmptyCode(t *testing.T) {
+	m := challengedModel(t)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("expected nil cmd without a code")
+	}
+	if !strings.Contains(m.View(), "code is required") {
+		t.Error("expected a missing code error")
+	}
+}
+
+func TestLoginCodeStepSubmitsCode(t *testing.T) {
+	m := challengedModel(t)
+	for _, r := range "123456" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	// Without a client the command reports an error on the code
+	msg, ok := cmd().(app.MsgAuthError)
+	if !ok || msg.Field != "code" {
+		t.Fatalf("msg = %+v, want MsgAuthError on the code", msg)
+	}
+
+	m, _ = m.Update(app.MsgAuthError{Message: "invalid two-factor code", Field: "code"})
+	if !strings.Contains(m.View(), "invalid two-factor code") {
+		t.Error("expected the code error in output")
+	}
+	if !m.EnteringCode() {
+		t.Error("a wrong code should keep the code step open")
+	}
+}
+
+func TestLoginCodeStepEscGoesBack(t *testing.T) {
+	m := challengedModel(t)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.EnteringCode() {
+		t.Error("Esc should leave the code step")
+	}
+	if !strings.Contains(m.View(), "Login") {
+		t.Error("expected the login form after Esc")
+	}
+}
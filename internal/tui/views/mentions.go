@@ -0,0 +1,18 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+)
+
+// openMention opens the profile of the first user mentioned in post, or
+// does nothing when the post mentions no one.
+func openMention(post models.Post) tea.Cmd {
+	if len(post.Mentions) == 0 {
+		return nil
+	}
+	userID := post.Mentions[0].UserID
+	return func() tea.Msg { return app.MsgOpenProfile{UserID: userID} }
+}
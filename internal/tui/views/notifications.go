@@ -0,0 +1,270 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+var (
+	notificationUnreadStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("3")).
+				Bold(true)
+
+	notificationActorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("6")).
+				Bold(true)
+)
+
+// NotificationsModel manages the list of the user's notifications. Loading
+// the first page marks everything on it as read, while keeping the unread
+// markers visible until the view is closed.
+type NotificationsModel struct {
+	notifications []models.Notification
+	cursor        int
+	scrollTop     int
+	nextCursor    string
+	hasMore       bool
+	loading       bool
+	dismissed     bool
+	client        *client.Client
+	width         int
+	height        int
+}
+
+// NewNotificationsModel creates a notifications view.
+func NewNotificationsModel(c *client.Client) NotificationsModel {
+	return NotificationsModel{
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch notifications.
+func (m NotificationsModel) Init() tea.Cmd {
+	return m.fetchNotifications("")
+}
+
+func (m NotificationsModel) fetchNotifications(cursor string) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		resp, err := c.GetNotifications(cursor, 20)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		nextCursor := ""
+		if resp.NextCursor != nil {
+			nextCursor = *resp.NextCursor
+		}
+		return app.MsgNotificationsLoaded{
+			Notifications: resp.Notifications,
+			NextCursor:    nextCursor,
+			HasMore:       resp.HasMore,
+			UnreadCount:   resp.UnreadCount,
+			Append:        cursor != "",
+		}
+	}
+}
+
+// markRead marks notifications up to upTo as read.
+func (m NotificationsModel) markRead(upTo time.Time) tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		count, err := c.MarkNotificationsRead(upTo)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgUnreadCount{Count: count}
+	}
+}
+
+// Dismissed returns whether the user left the notifications view.
+func (m NotificationsModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// Update handles messages for the notifications view.
+func (m NotificationsModel) Update(msg tea.Msg) (NotificationsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgNotificationsLoaded:
+		m.loading = false
+		m.nextCursor = msg.NextCursor
+		m.hasMore = msg.HasMore
+		if msg.Append {
+			m.notifications = append(m.notifications, msg.Notifications...)
+			return m, nil
+		}
+		m.notifications = msg.Notifications
+		m.cursor, m.scrollTop = 0, 0
+		if msg.UnreadCount > 0 && len(m.notifications) > 0 {
+			return m, m.markRead(m.notifications[0].CreatedAt)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m NotificationsModel) handleKey(msg tea.KeyMsg) (NotificationsModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+		m.loading = true
+		return m, m.fetchNotifications("")
+
+	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+		if m.cursor < len(m.notifications)-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+			return m, nil
+		}
+		if m.hasMore && !m.loading {
+			m.loading = true
+			return m, m.fetchNotifications(m.nextCursor)
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+		return m, nil
+	}
+
+	if m.cursor >= len(m.notifications) {
+		return m, nil
+	}
+	n := m.notifications[m.cursor]
+
+	switch {
+	// Enter: open the post the notification is about, or the actor's
+	// profile for follows
+	case msg.Type == tea.KeyEnter:
+		if n.Post != nil && !n.Post.Deleted {
+			postID := n.Post.ID
+			return m, func() tea.Msg { return app.MsgOpenThread{PostID: postID} }
+		}
+		actorID := n.Actor.ID
+		return m, func() tea.Msg { return app.MsgOpenProfile{UserID: actorID} }
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'u':
+		actorID := n.Actor.ID
+		return m, func() tea.Msg { return app.MsgOpenProfile{UserID: actorID} }
+	}
+
+	return m, nil
+}
+
+func (m *NotificationsModel) ensureCursorVisible() {
+	visibleCount := m.visibleCount()
+	if m.cursor < m.scrollTop {
+		m.scrollTop = m.cursor
+	}
+	if m.cursor >= m.scrollTop+visibleCount {
+		m.scrollTop = m.cursor - visibleCount + 1
+	}
+}
+
+func (m NotificationsModel) visibleCount() int {
+	if m.height <= 0 {
+		return 5
+	}
+	// Each notification takes 3 lines
+	count := m.height / 3
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// View renders the notifications view.
+func (m NotificationsModel) View() string {
+	if m.loading && len(m.notifications) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading notifications..."))
+	}
+
+	if len(m.notifications) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("No notifications yet."))
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	end := m.scrollTop + m.visibleCount()
+	if end > len(m.notifications) {
+		end = len(m.notifications)
+	}
+	for i := m.scrollTop; i < end; i++ {
+		b.WriteString(m.renderNotification(m.notifications[i], i == m.cursor, now))
+	}
+	return b.String()
+}
+
+func (m NotificationsModel) renderNotification(n models.Notification, selected bool, now time.Time) string {
+	marker := "  "
+	if selected {
+		marker = feedActiveStyle.Render("▸") + " "
+	}
+	unread := "  "
+	if !n.Read {
+		unread = notificationUnreadStyle.Render("•") + " "
+	}
+
+	var icon, action string
+	switch n.Kind {
+	case models.NotificationKindReply:
+		icon, action = "↩", "replied to your post"
+	case models.NotificationKindFollow:
+		icon, action = "+", "followed you"
+	case models.NotificationKindLike:
+		icon, action = "♥", "liked your post"
+	case models.NotificationKindMention:
+		icon, action = "@", "mentioned you"
+	default:
+		icon, action = "·", n.Kind
+	}
+
+	line := marker + unread + icon + " " + notificationActorStyle.Render("@"+n.Actor.Username) + " " + action +
+		" " + emptyStateStyle.Render("· "+components.RelativeTimeFrom(n.CreatedAt, now))
+
+	excerpt := ""
+	if n.Post != nil {
+		if n.Post.Deleted {
+			excerpt = "This post was deleted."
+		} else {
+			excerpt = quoteExcerpt(n.Post.Content)
+		}
+	}
+	return line + "\n      " + emptyStateStyle.Render(excerpt) + "\n\n"
+}
+
+// HelpText returns the status bar help text for the notifications view.
+func (m NotificationsModel) HelpText() string {
+	return "j/k: navigate  Enter: open  u: profile  r: refresh  Esc: back  ?: help"
+}
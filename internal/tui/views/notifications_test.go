@@ -0,0 +1,77 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestNotificationsViewOpensTargets(t *testing.T) {
+	now := time.Now()
+	m := views.NewNotificationsModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, cmd := m.Update(app.MsgNotificationsLoaded{
+		Notifications: []models.Notification{
+			{
+				ID:        "n1",
+				Kind:      models.NotificationKindLike,
+				Actor:     models.User{ID: "u2", Username: "bob"},
+				Post:      &models.Post{ID: "p1", Content: "hello world"},
+				CreatedAt: now,
+			},
+			{
+				ID:        "n2",
+				Kind:      models.NotificationKindFollow,
+				Actor:     models.User{ID: "u3", Username: "carol"},
+				Read:      true,
+				CreatedAt: now.Add(-time.Hour),
+			},
+		},
+		UnreadCount: 1,
+	})
+	if cmd == nil {
+		t.Error("loading unread notifications should mark them read")
+	}
+
+	view := m.View()
+	for _, want := range []string{"@bob", "liked your post", "hello world", "@carol", "followed you"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(app.MsgOpenThread); !ok || msg.PostID != "p1" {
+		t.Errorf("got %+v, want MsgOpenThread{p1}", msg)
+	}
+
+	// Follows have no post, so Enter opens the follower's profile
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(app.MsgOpenProfile); !ok || msg.UserID != "u3" {
+		t.Errorf("got %+v, want MsgOpenProfile{u3}", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("expected Esc to dismiss the notifications view")
+	}
+}
+
+func TestNotificationsViewEmptyState(t *testing.T) {
+	m := views.NewNotificationsModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, cmd := m.Update(app.MsgNotificationsLoaded{})
+	if cmd != nil {
+		t.Error("nothing to mark read in an empty list")
+	}
+	if !strings.Contains(m.View(), "No notifications yet") {
+		t.Errorf("expected empty state, got:\n%s", m.View())
+	}
+}
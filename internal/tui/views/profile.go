@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/Akram012388/niotebook-tui/internal/tui/app"
 	"github.com/Akram012388/niotebook-tui/internal/tui/client"
 	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+	"github.com/Akram012388/niotebook-tui/internal/tui/config"
 )
 
 var (
@@ -38,6 +40,7 @@ var (
 
 // ProfileModel manages the profile view state.
 type ProfileModel struct {
+	userID    string
 	user      *models.User
 	posts     []models.Post
 	cursor    int
@@ -46,14 +49,27 @@ type ProfileModel struct {
 	editing   bool
 	dismissed bool
 	isOwn     bool
-	client    *client.Client
-	width     int
-	height    int
+	following bool
+	blocking  bool
+	muting    bool
+	// confirmBlock is set while asking the user to confirm a block.
+	confirmBlock bool
+	// confirmDelete is set while asking for the password to delete the
+	// user's own account.
+	confirmDelete bool
+	// confirmLogout is set while asking whether to log out of this device
+	// or all devices.
+	confirmLogout  bool
+	deletePassword textinput.Model
+	client         *client.Client
+	width          int
+	height         int
 }
 
 // NewProfileModel creates a new profile view model.
 func NewProfileModel(c *client.Client, userID string, isOwn bool) ProfileModel {
 	m := ProfileModel{
+		userID:  userID,
 		client:  c,
 		isOwn:   isOwn,
 		loading: true,
@@ -69,7 +85,7 @@ func (m ProfileModel) Init() tea.Cmd {
 func (m ProfileModel) fetchProfile() tea.Cmd {
 	c := m.client
 	isOwn := m.isOwn
-	var userID string
+	userID := m.userID
 	if m.user != nil {
 		userID = m.user.ID
 	}
@@ -93,9 +109,54 @@ func (m ProfileModel) fetchProfile() tea.Cmd {
 			return app.MsgAPIError{Message: err.Error()}
 		}
 
+		var following, blocking, muting bool
+		if !isOwn {
+			following, err = c.IsFollowing(user.ID)
+			if err != nil {
+				return app.MsgAPIError{Message: err.Error()}
+			}
+			blocking, err = c.IsBlocking(user.ID)
+			if err != nil {
+				return app.MsgAPIError{Message: err.Error()}
+			}
+			muting, err = c.IsMuting(user.ID)
+			if err != nil {
+				return app.MsgAPIError{Message: err.Error()}
+			}
+		}
+
 		return app.MsgProfileLoaded{
-			User:  user,
-			Posts: resp.Posts,
+			User:      user,
+			Posts:     resp.Posts,
+			Following: following,
+			Blocking:  blocking,
+			Muting:    muting,
+		}
+	}
+}
+
+// toggleFollow follows or unfollows the profile's user.
+func (m ProfileModel) toggleFollow() tea.Cmd {
+	c := m.client
+	user := m.user
+	follow := !m.following
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		var err error
+		if follow {
+			err = c.Follow(user.ID)
+		} else {
+			err = c.Unfollow(user.ID)
+		}
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgFollowToggled{
+			UserID:    user.ID,
+			Username:  user.Username,
+			Following: follow,
 		}
 	}
 }
@@ -105,9 +166,10 @@ func (m ProfileModel) User() *models.User {
 	return m.user
 }
 
-// Editing returns whether the edit modal is active.
+// Editing returns whether the edit modal or the delete account dialog is
+// active, so that keys go to their text input.
 func (m ProfileModel) Editing() bool {
-	return m.editing
+	return m.editing || m.confirmDelete
 }
 
 // Dismissed returns whether the user pressed Esc to leave.
@@ -115,6 +177,21 @@ func (m ProfileModel) Dismissed() bool {
 	return m.dismissed
 }
 
+// Following returns whether the current user follows this profile.
+func (m ProfileModel) Following() bool {
+	return m.following
+}
+
+// Blocking returns whether the current user blocks this profile.
+func (m ProfileModel) Blocking() bool {
+	return m.blocking
+}
+
+// Muting returns whether the current user mutes this profile.
+func (m ProfileModel) Muting() bool {
+	return m.muting
+}
+
 // IsOwn returns whether this is the current user's own profile.
 func (m ProfileModel) IsOwn() bool {
 	return m.isOwn
@@ -132,6 +209,9 @@ func (m ProfileModel) Update(msg tea.Msg) (ProfileModel, tea.Cmd) {
 		m.loading = false
 		m.user = msg.User
 		m.posts = msg.Posts
+		m.following = msg.Following
+		m.blocking = msg.Blocking
+		m.muting = msg.Muting
 		m.cursor = 0
 		m.scrollTop = 0
 		return m, nil
@@ -141,6 +221,40 @@ func (m ProfileModel) Update(msg tea.Msg) (ProfileModel, tea.Cmd) {
 		m.editing = false
 		return m, nil
 
+	case app.MsgFollowToggled:
+		if m.user != nil && m.user.ID == msg.UserID {
+			m.following = msg.Following
+		}
+		return m, nil
+
+	// Blocks and mutes change which posts the profile shows, and blocks
+	// remove follows, so reload it
+	case app.MsgBlockToggled:
+		if m.user != nil && m.user.ID == msg.UserID {
+			m.blocking = msg.Blocking
+			return m, m.fetchProfile()
+		}
+		return m, nil
+
+	case app.MsgMuteToggled:
+		if m.user != nil && m.user.ID == msg.UserID {
+			m.muting = msg.Muting
+			return m, m.fetchProfile()
+		}
+		return m, nil
+
+	case app.MsgLikeToggled:
+		for i := range m.posts {
+			applyLike(&m.posts[i], msg)
+		}
+		return m, nil
+
+	case app.MsgRepostToggled:
+		for i := range m.posts {
+			applyRepost(&m.posts[i], msg)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 	}
@@ -148,7 +262,133 @@ func (m ProfileModel) Update(msg tea.Msg) (ProfileModel, tea.Cmd) {
 	return m, nil
 }
 
+// deleteAccount schedules the user's account for deletion.
+func deleteAccount(c *client.Client, password string) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		deleteAfter, err := c.DeleteAccount(password)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgAccountDeletionScheduled{DeleteAfter: deleteAfter}
+	}
+}
+
+const (
+	// exportPollInterval is how often a data export's status is checked.
+	exportPollInterval = time.Second
+	// exportWait is how long to wait for the server to build an export.
+	exportWait = 2 * time.Minute
+)
+
+// exportData requests an archive of the user's data, waits for the server
+// to build it and saves it into the XDG data directory.
+func exportData(c *client.Client) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		export, err := c.RequestExport()
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		deadline := time.Now().Add(exportWait)
+		for export.Status == models.ExportPending {
+			if time.Now().After(deadline) {
+				return app.MsgAPIError{Message: "data export is taking too long; try again later"}
+			}
+			time.Sleep(exportPollInterval)
+			export, err = c.GetExport(export.ID)
+			if err != nil {
+				return app.MsgAPIError{Message: err.Error()}
+			}
+		}
+		if export.Status != models.ExportReady {
+			return app.MsgAPIError{Message: "data export failed"}
+		}
+		archive, err := c.DownloadExport(export.ID)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		path, err := config.SaveExport(archive, export.CreatedAt)
+		if err != nil {
+			return app.MsgAPIError{Message: "saving data export: " + err.Error()}
+		}
+		return app.MsgExportSaved{Path: path}
+	}
+}
+
+// logout ends this device's session, or every session when all is set.
+// Logging out of this device always returns to the login screen, since the
+// local tokens are gone even if the server could not be told.
+func logout(c *client.Client, all bool) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		if all {
+			if err := c.LogoutAll(); err != nil {
+				return app.MsgAPIError{Message: err.Error()}
+			}
+			return app.MsgLoggedOut{}
+		}
+		_ = c.Logout()
+		return app.MsgLoggedOut{}
+	}
+}
+
+// handleDeleteKey handles keys while the delete account dialog is open:
+// Enter submits the password and Esc cancels.
+func (m ProfileModel) handleDeleteKey(msg tea.KeyMsg) (ProfileModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.confirmDelete = false
+		return m, nil
+	case tea.KeyEnter:
+		password := m.deletePassword.Value()
+		if password == "" {
+			return m, nil
+		}
+		m.confirmDelete = false
+		return m, deleteAccount(m.client, password)
+	}
+
+	var cmd tea.Cmd
+	m.deletePassword, cmd = m.deletePassword.Update(msg)
+	return m, cmd
+}
+
 func (m ProfileModel) handleKey(msg tea.KeyMsg) (ProfileModel, tea.Cmd) {
+	if m.confirmDelete {
+		return m.handleDeleteKey(msg)
+	}
+
+	// y logs out of this device, a out of all devices, and any other key
+	// cancels
+	if m.confirmLogout {
+		m.confirmLogout = false
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 {
+			switch msg.Runes[0] {
+			case 'y':
+				return m, logout(m.client, false)
+			case 'a':
+				return m, logout(m.client, true)
+			}
+		}
+		return m, nil
+	}
+
+	// Any key other than y cancels a pending block
+	if m.confirmBlock {
+		m.confirmBlock = false
+		if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'y' {
+			return m, toggleBlock(m.client, *m.user, true)
+		}
+		return m, nil
+	}
+
 	switch {
 	case msg.Type == tea.KeyEsc:
 		m.dismissed = true
@@ -160,6 +400,128 @@ func (m ProfileModel) handleKey(msg tea.KeyMsg) (ProfileModel, tea.Cmd) {
 		}
 		return m, nil
 
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'f':
+		if m.isOwn || m.user == nil {
+			return m, nil
+		}
+		return m, m.toggleFollow()
+
+	// d: send the profile's user a direct message
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'd':
+		if m.isOwn || m.user == nil {
+			return m, nil
+		}
+		return m, startConversation(m.client, []string{m.user.Username})
+
+	// b: block or unblock the profile's user, asking first before a block;
+	// on your own profile, list the accounts you blocked and muted
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'b':
+		if m.isOwn {
+			return m, func() tea.Msg { return app.MsgOpenBlocked{} }
+		}
+		if m.user == nil {
+			return m, nil
+		}
+		if m.blocking {
+			return m, toggleBlock(m.client, *m.user, false)
+		}
+		m.confirmBlock = true
+		return m, nil
+
+	// E: export your own data to a file
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'E':
+		if !m.isOwn || m.user == nil {
+			return m, nil
+		}
+		return m, exportData(m.client)
+
+	// S: list the devices signed in to your account
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'S':
+		if !m.isOwn {
+			return m, nil
+		}
+		return m, func() tea.Msg { return app.MsgOpenSessions{} }
+
+	// T: set up or turn off two-factor authentication
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'T':
+		if !m.isOwn {
+			return m, nil
+		}
+		return m, func() tea.Msg { return app.MsgOpenTwoFactor{} }
+
+	// L: log out, after asking which sessions to end
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'L':
+		if !m.isOwn || m.user == nil {
+			return m, nil
+		}
+		m.confirmLogout = true
+		return m, nil
+
+	// X: delete your own account, after confirming your password
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'X':
+		if !m.isOwn || m.user == nil {
+			return m, nil
+		}
+		m.deletePassword = textinput.New()
+		m.deletePassword.Placeholder = "password"
+		m.deletePassword.EchoMode = textinput.EchoPassword
+		m.deletePassword.EchoCharacter = '●'
+		m.deletePassword.CharLimit = 128
+		m.deletePassword.Width = 30
+		m.confirmDelete = true
+		return m, m.deletePassword.Focus()
+
+	// x: mute or unmute the profile's user
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'x':
+		if m.isOwn || m.user == nil {
+			return m, nil
+		}
+		return m, toggleMute(m.client, *m.user, !m.muting)
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'l':
+		if m.cursor < len(m.posts) {
+			return m, toggleLike(m.client, *m.posts[m.cursor].Subject())
+		}
+		return m, nil
+
+	// v: show the selected post collapsed by a filter
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'v':
+		if m.cursor < len(m.posts) {
+			m.posts[m.cursor].Filtered = ""
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'R':
+		if m.cursor < len(m.posts) {
+			return m, toggleRepost(m.client, *m.posts[m.cursor].Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'Q':
+		if m.cursor < len(m.posts) {
+			return m, openQuote(*m.posts[m.cursor].Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'm':
+		if m.cursor < len(m.posts) {
+			return m, openMention(*m.posts[m.cursor].Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '#':
+		if m.cursor < len(m.posts) {
+			return m, openTag(*m.posts[m.cursor].Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyEnter:
+		if m.cursor < len(m.posts) {
+			postID := m.posts[m.cursor].Subject().ID
+			return m, func() tea.Msg { return app.MsgOpenThread{PostID: postID} }
+		}
+		return m, nil
+
 	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
 		if len(m.posts) > 0 && m.cursor < len(m.posts)-1 {
 			m.cursor++
@@ -250,6 +612,15 @@ func (m ProfileModel) View() string {
 	// Joined date
 	joined := m.user.CreatedAt.Format("Jan 2006")
 	b.WriteString(profileJoinedStyle.Render("Joined " + joined))
+	if m.following {
+		b.WriteString(profileJoinedStyle.Render(" · Following"))
+	}
+	if m.blocking {
+		b.WriteString(profileJoinedStyle.Render(" · Blocked"))
+	}
+	if m.muting {
+		b.WriteString(profileJoinedStyle.Render(" · Muted"))
+	}
 	b.WriteString("\n")
 
 	b.WriteString(profileSeparatorStyle.Render(strings.Repeat("─", m.width)))
@@ -280,10 +651,33 @@ func (m ProfileModel) View() string {
 		}
 	}
 
-	// Edit hint for own profile
-	if m.isOwn {
+	// Edit hint for own profile, follow, block and mute hints for others
+	b.WriteString("\n")
+	switch {
+	case m.confirmBlock:
+		b.WriteString(counterWarningStyle.Render("Block @" + m.user.Username + "? You will unfollow each other and stop seeing each other's posts. [y/N]"))
+	case m.confirmLogout:
+		b.WriteString(counterWarningStyle.Render("Log out? [y] This device  [a] All devices  [N] Cancel"))
+	case m.confirmDelete:
+		b.WriteString(counterWarningStyle.Render("Delete your account? It will be permanently deleted after a grace period, along with all your posts and messages."))
 		b.WriteString("\n")
-		b.WriteString(hintStyle.Render("[e] Edit profile"))
+		b.WriteString(labelStyle.Render("Password: ") + m.deletePassword.View())
+		b.WriteString("\n")
+		b.WriteString(hintStyle.Render("Enter: delete account  Esc: cancel"))
+	case m.isOwn:
+		b.WriteString(hintStyle.Render("[e] Edit profile  [b] Blocked & muted  [S] Sessions  [T] Two-factor  [E] Export data  [L] Log out  [X] Delete account"))
+	default:
+		follow, block, mute := "[f] Follow", "[b] Block", "[x] Mute"
+		if m.following {
+			follow = "[f] Unfollow"
+		}
+		if m.blocking {
+			block = "[b] Unblock"
+		}
+		if m.muting {
+			mute = "[x] Unmute"
+		}
+		b.WriteString(hintStyle.Render(follow + "  " + block + "  " + mute))
 	}
 
 	return b.String()
@@ -292,7 +686,7 @@ func (m ProfileModel) View() string {
 // HelpText returns the status bar help text for the profile view.
 func (m ProfileModel) HelpText() string {
 	if m.isOwn {
-		return "j/k: scroll  e: edit bio  Esc: back  ?: help"
+		return "j/k: scroll  Enter: thread  l: like  R: repost  e: edit bio  b: blocked & muted  S: sessions  T: two-factor  E: export  L: log out  X: delete account  Esc: back  ?: help"
 	}
-	return "j/k: scroll  Esc: back  ?: help"
+	return "j/k: scroll  Enter: thread  l: like  R: repost  v: show filtered  f: follow/unfollow  d: message  b: block  x: mute  Esc: back  ?: help"
 }
@@ -288,3 +288,202 @@ func TestProfileEscSetsDismissed(t *testing.T) {
 		t.Error("expected dismissed to be true after pressing Esc")
 	}
 }
+
+func TestProfileFollowToggle(t *testing.T) {
+	m := views.NewProfileModel(nil, "u2", false)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgProfileLoaded{
+		User: &models.User{ID: "u2", Username: "other", CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	if !strings.Contains(m.View(), "[f] Follow") {
+		t.Error("expected follow hint on other user's profile")
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if cmd == nil {
+		t.Fatal("expected command after f")
+	}
+
+	m, _ = m.Update(app.MsgFollowToggled{UserID: "u2", Username: "other", Following: true})
+	if !m.Following() {
+		t.Error("expected following after MsgFollowToggled")
+	}
+	if !strings.Contains(m.View(), "[f] Unfollow") {
+		t.Error("expected unfollow hint when following")
+	}
+}
+
+func TestProfileFollowIgnoredOnOwnProfile(t *testing.T) {
+	m := views.NewProfileModel(nil, "", true)
+	m, _ = m.Update(app.MsgProfileLoaded{
+		User: &models.User{ID: "u1", Username: "akram", CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if cmd != nil {
+		t.Error("f should do nothing on own profile")
+	}
+}
+
+func TestProfileBlockAsksFirst(t *testing.T) {
+	m := views.NewProfileModel(nil, "u2", false)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 200, Height: 24})
+	m, _ = m.Update(app.MsgProfileLoaded{
+		User: &models.User{ID: "u2", Username: "other", CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if cmd != nil {
+		t.Fatal("b should ask for confirmation before blocking")
+	}
+	if !strings.Contains(m.View(), "Block @other?") {
+		t.Error("expected block confirmation prompt")
+	}
+
+	// Anything but y cancels
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if cmd != nil || strings.Contains(m.View(), "Block @other?") {
+		t.Error("n should cancel the block")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("y should block")
+	}
+
+	m, _ = m.Update(app.MsgBlockToggled{UserID: "u2", Username: "other", Blocking: true})
+	if !m.Blocking() || !strings.Contains(m.View(), "[b] Unblock") {
+		t.Error("expected blocked state after MsgBlockToggled")
+	}
+
+	// Unblocking needs no confirmation
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}}); cmd == nil {
+		t.Error("b should unblock straight away")
+	}
+}
+
+func TestProfileMuteToggle(t *testing.T) {
+	m := views.NewProfileModel(nil, "u2", false)
+	m, _ = m.Update(app.MsgProfileLoaded{
+		User:   &models.User{ID: "u2", Username: "other"},
+		Muting: true,
+	})
+	if !m.Muting() || !strings.Contains(m.View(), "Muted") {
+		t.Error("expected muted state from MsgProfileLoaded")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd == nil {
+		t.Error("x should unmute")
+	}
+}
+
+func TestProfileBOnOwnProfileOpensBlockedList(t *testing.T) {
+	m := views.NewProfileModel(nil, "", true)
+	m, _ = m.Update(app.MsgProfileLoaded{User: &models.User{ID: "u1", Username: "akram"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	if cmd == nil {
+		t.Fatal("expected command after b")
+	}
+	if _, ok := cmd().(app.MsgOpenBlocked); !ok {
+		t.Error("b on own profile should open the blocked list")
+	}
+}
+
+func TestProfileSOnOwnProfileOpensSessions(t *testing.T) {
+	m := views.NewProfileModel(nil, "", true)
+	m, _ = m.Update(app.MsgProfileLoaded{User: &models.User{ID: "u1", Username: "akram"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	if cmd == nil {
+		t.Fatal("expected command after S")
+	}
+	if _, ok := cmd().(app.MsgOpenSessions); !ok {
+		t.Error("S on own profile should open the sessions list")
+	}
+}
+
+func TestProfileDeleteAccountAsksForPassword(t *testing.T) {
+	m := views.NewProfileModel(nil, "", true)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 200, Height: 24})
+	m, _ = m.Update(app.MsgProfileLoaded{User: &models.User{ID: "u1", Username: "akram"}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if !m.Editing() || !strings.Contains(m.View(), "Delete your account?") {
+		t.Fatal("X should open the delete account dialog")
+	}
+
+	// Enter does nothing until a password is typed
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Enter with no password should not delete")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Editing() || m.Dismissed() {
+		t.Error("Esc should close the dialog without leaving the profile")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("secret")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter should delete the account")
+	}
+	if m.Editing() {
+		t.Error("expected dialog closed after submitting")
+	}
+	if msg, ok := cmd().(app.MsgAPIError); !ok || msg.Message != "no server connection" {
+		t.Errorf("expected no server connection error, got %v", msg)
+	}
+}
+
+func TestProfileDeleteAccountIgnoredOnOtherProfile(t *testing.T) {
+	m := views.NewProfileModel(nil, "u2", false)
+	m, _ = m.Update(app.MsgProfileLoaded{User: &models.User{ID: "u2", Username: "other"}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	if m.Editing() {
+		t.Error("X should do nothing on another user's profile")
+	}
+}
+
+func TestProfileExportDataOnOwnProfile(t *testing.T) {
+	m := views.NewProfileModel(nil, "", true)
+	m, _ = m.Update(app.MsgProfileLoaded{User: &models.User{ID: "u1", Username: "akram"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	if cmd == nil {
+		t.Fatal("E on own profile should start an export")
+	}
+	if msg, ok := cmd().(app.MsgAPIError); !ok || msg.Message != "no server connection" {
+		t.Errorf("expected no server connection error, got %v", msg)
+	}
+
+	other := views.NewProfileModel(nil, "u2", false)
+	other, _ = other.Update(app.MsgProfileLoaded{User: &models.User{ID: "u2", Username: "other"}})
+	if _, cmd := other.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}}); cmd != nil {
+		t.Error("E should do nothing on another user's profile")
+	}
+}
+
+func TestProfileLogoutAsksWhichSessions(t *testing.T) {
+	m := views.NewProfileModel(nil, "", true)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 200, Height: 24})
+	m, _ = m.Update(app.MsgProfileLoaded{User: &models.User{ID: "u1", Username: "akram"}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	if !strings.Contains(m.View(), "Log out?") {
+		t.Fatal("L should ask before logging out")
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if cmd != nil || strings.Contains(m.View(), "Log out?") {
+		t.Error("n should cancel logging out")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'L'}})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("y should log out")
+	}
+	if msg, ok := cmd().(app.MsgAPIError); !ok || msg.Message != "no server connection" {
+		t.Errorf("expected no server connection error, got %v", msg)
+	}
+}
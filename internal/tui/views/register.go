@@ -30,6 +30,13 @@ type RegisterModel struct {
 	client        *client.Client
 	width         int
 	height        int
+
+	// After registering, the user is asked for the code emailed to them.
+	// registered holds the new account until they verify or skip.
+	verifying  bool
+	registered app.MsgRegistered
+	codeInput  textinput.Model
+	notice     string
 }
 
 // NewRegisterModel creates a new register form model.
@@ -52,14 +59,26 @@ func NewRegisterModel(c *client.Client) RegisterModel {
 	password.CharLimit = 128
 	password.Width = 30
 
+	code := textinput.New()
+	code.Placeholder = "paste the code from the email"
+	code.CharLimit = 64
+	code.Width = 30
+
 	return RegisterModel{
 		usernameInput: username,
 		emailInput:    email,
 		passwordInput: password,
+		codeInput:     code,
 		client:        c,
 	}
 }
 
+// Verifying reports whether the view is asking for the emailed
+// verification code.
+func (m RegisterModel) Verifying() bool {
+	return m.verifying
+}
+
 // FocusIndex returns the currently focused field index.
 func (m RegisterModel) FocusIndex() int {
 	return m.focusIndex
@@ -84,7 +103,26 @@ func (m RegisterModel) Update(msg tea.Msg) (RegisterModel, tea.Cmd) {
 		m.errField = msg.Field
 		return m, nil
 
+	case app.MsgRegistered:
+		m.submitting = false
+		m.verifying = true
+		m.registered = msg
+		m.blurAll()
+		return m, m.codeInput.Focus()
+
+	case app.MsgEmailVerified:
+		m.submitting = false
+		return m, m.finish()
+
+	case app.MsgVerificationSent:
+		m.submitting = false
+		m.notice = "A new code is on its way."
+		return m, nil
+
 	case tea.KeyMsg:
+		if m.verifying {
+			return m.updateVerify(msg)
+		}
 		if m.err != "" && msg.Type != tea.KeyEnter {
 			m.err = ""
 			m.errField = ""
@@ -114,6 +152,82 @@ func (m RegisterModel) Update(msg tea.Msg) (RegisterModel, tea.Cmd) {
 	return m, cmd
 }
 
+// updateVerify handles keys on the verification step.
+func (m RegisterModel) updateVerify(msg tea.KeyMsg) (RegisterModel, tea.Cmd) {
+	if m.err != "" && msg.Type != tea.KeyEnter {
+		m.err = ""
+		m.errField = ""
+	}
+	if m.submitting {
+		return m, nil
+	}
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		return m, m.verify()
+
+	case tea.KeyEsc:
+		return m, m.finish()
+
+	case tea.KeyCtrlR:
+		return m, m.resend()
+	}
+
+	var cmd tea.Cmd
+	m.codeInput, cmd = m.codeInput.Update(msg)
+	return m, cmd
+}
+
+// finish signs the new account in, verified or not.
+func (m RegisterModel) finish() tea.Cmd {
+	registered := m.registered
+	return func() tea.Msg {
+		return app.MsgAuthSuccess{User: registered.User, Tokens: registered.Tokens}
+	}
+}
+
+// verify submits the emailed verification code.
+func (m *RegisterModel) verify() tea.Cmd {
+	code := strings.TrimSpace(m.codeInput.Value())
+	if code == "" {
+		m.err = "paste the code from the email"
+		m.errField = "token"
+		return nil
+	}
+
+	m.submitting = true
+	m.notice = ""
+	c := m.client
+
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAuthError{Message: "no server connection"}
+		}
+		if err := c.VerifyEmail(code); err != nil {
+			return app.MsgAuthError{Message: err.Error(), Field: "token"}
+		}
+		return app.MsgEmailVerified{}
+	}
+}
+
+// resend asks the server to email a new code.
+func (m *RegisterModel) resend() tea.Cmd {
+	m.submitting = true
+	m.notice = ""
+	m.codeInput.SetValue("")
+	c := m.client
+
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAuthError{Message: "no server connection"}
+		}
+		if err := c.ResendVerification(); err != nil {
+			return app.MsgAuthError{Message: err.Error()}
+		}
+		return app.MsgVerificationSent{}
+	}
+}
+
 // nextField moves focus to the next field.
 func (m RegisterModel) nextField() (RegisterModel, tea.Cmd) {
 	if m.focusIndex >= 2 {
@@ -199,6 +313,9 @@ func (m *RegisterModel) submit() tea.Cmd {
 		if err != nil {
 			return app.MsgAuthError{Message: err.Error()}
 		}
+		if !resp.EmailVerified {
+			return app.MsgRegistered{User: resp.User, Tokens: resp.Tokens}
+		}
 		return app.MsgAuthSuccess{
 			User:   resp.User,
 			Tokens: resp.Tokens,
@@ -208,6 +325,10 @@ func (m *RegisterModel) submit() tea.Cmd {
 
 // View renders the register form.
 func (m RegisterModel) View() string {
+	if m.verifying {
+		return m.viewVerify()
+	}
+
 	var b strings.Builder
 
 	b.WriteString(formTitleStyle.Render("Register"))
@@ -282,7 +403,49 @@ func (m RegisterModel) View() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form)
 }
 
+// viewVerify renders the "check your inbox" step.
+func (m RegisterModel) viewVerify() string {
+	var b strings.Builder
+
+	b.WriteString(formTitleStyle.Render("Check your inbox"))
+	b.WriteString("\n\n")
+
+	b.WriteString(hintStyle.Render("We sent a code to " + strings.TrimSpace(m.emailInput.Value()) + "."))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Code"))
+	b.WriteString("\n")
+	b.WriteString(m.codeInput.View())
+	b.WriteString("\n")
+	if m.err != "" {
+		b.WriteString(errMsgStyle.Render(m.err))
+		b.WriteString("\n")
+	} else if m.notice != "" {
+		b.WriteString(hintStyle.Render(m.notice))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if m.submitting {
+		b.WriteString(hintStyle.Render("Checking..."))
+	} else {
+		b.WriteString(buttonStyle.Render("[Enter] Verify"))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(hintStyle.Render("[Ctrl+R] Send a new code"))
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("[Esc] Skip for now"))
+
+	form := formBoxStyle.Render(b.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form)
+}
+
 // HelpText returns the status bar help text for the register view.
 func (m RegisterModel) HelpText() string {
+	if m.verifying {
+		return "Enter: verify  Ctrl+R: resend code  Esc: skip"
+	}
 	return "Tab: switch fields/login  Enter: submit  q: quit"
 }
@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/Akram012388/niotebook-tui/internal/models"
 	"github.com/Akram012388/niotebook-tui/internal/tui/app"
 	"github.com/Akram012388/niotebook-tui/internal/tui/views"
 )
@@ -205,3 +206,94 @@ func TestRegisterKeypressClearsError(t *testing.T) {
 		t.Error("error should be cleared after keypress")
 	}
 }
+
+func registeredModel(t *testing.T) views.RegisterModel {
+	t.Helper()
+	m := views.NewRegisterModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgRegistered{User: &models.User{ID: "u1", Username: "akram"}, Tokens: &models.TokenPair{AccessToken: "tok"}})
+	if !m.Verifying() {
+		t.Fatal("expected the verification step after registering")
+	}
+	return m
+}
+
+func TestRegisterVerifyStepRenders(t *testing.T) {
+	m := registeredModel(t)
+	view := m.View()
+	if !strings.Contains(view, "Check your inbox") {
+		t.Error("expected the check your inbox title")
+	}
+	if !strings.Contains(m.HelpText(), "Esc: skip") {
+		t.Errorf("help text = %q, want the verification keys", m.HelpText())
+	}
+}
+
+func TestRegisterVerifyEmptyCode(t *testing.T) {
+	m := registeredModel(t)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("expected nil cmd without a code")
+	}
+	if !strings.Contains(m.View(), "paste the code") {
+		t.Error("expected a missing code error")
+	}
+}
+
+func TestRegisterVerifySubmitsCode(t *testing.T) {
+	m := registeredModel(t)
+	for _, r := range "abc123" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a verify command")
+	}
+	// Without a client the command reports an error
+	msg := cmd()
+	if _, ok := msg.(app.MsgAuthError); !ok {
+		t.Fatalf("msg = %T, want MsgAuthError", msg)
+	}
+
+	m, _ = m.Update(app.MsgAuthError{Message: "invalid or expired verification code", Field: "token"})
+	if !strings.Contains(m.View(), "invalid or expired verification code") {
+		t.Error("expected the verification error in output")
+	}
+	if !m.Verifying() {
+		t.Error("a failed code should keep the verification step open")
+	}
+}
+
+func TestRegisterVerifyFinishes(t *testing.T) {
+	for name, msg := range map[string]tea.Msg{
+		"verified": app.MsgEmailVerified{},
+		"skipped":  tea.KeyMsg{Type: tea.KeyEsc},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := registeredModel(t)
+			_, cmd := m.Update(msg)
+			if cmd == nil {
+				t.Fatal("expected a command")
+			}
+			success, ok := cmd().(app.MsgAuthSuccess)
+			if !ok {
+				t.Fatal("expected MsgAuthSuccess")
+			}
+			if success.User == nil || success.User.ID != "u1" || success.Tokens.AccessToken != "tok" {
+				t.Errorf("success = %+v, want the registered account", success)
+			}
+		})
+	}
+}
+
+func TestRegisterVerificationResent(t *testing.T) {
+	m := registeredModel(t)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if cmd == nil {
+		t.Fatal("expected a resend command")
+	}
+	m, _ = m.Update(app.MsgVerificationSent{})
+	if !strings.Contains(m.View(), "new code is on its way") {
+		t.Error("expected a resent notice")
+	}
+}
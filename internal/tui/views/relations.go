@@ -0,0 +1,47 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+)
+
+// toggleBlock blocks or unblocks user.
+func toggleBlock(c *client.Client, user models.User, block bool) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		var err error
+		if block {
+			err = c.Block(user.ID)
+		} else {
+			err = c.Unblock(user.ID)
+		}
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgBlockToggled{UserID: user.ID, Username: user.Username, Blocking: block}
+	}
+}
+
+// toggleMute mutes or unmutes user.
+func toggleMute(c *client.Client, user models.User, mute bool) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		var err error
+		if mute {
+			err = c.Mute(user.ID)
+		} else {
+			err = c.Unmute(user.ID)
+		}
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgMuteToggled{UserID: user.ID, Username: user.Username, Muting: mute}
+	}
+}
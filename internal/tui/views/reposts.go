@@ -0,0 +1,49 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+)
+
+// toggleRepost reposts or un-reposts post depending on its current state.
+// post should already be resolved to the original with Subject.
+func toggleRepost(c *client.Client, post models.Post) tea.Cmd {
+	postID := post.ID
+	repost := !post.RepostedByMe
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		var count int
+		var err error
+		if repost {
+			count, err = c.Repost(postID)
+		} else {
+			count, err = c.Unrepost(postID)
+		}
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgRepostToggled{PostID: postID, Reposted: repost, RepostCount: count}
+	}
+}
+
+// applyRepost updates the repost state of the matching post, or the original
+// it embeds, in place.
+func applyRepost(post *models.Post, msg app.MsgRepostToggled) {
+	if post.Original != nil {
+		applyRepost(post.Original, msg)
+	}
+	if post.ID == msg.PostID {
+		post.RepostedByMe = msg.Reposted
+		post.RepostCount = msg.RepostCount
+	}
+}
+
+// openQuote asks the app to open the quote composer for post.
+func openQuote(post models.Post) tea.Cmd {
+	return func() tea.Msg { return app.MsgOpenQuote{Post: post} }
+}
@@ -0,0 +1,398 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+var (
+	searchUserStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("6")).
+			Bold(true)
+
+	searchSelectedUserStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("5")).
+				Bold(true)
+)
+
+// SearchModel manages the search view: a query box above a list of matching
+// posts or users.
+type SearchModel struct {
+	input      textinput.Model
+	searchType string
+	query      string
+	posts      []models.Post
+	users      []models.User
+	cursor     int
+	scrollTop  int
+	nextCursor string
+	hasMore    bool
+	loading    bool
+	dismissed  bool
+	client     *client.Client
+	width      int
+	height     int
+}
+
+// NewSearchModel creates a search view with the query box focused.
+func NewSearchModel(c *client.Client) SearchModel {
+	input := textinput.New()
+	input.Placeholder = "Search posts"
+	input.CharLimit = 100
+	input.Width = 40
+	input.Focus()
+
+	return SearchModel{
+		input:      input,
+		searchType: models.SearchTypePosts,
+		client:     c,
+	}
+}
+
+// Init returns the initial command (cursor blink).
+func (m SearchModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// SearchType returns whether posts or users are being searched.
+func (m SearchModel) SearchType() string {
+	return m.searchType
+}
+
+// IsTextInputFocused returns whether the query box has focus.
+func (m SearchModel) IsTextInputFocused() bool {
+	return m.input.Focused()
+}
+
+// Dismissed returns whether the user left the search view.
+func (m SearchModel) Dismissed() bool {
+	return m.dismissed
+}
+
+func (m SearchModel) resultCount() int {
+	if m.searchType == models.SearchTypeUsers {
+		return len(m.users)
+	}
+	return len(m.posts)
+}
+
+// SelectedPost returns the highlighted post result, or nil if none.
+func (m SearchModel) SelectedPost() *models.Post {
+	if m.searchType != models.SearchTypePosts || m.cursor < 0 || m.cursor >= len(m.posts) {
+		return nil
+	}
+	return &m.posts[m.cursor]
+}
+
+func (m SearchModel) search(cursor string) tea.Cmd {
+	c := m.client
+	query := m.query
+	searchType := m.searchType
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		msg := app.MsgSearchResults{Query: query, Type: searchType, Append: cursor != ""}
+		var next *string
+		if searchType == models.SearchTypeUsers {
+			resp, err := c.SearchUsers(query, cursor, 20)
+			if err != nil {
+				return app.MsgAPIError{Message: err.Error()}
+			}
+			msg.Users, msg.HasMore, next = resp.Users, resp.HasMore, resp.NextCursor
+		} else {
+			resp, err := c.SearchPosts(query, cursor, 20)
+			if err != nil {
+				return app.MsgAPIError{Message: err.Error()}
+			}
+			msg.Posts, msg.HasMore, next = resp.Posts, resp.HasMore, resp.NextCursor
+		}
+		if next != nil {
+			msg.NextCursor = *next
+		}
+		return msg
+	}
+}
+
+// toggleType switches between post and user search, re-running the current
+// query if there is one.
+func (m SearchModel) toggleType() (SearchModel, tea.Cmd) {
+	if m.searchType == models.SearchTypePosts {
+		m.searchType = models.SearchTypeUsers
+		m.input.Placeholder = "Search users"
+	} else {
+		m.searchType = models.SearchTypePosts
+		m.input.Placeholder = "Search posts"
+	}
+	m.posts, m.users = nil, nil
+	m.cursor, m.scrollTop = 0, 0
+	if m.query == "" {
+		return m, nil
+	}
+	m.loading = true
+	return m, m.search("")
+}
+
+// Update handles messages for the search view.
+func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgSearchResults:
+		// Drop results for a query or type the user has since moved on from.
+		if msg.Query != m.query || msg.Type != m.searchType {
+			return m, nil
+		}
+		m.loading = false
+		m.nextCursor = msg.NextCursor
+		m.hasMore = msg.HasMore
+		if msg.Append {
+			m.posts = append(m.posts, msg.Posts...)
+			m.users = append(m.users, msg.Users...)
+			return m, nil
+		}
+		m.posts = msg.Posts
+		m.users = msg.Users
+		m.cursor, m.scrollTop = 0, 0
+		return m, nil
+
+	case app.MsgLikeToggled:
+		for i := range m.posts {
+			applyLike(&m.posts[i], msg)
+		}
+		return m, nil
+
+	case app.MsgRepostToggled:
+		for i := range m.posts {
+			applyRepost(&m.posts[i], msg)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.input.Focused() {
+			return m.handleInputKey(msg)
+		}
+		return m.handleKey(msg)
+	}
+
+	if m.input.Focused() {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m SearchModel) handleInputKey(msg tea.KeyMsg) (SearchModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		if m.query == "" {
+			m.dismissed = true
+		} else {
+			m.input.Blur()
+		}
+		return m, nil
+
+	case tea.KeyTab:
+		return m.toggleType()
+
+	case tea.KeyEnter:
+		query := strings.TrimSpace(m.input.Value())
+		if query == "" {
+			return m, nil
+		}
+		m.query = query
+		m.input.Blur()
+		m.loading = true
+		m.posts, m.users = nil, nil
+		m.cursor, m.scrollTop = 0, 0
+		return m, m.search("")
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m SearchModel) handleKey(msg tea.KeyMsg) (SearchModel, tea.Cmd) {
+	count := m.resultCount()
+
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '/':
+		return m, m.input.Focus()
+
+	case msg.Type == tea.KeyTab:
+		return m.toggleType()
+
+	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+		if m.cursor < count-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+			return m, nil
+		}
+		// At the bottom: fetch the next page if there is one.
+		if m.hasMore && !m.loading {
+			m.loading = true
+			return m, m.search(m.nextCursor)
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+		return m, nil
+	}
+
+	if m.cursor >= count {
+		return m, nil
+	}
+
+	if m.searchType == models.SearchTypeUsers {
+		if msg.Type == tea.KeyEnter {
+			userID := m.users[m.cursor].ID
+			return m, func() tea.Msg { return app.MsgOpenProfile{UserID: userID} }
+		}
+		return m, nil
+	}
+
+	post := m.posts[m.cursor]
+	switch {
+	case msg.Type == tea.KeyEnter:
+		postID := post.ID
+		return m, func() tea.Msg { return app.MsgOpenThread{PostID: postID} }
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'l':
+		return m, toggleLike(m.client, post)
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'u':
+		authorID := post.AuthorID
+		return m, func() tea.Msg { return app.MsgOpenProfile{UserID: authorID} }
+	}
+
+	return m, nil
+}
+
+func (m *SearchModel) ensureCursorVisible() {
+	visibleCount := m.visibleCount()
+	if m.cursor < m.scrollTop {
+		m.scrollTop = m.cursor
+	}
+	if m.cursor >= m.scrollTop+visibleCount {
+		m.scrollTop = m.cursor - visibleCount + 1
+	}
+}
+
+func (m SearchModel) visibleCount() int {
+	if m.height <= 0 {
+		return 5
+	}
+	// Users take 3 lines each and post cards ~4, below the 2-line query box
+	perItem := 4
+	if m.searchType == models.SearchTypeUsers {
+		perItem = 3
+	}
+	count := (m.height - 2) / perItem
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// View renders the search view.
+func (m SearchModel) View() string {
+	var b strings.Builder
+	b.WriteString("  / " + m.input.View())
+	b.WriteString("\n")
+
+	var tabs []string
+	for _, t := range []string{models.SearchTypePosts, models.SearchTypeUsers} {
+		label := strings.ToUpper(t[:1]) + t[1:]
+		if t == m.searchType {
+			tabs = append(tabs, feedActiveStyle.Render(label))
+		} else {
+			tabs = append(tabs, feedInactiveStyle.Render(label))
+		}
+	}
+	b.WriteString("  " + strings.Join(tabs, "  "))
+	b.WriteString("\n")
+
+	bodyHeight := m.height - 2
+	count := m.resultCount()
+	switch {
+	case m.loading && count == 0:
+		b.WriteString(lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Searching...")))
+		return b.String()
+	case m.query == "":
+		b.WriteString(lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("Type a query and press Enter. Tab switches between posts and users.")))
+		return b.String()
+	case count == 0:
+		b.WriteString(lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("No results for \""+m.query+"\".")))
+		return b.String()
+	}
+
+	now := time.Now()
+	end := m.scrollTop + m.visibleCount()
+	if end > count {
+		end = count
+	}
+	for i := m.scrollTop; i < end; i++ {
+		selected := i == m.cursor
+		if m.searchType == models.SearchTypeUsers {
+			b.WriteString(renderUserResult(m.users[i], selected))
+		} else {
+			b.WriteString(components.RenderPostCard(m.posts[i], m.width, selected, now))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderUserResult renders a user search result as a handle and display name
+// over their bio.
+func renderUserResult(u models.User, selected bool) string {
+	marker := "  "
+	handle := searchUserStyle.Render("@" + u.Username)
+	if selected {
+		marker = feedActiveStyle.Render("▸") + " "
+		handle = searchSelectedUserStyle.Render("@" + u.Username)
+	}
+	line := marker + handle
+	if u.DisplayName != "" {
+		line += "  " + u.DisplayName
+	}
+	bio := u.Bio
+	if bio == "" {
+		bio = "No bio"
+	}
+	return line + "\n  " + emptyStateStyle.Render(bio) + "\n"
+}
+
+// HelpText returns the status bar help text for the search view.
+func (m SearchModel) HelpText() string {
+	if m.input.Focused() {
+		return "Enter: search  Tab: posts/users  Esc: back"
+	}
+	return "j/k: navigate  Enter: open  l: like  u: author  /: edit query  Tab: posts/users  Esc: back  ?: help"
+}
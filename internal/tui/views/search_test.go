@@ -0,0 +1,126 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func typeQuery(m views.SearchModel, query string) views.SearchModel {
+	for _, r := range query {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestSearchViewSubmitsAndShowsPosts(t *testing.T) {
+	m := views.NewSearchModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	if !m.IsTextInputFocused() {
+		t.Fatal("expected the query box to start focused")
+	}
+
+	// Enter on an empty query does nothing
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected no search for an empty query")
+	}
+
+	m = typeQuery(m, "bubbletea")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a search command after Enter")
+	}
+	if m.IsTextInputFocused() {
+		t.Error("expected the results list to take focus after searching")
+	}
+
+	// Stale results for another query are dropped
+	m, _ = m.Update(app.MsgSearchResults{Query: "other", Type: models.SearchTypePosts, Posts: []models.Post{{ID: "x"}}})
+	if m.SelectedPost() != nil {
+		t.Fatal("expected results for a different query to be ignored")
+	}
+
+	m, _ = m.Update(app.MsgSearchResults{
+		Query: "bubbletea", Type: models.SearchTypePosts, HasMore: true, NextCursor: "2026-01-01T00:00:00Z",
+		Posts: []models.Post{
+			{ID: "p1", Content: "Learning bubbletea", Author: &models.User{Username: "akram"}, CreatedAt: time.Now()},
+		},
+	})
+	if !strings.Contains(m.View(), "Learning bubbletea") {
+		t.Errorf("view missing result:\n%s", m.View())
+	}
+
+	// j at the last result fetches the next page, which is appended
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}); cmd == nil {
+		t.Error("expected j at the end of the results to load more")
+	}
+	m, _ = m.Update(app.MsgSearchResults{
+		Query: "bubbletea", Type: models.SearchTypePosts, Append: true,
+		Posts: []models.Post{{ID: "p2", Content: "More bubbletea", Author: &models.User{Username: "sara"}, CreatedAt: time.Now()}},
+	})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if post := m.SelectedPost(); post == nil || post.ID != "p2" {
+		t.Fatalf("selected = %+v, want p2", post)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(app.MsgOpenThread); !ok || msg.PostID != "p2" {
+		t.Errorf("got %+v, want MsgOpenThread{p2}", msg)
+	}
+}
+
+func TestSearchViewUsers(t *testing.T) {
+	m := views.NewSearchModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.SearchType() != models.SearchTypeUsers {
+		t.Fatalf("type = %q, want users after Tab", m.SearchType())
+	}
+
+	m = typeQuery(m, "sar")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(app.MsgSearchResults{
+		Query: "sar", Type: models.SearchTypeUsers,
+		Users: []models.User{{ID: "u2", Username: "sara", DisplayName: "Sara K", Bio: "Gopher"}},
+	})
+	view := m.View()
+	for _, want := range []string{"@sara", "Sara K", "Gopher"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(app.MsgOpenProfile); !ok || msg.UserID != "u2" {
+		t.Errorf("got %+v, want MsgOpenProfile{u2}", msg)
+	}
+
+	// / returns focus to the query box
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if !m.IsTextInputFocused() {
+		t.Error("expected / to focus the query box")
+	}
+}
+
+func TestSearchViewNoResults(t *testing.T) {
+	m := views.NewSearchModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = typeQuery(m, "zzz")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(app.MsgSearchResults{Query: "zzz", Type: models.SearchTypePosts})
+	if !strings.Contains(m.View(), `No results for "zzz".`) {
+		t.Errorf("expected empty state, got:\n%s", m.View())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("expected Esc to dismiss the search view")
+	}
+}
@@ -0,0 +1,226 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+// SessionsModel lists the devices signed in to the user's account and lets
+// them sign one out.
+type SessionsModel struct {
+	sessions  []models.Session
+	cursor    int
+	scrollTop int
+	loading   bool
+	dismissed bool
+	client    *client.Client
+	width     int
+	height    int
+}
+
+// NewSessionsModel creates a signed-in devices view.
+func NewSessionsModel(c *client.Client) SessionsModel {
+	return SessionsModel{
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch the sessions.
+func (m SessionsModel) Init() tea.Cmd {
+	return m.fetchSessions()
+}
+
+func (m SessionsModel) fetchSessions() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		sessions, err := c.GetSessions()
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgSessionsLoaded{Sessions: sessions}
+	}
+}
+
+func deleteSession(c *client.Client, id string) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		if err := c.DeleteSession(id); err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgSessionDeleted{ID: id}
+	}
+}
+
+// Dismissed returns whether the user left the view.
+func (m SessionsModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// Update handles messages for the sessions view.
+func (m SessionsModel) Update(msg tea.Msg) (SessionsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgSessionsLoaded:
+		m.loading = false
+		m.sessions = msg.Sessions
+		m.cursor, m.scrollTop = 0, 0
+		return m, nil
+
+	case app.MsgSessionDeleted:
+		kept := m.sessions[:0:0]
+		for _, s := range m.sessions {
+			if s.ID != msg.ID {
+				kept = append(kept, s)
+			}
+		}
+		m.sessions = kept
+		if n := len(m.sessions); m.cursor >= n && n > 0 {
+			m.cursor = n - 1
+		}
+		m.ensureCursorVisible()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m SessionsModel) handleKey(msg tea.KeyMsg) (SessionsModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+		m.loading = true
+		return m, m.fetchSessions()
+
+	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+		if m.cursor < len(m.sessions)-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	// x: sign out the selected device. This device is signed out with
+	// logout instead, which also clears the stored tokens.
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'x':
+		if m.cursor >= len(m.sessions) {
+			return m, nil
+		}
+		session := m.sessions[m.cursor]
+		if session.Current {
+			return m, func() tea.Msg {
+				return app.MsgAPIError{Message: "This is the current device; log out from your profile instead"}
+			}
+		}
+		return m, deleteSession(m.client, session.ID)
+	}
+
+	return m, nil
+}
+
+func (m *SessionsModel) ensureCursorVisible() {
+	visibleCount := m.visibleCount()
+	if m.cursor < m.scrollTop {
+		m.scrollTop = m.cursor
+	}
+	if m.cursor >= m.scrollTop+visibleCount {
+		m.scrollTop = m.cursor - visibleCount + 1
+	}
+}
+
+func (m SessionsModel) visibleCount() int {
+	if m.height <= 0 {
+		return 5
+	}
+	// Each session takes 2 lines
+	count := m.height / 2
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// View renders the sessions view.
+func (m SessionsModel) View() string {
+	if m.loading && len(m.sessions) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading sessions..."))
+	}
+	if len(m.sessions) == 0 {
+		return emptyStateStyle.Render("  No devices are signed in.")
+	}
+
+	var b strings.Builder
+	now := time.Now()
+	end := m.scrollTop + m.visibleCount()
+	if end > len(m.sessions) {
+		end = len(m.sessions)
+	}
+	for i := m.scrollTop; i < end; i++ {
+		b.WriteString(renderSession(m.sessions[i], i == m.cursor, now))
+	}
+	return b.String()
+}
+
+// renderSession renders a device's name on one line and where and when it
+// was last used on the next.
+func renderSession(s models.Session, selected bool, now time.Time) string {
+	marker := "  "
+	name := s.DeviceName
+	if name == "" {
+		name = "Unknown device"
+	}
+	title := searchUserStyle.Render(name)
+	if selected {
+		marker = feedActiveStyle.Render("▸") + " "
+		title = searchSelectedUserStyle.Render(name)
+	}
+	line := marker + title
+	if s.Current {
+		line += "  " + feedActiveStyle.Render("(this device)")
+	}
+
+	details := []string{}
+	if s.UserAgent != "" {
+		details = append(details, s.UserAgent)
+	}
+	if s.IP != "" {
+		details = append(details, s.IP)
+	}
+	details = append(details, "active "+components.RelativeTimeFrom(s.LastUsedAt, now))
+	return line + "\n  " + emptyStateStyle.Render(strings.Join(details, " · ")) + "\n"
+}
+
+// HelpText returns the status bar help text for the sessions view.
+func (m SessionsModel) HelpText() string {
+	return "j/k: navigate  x: sign out device  r: refresh  Esc: back  ?: help"
+}
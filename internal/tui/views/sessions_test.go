@@ -0,0 +1,54 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestSessionsViewListsAndSignsOut(t *testing.T) {
+	m := views.NewSessionsModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgSessionsLoaded{Sessions: []models.Session{
+		{ID: "s1", DeviceName: "desktop", Current: true, LastUsedAt: time.Now()},
+		{ID: "s2", DeviceName: "old-laptop", IP: "10.0.0.7", LastUsedAt: time.Now().Add(-3 * time.Hour)},
+	}})
+
+	view := m.View()
+	if !strings.Contains(view, "desktop") || !strings.Contains(view, "(this device)") {
+		t.Errorf("view should mark the current device:\n%s", view)
+	}
+	if !strings.Contains(view, "old-laptop") || !strings.Contains(view, "10.0.0.7") {
+		t.Errorf("view should list the other device and its address:\n%s", view)
+	}
+
+	// The current device is logged out from the profile instead
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected a command after x on the current device")
+	}
+	if _, ok := cmd().(app.MsgAPIError); !ok {
+		t.Error("x on the current device should explain how to log out")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd == nil {
+		t.Error("x should sign out the selected device")
+	}
+
+	m, _ = m.Update(app.MsgSessionDeleted{ID: "s2"})
+	if strings.Contains(m.View(), "old-laptop") {
+		t.Error("signed out device should leave the list")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("Esc should dismiss the view")
+	}
+}
@@ -0,0 +1,269 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+var tagTitleStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("6"))
+
+// openTag opens the timeline of the first hashtag in post, or does nothing
+// when the post has no hashtags.
+func openTag(post models.Post) tea.Cmd {
+	if len(post.Tags) == 0 {
+		return nil
+	}
+	tag := post.Tags[0]
+	return func() tea.Msg { return app.MsgOpenTag{Tag: tag} }
+}
+
+// TagModel manages the timeline of posts carrying a single hashtag.
+type TagModel struct {
+	tag       string
+	posts     []models.Post
+	cursor    int
+	scrollTop int
+	loading   bool
+	dismissed bool
+	client    *client.Client
+	width     int
+	height    int
+}
+
+// NewTagModel creates a tag timeline view for tag.
+func NewTagModel(c *client.Client, tag string) TagModel {
+	return TagModel{
+		tag:     tag,
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch the tag's posts.
+func (m TagModel) Init() tea.Cmd {
+	return m.fetchPosts()
+}
+
+func (m TagModel) fetchPosts() tea.Cmd {
+	c := m.client
+	tag := m.tag
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		resp, err := c.GetTagPosts(tag, "", 20)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		nextCursor := ""
+		if resp.NextCursor != nil {
+			nextCursor = *resp.NextCursor
+		}
+		return app.MsgTagLoaded{
+			Tag:        tag,
+			Posts:      resp.Posts,
+			NextCursor: nextCursor,
+			HasMore:    resp.HasMore,
+		}
+	}
+}
+
+// Tag returns the hashtag shown, without its leading '#'.
+func (m TagModel) Tag() string {
+	return m.tag
+}
+
+// SelectedPost returns the currently highlighted post, or nil if none.
+func (m TagModel) SelectedPost() *models.Post {
+	if m.cursor >= 0 && m.cursor < len(m.posts) {
+		return &m.posts[m.cursor]
+	}
+	return nil
+}
+
+// Dismissed returns whether the user left the tag view.
+func (m TagModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// Update handles messages for the tag view.
+func (m TagModel) Update(msg tea.Msg) (TagModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgTagLoaded:
+		if msg.Tag != m.tag {
+			return m, nil
+		}
+		m.loading = false
+		m.posts = msg.Posts
+		m.cursor = 0
+		m.scrollTop = 0
+		return m, nil
+
+	case app.MsgLikeToggled:
+		for i := range m.posts {
+			applyLike(&m.posts[i], msg)
+		}
+		return m, nil
+
+	case app.MsgRepostToggled:
+		for i := range m.posts {
+			applyRepost(&m.posts[i], msg)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m TagModel) handleKey(msg tea.KeyMsg) (TagModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+		m.loading = true
+		return m, m.fetchPosts()
+
+	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+		if m.cursor < len(m.posts)-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'g':
+		m.cursor = 0
+		m.scrollTop = 0
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'G':
+		if len(m.posts) > 0 {
+			m.cursor = len(m.posts) - 1
+			m.ensureCursorVisible()
+		}
+		return m, nil
+	}
+
+	post := m.SelectedPost()
+	if post == nil {
+		return m, nil
+	}
+
+	switch {
+	case msg.Type == tea.KeyEnter:
+		postID := post.ID
+		return m, func() tea.Msg { return app.MsgOpenThread{PostID: postID} }
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'l':
+		return m, toggleLike(m.client, *post)
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'R':
+		return m, toggleRepost(m.client, *post)
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'Q':
+		return m, openQuote(*post)
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'm':
+		return m, openMention(*post)
+
+	// #: open another tag on the selected post
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '#':
+		for _, tag := range post.Tags {
+			if tag != m.tag {
+				return m, func() tea.Msg { return app.MsgOpenTag{Tag: tag} }
+			}
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'u':
+		authorID := post.AuthorID
+		return m, func() tea.Msg { return app.MsgOpenProfile{UserID: authorID} }
+	}
+
+	return m, nil
+}
+
+func (m *TagModel) ensureCursorVisible() {
+	visibleCount := m.visiblePostCount()
+	if m.cursor < m.scrollTop {
+		m.scrollTop = m.cursor
+	}
+	if m.cursor >= m.scrollTop+visibleCount {
+		m.scrollTop = m.cursor - visibleCount + 1
+	}
+}
+
+func (m TagModel) visiblePostCount() int {
+	if m.height <= 0 {
+		return 5
+	}
+	// ~4 lines per post card, after the one-line title
+	count := (m.height - 1) / 4
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// View renders the tag view.
+func (m TagModel) View() string {
+	title := "  " + tagTitleStyle.Render("#"+m.tag)
+	bodyHeight := m.height - 1
+
+	if m.loading && len(m.posts) == 0 {
+		return title + "\n" + lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading posts..."))
+	}
+
+	if len(m.posts) == 0 {
+		return title + "\n" + lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("No posts with #"+m.tag+" yet."))
+	}
+
+	now := time.Now()
+	var b strings.Builder
+	b.WriteString(title)
+	b.WriteString("\n")
+
+	end := m.scrollTop + m.visiblePostCount()
+	if end > len(m.posts) {
+		end = len(m.posts)
+	}
+	for i := m.scrollTop; i < end; i++ {
+		b.WriteString(components.RenderPostCard(m.posts[i], m.width, i == m.cursor, now))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// HelpText returns the status bar help text for the tag view.
+func (m TagModel) HelpText() string {
+	return "j/k: navigate  Enter: thread  l: like  R: repost  Q: quote  #: other tag  u: author  n: compose  r: refresh  Esc: back  ?: help"
+}
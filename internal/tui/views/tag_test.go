@@ -0,0 +1,70 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestTagViewLoadsAndNavigates(t *testing.T) {
+	m := views.NewTagModel(nil, "go")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if !strings.Contains(m.View(), "Loading") {
+		t.Error("expected loading state before posts arrive")
+	}
+
+	// Posts for another tag are ignored
+	m, _ = m.Update(app.MsgTagLoaded{Tag: "tui", Posts: []models.Post{{ID: "other"}}})
+	if m.SelectedPost() != nil {
+		t.Fatal("expected posts for a different tag to be ignored")
+	}
+
+	m, _ = m.Update(app.MsgTagLoaded{Tag: "go", Posts: []models.Post{
+		{ID: "p1", AuthorID: "u1", Content: "#go rocks", Tags: []string{"go"}, Author: &models.User{Username: "akram"}, CreatedAt: time.Now()},
+		{ID: "p2", AuthorID: "u2", Content: "#go with #tui", Tags: []string{"go", "tui"}, Author: &models.User{Username: "sara"}, CreatedAt: time.Now()},
+	}})
+	view := m.View()
+	for _, want := range []string{"#go", "#go rocks", "@sara"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	// # skips the tag being viewed
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}}); cmd != nil {
+		t.Error("expected no command when the post has no other tag")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	if cmd == nil {
+		t.Fatal("expected command after #")
+	}
+	if msg, ok := cmd().(app.MsgOpenTag); !ok || msg.Tag != "tui" {
+		t.Errorf("got %+v, want MsgOpenTag{tui}", msg)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(app.MsgOpenThread); !ok || msg.PostID != "p2" {
+		t.Errorf("got %+v, want MsgOpenThread{p2}", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("expected Esc to dismiss the tag view")
+	}
+}
+
+func TestTagViewEmptyState(t *testing.T) {
+	m := views.NewTagModel(nil, "quiet")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgTagLoaded{Tag: "quiet"})
+	if !strings.Contains(m.View(), "No posts with #quiet yet.") {
+		t.Errorf("expected empty state, got:\n%s", m.View())
+	}
+}
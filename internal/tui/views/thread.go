@@ -0,0 +1,307 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+// maxThreadIndent caps reply nesting so deep threads stay readable.
+const maxThreadIndent = 6
+
+var threadRuleStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("8"))
+
+// threadEntry is a post in the flattened thread with its nesting depth.
+type threadEntry struct {
+	post  models.Post
+	depth int
+}
+
+// ThreadModel manages the conversation view for a single post.
+type ThreadModel struct {
+	postID    string
+	entries   []threadEntry
+	focus     int
+	cursor    int
+	scrollTop int
+	loading   bool
+	dismissed bool
+	client    *client.Client
+	width     int
+	height    int
+}
+
+// NewThreadModel creates a thread view for the given post.
+func NewThreadModel(c *client.Client, postID string) ThreadModel {
+	return ThreadModel{
+		postID:  postID,
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch the thread.
+func (m ThreadModel) Init() tea.Cmd {
+	return m.fetchThread()
+}
+
+func (m ThreadModel) fetchThread() tea.Cmd {
+	c := m.client
+	postID := m.postID
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		thread, err := c.GetThread(postID)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgThreadLoaded{Thread: thread}
+	}
+}
+
+// SetThread replaces the displayed thread and selects its focal post.
+func (m *ThreadModel) SetThread(thread *models.ThreadResponse) {
+	m.entries = m.entries[:0]
+	for _, p := range thread.Ancestors {
+		m.entries = append(m.entries, threadEntry{post: p})
+	}
+	m.focus = len(m.entries)
+	m.entries = append(m.entries, threadEntry{post: thread.Post})
+
+	// Replies arrive depth-first, so every parent precedes its children.
+	depths := map[string]int{thread.Post.ID: 0}
+	for _, p := range thread.Replies {
+		depth := 1
+		if p.ParentID != nil {
+			depth = depths[*p.ParentID] + 1
+		}
+		depths[p.ID] = depth
+		m.entries = append(m.entries, threadEntry{post: p, depth: depth})
+	}
+
+	m.cursor = m.focus
+	m.scrollTop = 0
+	m.ensureCursorVisible()
+}
+
+// SelectedPost returns the post under the cursor, or nil while loading.
+func (m ThreadModel) SelectedPost() *models.Post {
+	if m.cursor >= 0 && m.cursor < len(m.entries) {
+		return &m.entries[m.cursor].post
+	}
+	return nil
+}
+
+// CursorIndex returns the current cursor position.
+func (m ThreadModel) CursorIndex() int {
+	return m.cursor
+}
+
+// Dismissed returns whether the user left the thread view.
+func (m ThreadModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// Update handles messages for the thread view.
+func (m ThreadModel) Update(msg tea.Msg) (ThreadModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgThreadLoaded:
+		m.loading = false
+		if msg.Thread != nil {
+			m.SetThread(msg.Thread)
+		}
+		return m, nil
+
+	case app.MsgLikeToggled:
+		for i := range m.entries {
+			applyLike(&m.entries[i].post, msg)
+		}
+		return m, nil
+
+	case app.MsgRepostToggled:
+		for i := range m.entries {
+			applyRepost(&m.entries[i].post, msg)
+		}
+		return m, nil
+
+	case app.MsgPostPublished:
+		// A reply was posted from this view; reload to show it in place.
+		return m, m.fetchThread()
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m ThreadModel) handleKey(msg tea.KeyMsg) (ThreadModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+		if m.cursor > 0 {
+			m.cursor--
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'g':
+		m.cursor = 0
+		m.scrollTop = 0
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'G':
+		if len(m.entries) > 0 {
+			m.cursor = len(m.entries) - 1
+			m.ensureCursorVisible()
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyEnter:
+		// Re-root the view on the selected post.
+		if post := m.SelectedPost(); post != nil && m.cursor != m.focus {
+			id := post.ID
+			return m, func() tea.Msg { return app.MsgOpenThread{PostID: id} }
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'l':
+		if post := m.SelectedPost(); post != nil {
+			return m, toggleLike(m.client, *post.Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'R':
+		if post := m.SelectedPost(); post != nil {
+			return m, toggleRepost(m.client, *post.Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'Q':
+		if post := m.SelectedPost(); post != nil {
+			return m, openQuote(*post.Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'm':
+		if post := m.SelectedPost(); post != nil {
+			return m, openMention(*post.Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '#':
+		if post := m.SelectedPost(); post != nil {
+			return m, openTag(*post.Subject())
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'u':
+		if post := m.SelectedPost(); post != nil {
+			authorID := post.AuthorID
+			return m, func() tea.Msg { return app.MsgOpenProfile{UserID: authorID} }
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+		m.loading = true
+		return m, m.fetchThread()
+	}
+
+	return m, nil
+}
+
+func (m *ThreadModel) ensureCursorVisible() {
+	visibleCount := m.visiblePostCount()
+	if m.cursor < m.scrollTop {
+		m.scrollTop = m.cursor
+	}
+	if m.cursor >= m.scrollTop+visibleCount {
+		m.scrollTop = m.cursor - visibleCount + 1
+	}
+}
+
+func (m ThreadModel) visiblePostCount() int {
+	if m.height <= 0 {
+		return 5
+	}
+	// Each post card is ~4 lines
+	count := m.height / 4
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// View renders the thread view.
+func (m ThreadModel) View() string {
+	if m.loading && len(m.entries) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading thread..."))
+	}
+
+	if len(m.entries) == 0 {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("Thread not found."))
+	}
+
+	now := time.Now()
+	var b strings.Builder
+
+	end := m.scrollTop + m.visiblePostCount()
+	if end > len(m.entries) {
+		end = len(m.entries)
+	}
+
+	for i := m.scrollTop; i < end; i++ {
+		entry := m.entries[i]
+		indent := entry.depth
+		if indent > maxThreadIndent {
+			indent = maxThreadIndent
+		}
+		pad := strings.Repeat("  ", indent)
+
+		card := components.RenderPostCard(entry.post, m.width-len(pad), i == m.cursor, now)
+		for _, line := range strings.Split(card, "\n") {
+			b.WriteString(pad)
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+
+		// Mark the boundary between the focal post and its replies.
+		if i == m.focus && i < len(m.entries)-1 {
+			b.WriteString(threadRuleStyle.Render("  Replies"))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// HelpText returns the status bar help text for the thread view.
+func (m ThreadModel) HelpText() string {
+	return "j/k: navigate  n: reply  l: like  R: repost  Q: quote  Enter: open  u: author  m: mentioned user  #: tag  r: refresh  Esc: back  ?: help"
+}
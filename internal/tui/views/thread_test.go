@@ -0,0 +1,114 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func testThread() *models.ThreadResponse {
+	root := "root"
+	focus := "focus"
+	child := "child"
+	return &models.ThreadResponse{
+		Ancestors: []models.Post{
+			{ID: root, Content: "Root post", Author: &models.User{Username: "akram"}, CreatedAt: time.Now()},
+		},
+		Post: models.Post{ID: focus, ParentID: &root, Content: "Focused post", Author: &models.User{Username: "sara"}, CreatedAt: time.Now()},
+		Replies: []models.Post{
+			{ID: child, ParentID: &focus, Content: "Direct reply", Author: &models.User{Username: "omar"}, CreatedAt: time.Now()},
+			{ID: "grandchild", ParentID: &child, Content: "Nested reply", Author: &models.User{Username: "akram"}, CreatedAt: time.Now()},
+		},
+	}
+}
+
+func TestThreadViewRendersAncestorsAndReplies(t *testing.T) {
+	m := views.NewThreadModel(nil, "focus")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	m, _ = m.Update(app.MsgThreadLoaded{Thread: testThread()})
+
+	view := m.View()
+	for _, want := range []string{"Root post", "Focused post", "Direct reply", "Nested reply"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	// The nested reply is indented further than the direct reply.
+	var directIndent, nestedIndent int
+	for _, line := range strings.Split(view, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		switch trimmed {
+		case "Direct reply":
+			directIndent = len(line) - len(trimmed)
+		case "Nested reply":
+			nestedIndent = len(line) - len(trimmed)
+		}
+	}
+	if nestedIndent <= directIndent {
+		t.Errorf("nested indent = %d, direct indent = %d; want nested deeper", nestedIndent, directIndent)
+	}
+}
+
+func TestThreadCursorStartsOnFocusedPost(t *testing.T) {
+	m := views.NewThreadModel(nil, "focus")
+	m, _ = m.Update(app.MsgThreadLoaded{Thread: testThread()})
+
+	if m.CursorIndex() != 1 {
+		t.Errorf("cursor = %d, want 1 (after one ancestor)", m.CursorIndex())
+	}
+	if sel := m.SelectedPost(); sel == nil || sel.ID != "focus" {
+		t.Errorf("selected = %+v, want focus post", sel)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if sel := m.SelectedPost(); sel == nil || sel.ID != "child" {
+		t.Errorf("selected after j = %+v, want child", sel)
+	}
+}
+
+func TestThreadEnterOpensSelectedThread(t *testing.T) {
+	m := views.NewThreadModel(nil, "focus")
+	m, _ = m.Update(app.MsgThreadLoaded{Thread: testThread()})
+
+	// Enter on the focused post does nothing.
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("Enter on focused post should not re-open it")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected command after Enter on ancestor")
+	}
+	msg, ok := cmd().(app.MsgOpenThread)
+	if !ok || msg.PostID != "root" {
+		t.Errorf("got %+v, want MsgOpenThread{root}", msg)
+	}
+}
+
+func TestThreadEscDismisses(t *testing.T) {
+	m := views.NewThreadModel(nil, "focus")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("expected dismissed after Esc")
+	}
+}
+
+func TestThreadInitReturnsCmd(t *testing.T) {
+	m := views.NewThreadModel(nil, "focus")
+	cmd := m.Init()
+	if cmd == nil {
+		t.Fatal("Init should return a fetch command")
+	}
+	if _, ok := cmd().(app.MsgAPIError); !ok {
+		t.Error("expected MsgAPIError with nil client")
+	}
+}
@@ -20,10 +20,41 @@ var (
 
 	loadingStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("3"))
+
+	feedActiveStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("5"))
+
+	feedInactiveStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("8"))
+)
+
+// Feed identifies which timeline the TimelineModel shows.
+type Feed int
+
+const (
+	// FeedGlobal shows posts from everyone.
+	FeedGlobal Feed = iota
+	// FeedHome shows posts from followed users only.
+	FeedHome
+	// FeedMentions shows posts that mention the current user.
+	FeedMentions
 )
 
+// String returns the display name of the feed.
+func (f Feed) String() string {
+	switch f {
+	case FeedHome:
+		return "Home"
+	case FeedMentions:
+		return "Mentions"
+	}
+	return "Global"
+}
+
 // TimelineModel manages the timeline view state.
 type TimelineModel struct {
+	feed       Feed
 	posts      []models.Post
 	cursor     int
 	scrollTop  int
@@ -49,6 +80,11 @@ func (m *TimelineModel) SetPosts(posts []models.Post) {
 	m.scrollTop = 0
 }
 
+// Feed returns the feed currently shown.
+func (m TimelineModel) Feed() Feed {
+	return m.feed
+}
+
 // CursorIndex returns the current cursor position.
 func (m TimelineModel) CursorIndex() int {
 	return m.cursor
@@ -74,11 +110,21 @@ func (m TimelineModel) FetchLatest() tea.Cmd {
 
 func (m TimelineModel) fetchTimeline(cursor string) tea.Cmd {
 	c := m.client
+	feed := m.feed
 	return func() tea.Msg {
 		if c == nil {
 			return app.MsgAPIError{Message: "no server connection"}
 		}
-		resp, err := c.GetTimeline(cursor, 20)
+		var resp *models.TimelineResponse
+		var err error
+		switch feed {
+		case FeedHome:
+			resp, err = c.GetHomeTimeline(cursor, 20)
+		case FeedMentions:
+			resp, err = c.GetMentions(cursor, 20)
+		default:
+			resp, err = c.GetTimeline(cursor, 20)
+		}
 		if err != nil {
 			return app.MsgAPIError{Message: err.Error()}
 		}
@@ -94,7 +140,6 @@ func (m TimelineModel) fetchTimeline(cursor string) tea.Cmd {
 	}
 }
 
-
 // Update handles messages for the timeline view.
 func (m TimelineModel) Update(msg tea.Msg) (TimelineModel, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -121,6 +166,18 @@ func (m TimelineModel) Update(msg tea.Msg) (TimelineModel, tea.Cmd) {
 		m.scrollTop = 0
 		return m, nil
 
+	case app.MsgLikeToggled:
+		for i := range m.posts {
+			applyLike(&m.posts[i], msg)
+		}
+		return m, nil
+
+	case app.MsgRepostToggled:
+		for i := range m.posts {
+			applyRepost(&m.posts[i], msg)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 	}
@@ -129,6 +186,29 @@ func (m TimelineModel) Update(msg tea.Msg) (TimelineModel, tea.Cmd) {
 }
 
 func (m TimelineModel) handleKey(msg tea.KeyMsg) (TimelineModel, tea.Cmd) {
+	// Tab: switch between the home and global feeds
+	if msg.Type == tea.KeyTab {
+		if m.feed == FeedGlobal {
+			m.feed = FeedHome
+		} else {
+			m.feed = FeedGlobal
+		}
+		m.loading = true
+		return m, m.fetchTimeline("")
+	}
+
+	// t: open the trending tags
+	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 't' {
+		return m, func() tea.Msg { return app.MsgOpenTrending{} }
+	}
+
+	// M: show posts that mention the current user
+	if msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'M' && m.feed != FeedMentions {
+		m.feed = FeedMentions
+		m.loading = true
+		return m, m.fetchTimeline("")
+	}
+
 	postCount := len(m.posts)
 	if postCount == 0 {
 		return m, nil
@@ -185,6 +265,69 @@ func (m TimelineModel) handleKey(msg tea.KeyMsg) (TimelineModel, tea.Cmd) {
 			m.cursor = 0
 		}
 		m.scrollTop = m.cursor
+
+	// u: open the selected post author's profile
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'u':
+		post := m.SelectedPost()
+		if post == nil {
+			return m, nil
+		}
+		userID := post.Subject().AuthorID
+		return m, func() tea.Msg { return app.MsgOpenProfile{UserID: userID} }
+
+	// l: like or unlike the selected post
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'l':
+		post := m.SelectedPost()
+		if post == nil {
+			return m, nil
+		}
+		return m, toggleLike(m.client, *post.Subject())
+
+	// v: show the selected post collapsed by a filter
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'v':
+		if post := m.SelectedPost(); post != nil {
+			post.Filtered = ""
+		}
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'R':
+		post := m.SelectedPost()
+		if post == nil {
+			return m, nil
+		}
+		return m, toggleRepost(m.client, *post.Subject())
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'Q':
+		post := m.SelectedPost()
+		if post == nil {
+			return m, nil
+		}
+		return m, openQuote(*post.Subject())
+
+	// m: open the profile of the first user mentioned in the selected post
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'm':
+		post := m.SelectedPost()
+		if post == nil {
+			return m, nil
+		}
+		return m, openMention(*post.Subject())
+
+	// #: open the timeline of the first hashtag in the selected post
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == '#':
+		post := m.SelectedPost()
+		if post == nil {
+			return m, nil
+		}
+		return m, openTag(*post.Subject())
+
+	// Enter: open the selected post's conversation
+	case msg.Type == tea.KeyEnter:
+		post := m.SelectedPost()
+		if post == nil {
+			return m, nil
+		}
+		postID := post.Subject().ID
+		return m, func() tea.Msg { return app.MsgOpenThread{PostID: postID} }
 	}
 
 	return m, nil
@@ -194,8 +337,9 @@ func (m TimelineModel) visiblePostCount() int {
 	if m.height <= 0 {
 		return 5
 	}
-	// Estimate ~4 lines per post card (header + content + separator + spacing)
-	count := m.height / 4
+	// Estimate ~4 lines per post card (header + content + separator + spacing),
+	// after the one-line feed switcher
+	count := (m.height - 1) / 4
 	if count < 1 {
 		count = 1
 	}
@@ -204,18 +348,30 @@ func (m TimelineModel) visiblePostCount() int {
 
 // View renders the timeline view.
 func (m TimelineModel) View() string {
+	tabs := m.renderFeedTabs()
+	bodyHeight := m.height - 1
+
 	if m.loading {
-		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+		return tabs + "\n" + lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
 			loadingStyle.Render("Loading timeline..."))
 	}
 
 	if len(m.posts) == 0 {
-		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-			emptyStateStyle.Render("No posts yet. Press n to compose one!"))
+		empty := "No posts yet. Press n to compose one!"
+		switch m.feed {
+		case FeedHome:
+			empty = "Your home feed is empty. Follow people from their profile with f."
+		case FeedMentions:
+			empty = "No one has mentioned you yet."
+		}
+		return tabs + "\n" + lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render(empty))
 	}
 
 	now := time.Now()
 	var b strings.Builder
+	b.WriteString(tabs)
+	b.WriteString("\n")
 
 	visibleCount := m.visiblePostCount()
 	end := m.scrollTop + visibleCount
@@ -233,7 +389,20 @@ func (m TimelineModel) View() string {
 	return b.String()
 }
 
+// renderFeedTabs renders the feed switcher with the active feed highlighted.
+func (m TimelineModel) renderFeedTabs() string {
+	var parts []string
+	for _, f := range []Feed{FeedHome, FeedGlobal, FeedMentions} {
+		if f == m.feed {
+			parts = append(parts, feedActiveStyle.Render(f.String()))
+		} else {
+			parts = append(parts, feedInactiveStyle.Render(f.String()))
+		}
+	}
+	return "  " + strings.Join(parts, "  ")
+}
+
 // HelpText returns the status bar help text for the timeline view.
 func (m TimelineModel) HelpText() string {
-	return "j/k: navigate  n: compose  r: refresh  ?: help  q: quit"
+	return "j/k: navigate  Enter: thread  l: like  R: repost  Q: quote  v: show filtered  Tab: home/global  M: mentions  t: trending  /: search  N: notifications  D: messages  m: mentioned user  #: tag  u: author  n: compose  r: refresh  ?: help  q: quit"
 }
@@ -235,3 +235,203 @@ func TestTimelineSpaceAndBPagination(t *testing.T) {
 		t.Errorf("cursor = %d after b, want 0", m.CursorIndex())
 	}
 }
+
+func TestTimelineTabTogglesFeed(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if m.Feed() != views.FeedGlobal {
+		t.Fatalf("initial feed = %v, want global", m.Feed())
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Feed() != views.FeedHome {
+		t.Errorf("feed = %v after Tab, want home", m.Feed())
+	}
+	if cmd == nil {
+		t.Error("expected fetch command after switching feed")
+	}
+
+	m, _ = m.Update(app.MsgTimelineLoaded{})
+	if !strings.Contains(m.View(), "home feed is empty") {
+		t.Error("expected home feed empty state")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Feed() != views.FeedGlobal {
+		t.Errorf("feed = %v after second Tab, want global", m.Feed())
+	}
+}
+
+func TestTimelineUOpensAuthorProfile(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m.SetPosts([]models.Post{{ID: "1", AuthorID: "author-1", Content: "Hi"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if cmd == nil {
+		t.Fatal("expected command after u")
+	}
+	msg, ok := cmd().(app.MsgOpenProfile)
+	if !ok {
+		t.Fatalf("expected MsgOpenProfile, got %T", cmd())
+	}
+	if msg.UserID != "author-1" {
+		t.Errorf("UserID = %q, want %q", msg.UserID, "author-1")
+	}
+}
+
+func TestTimelineEnterOpensThread(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m.SetPosts([]models.Post{{ID: "post-1", Content: "Hi"}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected command after Enter")
+	}
+	msg, ok := cmd().(app.MsgOpenThread)
+	if !ok || msg.PostID != "post-1" {
+		t.Errorf("got %+v, want MsgOpenThread{post-1}", msg)
+	}
+}
+
+func TestTimelineLikeToggle(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m.SetPosts([]models.Post{{ID: "post-1", Content: "Hi", Author: &models.User{Username: "sara"}}})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	if cmd == nil {
+		t.Fatal("expected command after l")
+	}
+
+	m, _ = m.Update(app.MsgLikeToggled{PostID: "post-1", Liked: true, LikeCount: 4})
+	post := m.SelectedPost()
+	if !post.LikedByMe || post.LikeCount != 4 {
+		t.Errorf("post = %+v, want liked with count 4", post)
+	}
+	if !strings.Contains(m.View(), "♥ 4") {
+		t.Error("expected updated like count in view")
+	}
+}
+
+func TestTimelineRepostTargetsOriginal(t *testing.T) {
+	original := models.Post{ID: "post-1", Content: "Hi", Author: &models.User{Username: "sara"}}
+	m := views.NewTimelineModel(nil)
+	m.SetPosts([]models.Post{{
+		ID: "post-2", Kind: models.PostKindRepost, Original: &original,
+		Author: &models.User{Username: "omar"},
+	}})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Q'}})
+	if cmd == nil {
+		t.Fatal("expected command after Q")
+	}
+	if msg, ok := cmd().(app.MsgOpenQuote); !ok || msg.Post.ID != "post-1" {
+		t.Errorf("got %+v, want MsgOpenQuote for post-1", msg)
+	}
+
+	m, _ = m.Update(app.MsgRepostToggled{PostID: "post-1", Reposted: true, RepostCount: 3})
+	post := m.SelectedPost()
+	if !post.Original.RepostedByMe || post.Original.RepostCount != 3 {
+		t.Errorf("original = %+v, want reposted with count 3", post.Original)
+	}
+	if !strings.Contains(m.View(), "⟲ 3") {
+		t.Error("expected updated repost count in view")
+	}
+}
+
+func TestTimelineMentionsFeed(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	if m.Feed() != views.FeedMentions {
+		t.Errorf("feed = %v after M, want mentions", m.Feed())
+	}
+	if cmd == nil {
+		t.Error("expected fetch command after switching feed")
+	}
+
+	m, _ = m.Update(app.MsgTimelineLoaded{})
+	if !strings.Contains(m.View(), "No one has mentioned you yet") {
+		t.Error("expected mentions empty state")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if m.Feed() != views.FeedGlobal {
+		t.Errorf("feed = %v after Tab from mentions, want global", m.Feed())
+	}
+}
+
+func TestTimelineMOpensMentionedProfile(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m.SetPosts([]models.Post{
+		{ID: "post-1", Content: "hi @sara", Mentions: []models.Mention{{UserID: "u2", Username: "sara"}}},
+		{ID: "post-2", Content: "no mentions"},
+	})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	if cmd == nil {
+		t.Fatal("expected command after m")
+	}
+	if msg, ok := cmd().(app.MsgOpenProfile); !ok || msg.UserID != "u2" {
+		t.Errorf("got %+v, want MsgOpenProfile{u2}", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}}); cmd != nil {
+		t.Error("expected no command for a post without mentions")
+	}
+}
+
+func TestTimelineHashOpensTag(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m.SetPosts([]models.Post{
+		{ID: "post-1", Content: "#go and #tui", Tags: []string{"go", "tui"}},
+		{ID: "post-2", Content: "no tags"},
+	})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}})
+	if cmd == nil {
+		t.Fatal("expected command after #")
+	}
+	if msg, ok := cmd().(app.MsgOpenTag); !ok || msg.Tag != "go" {
+		t.Errorf("got %+v, want MsgOpenTag{go}", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'#'}}); cmd != nil {
+		t.Error("expected no command for a post without tags")
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if cmd == nil {
+		t.Fatal("expected command after t")
+	}
+	if _, ok := cmd().(app.MsgOpenTrending); !ok {
+		t.Error("expected t to open trending tags")
+	}
+}
+
+func TestTimelineVRevealsFilteredPost(t *testing.T) {
+	m := views.NewTimelineModel(nil)
+	m.SetPosts([]models.Post{{
+		ID:        "1",
+		Author:    &models.User{Username: "sara"},
+		Content:   "the ending was a twist",
+		Filtered:  "ending",
+		CreatedAt: time.Now(),
+	}})
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	if view := m.View(); strings.Contains(view, "twist") || !strings.Contains(view, "Filtered") {
+		t.Fatalf("expected filtered post to render collapsed, got:\n%s", view)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if view := m.View(); !strings.Contains(view, "twist") {
+		t.Errorf("expected v to reveal the post, got:\n%s", view)
+	}
+}
@@ -0,0 +1,146 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+)
+
+var (
+	trendingSelectedStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("6"))
+
+	trendingCountStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("8"))
+)
+
+// TrendingModel manages the list of hashtags trending over the last day.
+type TrendingModel struct {
+	tags      []models.TrendingTag
+	cursor    int
+	loading   bool
+	dismissed bool
+	client    *client.Client
+	width     int
+	height    int
+}
+
+// NewTrendingModel creates a trending tags view.
+func NewTrendingModel(c *client.Client) TrendingModel {
+	return TrendingModel{
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch the trending tags.
+func (m TrendingModel) Init() tea.Cmd {
+	return m.fetchTrending()
+}
+
+func (m TrendingModel) fetchTrending() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		tags, err := c.GetTrendingTags(20)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgTrendingLoaded{Tags: tags}
+	}
+}
+
+// Dismissed returns whether the user left the trending view.
+func (m TrendingModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// Update handles messages for the trending view.
+func (m TrendingModel) Update(msg tea.Msg) (TrendingModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgTrendingLoaded:
+		m.loading = false
+		m.tags = msg.Tags
+		m.cursor = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case msg.Type == tea.KeyEsc:
+			m.dismissed = true
+		case msg.Type == tea.KeyDown || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'j'):
+			if m.cursor < len(m.tags)-1 {
+				m.cursor++
+			}
+		case msg.Type == tea.KeyUp || (msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'k'):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+			m.loading = true
+			return m, m.fetchTrending()
+		case msg.Type == tea.KeyEnter:
+			if m.cursor < len(m.tags) {
+				tag := m.tags[m.cursor].Tag
+				return m, func() tea.Msg { return app.MsgOpenTag{Tag: tag} }
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the trending view.
+func (m TrendingModel) View() string {
+	title := "  " + tagTitleStyle.Render("Trending today")
+	bodyHeight := m.height - 1
+
+	if m.loading && len(m.tags) == 0 {
+		return title + "\n" + lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading trending tags..."))
+	}
+
+	if len(m.tags) == 0 {
+		return title + "\n" + lipgloss.Place(m.width, bodyHeight, lipgloss.Center, lipgloss.Center,
+			emptyStateStyle.Render("Nothing is trending yet. Tag a post with #something!"))
+	}
+
+	var b strings.Builder
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	for i, tag := range m.tags {
+		noun := "posts"
+		if tag.PostCount == 1 {
+			noun = "post"
+		}
+		name := fmt.Sprintf("%2d. #%s", i+1, tag.Tag)
+		if i == m.cursor {
+			name = trendingSelectedStyle.Render("> " + name)
+		} else {
+			name = "  " + name
+		}
+		b.WriteString("  " + name + "  " + trendingCountStyle.Render(fmt.Sprintf("%d %s", tag.PostCount, noun)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// HelpText returns the status bar help text for the trending view.
+func (m TrendingModel) HelpText() string {
+	return "j/k: navigate  Enter: open tag  r: refresh  Esc: back  ?: help"
+}
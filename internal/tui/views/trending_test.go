@@ -0,0 +1,51 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestTrendingViewOpensSelectedTag(t *testing.T) {
+	m := views.NewTrendingModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgTrendingLoaded{Tags: []models.TrendingTag{
+		{Tag: "go", PostCount: 12},
+		{Tag: "tui", PostCount: 1},
+	}})
+
+	view := m.View()
+	for _, want := range []string{"#go", "12 posts", "#tui", "1 post"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected command after Enter")
+	}
+	if msg, ok := cmd().(app.MsgOpenTag); !ok || msg.Tag != "tui" {
+		t.Errorf("got %+v, want MsgOpenTag{tui}", msg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("expected Esc to dismiss the trending view")
+	}
+}
+
+func TestTrendingViewEmptyState(t *testing.T) {
+	m := views.NewTrendingModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m, _ = m.Update(app.MsgTrendingLoaded{})
+	if !strings.Contains(m.View(), "Nothing is trending yet") {
+		t.Errorf("expected empty state, got:\n%s", m.View())
+	}
+}
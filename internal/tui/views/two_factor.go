@@ -0,0 +1,383 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/client"
+	"github.com/Akram012388/niotebook-tui/internal/tui/components"
+)
+
+// twoFactorStage is the step of the two-factor view being shown.
+type twoFactorStage int
+
+const (
+	twoFactorOverview twoFactorStage = iota
+	// twoFactorSetup shows the QR code and asks for a code from the app.
+	twoFactorSetup
+	// twoFactorRecovery shows the recovery codes once, after enabling.
+	twoFactorRecovery
+	// twoFactorDisable asks for the password and a code to turn it off.
+	twoFactorDisable
+)
+
+// TwoFactorModel lets the user turn TOTP two-factor authentication on with
+// an authenticator app, and off again.
+type TwoFactorModel struct {
+	status        *models.TwoFactorStatus
+	stage         twoFactorStage
+	setup         *models.TwoFactorSetup
+	qr            string
+	recoveryCodes []string
+	codeInput     textinput.Model
+	passwordInput textinput.Model
+	// focusIndex is the focused field when disabling: 0 password, 1 code.
+	focusIndex int
+	loading    bool
+	dismissed  bool
+	client     *client.Client
+	width      int
+	height     int
+}
+
+// NewTwoFactorModel creates a two-factor authentication settings view.
+func NewTwoFactorModel(c *client.Client) TwoFactorModel {
+	return TwoFactorModel{
+		client:  c,
+		loading: true,
+	}
+}
+
+// Init returns the initial command to fetch the current status.
+func (m TwoFactorModel) Init() tea.Cmd {
+	return m.fetchStatus()
+}
+
+func (m TwoFactorModel) fetchStatus() tea.Cmd {
+	c := m.client
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		status, err := c.TwoFactorStatus()
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgTwoFactorStatusLoaded{Status: *status}
+	}
+}
+
+func setupTwoFactor(c *client.Client) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		setup, err := c.SetupTwoFactor()
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgTwoFactorSetup{Setup: *setup}
+	}
+}
+
+func enableTwoFactor(c *client.Client, code string) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		codes, err := c.EnableTwoFactor(code)
+		if err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgTwoFactorEnabled{RecoveryCodes: codes}
+	}
+}
+
+func disableTwoFactor(c *client.Client, password, code string) tea.Cmd {
+	return func() tea.Msg {
+		if c == nil {
+			return app.MsgAPIError{Message: "no server connection"}
+		}
+		if err := c.DisableTwoFactor(password, code); err != nil {
+			return app.MsgAPIError{Message: err.Error()}
+		}
+		return app.MsgTwoFactorDisabled{}
+	}
+}
+
+// Dismissed returns whether the user left the view.
+func (m TwoFactorModel) Dismissed() bool {
+	return m.dismissed
+}
+
+// IsTextInputFocused reports whether a code or password is being typed.
+func (m TwoFactorModel) IsTextInputFocused() bool {
+	return m.stage == twoFactorSetup || m.stage == twoFactorDisable
+}
+
+// Update handles messages for the two-factor view.
+func (m TwoFactorModel) Update(msg tea.Msg) (TwoFactorModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case app.MsgTwoFactorStatusLoaded:
+		m.loading = false
+		status := msg.Status
+		m.status = &status
+		return m, nil
+
+	case app.MsgTwoFactorSetup:
+		setup := msg.Setup
+		m.setup = &setup
+		// Without a QR code the key can still be typed in by hand
+		m.qr, _ = components.RenderQRCode(setup.URI)
+		m.stage = twoFactorSetup
+		m.codeInput = newTwoFactorCodeInput()
+		return m, m.codeInput.Focus()
+
+	case app.MsgTwoFactorEnabled:
+		m.stage = twoFactorRecovery
+		m.setup, m.qr = nil, ""
+		m.recoveryCodes = msg.RecoveryCodes
+		m.status = &models.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: len(msg.RecoveryCodes)}
+		return m, nil
+
+	case app.MsgTwoFactorDisabled:
+		m.stage = twoFactorOverview
+		m.status = &models.TwoFactorStatus{}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.stage {
+		case twoFactorSetup:
+			return m.handleSetupKey(msg)
+		case twoFactorRecovery:
+			return m.handleRecoveryKey(msg)
+		case twoFactorDisable:
+			return m.handleDisableKey(msg)
+		}
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func newTwoFactorCodeInput() textinput.Model {
+	code := textinput.New()
+	code.Placeholder = "123456"
+	code.CharLimit = 32
+	code.Width = 30
+	return code
+}
+
+func (m TwoFactorModel) handleKey(msg tea.KeyMsg) (TwoFactorModel, tea.Cmd) {
+	switch {
+	case msg.Type == tea.KeyEsc:
+		m.dismissed = true
+		return m, nil
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'r':
+		m.loading = true
+		return m, m.fetchStatus()
+
+	// s: set up two-factor authentication with a new secret
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 's':
+		if m.status == nil || m.status.Enabled {
+			return m, nil
+		}
+		return m, setupTwoFactor(m.client)
+
+	// d: turn two-factor authentication off, after confirming the password
+	// and a code
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] == 'd':
+		if m.status == nil || !m.status.Enabled {
+			return m, nil
+		}
+		m.passwordInput = textinput.New()
+		m.passwordInput.Placeholder = "password"
+		m.passwordInput.EchoMode = textinput.EchoPassword
+		m.passwordInput.EchoCharacter = '●'
+		m.passwordInput.CharLimit = 128
+		m.passwordInput.Width = 30
+		m.codeInput = newTwoFactorCodeInput()
+		m.codeInput.Placeholder = "123456 or a recovery code"
+		m.focusIndex = 0
+		m.stage = twoFactorDisable
+		return m, m.passwordInput.Focus()
+	}
+
+	return m, nil
+}
+
+// handleSetupKey handles keys while enrolling: Enter submits the code from
+// the app and Esc cancels. A cancelled setup leaves sign in unchanged.
+func (m TwoFactorModel) handleSetupKey(msg tea.KeyMsg) (TwoFactorModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.stage = twoFactorOverview
+		m.setup, m.qr = nil, ""
+		return m, nil
+	case tea.KeyEnter:
+		code := strings.TrimSpace(m.codeInput.Value())
+		if code == "" {
+			return m, nil
+		}
+		m.codeInput.SetValue("")
+		return m, enableTwoFactor(m.client, code)
+	}
+
+	var cmd tea.Cmd
+	m.codeInput, cmd = m.codeInput.Update(msg)
+	return m, cmd
+}
+
+// handleRecoveryKey returns to the overview once the user has saved their
+// recovery codes, which aren't shown again.
+func (m TwoFactorModel) handleRecoveryKey(msg tea.KeyMsg) (TwoFactorModel, tea.Cmd) {
+	if msg.Type == tea.KeyEnter || msg.Type == tea.KeyEsc {
+		m.stage = twoFactorOverview
+		m.recoveryCodes = nil
+	}
+	return m, nil
+}
+
+// handleDisableKey handles keys while turning two-factor authentication
+// off: Tab switches fields, Enter submits and Esc cancels.
+func (m TwoFactorModel) handleDisableKey(msg tea.KeyMsg) (TwoFactorModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.stage = twoFactorOverview
+		return m, nil
+	case tea.KeyTab, tea.KeyShiftTab:
+		if m.focusIndex == 0 {
+			m.focusIndex = 1
+			m.passwordInput.Blur()
+			return m, m.codeInput.Focus()
+		}
+		m.focusIndex = 0
+		m.codeInput.Blur()
+		return m, m.passwordInput.Focus()
+	case tea.KeyEnter:
+		password := m.passwordInput.Value()
+		code := strings.TrimSpace(m.codeInput.Value())
+		if password == "" || code == "" {
+			return m, nil
+		}
+		m.codeInput.SetValue("")
+		return m, disableTwoFactor(m.client, password, code)
+	}
+
+	var cmd tea.Cmd
+	if m.focusIndex == 0 {
+		m.passwordInput, cmd = m.passwordInput.Update(msg)
+	} else {
+		m.codeInput, cmd = m.codeInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// View renders the two-factor view.
+func (m TwoFactorModel) View() string {
+	switch m.stage {
+	case twoFactorSetup:
+		return m.viewSetup()
+	case twoFactorRecovery:
+		return m.viewRecovery()
+	case twoFactorDisable:
+		return m.viewDisable()
+	}
+
+	if m.loading && m.status == nil {
+		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
+			loadingStyle.Render("Loading two-factor authentication..."))
+	}
+	if m.status == nil {
+		return emptyStateStyle.Render("  Couldn't load two-factor authentication. Press r to retry.")
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	if m.status.Enabled {
+		b.WriteString("  " + labelStyle.Render("Two-factor authentication is ") + feedActiveStyle.Render("on"))
+		b.WriteString("\n")
+		b.WriteString("  " + emptyStateStyle.Render(fmt.Sprintf("%d recovery codes left", m.status.RecoveryCodesLeft)))
+		b.WriteString("\n\n")
+		b.WriteString("  " + hintStyle.Render("[d] Turn off"))
+	} else {
+		b.WriteString("  " + labelStyle.Render("Two-factor authentication is off"))
+		b.WriteString("\n")
+		b.WriteString("  " + emptyStateStyle.Render("Signing in will also ask for a code from an authenticator app."))
+		b.WriteString("\n\n")
+		b.WriteString("  " + hintStyle.Render("[s] Set up"))
+	}
+	return b.String()
+}
+
+func (m TwoFactorModel) viewSetup() string {
+	var b strings.Builder
+	if m.qr != "" {
+		b.WriteString(m.qr)
+		b.WriteString("\n\n")
+	}
+	b.WriteString(labelStyle.Render("Scan the code with your authenticator app, or enter this key:"))
+	b.WriteString("\n")
+	if m.setup != nil {
+		b.WriteString(feedActiveStyle.Render(m.setup.Secret))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(labelStyle.Render("Code from the app: ") + m.codeInput.View())
+	b.WriteString("\n")
+	b.WriteString(hintStyle.Render("Enter: turn on  Esc: cancel"))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, b.String())
+}
+
+func (m TwoFactorModel) viewRecovery() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString("  " + labelStyle.Render("Two-factor authentication is on. Save these recovery codes somewhere safe:"))
+	b.WriteString("\n")
+	b.WriteString("  " + emptyStateStyle.Render("each one signs you in once if you lose your device. They won't be shown again."))
+	b.WriteString("\n\n")
+	for _, code := range m.recoveryCodes {
+		b.WriteString("    " + feedActiveStyle.Render(code))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("  " + hintStyle.Render("Enter: done"))
+	return b.String()
+}
+
+func (m TwoFactorModel) viewDisable() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString("  " + counterWarningStyle.Render("Turn off two-factor authentication? Signing in will only need your password."))
+	b.WriteString("\n\n")
+	b.WriteString("  " + labelStyle.Render("Password: ") + m.passwordInput.View())
+	b.WriteString("\n")
+	b.WriteString("  " + labelStyle.Render("Code:     ") + m.codeInput.View())
+	b.WriteString("\n\n")
+	b.WriteString("  " + hintStyle.Render("Tab: switch field  Enter: turn off  Esc: cancel"))
+	return b.String()
+}
+
+// HelpText returns the status bar help text for the two-factor view.
+func (m TwoFactorModel) HelpText() string {
+	switch m.stage {
+	case twoFactorSetup:
+		return "Enter: turn on  Esc: cancel"
+	case twoFactorRecovery:
+		return "Enter: done"
+	case twoFactorDisable:
+		return "Tab: switch field  Enter: turn off  Esc: cancel"
+	}
+	return "s: set up  d: turn off  r: refresh  Esc: back  ?: help"
+}
@@ -0,0 +1,87 @@
+package views_test
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Akram012388/niotebook-tui/internal/models"
+	"github.com/Akram012388/niotebook-tui/internal/tui/app"
+	"github.com/Akram012388/niotebook-tui/internal/tui/views"
+)
+
+func TestTwoFactorViewEnrolls(t *testing.T) {
+	m := views.NewTwoFactorModel(nil)
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m, _ = m.Update(app.MsgTwoFactorStatusLoaded{Status: models.TwoFactorStatus{}})
+	if !strings.Contains(m.View(), "is off") {
+		t.Errorf("view should say two-factor authentication is off:\n%s", m.View())
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}}); cmd == nil {
+		t.Error("s should start setting up")
+	}
+
+	m, _ = m.Update(app.MsgTwoFactorSetup{Setup: models.TwoFactorSetup{
+		Secret: "JBSWY3DPEHPK3PXP",
+		URI:    "otpauth://totp/niotebook:akram?secret=JBSWY3DPEHPK3PXP",
+	}})
+	view := m.View()
+	if !strings.Contains(view, "JBSWY3DPEHPK3PXP") || !strings.Contains(view, "█") {
+		t.Errorf("setup should show the QR code and the key:\n%s", view)
+	}
+	if !m.IsTextInputFocused() {
+		t.Error("setup should focus the code input")
+	}
+
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Enter without a code should do nothing")
+	}
+	for _, r := range "123456" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Error("Enter should submit the code")
+	}
+
+	m, _ = m.Update(app.MsgTwoFactorEnabled{RecoveryCodes: []string{"aaaaa-bbbbb", "ccccc-ddddd"}})
+	if view := m.View(); !strings.Contains(view, "aaaaa-bbbbb") || !strings.Contains(view, "ccccc-ddddd") {
+		t.Errorf("view should show the recovery codes:\n%s", view)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.View(); !strings.Contains(view, "is on") || !strings.Contains(view, "2 recovery codes left") {
+		t.Errorf("view should say two-factor authentication is on:\n%s", view)
+	}
+}
+
+func TestTwoFactorViewDisables(t *testing.T) {
+	m := views.NewTwoFactorModel(nil)
+	m, _ = m.Update(app.MsgTwoFactorStatusLoaded{Status: models.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: 10}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if !strings.Contains(m.View(), "Turn off two-factor authentication?") {
+		t.Fatal("d should ask for the password and a code")
+	}
+	for _, r := range "password123" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Enter without a code should do nothing")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	for _, r := range "123456" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Error("Enter should turn two-factor authentication off")
+	}
+
+	m, _ = m.Update(app.MsgTwoFactorDisabled{})
+	if !strings.Contains(m.View(), "is off") {
+		t.Errorf("view should say two-factor authentication is off:\n%s", m.View())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.Dismissed() {
+		t.Error("Esc should dismiss the view")
+	}
+}
@@ -0,0 +1,10 @@
+CREATE TABLE follows (
+    follower_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CONSTRAINT follows_no_self_follow CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX idx_follows_follower_created ON follows (follower_id, created_at DESC);
+CREATE INDEX idx_follows_followee_created ON follows (followee_id, created_at DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_posts_root_created;
+DROP INDEX IF EXISTS idx_posts_parent_created;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS root_id,
+    DROP COLUMN IF EXISTS parent_id;
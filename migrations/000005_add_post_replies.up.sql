@@ -0,0 +1,6 @@
+ALTER TABLE posts
+    ADD COLUMN parent_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+    ADD COLUMN root_id   UUID REFERENCES posts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_posts_parent_created ON posts (parent_id, created_at);
+CREATE INDEX idx_posts_root_created ON posts (root_id, created_at);
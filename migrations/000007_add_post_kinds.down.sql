@@ -0,0 +1,12 @@
+DELETE FROM posts WHERE kind = 'repost';
+
+DROP INDEX IF EXISTS idx_posts_unique_repost;
+DROP INDEX IF EXISTS idx_posts_original_created;
+
+ALTER TABLE posts DROP CONSTRAINT posts_content_not_empty;
+ALTER TABLE posts ADD CONSTRAINT posts_content_not_empty CHECK (char_length(TRIM(content)) > 0);
+
+ALTER TABLE posts
+    DROP CONSTRAINT IF EXISTS posts_kind_valid,
+    DROP COLUMN IF EXISTS original_id,
+    DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,14 @@
+ALTER TABLE posts
+    ADD COLUMN kind        TEXT NOT NULL DEFAULT 'post',
+    ADD COLUMN original_id UUID REFERENCES posts(id) ON DELETE SET NULL,
+    ADD CONSTRAINT posts_kind_valid CHECK (kind IN ('post', 'repost', 'quote'));
+
+-- Reposts carry no content of their own.
+ALTER TABLE posts DROP CONSTRAINT posts_content_not_empty;
+ALTER TABLE posts ADD CONSTRAINT posts_content_not_empty
+    CHECK (kind = 'repost' OR char_length(TRIM(content)) > 0);
+
+CREATE INDEX idx_posts_original_created ON posts (original_id, created_at DESC)
+    WHERE original_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_posts_unique_repost ON posts (author_id, original_id)
+    WHERE kind = 'repost';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS post_edits;
+DROP FUNCTION IF EXISTS post_edits_append_only();
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS edited_at;
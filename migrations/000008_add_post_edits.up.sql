@@ -0,0 +1,24 @@
+ALTER TABLE posts
+    ADD COLUMN edited_at  TIMESTAMPTZ,
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Each row holds the content a post had before one edit.
+CREATE TABLE post_edits (
+    id        UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    post_id   UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    content   TEXT NOT NULL,
+    edited_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_post_edits_post_edited ON post_edits (post_id, edited_at DESC);
+
+-- History is append-only: rows may be added but never rewritten.
+CREATE FUNCTION post_edits_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'post_edits is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER post_edits_no_update
+    BEFORE UPDATE ON post_edits
+    FOR EACH ROW EXECUTE FUNCTION post_edits_append_only();
@@ -0,0 +1,18 @@
+CREATE TABLE tags (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT tags_name_normalized CHECK (name = LOWER(name) AND char_length(name) BETWEEN 1 AND 50)
+);
+
+-- position records where the tag first appears in the post, from 1.
+CREATE TABLE post_tags (
+    post_id    UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    tag_id     UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    position   SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, tag_id)
+);
+
+CREATE INDEX idx_post_tags_tag_created ON post_tags (tag_id, created_at DESC);
+CREATE INDEX idx_post_tags_created ON post_tags (created_at DESC);
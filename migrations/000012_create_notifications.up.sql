@@ -0,0 +1,19 @@
+CREATE TABLE notifications (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind       TEXT NOT NULL,
+    post_id    UUID REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at    TIMESTAMPTZ,
+    CONSTRAINT notifications_kind_valid CHECK (kind IN ('reply', 'follow', 'like', 'mention')),
+    CONSTRAINT notifications_not_self CHECK (user_id <> actor_id)
+);
+
+-- One notification per event, so liking, unliking and liking again, or
+-- refollowing, does not notify twice.
+CREATE UNIQUE INDEX idx_notifications_event ON notifications
+    (user_id, actor_id, kind, COALESCE(post_id, '00000000-0000-0000-0000-000000000000'));
+
+CREATE INDEX idx_notifications_user_created ON notifications (user_id, created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
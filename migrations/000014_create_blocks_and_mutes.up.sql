@@ -0,0 +1,20 @@
+CREATE TABLE blocks (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT blocks_no_self_block CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_blocks_blocker_created ON blocks (blocker_id, created_at DESC);
+CREATE INDEX idx_blocks_blocked ON blocks (blocked_id);
+
+CREATE TABLE mutes (
+    muter_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id   UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (muter_id, muted_id),
+    CONSTRAINT mutes_no_self_mute CHECK (muter_id <> muted_id)
+);
+
+CREATE INDEX idx_mutes_muter_created ON mutes (muter_id, created_at DESC);
@@ -0,0 +1,16 @@
+-- Keyword filters are matched against post content when a user reads a
+-- feed, so they need no index on the posts side.
+CREATE TABLE filters (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phrase     TEXT NOT NULL,
+    match_type TEXT NOT NULL DEFAULT 'phrase',
+    action     TEXT NOT NULL DEFAULT 'hide',
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT filters_phrase_length CHECK (char_length(phrase) BETWEEN 1 AND 100),
+    CONSTRAINT filters_match_type_valid CHECK (match_type IN ('phrase', 'word', 'regex')),
+    CONSTRAINT filters_action_valid CHECK (action IN ('hide', 'flag'))
+);
+
+CREATE INDEX idx_filters_user_created ON filters (user_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS moderation_log CASCADE;
+DROP TABLE IF EXISTS reports CASCADE;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_at;
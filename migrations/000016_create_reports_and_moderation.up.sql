@@ -0,0 +1,40 @@
+ALTER TABLE users ADD COLUMN suspended_at TIMESTAMPTZ;
+
+-- Every report names the reported account; reports about a post also name
+-- the post, and user_id is then its author.
+CREATE TABLE reports (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    post_id     UUID REFERENCES posts(id) ON DELETE CASCADE,
+    reason      TEXT NOT NULL,
+    details     TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL DEFAULT 'open',
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT reports_no_self_report CHECK (reporter_id <> user_id),
+    CONSTRAINT reports_reason_valid CHECK (reason IN ('spam', 'harassment', 'hate', 'violence', 'sexual', 'other')),
+    CONSTRAINT reports_details_length CHECK (char_length(details) <= 500),
+    CONSTRAINT reports_status_valid CHECK (status IN ('open', 'resolved', 'dismissed'))
+);
+
+CREATE UNIQUE INDEX idx_reports_open_post ON reports (reporter_id, post_id)
+    WHERE status = 'open' AND post_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_reports_open_user ON reports (reporter_id, user_id)
+    WHERE status = 'open' AND post_id IS NULL;
+CREATE INDEX idx_reports_status_created ON reports (status, created_at DESC);
+
+CREATE TABLE moderation_log (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action       TEXT NOT NULL,
+    user_id      UUID REFERENCES users(id) ON DELETE SET NULL,
+    post_id      UUID REFERENCES posts(id) ON DELETE SET NULL,
+    report_id    UUID REFERENCES reports(id) ON DELETE SET NULL,
+    reason       TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT moderation_log_action_valid CHECK (action IN ('hide_post', 'suspend_user', 'unsuspend_user', 'dismiss_report'))
+);
+
+CREATE INDEX idx_moderation_log_created ON moderation_log (created_at DESC);
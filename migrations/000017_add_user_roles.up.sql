@@ -0,0 +1,3 @@
+ALTER TABLE users
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'user',
+    ADD CONSTRAINT users_role_valid CHECK (role IN ('user', 'moderator', 'admin'));
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_users_inactive;
+
+ALTER TABLE users ADD COLUMN suspended_at TIMESTAMPTZ;
+
+UPDATE users SET suspended_at = COALESCE(status_changed_at, NOW())
+WHERE status = 'suspended';
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,14 @@
+-- status replaces suspended_at: an account is active, deactivated by its
+-- owner, suspended by a moderator or admin, or pending deletion.
+ALTER TABLE users
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'active',
+    ADD COLUMN status_changed_at TIMESTAMPTZ,
+    ADD CONSTRAINT users_status_valid
+        CHECK (status IN ('active', 'deactivated', 'suspended', 'pending_deletion'));
+
+UPDATE users SET status = 'suspended', status_changed_at = suspended_at
+WHERE suspended_at IS NOT NULL;
+
+ALTER TABLE users DROP COLUMN suspended_at;
+
+CREATE INDEX idx_users_inactive ON users (id) WHERE status <> 'active';
@@ -0,0 +1,13 @@
+-- A data export is built in the background; archive holds the finished
+-- JSON document once status is 'ready'.
+CREATE TABLE data_exports (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status       TEXT NOT NULL DEFAULT 'pending',
+    archive      JSONB,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT data_exports_status_valid CHECK (status IN ('pending', 'ready', 'failed'))
+);
+
+CREATE INDEX idx_data_exports_user ON data_exports (user_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- Each refresh token is a session; remember which device it belongs to.
+-- Tokens are rotated in place, so a session keeps its ID and created_at.
+ALTER TABLE refresh_tokens
+    ADD COLUMN device_name  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN user_agent   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN ip           TEXT NOT NULL DEFAULT '',
+    ADD COLUMN last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
@@ -0,0 +1,14 @@
+-- Rotating a refresh token now marks it rotated and issues a new token in
+-- the same family, so a replayed old token can be recognised. A family is
+-- one session; existing tokens each start their own, keeping their IDs.
+ALTER TABLE refresh_tokens
+    ADD COLUMN family_id  UUID,
+    ADD COLUMN rotated_at TIMESTAMPTZ;
+
+UPDATE refresh_tokens SET family_id = id;
+
+ALTER TABLE refresh_tokens
+    ALTER COLUMN family_id SET NOT NULL,
+    ALTER COLUMN family_id SET DEFAULT gen_random_uuid();
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
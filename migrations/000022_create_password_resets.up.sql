@@ -0,0 +1,11 @@
+-- A password reset token is emailed to the user and stored only as its
+-- SHA-256 hash. Confirming a reset deletes the row, so a token works once.
+CREATE TABLE password_resets (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_password_resets_user ON password_resets (user_id);
@@ -0,0 +1,18 @@
+-- Accounts record when their email address was confirmed. Accounts that
+-- existed before verification count as verified, so requiring it doesn't
+-- lock them out.
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+UPDATE users SET email_verified_at = created_at;
+
+-- A verification code is emailed at registration and stored only as its
+-- SHA-256 hash. Using it deletes the row, so a code works once.
+CREATE TABLE email_verifications (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_verifications_user ON email_verifications (user_id);
@@ -0,0 +1,35 @@
+-- A user's TOTP secret. It is pending until enabled_at is set by entering a
+-- code from the authenticator app. last_step is the time step of the last
+-- code accepted, so a code can't be used twice.
+CREATE TABLE user_totp (
+    user_id    UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret     TEXT NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    last_step  BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Single-use recovery codes, stored only as their SHA-256 hash.
+CREATE TABLE totp_recovery_codes (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, code_hash)
+);
+
+-- A password was accepted for an account with two-factor authentication
+-- and the second step is pending. attempts counts codes tried against it.
+-- reactivate marks a challenge started by reactivating a deactivated
+-- account.
+CREATE TABLE login_challenges (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    reactivate BOOLEAN NOT NULL DEFAULT FALSE,
+    attempts   INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_challenges_user ON login_challenges (user_id);